@@ -8,6 +8,7 @@ import (
 	"github.com/bvobart/mllint/api"
 	"github.com/bvobart/mllint/categories"
 	"github.com/bvobart/mllint/config"
+	"github.com/bvobart/mllint/utils"
 )
 
 func TestCategoryString(t *testing.T) {
@@ -120,3 +121,77 @@ func TestReportOverallScore(t *testing.T) {
 		require.Equal(t, 81.0, report.OverallScore())
 	})
 }
+
+func TestReportOverrideWeights(t *testing.T) {
+	rule1 := api.Rule{Slug: "test-1", Weight: 1}
+	rule2 := api.Rule{Slug: "test-2", Weight: 1}
+	rule3 := api.Rule{Slug: "test-3", Weight: 1}
+
+	report := api.NewReport()
+	report.Scores[rule1] = 100
+	report.Details[rule1] = "details"
+	report.Scores[rule2] = 40
+	report.Scores[rule3] = 70
+	require.Equal(t, 70.0, report.OverallScore())
+
+	weight, zero := 4.0, 0.0
+	report.OverrideWeights(map[string]config.RuleOverride{
+		"test-1":  {Weight: &weight},
+		"test-3":  {Weight: &zero},
+		"test-42": {Weight: &weight},
+	})
+
+	weighted1 := api.Rule{Slug: "test-1", Weight: 4}
+	weighted3 := api.Rule{Slug: "test-3", Weight: 0}
+	require.Equal(t, map[api.Rule]float64{weighted1: 100, rule2: 40, weighted3: 70}, report.Scores)
+	require.Equal(t, map[api.Rule]string{weighted1: "details"}, report.Details)
+	require.Equal(t, 88.0, report.OverallScore())
+}
+
+func TestProjectReportOverallScore(t *testing.T) {
+	report1 := api.NewReport()
+	report1.Scores[api.Rule{Slug: "test-1", Weight: 1}] = 100
+	report2 := api.NewReport()
+	report2.Scores[api.Rule{Slug: "test-2", Weight: 3}] = 60
+
+	project := api.ProjectReport{Reports: map[api.Category]api.Report{categories.Testing: report1, categories.CodeQuality: report2}}
+	require.Equal(t, 70.0, project.OverallScore())
+	require.Equal(t, 0.0, api.ProjectReport{}.OverallScore())
+}
+
+func TestRuleParam(t *testing.T) {
+	param := api.RuleParam{Slug: "test-rule", Name: "penalty", Default: 25, Min: 0, Max: 100}
+
+	project := api.Project{Config: *config.Default()}
+	require.Equal(t, 25.0, param.Value(project))
+
+	project.Config.Rules.Overrides["test-rule"] = config.RuleOverride{Params: map[string]float64{"penalty": 10}}
+	require.Equal(t, 10.0, param.Value(project))
+
+	require.NoError(t, param.Validate(0))
+	require.NoError(t, param.Validate(100))
+	require.EqualError(t, param.Validate(101), "parameter 'penalty' of rule 'test-rule' must be between 0 and 100, but was 101")
+}
+
+func TestProjectAllPythonFiles(t *testing.T) {
+	project := api.Project{PythonFiles: utils.Filenames{"a.py", "b.py"}}
+	require.Equal(t, utils.Filenames{"a.py", "b.py"}, project.AllPythonFiles())
+
+	project.Partial = &api.PartialScope{Description: "staged files", AllPythonFiles: project.PythonFiles}
+	project.PythonFiles = utils.Filenames{"b.py"}
+	require.Equal(t, utils.Filenames{"a.py", "b.py"}, project.AllPythonFiles())
+}
+
+func TestProjectRuleDisabled(t *testing.T) {
+	rule1 := api.Rule{Slug: "test-rule-1"}
+	rule2 := api.Rule{Slug: "test-rule-2"}
+	project := api.Project{}
+	require.False(t, project.RuleDisabled(&rule1))
+
+	project.DisabledRules = map[string]bool{rule1.Slug: true}
+	require.True(t, project.RuleDisabled(&rule1))
+	require.False(t, project.RuleDisabled(&rule2))
+
+	rule2.Disable()
+	require.True(t, project.RuleDisabled(&rule2))
+}
@@ -1,6 +1,9 @@
 package api
 
-import "fmt"
+import (
+	"context"
+	"fmt"
+)
 
 type CQLinterType string
 
@@ -25,8 +28,8 @@ type CQLinter interface {
 	IsInstalled() bool
 
 	// Run runs the linter on the project, collects the issues that it reports and returns them,
-	// or an error if that failed.
-	Run(project Project) ([]CQLinterResult, error)
+	// or an error if that failed. The linter's process is killed once the context is done.
+	Run(ctx context.Context, project Project) ([]CQLinterResult, error)
 }
 
 type CQLinterResult interface {
@@ -0,0 +1,66 @@
+package api
+
+import "fmt"
+
+type DataVersionControlType interface {
+	fmt.Stringer
+
+	// Detect whether a project is using this type of data version control tool and return the tool instantiated for this project if it is detected,
+	// or an error if it is not detected.
+	//
+	// Generally, this is done by detecting whether the tool's configuration or metadata files exist.
+	Detect(project Project) (DataVersionControl, error)
+}
+
+type DataVersionControl interface {
+	// Files returns the paths of the files and folders that this tool is versioning, relative to the project's root directory.
+	Files() []string
+
+	// HasRemote returns true if this tool stores the versioned data in a remote storage, such that it can be shared with others.
+	HasRemote() bool
+
+	// Type returns the type of this DataVersionControl.
+	Type() DataVersionControlType
+}
+
+type DataVersionControlList []DataVersionControl
+
+// Main returns the first data version control tool in the list, under the assumption that that is the main / primary tool used in the project.
+func (list DataVersionControlList) Main() DataVersionControl {
+	if len(list) > 0 {
+		return list[0]
+	}
+	return nil
+}
+
+func (list DataVersionControlList) ContainsType(target DataVersionControlType) bool {
+	if target == nil {
+		return false
+	}
+
+	for _, item := range list {
+		if item.Type() == target {
+			return true
+		}
+	}
+	return false
+}
+
+// Files returns the paths of all files and folders that are versioned by any of the data version control tools in the list.
+func (list DataVersionControlList) Files() []string {
+	files := []string{}
+	for _, item := range list {
+		files = append(files, item.Files()...)
+	}
+	return files
+}
+
+// HasRemote returns true if any of the data version control tools in the list stores its data in a remote storage.
+func (list DataVersionControlList) HasRemote() bool {
+	for _, item := range list {
+		if item.HasRemote() {
+			return true
+		}
+	}
+	return false
+}
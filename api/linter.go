@@ -1,6 +1,8 @@
 package api
 
 import (
+	"context"
+
 	"github.com/bvobart/mllint/config"
 )
 
@@ -22,7 +24,10 @@ type Linter interface {
 	// The returned Report should contain a mapping of each checked Rule to a percentual score between 0 and 100.
 	// A linter may also add additional details to a report related to a specific rule, which is especially
 	// recommended if the rule scored less than 100.
-	LintProject(project Project) (Report, error)
+	//
+	// The context is cancelled when mllint is interrupted or when the linter exceeds its configured timeout.
+	// Linters must pass it on to any external processes they run (see utils/exec) and return promptly once it is done.
+	LintProject(ctx context.Context, project Project) (Report, error)
 }
 
 // Configure should be implemented such that the struct that implements it configures itself to use the settings
@@ -5,6 +5,7 @@
 package mock_api
 
 import (
+	context "context"
 	api "github.com/bvobart/mllint/api"
 	gomock "github.com/golang/mock/gomock"
 	reflect "reflect"
@@ -118,18 +119,18 @@ func (mr *MockCQLinterMockRecorder) IsInstalled() *gomock.Call {
 }
 
 // Run mocks base method
-func (m *MockCQLinter) Run(project api.Project) ([]api.CQLinterResult, error) {
+func (m *MockCQLinter) Run(ctx context.Context, project api.Project) ([]api.CQLinterResult, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Run", project)
+	ret := m.ctrl.Call(m, "Run", ctx, project)
 	ret0, _ := ret[0].([]api.CQLinterResult)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // Run indicates an expected call of Run
-func (mr *MockCQLinterMockRecorder) Run(project interface{}) *gomock.Call {
+func (mr *MockCQLinterMockRecorder) Run(ctx, project interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Run", reflect.TypeOf((*MockCQLinter)(nil).Run), project)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Run", reflect.TypeOf((*MockCQLinter)(nil).Run), ctx, project)
 }
 
 // MockCQLinterResult is a mock of CQLinterResult interface
@@ -5,6 +5,7 @@
 package mock_api
 
 import (
+	context "context"
 	api "github.com/bvobart/mllint/api"
 	config "github.com/bvobart/mllint/config"
 	gomock "github.com/golang/mock/gomock"
@@ -63,18 +64,18 @@ func (mr *MockLinterMockRecorder) Rules() *gomock.Call {
 }
 
 // LintProject mocks base method
-func (m *MockLinter) LintProject(project api.Project) (api.Report, error) {
+func (m *MockLinter) LintProject(ctx context.Context, project api.Project) (api.Report, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "LintProject", project)
+	ret := m.ctrl.Call(m, "LintProject", ctx, project)
 	ret0, _ := ret[0].(api.Report)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // LintProject indicates an expected call of LintProject
-func (mr *MockLinterMockRecorder) LintProject(project interface{}) *gomock.Call {
+func (mr *MockLinterMockRecorder) LintProject(ctx, project interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LintProject", reflect.TypeOf((*MockLinter)(nil).LintProject), project)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LintProject", reflect.TypeOf((*MockLinter)(nil).LintProject), ctx, project)
 }
 
 // MockConfigurable is a mock of Configurable interface
@@ -166,18 +167,18 @@ func (mr *MockConfigurableLinterMockRecorder) Rules() *gomock.Call {
 }
 
 // LintProject mocks base method
-func (m *MockConfigurableLinter) LintProject(project api.Project) (api.Report, error) {
+func (m *MockConfigurableLinter) LintProject(ctx context.Context, project api.Project) (api.Report, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "LintProject", project)
+	ret := m.ctrl.Call(m, "LintProject", ctx, project)
 	ret0, _ := ret[0].(api.Report)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // LintProject indicates an expected call of LintProject
-func (mr *MockConfigurableLinterMockRecorder) LintProject(project interface{}) *gomock.Call {
+func (mr *MockConfigurableLinterMockRecorder) LintProject(ctx, project interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LintProject", reflect.TypeOf((*MockConfigurableLinter)(nil).LintProject), project)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LintProject", reflect.TypeOf((*MockConfigurableLinter)(nil).LintProject), ctx, project)
 }
 
 // Configure mocks base method
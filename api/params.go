@@ -0,0 +1,54 @@
+package api
+
+import (
+	"fmt"
+	"math"
+)
+
+// RuleParam describes a numeric parameter of a rule, such as the penalty per violation, which users can override
+// with the `rules.overrides` section of mllint's configuration.
+type RuleParam struct {
+	// Slug of the rule that this parameter belongs to.
+	Slug string
+	// Name of the parameter as it is used in the configuration, e.g. 'maxLoCperMsg'
+	Name string
+	// Short description of what the parameter does.
+	Description string
+	// Value of the parameter when it is not overridden.
+	Default float64
+	// Minimum and maximum values of the parameter, both inclusive.
+	Min float64
+	Max float64
+}
+
+// Value returns the value of this parameter in the given project, i.e. its override in the project's configuration if it has one, or its default value.
+func (p RuleParam) Value(project Project) float64 {
+	if value, ok := project.Config.Rules.Overrides[p.Slug].Params[p.Name]; ok {
+		return value
+	}
+	return p.Default
+}
+
+// Validate returns an error if the given value is outside of the parameter's valid range.
+func (p RuleParam) Validate(value float64) error {
+	if math.IsNaN(value) || value < p.Min || value > p.Max {
+		return fmt.Errorf("parameter '%s' of rule '%s' must be between %g and %g, but was %g", p.Name, p.Slug, p.Min, p.Max, value)
+	}
+	return nil
+}
+
+// ParameterisedLinter is a Linter whose rules have parameters that users can override with the `rules.overrides` section of mllint's configuration.
+// The linter should use RuleParam.Value to get the value of each parameter for the project that it is linting.
+type ParameterisedLinter interface {
+	Linter
+	// RuleParams returns the parameters of all the rules that this linter checks.
+	RuleParams() []RuleParam
+}
+
+// RuleParamsOf returns the parameters of the rules checked by the given linter, or nil if the linter is not a ParameterisedLinter.
+func RuleParamsOf(linter Linter) []RuleParam {
+	if parameterised, ok := linter.(ParameterisedLinter); ok {
+		return parameterised.RuleParams()
+	}
+	return nil
+}
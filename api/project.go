@@ -19,10 +19,61 @@ type Project struct {
 	ConfigType config.FileType
 	// Dependency managers that this project uses, e.g. requirements.txt, Poetry or Pipenv
 	DepManagers DependencyManagerList
+	// Data version control tools that this project uses, e.g. DVC, Git LFS or git-annex
+	DataVCs DataVersionControlList
 	// Code Quality linters that this project uses, i.e. static analysis tools that focus on analysing code, such as Pylint, Mypy and Bandit.
 	CQLinters []CQLinter
-	// Absolute paths to the Python files that are in this project's repository
+	// Absolute paths to the Python files that are in this project's repository.
+	// When only part of the project is being linted (see Partial), this only contains the Python files in that part.
 	PythonFiles utils.Filenames
+	// Absolute paths to the Jupyter Notebook files that are in this project's repository
+	Notebooks utils.Filenames
+	// Set when only part of the project is being linted, e.g. only the files that changed since a certain Git ref. Nil otherwise.
+	Partial *PartialScope
+	// Slugs of the rules that are disabled for this project only, e.g. in the configuration of a sub-project in a monorepo,
+	// as opposed to rules that are disabled for all projects with Rule.Disable. See RuleDisabled.
+	DisabledRules map[string]bool
+}
+
+// PartialScope describes which part of a project is being linted, when mllint is not linting the entire project.
+type PartialScope struct {
+	// Human-readable, Markdown-formatted description of the part of the project that is being linted, e.g. "files changed since `main`"
+	Description string
+	// Absolute paths to all of the Python files that are in the project's repository, of which the project's PythonFiles are a subset.
+	AllPythonFiles utils.Filenames
+}
+
+// AllPythonFiles returns the absolute paths to all Python files in this project's repository,
+// even when only part of the project is being linted. Linters should use this for rules that concern the project as a whole.
+func (p Project) AllPythonFiles() utils.Filenames {
+	if p.Partial != nil {
+		return p.Partial.AllPythonFiles
+	}
+	return p.PythonFiles
+}
+
+// RuleDisabled returns whether the given rule is disabled, either for all projects or for this project only.
+// Linters should check this before doing any analysis that is only needed for a specific rule.
+func (p Project) RuleDisabled(rule *Rule) bool {
+	return rule.Disabled || p.DisabledRules[rule.Slug]
+}
+
+// AllRulesDisabled returns whether the given linter has rules and all of them are disabled for this project, see RuleDisabled,
+// in which case the linter does not need to be run on this project at all.
+func (p Project) AllRulesDisabled(linter Linter) bool {
+	rules := linter.Rules()
+	for _, rule := range rules {
+		if !p.RuleDisabled(rule) {
+			return false
+		}
+	}
+	return len(rules) > 0
+}
+
+// FileFilter returns the filter that selects which of the project's files mllint analyses, based on the `include` and `exclude` patterns in its configuration.
+// Linters that search the project's directory for files themselves should use this, e.g. `project.FileFilter().FindFiles(project.Dir, ...)`
+func (p Project) FileFilter() utils.FileFilter {
+	return utils.FileFilter{Include: p.Config.Include, Exclude: p.Config.Exclude}
 }
 
 // GitInfo describes some info about the Git repository that a project is in.
@@ -42,4 +93,15 @@ type ProjectReport struct {
 	Project
 	Reports map[Category]Report
 	Errors  *multierror.Error
+	// Linters that produced the reports, by category. Their rules determine which rules are listed in each category's report.
+	Linters map[Category]Linter
+}
+
+// OverallScore returns the weighted average of the scores of all rules in all of the project's reports, weighted with each rule's respective weight.
+func (p ProjectReport) OverallScore() float64 {
+	all := NewReport()
+	for _, report := range p.Reports {
+		MergeReports(all, report)
+	}
+	return all.OverallScore()
 }
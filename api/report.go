@@ -1,5 +1,7 @@
 package api
 
+import "github.com/bvobart/mllint/config"
+
 // Report is the type of object returned by a Linter after linting a project.
 type Report struct {
 	// Scores maps each evaluated rule to a score
@@ -29,6 +31,27 @@ func (r Report) OverallScore() float64 {
 	return sumScores / sumWeights
 }
 
+// OverrideWeights replaces the weights of the rules in this report with the weights that the given overrides set for them, by the rules' slugs.
+// Since rules are shared between the linters of all projects that mllint lints, their weights are overridden in each project's reports
+// after linting, instead of on the rules themselves.
+func (r Report) OverrideWeights(overrides map[string]config.RuleOverride) {
+	for rule, score := range r.Scores {
+		override, ok := overrides[rule.Slug]
+		if !ok || override.Weight == nil || *override.Weight == rule.Weight {
+			continue
+		}
+
+		weighted := rule
+		weighted.Weight = *override.Weight
+		delete(r.Scores, rule)
+		r.Scores[weighted] = score
+		if details, ok := r.Details[rule]; ok {
+			delete(r.Details, rule)
+			r.Details[weighted] = details
+		}
+	}
+}
+
 func NewReport() Report {
 	return Report{
 		Scores:  map[Rule]float64{},
@@ -15,11 +15,16 @@ type Rule struct {
 	// as well as provide background info on the subject and pointers on how to fix violations of the rule.
 	Details string
 
-	// Weight determines the weight of this rule's score within its respective category.
-	Weight float64 // TODO: figure out what to with this...
+	// Weight determines the weight of this rule's score within its respective category, see Report.OverallScore.
+	// Users can override it with the `rules.overrides` section of mllint's configuration, see Report.OverrideWeights.
+	Weight float64
 
 	// Whether this rule was explicitly disabled by the user.
 	Disabled bool
+
+	// PerFile should be true if this rule's score is determined by analysing each of the project's Python files individually,
+	// such that the score only reflects part of the project when only part of it is being linted, see Project.Partial.
+	PerFile bool
 }
 
 func (r *Rule) Disable() {
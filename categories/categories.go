@@ -88,6 +88,39 @@ We recommend that you configure each of these linters as you see fit using their
 Those will then automatically be picked up as ` + "`mllint`" + ` runs them.`,
 }
 
+var Notebooks = api.Category{
+	Name: "Jupyter Notebooks",
+	Slug: "notebooks",
+	Description: `This category contains rules relating to the Jupyter Notebooks (*.ipynb files) in your project.
+
+Notebooks are great for exploring data and experimenting with models, but they are less suited for keeping code that needs to be maintained, tested and reused.
+Notebook files also do not play well with version control: Git stores them as large JSON files that, besides your code, also contain
+the outputs of every cell (including any plots as embedded images) and the order in which the cells were executed.
+This makes every re-run of a notebook show up as a change, bloats your repository and makes notebooks hard to review and merge.
+
+The rules in this category therefore check whether your notebooks are committed without outputs and embedded images,
+whether they were executed in order from top to bottom, whether they stay within a reasonable file size,
+whether they do not contain hard-coded absolute paths that only exist on your machine,
+and whether most of your project's code lives in Python modules rather than in notebooks.
+
+Optionally, ` + "`mllint`" + ` can also extract the code cells of your notebooks and run the configured code quality linters on them.
+You can configure this category using the following snippet of YAML in a ` + "`.mllint.yml`" + ` configuration file:
+` + "```yaml" + `
+notebooks:
+  maxFileSize: 1000000 # bytes, default is 1 MB
+  maxCodeRatio: 0.5 # at most half of the project's lines of code may be in notebooks
+  lint: true # run the code quality linters on the notebooks' code cells, default is false
+` + "```" + `
+
+or TOML:
+` + "```toml" + `
+[tool.mllint.notebooks]
+maxFileSize = 1000000
+maxCodeRatio = 0.5
+lint = true
+` + "```",
+}
+
 func asInterfaceList(list []api.CQLinterType) []interface{} {
 	res := make([]interface{}, len(list))
 	for i := range list {
@@ -224,6 +257,7 @@ Property | Type | Description
 ` + "`details`" + ` | string | A longer, descriptive, Markdown-formatted text that explains the rule in more detail. This text should explain... _1)_ what exactly the rule checks; _2)_ why the rule checks what it checks, i.e., why is this practice important?; and 3) how should a user fix violations of this rule?
 ` + "`weight`" + ` | float | The weight of this rule compared to other rules in the same category. This is used for calculating the category score as a weighted average of the scores of all rules. Zero weight means the rule's results will be shown in the report, but won't count for the category score. Note that YAML accepts any number for this property, e.g. ` + "`4`" + `, but TOML is more strict with typing and requires you to specify a number with a decimal point, e.g. ` + "`4.0`" + `
 ` + "`run`" + ` | string | The command to run for evaluating this rule. This command will be run in the project's root directory. Note that the command will be run using Golang's ` + "[`os/exec`](https://pkg.go.dev/os/exec)" + ` package, which _"intentionally does not invoke the system shell and does not expand any glob patterns or handle other expansions, pipelines, or redirections typically done by shells."_ To run shell commands, invoke the shell directly using e.g. ` + "`bash -c 'your command && here'`" + ` or using the example above to execute shell scripts.
+` + "`cache`" + ` | bool | Optional. Whether ` + "`mllint`" + ` may cache the output of the rule's command, such that the command is only run again when any of the files that ` + "`mllint`" + ` analyses change. Defaults to ` + "`false`" + `. Only enable this if your command does not depend on any hidden, gitignored or virtualenv files, since changes to those do not cause the command to be run again.
 
 The command specified with ` + "`run`" + ` is expected to print a simple YAML (or JSON) object with the following structure: 
 
@@ -250,6 +284,7 @@ var All = []api.Category{
 	FileStructure,
 	DependencyMgmt,
 	CodeQuality,
+	Notebooks,
 	DataQuality,
 	Testing,
 	ContinuousIntegration,
@@ -0,0 +1,61 @@
+package commands
+
+import (
+	"fmt"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+
+	"github.com/bvobart/mllint/utils/cache"
+)
+
+func NewCacheCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "cache",
+		Short: "Manages the cache in which mllint stores the results of the linters it runs.",
+		Long: fmt.Sprintf(`Manages the cache in which %s stores the results of the linters it runs, such as Pylint, Mypy and custom rules.
+Results are keyed on the linter's version, its configuration and the contents of the files it analyses, so re-running %s on an unchanged project is fast.
+
+Use %s to run %s without the cache.`, formatInlineCode("mllint"), formatInlineCode("mllint"), formatInlineCode("--no-cache"), formatInlineCode("mllint")),
+		Args: cobra.NoArgs,
+	}
+	cmd.AddCommand(&cobra.Command{
+		Use:   "clear",
+		Short: "Removes all results from mllint's cache.",
+		Long:  "Removes all results from mllint's cache.",
+		RunE:  runCacheClear,
+		Args:  cobra.NoArgs,
+	})
+	cmd.AddCommand(&cobra.Command{
+		Use:   "dir",
+		Short: "Prints the directory in which mllint stores its cache.",
+		Long:  "Prints the directory in which mllint stores its cache.",
+		RunE:  runCacheDir,
+		Args:  cobra.NoArgs,
+	})
+	return cmd
+}
+
+func runCacheClear(_ *cobra.Command, _ []string) error {
+	dir, err := cache.UserDir()
+	if err != nil {
+		return fmt.Errorf("could not determine the cache directory: %w", err)
+	}
+
+	if err := cache.New(dir).Clear(); err != nil {
+		return fmt.Errorf("failed to clear the cache at %s: %w", formatInlineCode(dir), err)
+	}
+
+	shush(func() { color.Green("Cleared the cache at %s", color.HiWhiteString(dir)) })
+	return nil
+}
+
+func runCacheDir(_ *cobra.Command, _ []string) error {
+	dir, err := cache.UserDir()
+	if err != nil {
+		return fmt.Errorf("could not determine the cache directory: %w", err)
+	}
+
+	fmt.Println(dir)
+	return nil
+}
@@ -2,6 +2,8 @@ package commands
 
 import (
 	"fmt"
+	"sort"
+	"strings"
 
 	"github.com/fatih/color"
 	"github.com/google/go-cmp/cmp"
@@ -24,8 +26,17 @@ This can be either:
   - %s  Uses the TOML syntax configuration in the [tool.mllint] section. Has the same structure as the YAML
   - the default configuration if none of the files above was found.
 
+A configuration file can extend other configurations with %s, listing the names of mllint's built-in presets (%s)
+and / or paths to other YAML or TOML configuration files, e.g. an organisation-wide configuration, relative to the file's folder.
+These are applied in order before the file itself, where later values override earlier ones. Maps are merged, the lists
+%s are combined, and any other list, e.g. %s, replaces the list of the configurations that it extends.
+
+This command prints the fully resolved configuration, with a comment stating the file or preset that each non-default value came from.
 Specifying %s or %s will cause this command to purely print the current or default config, allowing for e.g. %s`,
-			formatInlineCode(string(config.TypeYAML)), formatInlineCode(string(config.TypeTOML)), formatInlineCode("--quiet"), formatInlineCode("-q"), formatInlineCode("mllint config -q > .mllint.yml")),
+			formatInlineCode(string(config.TypeYAML)), formatInlineCode(string(config.TypeTOML)),
+			formatInlineCode("extends"), strings.Join(config.Presets(), ", "),
+			"include, exclude, rules.disabled, rules.custom, secrets.allowlist and secrets.ignoreFiles", formatInlineCode("code-quality.linters"),
+			formatInlineCode("--quiet"), formatInlineCode("-q"), formatInlineCode("mllint config -q > .mllint.yml")),
 		RunE: runConfig,
 		Args: cobra.MaximumNArgs(1),
 	}
@@ -45,24 +56,30 @@ func runConfig(_ *cobra.Command, args []string) error {
 	}
 	shush(func() { color.Green("Using project at  %s", color.HiWhiteString(projectdir)) })
 
-	conf, _, err := getConfig(projectdir)
+	conf, _, origins, err := getConfigWithOrigins(projectdir)
 	if err != nil {
 		return err
 	}
 	shush(func() { fmt.Print("---\n\n") })
 
-	// print the config
+	// print the config, along with where each of its values came from, unless quiet.
 	var output []byte
 	if outputToml {
 		output, err = conf.TOML()
-	} else {
+	} else if quiet {
 		output, err = conf.YAML()
+	} else {
+		output, err = conf.YAMLWithOrigins(origins)
 	}
 	if err != nil {
 		return err
 	}
 	fmt.Println(string(output))
 
+	if outputToml && len(origins) > 0 {
+		shush(func() { printOrigins(origins) })
+	}
+
 	shush(func() { fmt.Println("---") })
 	return nil
 }
@@ -85,21 +102,47 @@ func runConfigDefault() error {
 	return nil
 }
 
+// printOrigins prints the configuration file or preset that each of the config's non-default values came from.
+func printOrigins(origins config.Origins) {
+	paths := make([]string, 0, len(origins))
+	for path := range origins {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+
+	fmt.Println("---")
+	fmt.Println()
+	color.Green("Values not set by the default configuration:")
+	for _, path := range paths {
+		fmt.Printf("  %s  from %s\n", path, origins[path])
+	}
+	fmt.Println()
+}
+
 // Parses the config from the project dir and prints a nice message about where it came from.
 func getConfig(projectdir string) (*config.Config, config.FileType, error) {
-	conf, typee, err := config.ParseFromDir(projectdir)
+	conf, typee, _, err := getConfigWithOrigins(projectdir)
+	return conf, typee, err
+}
+
+// Parses the config from the project dir like getConfig, but also returns where each of the config's values came from.
+func getConfigWithOrigins(projectdir string) (*config.Config, config.FileType, config.Origins, error) {
+	conf, typee, origins, err := config.ParseFromDirWithOrigins(projectdir)
 	if err != nil {
-		return conf, typee, err
+		return conf, typee, origins, err
 	}
 
 	isDefault := cmp.Equal(conf, config.Default())
 	if typee == config.TypeYAML || typee == config.TypeTOML {
 		shush(func() { color.Green("Using configuration from %s (default: %v)\n", typee.String(), isDefault) })
+		if len(conf.Extends) > 0 {
+			shush(func() { color.Green("Extending %s\n", strings.Join(conf.Extends, ", ")) })
+		}
 	} else {
 		shush(func() {
 			color.Yellow("No .mllint.yml or pyproject.toml found in project folder, using default configuration\n")
 		})
 	}
 
-	return conf, typee, err
+	return conf, typee, origins, err
 }
@@ -116,10 +116,17 @@ func describeRules(rules []*api.Rule) string {
 }
 
 func describeRule(rule api.Rule) string {
+	params := markdowngen.RuleParams(linters.FindRuleParams(rule.Slug))
 	if outputToFile() || outputToStdout() {
+		if params != "" {
+			return markdowngen.RuleDetails(rule) + "\n" + params
+		}
 		return markdowngen.RuleDetails(rule)
 	}
 	prettyPrintRule(rule)
+	if params != "" {
+		fmt.Println(markdown.Render(params))
+	}
 	return ""
 }
 
@@ -0,0 +1,23 @@
+package commands
+
+import (
+	"context"
+
+	"github.com/bvobart/mllint/api"
+	"github.com/bvobart/mllint/config"
+)
+
+var (
+	FindSubProjects     = findSubProjects
+	DisableRules        = disableRules
+	RemoveDisabledRules = removeDisabledRules
+)
+
+// PrepareSubProject exposes prepareSubProject to the tests, returning the sub-project's report, name and number of disabled rules.
+func PrepareSubProject(ctx context.Context, root string, dir string, rootConfig *config.Config) (api.ProjectReport, string, int, error) {
+	sub, err := prepareSubProject(ctx, root, dir, rootConfig)
+	if err != nil {
+		return api.ProjectReport{}, "", 0, err
+	}
+	return sub.report, sub.name, sub.rulesDisabled, nil
+}
@@ -13,6 +13,15 @@ var (
 	outputFile    string
 	force         bool
 	progressPlain bool
+	jobs          int
+	serial        bool
+	noCache       bool
+	changedSince  string
+	staged        bool
+	progressJSON  string
+	profile       bool
+	profileTrace  string
+	monorepo      bool
 )
 
 func SetQuietFlag(cmd *cobra.Command) {
@@ -57,6 +66,43 @@ func SetProgressPlainFlag(cmd *cobra.Command) {
 	cmd.Flags().BoolVar(&progressPlain, "progress-plain", false, "Use this flag to print linting progress plainly, without rewriting terminal output. Enabled automatically in non-interactive terminals.")
 }
 
+func SetProgressJSONFlag(cmd *cobra.Command) {
+	cmd.Flags().StringVar(&progressJSON, "progress-json", "", fmt.Sprintf("Write linting progress as newline-delimited JSON events to the file at the given location, e.g. for CI wrappers or IDE plugins. Set this to %s (a single dash) to write these events to stderr.", formatInlineCode("-")))
+}
+
+func SetJobsFlag(cmd *cobra.Command) {
+	cmd.Flags().IntVarP(&jobs, "jobs", "j", 0, fmt.Sprintf("Maximum number of linters to run in parallel. Overrides %s from the configuration, which defaults to the number of CPUs.", formatInlineCode("runner.jobs")))
+}
+
+func SetSerialFlag(cmd *cobra.Command) {
+	cmd.Flags().BoolVar(&serial, "serial", false, "Use this flag to run all linters one by one instead of in parallel, e.g. for debugging. Equivalent to "+formatInlineCode("--jobs 1")+".")
+}
+
+func SetNoCacheFlag(cmd *cobra.Command) {
+	cmd.Flags().BoolVar(&noCache, "no-cache", false, "Use this flag to run all linters from scratch, without using or updating the results cached by previous runs of "+formatInlineCode("mllint")+".")
+}
+
+func SetProfileFlags(cmd *cobra.Command) {
+	cmd.Flags().BoolVar(&profile, "profile", false, "Use this flag to append a table to the report with the time that each linter spent queued, running, awaiting other linters and running external processes.")
+	cmd.Flags().StringVar(&profileTrace, "profile-trace", "", fmt.Sprintf("Write the timings of all linters to the file at the given location as a Chrome trace, which can be viewed in %s or Perfetto.", formatInlineCode("chrome://tracing")))
+}
+
+func SetMonorepoFlag(cmd *cobra.Command) {
+	cmd.Flags().BoolVar(&monorepo, "monorepo", false, fmt.Sprintf("Lint each of the sub-projects in the given directory, i.e. each folder with a %s, %s or %s file, and combine their reports. Sub-projects inherit the configuration in the given directory.", formatInlineCode("pyproject.toml"), formatInlineCode("setup.py"), formatInlineCode(".mllint.yml")))
+}
+
+func SetChangesFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(&changedSince, "changed-since", "", fmt.Sprintf("Only lint the Python files that changed since the given Git ref, e.g. %s or %s. Rules concerning the project as a whole are still checked, but the scores of rules that analyse each Python file are marked as partial.", formatInlineCode("main"), formatInlineCode("HEAD~1")))
+	cmd.Flags().BoolVar(&staged, "staged", false, "Only lint the Python files that are staged for the next commit, e.g. in a pre-commit hook. Works like "+formatInlineCode("--changed-since")+".")
+}
+
+func checkChangesFlags() error {
+	if changedSince != "" && staged {
+		return fmt.Errorf("%s and %s cannot be used together", formatInlineCode("--changed-since"), formatInlineCode("--staged"))
+	}
+	return nil
+}
+
 // only execute f when quiet is nil or false.
 func shush(f func()) {
 	if !quiet {
@@ -1,13 +1,14 @@
 package mllint
 
 import (
+	"context"
 	"time"
 
 	"github.com/bvobart/mllint/api"
 )
 
 type Runner interface {
-	RunLinter(id string, linter api.Linter, project api.Project, options ...TaskOption) *RunnerTask
+	RunLinter(ctx context.Context, id string, linter api.Linter, project api.Project, options ...TaskOption) *RunnerTask
 	CollectTasks(tasks ...*RunnerTask) chan *RunnerTask
 }
 
@@ -20,6 +21,56 @@ func DisplayName(name string) TaskOption {
 	}
 }
 
+// NamePrefix prepends the given prefix to the display name of a task, as well as to those of all tasks that its linter schedules
+// on its child runner, e.g. to tell apart the linters of the different sub-projects of a monorepo.
+func NamePrefix(prefix string) TaskOption {
+	return func(task *RunnerTask) {
+		task.namePrefix = prefix
+	}
+}
+
+// Weight sets the number of the runner's slots (see mllint.Jobs()) that a task occupies while it is running,
+// such that heavyweight linters, e.g. Mypy or Pylint, do not run alongside too many other linters. Defaults to 1.
+// A task whose weight exceeds the runner's number of jobs occupies all of the runner's slots.
+func Weight(weight int) TaskOption {
+	return func(task *RunnerTask) {
+		if weight > 0 {
+			task.weight = int32(weight)
+		}
+	}
+}
+
+// Timeout sets the maximum duration of a task, counted from the moment that it starts running.
+// When the linter does not complete in time, its context is cancelled and the task fails with ErrTimedOut.
+// A timeout of 0 means that the task can run indefinitely.
+func Timeout(timeout time.Duration) TaskOption {
+	return func(task *RunnerTask) {
+		task.timeout = timeout
+	}
+}
+
+// childOf marks a task as scheduled by the given parent task on its child runner.
+func childOf(parent *RunnerTask) TaskOption {
+	return func(task *RunnerTask) {
+		task.timer.timing.ParentID = parent.Id
+		task.namePrefix = parent.namePrefix
+	}
+}
+
+// RunnerOption is an option for a runner created by NewMLLintRunner, e.g. setting the number of parallel jobs.
+type RunnerOption func(runner *MLLintRunner)
+
+// Jobs sets the number of slots that the runner's running tasks can occupy at once, i.e. the number of linters that are run in parallel
+// when each has the default weight of 1. Use 1 to run all linters serially, e.g. for debugging.
+// Zero or less means the number of logical CPU cores, which is also the default.
+func Jobs(jobs int) RunnerOption {
+	return func(runner *MLLintRunner) {
+		if jobs > 0 {
+			runner.jobs = int32(jobs)
+		}
+	}
+}
+
 //---------------------------------------------------------------------------------------
 
 // RunnerTask represents a task to run a linter on a project that was created by a call to runner.RunLinter(...)
@@ -28,8 +79,18 @@ type RunnerTask struct {
 	Linter      api.Linter
 	Project     api.Project
 	Result      chan LinterResult
+	ctx         context.Context
+	timeout     time.Duration
+	weight      int32
 	displayName string
+	namePrefix  string
 	startTime   time.Time
+	// error that the task's linter completed with, set just before the runner is notified of its completion.
+	err error
+	// receives a value when the runner allows the task to resume after awaiting its child tasks.
+	resumed chan struct{}
+	// records the timing of the task, see `runner.Timings()`
+	timer *taskTimer
 }
 
 // LinterResult represents the two-valued return type of a Linter, containing a report and an error.
@@ -5,6 +5,7 @@
 package mock_mllint
 
 import (
+	context "context"
 	api "github.com/bvobart/mllint/api"
 	mllint "github.com/bvobart/mllint/commands/mllint"
 	config "github.com/bvobart/mllint/config"
@@ -36,9 +37,9 @@ func (m *MockRunner) EXPECT() *MockRunnerMockRecorder {
 }
 
 // RunLinter mocks base method
-func (m *MockRunner) RunLinter(id string, linter api.Linter, project api.Project, options ...mllint.TaskOption) *mllint.RunnerTask {
+func (m *MockRunner) RunLinter(ctx context.Context, id string, linter api.Linter, project api.Project, options ...mllint.TaskOption) *mllint.RunnerTask {
 	m.ctrl.T.Helper()
-	varargs := []interface{}{id, linter, project}
+	varargs := []interface{}{ctx, id, linter, project}
 	for _, a := range options {
 		varargs = append(varargs, a)
 	}
@@ -48,9 +49,9 @@ func (m *MockRunner) RunLinter(id string, linter api.Linter, project api.Project
 }
 
 // RunLinter indicates an expected call of RunLinter
-func (mr *MockRunnerMockRecorder) RunLinter(id, linter, project interface{}, options ...interface{}) *gomock.Call {
+func (mr *MockRunnerMockRecorder) RunLinter(ctx, id, linter, project interface{}, options ...interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	varargs := append([]interface{}{id, linter, project}, options...)
+	varargs := append([]interface{}{ctx, id, linter, project}, options...)
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RunLinter", reflect.TypeOf((*MockRunner)(nil).RunLinter), varargs...)
 }
 
@@ -159,18 +160,18 @@ func (mr *MockLinterWithRunnerMockRecorder) Rules() *gomock.Call {
 }
 
 // LintProject mocks base method
-func (m *MockLinterWithRunner) LintProject(project api.Project) (api.Report, error) {
+func (m *MockLinterWithRunner) LintProject(ctx context.Context, project api.Project) (api.Report, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "LintProject", project)
+	ret := m.ctrl.Call(m, "LintProject", ctx, project)
 	ret0, _ := ret[0].(api.Report)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // LintProject indicates an expected call of LintProject
-func (mr *MockLinterWithRunnerMockRecorder) LintProject(project interface{}) *gomock.Call {
+func (mr *MockLinterWithRunnerMockRecorder) LintProject(ctx, project interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LintProject", reflect.TypeOf((*MockLinterWithRunner)(nil).LintProject), project)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LintProject", reflect.TypeOf((*MockLinterWithRunner)(nil).LintProject), ctx, project)
 }
 
 // SetRunner mocks base method
@@ -237,18 +238,18 @@ func (mr *MockConfigurableLinterWithRunnerMockRecorder) Rules() *gomock.Call {
 }
 
 // LintProject mocks base method
-func (m *MockConfigurableLinterWithRunner) LintProject(project api.Project) (api.Report, error) {
+func (m *MockConfigurableLinterWithRunner) LintProject(ctx context.Context, project api.Project) (api.Report, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "LintProject", project)
+	ret := m.ctrl.Call(m, "LintProject", ctx, project)
 	ret0, _ := ret[0].(api.Report)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // LintProject indicates an expected call of LintProject
-func (mr *MockConfigurableLinterWithRunnerMockRecorder) LintProject(project interface{}) *gomock.Call {
+func (mr *MockConfigurableLinterWithRunnerMockRecorder) LintProject(ctx, project interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LintProject", reflect.TypeOf((*MockConfigurableLinterWithRunner)(nil).LintProject), project)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LintProject", reflect.TypeOf((*MockConfigurableLinterWithRunner)(nil).LintProject), ctx, project)
 }
 
 // Configure mocks base method
@@ -0,0 +1,163 @@
+package mllint
+
+import (
+	"encoding/json"
+	"io"
+	"sync"
+	"time"
+)
+
+// Types of events emitted by JSONRunnerProgress.
+const (
+	EventStarted       = "started"
+	EventTaskRunning   = "task_running"
+	EventTaskAwaiting  = "task_awaiting"
+	EventTaskResuming  = "task_resuming"
+	EventTaskCompleted = "task_completed"
+	EventAllDone       = "all_done"
+)
+
+// ProgressEvent is a single event emitted by JSONRunnerProgress.
+type ProgressEvent struct {
+	// Type of event, one of the Event* constants, e.g. EventTaskCompleted
+	Event string `json:"event"`
+	// Time at which the event occurred
+	Time time.Time `json:"time"`
+
+	// ID and display name of the task that the event concerns. Empty for EventStarted and EventAllDone.
+	TaskID   string `json:"task_id,omitempty"`
+	TaskName string `json:"task_name,omitempty"`
+	// Milliseconds since the task was scheduled, or since the runner was started for EventAllDone.
+	ElapsedMs float64 `json:"elapsed_ms"`
+	// Error that the task completed with, if any. Only set for EventTaskCompleted.
+	Error string `json:"error,omitempty"`
+
+	// Number of tasks that completed, and how many of those completed with an error. Only set for EventAllDone.
+	Completed *int `json:"completed,omitempty"`
+	Failed    *int `json:"failed,omitempty"`
+}
+
+// JSONRunnerProgress writes the progress of an `mllint.Runner` in running its tasks as newline-delimited JSON events (see ProgressEvent),
+// e.g. for CI wrappers and IDE plugins to show progress and collect timing metrics.
+type JSONRunnerProgress struct {
+	Out io.Writer
+
+	mu        sync.Mutex
+	startTime time.Time
+	completed int
+	failed    int
+}
+
+func NewJSONRunnerProgress(out io.Writer) RunnerProgress {
+	return &JSONRunnerProgress{Out: out}
+}
+
+func (p *JSONRunnerProgress) Start() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.startTime = time.Now()
+	p.emit(ProgressEvent{Event: EventStarted, Time: p.startTime})
+}
+
+func (p *JSONRunnerProgress) RunningTask(task *RunnerTask) {
+	p.emitTask(EventTaskRunning, task)
+}
+
+func (p *JSONRunnerProgress) TaskAwaiting(task *RunnerTask) {
+	p.emitTask(EventTaskAwaiting, task)
+}
+
+func (p *JSONRunnerProgress) TaskResuming(task *RunnerTask) {
+	p.emitTask(EventTaskResuming, task)
+}
+
+func (p *JSONRunnerProgress) CompletedTask(task *RunnerTask) {
+	p.emitTask(EventTaskCompleted, task)
+}
+
+func (p *JSONRunnerProgress) AllTasksDone() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	completed, failed := p.completed, p.failed
+	p.emit(ProgressEvent{Event: EventAllDone, Time: time.Now(), ElapsedMs: milliseconds(time.Since(p.startTime)), Completed: &completed, Failed: &failed})
+}
+
+func (p *JSONRunnerProgress) emitTask(event string, task *RunnerTask) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	e := ProgressEvent{Event: event, Time: time.Now(), TaskID: task.Id, TaskName: task.displayName, ElapsedMs: milliseconds(time.Since(task.startTime))}
+	if event == EventTaskCompleted {
+		p.completed++
+		if task.err != nil {
+			p.failed++
+			e.Error = task.err.Error()
+		}
+	}
+	p.emit(e)
+}
+
+// writes the event as a single line of JSON. Must be called while holding p.mu.
+func (p *JSONRunnerProgress) emit(event ProgressEvent) {
+	// progress is best-effort, failing to write it should not affect the linting itself.
+	_ = json.NewEncoder(p.Out).Encode(event)
+}
+
+func milliseconds(duration time.Duration) float64 {
+	return float64(duration.Microseconds()) / 1000
+}
+
+//---------------------------------------------------------------------------------------
+
+// CombinedRunnerProgress passes on the progress of an `mllint.Runner` to multiple RunnerProgress implementations,
+// e.g. to print progress to the terminal while also writing it as JSON to a file.
+type CombinedRunnerProgress []RunnerProgress
+
+// CombineProgress combines the given RunnerProgress implementations, ignoring any that are nil.
+func CombineProgress(progresses ...RunnerProgress) RunnerProgress {
+	combined := CombinedRunnerProgress{}
+	for _, progress := range progresses {
+		if progress != nil {
+			combined = append(combined, progress)
+		}
+	}
+	return combined
+}
+
+func (c CombinedRunnerProgress) Start() {
+	for _, p := range c {
+		p.Start()
+	}
+}
+
+func (c CombinedRunnerProgress) RunningTask(task *RunnerTask) {
+	for _, p := range c {
+		p.RunningTask(task)
+	}
+}
+
+func (c CombinedRunnerProgress) TaskAwaiting(task *RunnerTask) {
+	for _, p := range c {
+		p.TaskAwaiting(task)
+	}
+}
+
+func (c CombinedRunnerProgress) TaskResuming(task *RunnerTask) {
+	for _, p := range c {
+		p.TaskResuming(task)
+	}
+}
+
+func (c CombinedRunnerProgress) CompletedTask(task *RunnerTask) {
+	for _, p := range c {
+		p.CompletedTask(task)
+	}
+}
+
+func (c CombinedRunnerProgress) AllTasksDone() {
+	for _, p := range c {
+		p.AllTasksDone()
+	}
+}
@@ -1,10 +1,20 @@
 package mllint_test
 
 import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"testing"
 	"time"
 
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bvobart/mllint/api"
+	"github.com/bvobart/mllint/api/mock_api"
 	"github.com/bvobart/mllint/commands/mllint"
 )
 
@@ -31,3 +41,48 @@ func TestProgressLive(t *testing.T) {
 
 	progress.AllTasksDone()
 }
+
+func TestProgressJSON(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	project := api.Project{Dir: "TestDirProgress"}
+	createLinter := func(err error) api.Linter {
+		linter := mock_api.NewMockLinter(ctrl)
+		linter.EXPECT().Name().Times(1).Return("Test Linter")
+		linter.EXPECT().LintProject(gomock.Any(), project).Times(1).DoAndReturn(func(_ context.Context, _ api.Project) (api.Report, error) {
+			time.Sleep(10 * time.Millisecond)
+			return api.NewReport(), err
+		})
+		return linter
+	}
+
+	out := bytes.Buffer{}
+	runner := mllint.NewMLLintRunner(mllint.CombineProgress(nil, mllint.NewJSONRunnerProgress(&out)), mllint.Jobs(1))
+	runner.Start()
+	ok := runner.RunLinter(context.Background(), "ok", createLinter(nil), project, mllint.DisplayName("OK Linter"))
+	failing := runner.RunLinter(context.Background(), "failing", createLinter(errors.New("oops")), project)
+	mllint.ForEachTask(runner.CollectTasks(ok, failing), func(*mllint.RunnerTask, mllint.LinterResult) {})
+	runner.Close()
+
+	events := []mllint.ProgressEvent{}
+	scanner := bufio.NewScanner(&out)
+	for scanner.Scan() {
+		event := mllint.ProgressEvent{}
+		require.NoError(t, json.Unmarshal(scanner.Bytes(), &event), scanner.Text())
+		events = append(events, event)
+	}
+
+	types := []string{}
+	for _, event := range events {
+		types = append(types, event.Event+" "+event.TaskID)
+	}
+	require.Equal(t, []string{"started ", "task_running ok", "task_completed ok", "task_running failing", "task_completed failing", "all_done "}, types)
+
+	require.Equal(t, "OK Linter", events[1].TaskName)
+	require.Empty(t, events[2].Error)
+	require.GreaterOrEqual(t, events[2].ElapsedMs, 10.0)
+	require.Equal(t, "Test Linter", events[3].TaskName)
+	require.Equal(t, "oops", events[4].Error)
+	require.Equal(t, 2, *events[5].Completed)
+	require.Equal(t, 1, *events[5].Failed)
+	require.GreaterOrEqual(t, events[5].ElapsedMs, 20.0)
+}
@@ -1,83 +1,102 @@
 package mllint
 
-import (
-	"runtime"
-)
-
 // Watches the queue for new jobs, running or parking them as they come in / complete.
 // Run in a new go-routine using `go r.queueWorker()`
 func (r *MLLintRunner) queueWorker() {
-	var next *RunnerTask
+	queue := r.queue
 	parked := []*RunnerTask{}
+	resumable := []*RunnerTask{}
 	closed := false
 
-	checkParked := func() {
-		if r.nRunning < int32(runtime.NumCPU()) && len(parked) > 0 {
+	// resumes awaiting tasks whose child tasks have completed, then runs parked tasks, in the order in which they came in,
+	// for as long as there are enough free slots. Resumable tasks go first, since they have been running before.
+	schedule := func() {
+		for len(resumable) > 0 && r.fits(resumable[0]) {
+			var next *RunnerTask
+			next, resumable = resumable[0], resumable[1:]
+			r.used += r.weightOf(next)
+			r.progress.TaskResuming(next)
+			next.resumed <- struct{}{}
+		}
+
+		for len(resumable) == 0 && len(parked) > 0 && r.fits(parked[0]) {
+			var next *RunnerTask
 			next, parked = parked[0], parked[1:]
 			r.runTask(next)
 		}
 	}
 
+	// when the queue is closed and all tasks have completed, signal that we're finished.
+	finished := func() bool {
+		if closed && r.used == 0 && len(parked) == 0 && len(resumable) == 0 {
+			r.progress.AllTasksDone()
+			close(r.closed)
+			return true
+		}
+		return false
+	}
+
 	for {
 		select {
 		// when new task is scheduled...
-		case task, open := <-r.queue:
-			// if channel just closed and no tasks are running, signal that we're finished and exit
-			if !open && r.nRunning == 0 {
-				r.progress.AllTasksDone()
-				close(r.closed)
-				return
-			}
-			// if channel just closed, but there are still tasks running, signal to the next case that there will be no new tasks.
+		case task, open := <-queue:
+			// if channel just closed, signal that there will be no new tasks and exit if no tasks are running.
+			// Stop receiving from the closed channel, as that would never block.
 			if !open {
 				closed = true
-				checkParked()
+				queue = nil
+				schedule()
+				if finished() {
+					return
+				}
 				break
 			}
 
-			// if we're already running the maximum number of tasks, park it
-			if r.nRunning >= int32(runtime.NumCPU()) {
-				parked = append(parked, task)
-				break
-			}
+			// park the task, then run it straight away if there are enough free slots.
+			parked = append(parked, task)
+			schedule()
 
-			// otherwise just run the task
-			r.runTask(task)
-
-		// when a task starts awaiting results from tasks scheduled on a child linter
+		// when a task starts awaiting results from tasks scheduled on a child linter, it frees its slots for its child tasks.
 		case task := <-r.awaiting:
-			r.nRunning--
+			r.used -= r.weightOf(task)
 			r.progress.TaskAwaiting(task)
+			schedule()
 
-			// if there are parked tasks, run one of them.
-			checkParked()
-
-		// when a task is done awaiting results and resumes its execution
+		// when a task is done awaiting results, it resumes its execution once it fits.
 		case task := <-r.resuming:
-			r.nRunning++
-			r.progress.TaskResuming(task)
+			resumable = append(resumable, task)
+			schedule()
 
 		// when a task completes...
 		case task := <-r.done:
-			r.nRunning--
+			r.used -= r.weightOf(task)
 			r.progress.CompletedTask(task)
-
-			// if there are still parked tasks, run one of them.
-			checkParked()
-
-			// if the queue is closed and no other tasks are running, then signal that we're finished and exit
-			if closed && r.nRunning == 0 {
-				r.progress.AllTasksDone()
-				close(r.closed)
+			schedule()
+			if finished() {
 				return
 			}
 		}
 	}
 }
 
+// weightOf returns the number of slots that the task occupies while running, which is at most the runner's number of jobs.
+func (r *MLLintRunner) weightOf(task *RunnerTask) int32 {
+	if task.weight > r.jobs {
+		return r.jobs
+	}
+	return task.weight
+}
+
+// fits returns whether there are enough free slots for the task to run.
+func (r *MLLintRunner) fits(task *RunnerTask) bool {
+	return r.used+r.weightOf(task) <= r.jobs
+}
+
 // actually start running the task in a new go-routine
 func (r *MLLintRunner) runTask(task *RunnerTask) {
-	r.nRunning++
+	r.used += r.weightOf(task)
+	r.started = append(r.started, task)
+	task.timer.started()
 	r.progress.RunningTask(task)
 
 	go func() {
@@ -86,8 +105,10 @@ func (r *MLLintRunner) runTask(task *RunnerTask) {
 			l.SetRunner(&runner)
 		}
 
-		report, err := task.Linter.LintProject(task.Project)
-		task.Result <- LinterResult{Report: report, Err: err}
+		result := task.lint()
+		task.timer.completed()
+		task.err = result.Err
+		task.Result <- result
 		r.done <- task
 	}()
 }
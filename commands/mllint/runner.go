@@ -1,28 +1,58 @@
 package mllint
 
 import (
+	"context"
+	"errors"
+	"fmt"
 	"io"
+	"runtime"
 	"time"
 
 	"github.com/bvobart/mllint/api"
+	"github.com/bvobart/mllint/utils/exec"
 )
 
 const queueSize = 20 // arbitrarily chosen
 
+// ErrTimedOut is the error with which a task fails when its linter does not complete within the task's timeout.
+var ErrTimedOut = errors.New("timed out")
+
+// ErrInvalidTimeout is returned by ParseTimeout when a timeout is not a valid, positive duration.
+var ErrInvalidTimeout = errors.New("invalid timeout")
+
+// ParseTimeout parses a timeout as specified in mllint's configuration, e.g. `10m` or `90s`, for use with `mllint.Timeout()`.
+// An empty string means that there is no timeout, for which 0 is returned.
+func ParseTimeout(timeout string) (time.Duration, error) {
+	if timeout == "" {
+		return 0, nil
+	}
+
+	duration, err := time.ParseDuration(timeout)
+	if err != nil || duration < 0 {
+		return 0, fmt.Errorf("%w: `%s`", ErrInvalidTimeout, timeout)
+	}
+	return duration, nil
+}
+
 // NewMLLintRunner initialises an *mllint.MLLintRunner
-func NewMLLintRunner(progress RunnerProgress) *MLLintRunner {
+func NewMLLintRunner(progress RunnerProgress, options ...RunnerOption) *MLLintRunner {
 	if progress == nil {
 		progress = &BasicRunnerProgress{Out: io.Discard}
 	}
-	return &MLLintRunner{
+	runner := &MLLintRunner{
 		queue:    make(chan *RunnerTask, queueSize),
 		awaiting: make(chan *RunnerTask, queueSize),
 		resuming: make(chan *RunnerTask, queueSize),
 		done:     make(chan *RunnerTask, queueSize),
 		progress: progress,
 		closed:   make(chan struct{}),
-		nRunning: 0,
+		jobs:     int32(runtime.NumCPU()),
+		used:     0,
 	}
+	for _, optionFunc := range options {
+		optionFunc(runner)
+	}
+	return runner
 }
 
 // Runner implements a parallel linter runner for mllint.
@@ -33,6 +63,11 @@ func NewMLLintRunner(progress RunnerProgress) *MLLintRunner {
 // The Runner will ensure that all tasks added to its queue are executed,
 // run in parallel across all `runtime.NumCPU()` available logical cores (CPU threads, aka the amount of bars you see in `htop` :P).
 // No more than this number of tasks will be running in parallel, any additional tasks are parked and executed as running tasks complete.
+// Use `NewMLLintRunner(progress, mllint.Jobs(n))` to run a different number of tasks in parallel,
+// and `mllint.Weight(w)` when creating a task to let it occupy multiple of these slots.
+//
+// A task that awaits the results of its child tasks (see childRunner) frees its slots until all its child tasks have completed,
+// after which it resumes as soon as enough slots are available again, before any parked tasks are started.
 //
 // `r.RunLinter()` returns a *RunnerTask, use `result := <-task.Result` to await the completion of the linter task and receive the result of the task.
 //
@@ -56,7 +91,12 @@ type MLLintRunner struct {
 
 	progress RunnerProgress
 	closed   chan struct{}
-	nRunning int32
+	// number of slots that running tasks may occupy at once
+	jobs int32
+	// number of slots currently occupied by running tasks
+	used int32
+	// all tasks that the runner has started, in the order in which they were started
+	started []*RunnerTask
 }
 
 // Start starts the runner by running a queue worker go-routine in the background that will await tasks and run them as they come in.
@@ -78,19 +118,24 @@ func (r *MLLintRunner) Close() {
 // The task will be executed in parallel with other tasks by the runner.
 // If the runner is `nil`, then the linter will be executed directly on the current thread, returning its result the usual way.
 //
+// The linter receives the given context, or a derived context if the task has a timeout (see `mllint.Timeout()`).
+// Linters that schedule tasks on their own runner should pass on the context they received.
+//
 // Once the task completes, the linter's report and error will be sent to the task's `Result` channel,
 // i.e. use `<-task.Result` to await the linter's result.
-func (r *MLLintRunner) RunLinter(id string, linter api.Linter, project api.Project, options ...TaskOption) *RunnerTask {
+func (r *MLLintRunner) RunLinter(ctx context.Context, id string, linter api.Linter, project api.Project, options ...TaskOption) *RunnerTask {
 	result := make(chan LinterResult, 1)
-	task := RunnerTask{id, linter, project, result, linter.Name(), time.Now()}
+	task := RunnerTask{Id: id, Linter: linter, Project: project, Result: result, ctx: ctx, weight: 1, displayName: linter.Name(), startTime: time.Now(), resumed: make(chan struct{}, 1)}
+	task.timer = newTaskTimer(id, task.startTime)
 	for _, optionFunc := range options {
 		optionFunc(&task)
 	}
+	task.displayName = task.namePrefix + task.displayName
+	task.timer.timing.Name = task.displayName
 
 	// a nil runner simply runs the task on the current thread.
 	if r == nil {
-		report, err := linter.LintProject(project)
-		task.Result <- LinterResult{report, err}
+		task.Result <- task.lint()
 		return &task
 	}
 
@@ -102,6 +147,16 @@ func (r *MLLintRunner) CollectTasks(tasks ...*RunnerTask) chan *RunnerTask {
 	return collectTasks(func() {}, tasks...)
 }
 
+// Timings returns the timing information of all tasks that the runner has run, in the order in which they were started.
+// Only call this after `runner.Close()` has returned.
+func (r *MLLintRunner) Timings() []TaskTiming {
+	timings := make([]TaskTiming, len(r.started))
+	for i, task := range r.started {
+		timings[i] = task.timer.get()
+	}
+	return timings
+}
+
 type childRunner struct {
 	// the parent runner on which all tasks will actually be scheduled
 	parent *MLLintRunner
@@ -109,8 +164,8 @@ type childRunner struct {
 	task *RunnerTask
 }
 
-func (r *childRunner) RunLinter(id string, linter api.Linter, project api.Project, options ...TaskOption) *RunnerTask {
-	return r.parent.RunLinter(id, linter, project, options...)
+func (r *childRunner) RunLinter(ctx context.Context, id string, linter api.Linter, project api.Project, options ...TaskOption) *RunnerTask {
+	return r.parent.RunLinter(ctx, id, linter, project, append(options, childOf(r.task))...)
 }
 
 func (r *childRunner) CollectTasks(tasks ...*RunnerTask) chan *RunnerTask {
@@ -120,6 +175,32 @@ func (r *childRunner) CollectTasks(tasks ...*RunnerTask) chan *RunnerTask {
 		return funnel
 	}
 
+	resumed := r.task.timer.awaiting()
 	r.parent.awaiting <- r.task
-	return collectTasks(func() { r.parent.resuming <- r.task }, tasks...)
+	return collectTasks(func() {
+		r.parent.resuming <- r.task
+		<-r.task.resumed
+		resumed()
+	}, tasks...)
+}
+
+// lint runs the task's linter on the task's project, within the task's timeout.
+// When the task's context is done before the linter completes, its report is discarded as it cannot be trusted to be complete,
+// and its error is replaced by one that says so, since whatever the linter reported is merely a consequence of it being cancelled.
+func (task *RunnerTask) lint() LinterResult {
+	ctx := exec.WithRecorder(task.ctx, task.timer)
+	if task.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, task.timeout)
+		defer cancel()
+	}
+
+	report, err := task.Linter.LintProject(ctx, task.Project)
+	if task.ctx.Err() != nil {
+		return LinterResult{Report: api.NewReport(), Err: fmt.Errorf("%s was cancelled: %w", task.displayName, task.ctx.Err())}
+	}
+	if ctx.Err() != nil {
+		return LinterResult{Report: api.NewReport(), Err: fmt.Errorf("%s %w after %s", task.displayName, ErrTimedOut, task.timeout)}
+	}
+	return LinterResult{Report: report, Err: err}
 }
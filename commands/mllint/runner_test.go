@@ -1,6 +1,9 @@
 package mllint_test
 
 import (
+	"bytes"
+	"context"
+	"encoding/json"
 	"fmt"
 	"runtime"
 	"sync/atomic"
@@ -11,6 +14,7 @@ import (
 	"github.com/bvobart/mllint/api/mock_api"
 	"github.com/bvobart/mllint/commands/mllint"
 	"github.com/bvobart/mllint/commands/mllint/mock_mllint"
+	"github.com/bvobart/mllint/utils/exec"
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/require"
 )
@@ -206,12 +210,12 @@ func (t testCtl) createTestLinterTask(runner mllint.Runner, test testLinter) *ml
 
 	linter := mock_api.NewMockLinter(t.ctrl)
 	linter.EXPECT().Name().Times(1).Return(test.id)
-	linter.EXPECT().LintProject(project).Times(1).DoAndReturn(func(project api.Project) (api.Report, error) {
+	linter.EXPECT().LintProject(gomock.Any(), project).Times(1).DoAndReturn(func(_ context.Context, project api.Project) (api.Report, error) {
 		test.lintProject(project)
 		return test.report, test.err
 	})
 
-	task := runner.RunLinter(test.id, linter, project, mllint.DisplayName(fmt.Sprint("TestLinter", test.id)))
+	task := runner.RunLinter(context.Background(), test.id, linter, project, mllint.DisplayName(fmt.Sprint("TestLinter", test.id)))
 	require.NotNil(t.t, task)
 	require.Equal(t.t, test.id, task.Id)
 	require.Equal(t.t, linter, task.Linter)
@@ -225,7 +229,7 @@ func (t testCtl) createRecursiveTestTask(runner mllint.Runner, test testLinter)
 
 	linter := mock_mllint.NewMockLinterWithRunner(t.ctrl)
 	linter.EXPECT().Name().Times(1).Return(test.id)
-	linter.EXPECT().LintProject(project).Times(1).DoAndReturn(func(project api.Project) (api.Report, error) {
+	linter.EXPECT().LintProject(gomock.Any(), project).Times(1).DoAndReturn(func(_ context.Context, project api.Project) (api.Report, error) {
 		test.lintProject(project)
 		return test.report, test.err
 	})
@@ -233,7 +237,7 @@ func (t testCtl) createRecursiveTestTask(runner mllint.Runner, test testLinter)
 		test.setRunner(r)
 	})
 
-	task := runner.RunLinter(test.id, linter, project, mllint.DisplayName(fmt.Sprint("RecursiveLinter - ", test.id)))
+	task := runner.RunLinter(context.Background(), test.id, linter, project, mllint.DisplayName(fmt.Sprint("RecursiveLinter - ", test.id)))
 	require.NotNil(t.t, task)
 	require.Equal(t.t, test.id, task.Id)
 	require.Equal(t.t, linter, task.Linter)
@@ -246,3 +250,279 @@ func (t testCtl) checkTestLinterTaskResult(test testLinter, task *mllint.RunnerT
 	require.Equal(t.t, result.Report, test.report)
 	require.Equal(t.t, result.Err, test.err)
 }
+
+//---------------------------------------------------------------------------------------
+
+func TestMLLintRunnerTimeout(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	project := api.Project{Dir: "TestDirTimeout"}
+
+	// a linter that only completes once its context is done, reporting a score for a rule anyway.
+	rule := api.Rule{Slug: "test/rule"}
+	blockingLinter := func() api.Linter {
+		linter := mock_api.NewMockLinter(ctrl)
+		linter.EXPECT().Name().Times(1).Return("Blocking")
+		linter.EXPECT().LintProject(gomock.Any(), project).Times(1).DoAndReturn(func(ctx context.Context, _ api.Project) (api.Report, error) {
+			<-ctx.Done()
+			report := api.NewReport()
+			report.Scores[rule] = 100
+			return report, nil
+		})
+		return linter
+	}
+
+	runner := mllint.NewMLLintRunner(nil)
+	runner.Start()
+	defer runner.Close()
+
+	t.Run("TimedOut", func(t *testing.T) {
+		startTime := time.Now()
+		task := runner.RunLinter(context.Background(), "timeout", blockingLinter(), project, mllint.DisplayName("Test Linter"), mllint.Timeout(50*time.Millisecond))
+		result := <-task.Result
+		require.WithinDuration(t, startTime, time.Now(), time.Second)
+		require.ErrorIs(t, result.Err, mllint.ErrTimedOut)
+		require.EqualError(t, result.Err, "Test Linter timed out after 50ms")
+		require.Empty(t, result.Report.Scores)
+	})
+
+	t.Run("Cancelled", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		task := runner.RunLinter(ctx, "cancelled", blockingLinter(), project, mllint.DisplayName("Test Linter"), mllint.Timeout(time.Minute))
+		cancel()
+		result := <-task.Result
+		require.ErrorIs(t, result.Err, context.Canceled)
+		require.EqualError(t, result.Err, "Test Linter was cancelled: context canceled")
+		require.Empty(t, result.Report.Scores)
+	})
+
+	t.Run("NilRunner", func(t *testing.T) {
+		var nilRunner *mllint.MLLintRunner
+		task := nilRunner.RunLinter(context.Background(), "timeout", blockingLinter(), project, mllint.Timeout(50*time.Millisecond))
+		result := <-task.Result
+		require.ErrorIs(t, result.Err, mllint.ErrTimedOut)
+	})
+}
+
+func TestParseTimeout(t *testing.T) {
+	timeout, err := mllint.ParseTimeout("")
+	require.NoError(t, err)
+	require.Equal(t, time.Duration(0), timeout)
+
+	timeout, err = mllint.ParseTimeout("1m30s")
+	require.NoError(t, err)
+	require.Equal(t, 90*time.Second, timeout)
+
+	_, err = mllint.ParseTimeout("forever")
+	require.ErrorIs(t, err, mllint.ErrInvalidTimeout)
+
+	_, err = mllint.ParseTimeout("-5s")
+	require.ErrorIs(t, err, mllint.ErrInvalidTimeout)
+}
+
+//---------------------------------------------------------------------------------------
+
+func TestMLLintRunnerJobs(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	project := api.Project{Dir: "TestDirJobs"}
+
+	// a linter that keeps track of the maximum number of linters that were running concurrently.
+	var running, maxRunning int32
+	countingLinter := func() api.Linter {
+		linter := mock_api.NewMockLinter(ctrl)
+		linter.EXPECT().Name().Times(1).Return("Counting")
+		linter.EXPECT().LintProject(gomock.Any(), project).Times(1).DoAndReturn(func(_ context.Context, _ api.Project) (api.Report, error) {
+			current := atomic.AddInt32(&running, 1)
+			for {
+				max := atomic.LoadInt32(&maxRunning)
+				if current <= max || atomic.CompareAndSwapInt32(&maxRunning, max, current) {
+					break
+				}
+			}
+			time.Sleep(20 * time.Millisecond)
+			atomic.AddInt32(&running, -1)
+			return api.NewReport(), nil
+		})
+		return linter
+	}
+
+	runTasks := func(runner mllint.Runner, numTasks int, options ...mllint.TaskOption) {
+		atomic.StoreInt32(&maxRunning, 0)
+		tasks := make([]*mllint.RunnerTask, numTasks)
+		for i := range tasks {
+			tasks[i] = runner.RunLinter(context.Background(), fmt.Sprint(i), countingLinter(), project, options...)
+		}
+
+		completed := 0
+		mllint.ForEachTask(runner.CollectTasks(tasks...), func(task *mllint.RunnerTask, result mllint.LinterResult) {
+			require.NoError(t, result.Err)
+			completed++
+		})
+		require.Equal(t, numTasks, completed)
+	}
+
+	t.Run("Serial", func(t *testing.T) {
+		runner := mllint.NewMLLintRunner(nil, mllint.Jobs(1))
+		runner.Start()
+		defer runner.Close()
+
+		runTasks(runner, 5)
+		require.EqualValues(t, 1, atomic.LoadInt32(&maxRunning))
+	})
+
+	t.Run("Parallel", func(t *testing.T) {
+		runner := mllint.NewMLLintRunner(nil, mllint.Jobs(4))
+		runner.Start()
+		defer runner.Close()
+
+		runTasks(runner, 8)
+		require.EqualValues(t, 4, atomic.LoadInt32(&maxRunning))
+	})
+
+	t.Run("Weighted", func(t *testing.T) {
+		runner := mllint.NewMLLintRunner(nil, mllint.Jobs(4))
+		runner.Start()
+		defer runner.Close()
+
+		runTasks(runner, 6, mllint.Weight(2))
+		require.EqualValues(t, 2, atomic.LoadInt32(&maxRunning))
+	})
+
+	t.Run("WeightExceedsJobs", func(t *testing.T) {
+		runner := mllint.NewMLLintRunner(nil, mllint.Jobs(2))
+		runner.Start()
+		defer runner.Close()
+
+		runTasks(runner, 3, mllint.Weight(5))
+		require.EqualValues(t, 1, atomic.LoadInt32(&maxRunning))
+	})
+}
+
+func TestMLLintRunnerSerialChildLinters(t *testing.T) {
+	// with only a single job slot, a linter awaiting its child linters must yield its slot to them.
+	ctrl := gomock.NewController(t)
+	tester := testCtl{t, ctrl}
+	test := createNestedLinterTest(tester, 5)
+
+	runner := mllint.NewMLLintRunner(nil, mllint.Jobs(1))
+	runner.Start()
+	defer runner.Close()
+
+	task := tester.createRecursiveTestTask(runner, test)
+	select {
+	case result := <-task.Result:
+		tester.checkTestLinterTaskResult(test, task, result)
+	case <-time.After(5 * time.Second):
+		t.Fatal("expected nested linters to complete in serial mode, but they appear to be deadlocked")
+	}
+}
+
+func TestMLLintRunnerNamePrefix(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	project := api.Project{Dir: "."}
+
+	child := mock_api.NewMockLinter(ctrl)
+	child.EXPECT().Name().Times(1).Return("Child")
+	child.EXPECT().LintProject(gomock.Any(), project).Times(1).Return(api.NewReport(), nil)
+
+	var childRunner mllint.Runner
+	parent := mock_mllint.NewMockLinterWithRunner(ctrl)
+	parent.EXPECT().Name().Times(1).Return("Parent")
+	parent.EXPECT().SetRunner(gomock.Any()).Times(1).Do(func(r mllint.Runner) { childRunner = r })
+	parent.EXPECT().LintProject(gomock.Any(), project).Times(1).DoAndReturn(func(ctx context.Context, _ api.Project) (api.Report, error) {
+		task := childRunner.RunLinter(ctx, "child", child, project, mllint.DisplayName("Parent - Child"))
+		mllint.ForEachTask(childRunner.CollectTasks(task), func(_ *mllint.RunnerTask, result mllint.LinterResult) {
+			require.NoError(t, result.Err)
+		})
+		return api.NewReport(), nil
+	})
+
+	runner := mllint.NewMLLintRunner(nil)
+	runner.Start()
+	task := runner.RunLinter(context.Background(), "parent", parent, project, mllint.NamePrefix("sub-project - "))
+	result := <-task.Result
+	require.NoError(t, result.Err)
+	runner.Close()
+
+	// the prefix is also prepended to the names of the tasks scheduled by the parent task.
+	timings := runner.Timings()
+	require.Len(t, timings, 2)
+	require.Equal(t, "sub-project - Parent", timings[0].Name)
+	require.Equal(t, "sub-project - Parent - Child", timings[1].Name)
+}
+
+func TestMLLintRunnerTimings(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	project := api.Project{Dir: "."}
+
+	// a child linter that runs an external process
+	child := mock_api.NewMockLinter(ctrl)
+	child.EXPECT().Name().Times(1).Return("Child")
+	child.EXPECT().LintProject(gomock.Any(), project).Times(1).DoAndReturn(func(ctx context.Context, _ api.Project) (api.Report, error) {
+		_, err := exec.DefaultCommandOutput(ctx, ".", "sleep", "0.02")
+		return api.NewReport(), err
+	})
+
+	// a parent linter that awaits the child linter
+	var childRunner mllint.Runner
+	parent := mock_mllint.NewMockLinterWithRunner(ctrl)
+	parent.EXPECT().Name().Times(1).Return("Parent")
+	parent.EXPECT().SetRunner(gomock.Any()).Times(1).Do(func(r mllint.Runner) { childRunner = r })
+	parent.EXPECT().LintProject(gomock.Any(), project).Times(1).DoAndReturn(func(ctx context.Context, _ api.Project) (api.Report, error) {
+		task := childRunner.RunLinter(ctx, "child", child, project)
+		mllint.ForEachTask(childRunner.CollectTasks(task), func(_ *mllint.RunnerTask, result mllint.LinterResult) {
+			require.NoError(t, result.Err)
+		})
+		return api.NewReport(), nil
+	})
+
+	runner := mllint.NewMLLintRunner(nil)
+	runner.Start()
+	task := runner.RunLinter(context.Background(), "parent", parent, project)
+	result := <-task.Result
+	require.NoError(t, result.Err)
+	runner.Close()
+
+	timings := runner.Timings()
+	require.Len(t, timings, 2)
+
+	parentTiming, childTiming := timings[0], timings[1]
+	require.Equal(t, "parent", parentTiming.ID)
+	require.Equal(t, "Parent", parentTiming.Name)
+	require.Equal(t, "", parentTiming.ParentID)
+	require.Len(t, parentTiming.Awaiting, 1)
+	require.Len(t, parentTiming.Processes, 0)
+	require.GreaterOrEqual(t, int64(parentTiming.AwaitingTime()), int64(20*time.Millisecond))
+	require.GreaterOrEqual(t, int64(parentTiming.WallTime()), int64(parentTiming.AwaitingTime()))
+
+	require.Equal(t, "child", childTiming.ID)
+	require.Equal(t, "Child", childTiming.Name)
+	require.Equal(t, "parent", childTiming.ParentID)
+	require.Len(t, childTiming.Awaiting, 0)
+	require.Len(t, childTiming.Processes, 1)
+	require.Equal(t, "sleep 0.02", childTiming.Processes[0].Command)
+	require.GreaterOrEqual(t, int64(childTiming.ProcessTime()), int64(20*time.Millisecond))
+	require.GreaterOrEqual(t, int64(childTiming.WallTime()), int64(childTiming.ProcessTime()))
+
+	// the timings can be converted to a Chrome trace with a track per task.
+	var buf bytes.Buffer
+	require.NoError(t, mllint.WriteChromeTrace(&buf, timings))
+	trace := mllint.Trace{}
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &trace))
+	require.Equal(t, "ms", trace.DisplayTimeUnit)
+
+	names := map[int]string{}
+	categories := map[string]int{}
+	for _, event := range trace.TraceEvents {
+		if event.Phase == "M" {
+			names[event.ThreadID] = event.Args["name"]
+			continue
+		}
+		require.Equal(t, "X", event.Phase)
+		require.GreaterOrEqual(t, event.Timestamp, int64(0))
+		categories[event.Category]++
+	}
+	require.Equal(t, map[int]string{1: "Parent", 2: "Child"}, names)
+	require.Equal(t, 2, categories["task"])
+	require.Equal(t, 1, categories["awaiting"])
+	require.Equal(t, 1, categories["process"])
+}
@@ -0,0 +1,128 @@
+package mllint
+
+import (
+	"sync"
+	"time"
+)
+
+// TaskTiming holds the timing information of a task that was run by an MLLintRunner, see `runner.Timings()`.
+type TaskTiming struct {
+	ID   string
+	Name string
+	// ID of the task that scheduled this task on its child runner, empty for top-level tasks.
+	ParentID string
+
+	// moment at which the task was created by RunLinter
+	Scheduled time.Time
+	// moment at which the runner started running the task
+	Started time.Time
+	// moment at which the task's linter completed
+	Completed time.Time
+
+	// periods during which the task was waiting for its child tasks to complete, until it was allowed to resume.
+	Awaiting []Span
+	// external processes that the task's linter executed.
+	Processes []ProcessTiming
+}
+
+// Span is a period of time.
+type Span struct {
+	Start time.Time
+	End   time.Time
+}
+
+// Duration returns the length of the span.
+func (s Span) Duration() time.Duration {
+	return s.End.Sub(s.Start)
+}
+
+// ProcessTiming is the period during which an external process was running.
+type ProcessTiming struct {
+	Span
+	Command string
+}
+
+// QueuedTime returns how long the task was parked before the runner started it.
+func (t TaskTiming) QueuedTime() time.Duration {
+	return t.Started.Sub(t.Scheduled)
+}
+
+// WallTime returns how long the task took from the moment it started running until its linter completed.
+func (t TaskTiming) WallTime() time.Duration {
+	return t.Completed.Sub(t.Started)
+}
+
+// AwaitingTime returns how long the task spent waiting for its child tasks to complete.
+func (t TaskTiming) AwaitingTime() time.Duration {
+	total := time.Duration(0)
+	for _, span := range t.Awaiting {
+		total += span.Duration()
+	}
+	return total
+}
+
+// ProcessTime returns how long the task spent running external processes.
+// Processes that ran concurrently are counted separately, so this may exceed the task's wall time.
+func (t TaskTiming) ProcessTime() time.Duration {
+	total := time.Duration(0)
+	for _, process := range t.Processes {
+		total += process.Duration()
+	}
+	return total
+}
+
+//---------------------------------------------------------------------------------------
+
+// taskTimer records the timing of a task. Its methods may be called concurrently,
+// e.g. processes are recorded from the task's linter, while awaiting spans are recorded from a collector go-routine.
+type taskTimer struct {
+	mu     sync.Mutex
+	timing TaskTiming
+}
+
+func newTaskTimer(id string, scheduled time.Time) *taskTimer {
+	return &taskTimer{timing: TaskTiming{ID: id, Scheduled: scheduled}}
+}
+
+func (t *taskTimer) started() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.timing.Started = time.Now()
+}
+
+func (t *taskTimer) completed() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.timing.Completed = time.Now()
+}
+
+// awaiting records that the task starts awaiting its child tasks. Call the returned function once the task resumes.
+func (t *taskTimer) awaiting() func() {
+	start := time.Now()
+	return func() {
+		t.mu.Lock()
+		defer t.mu.Unlock()
+		t.timing.Awaiting = append(t.timing.Awaiting, Span{Start: start, End: time.Now()})
+	}
+}
+
+// RecordProcess implements exec.ProcessRecorder
+func (t *taskTimer) RecordProcess(command string, start time.Time, duration time.Duration) {
+	if t == nil {
+		return
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.timing.Processes = append(t.timing.Processes, ProcessTiming{Span: Span{Start: start, End: start.Add(duration)}, Command: command})
+}
+
+// get returns a copy of the recorded timing.
+func (t *taskTimer) get() TaskTiming {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	timing := t.timing
+	timing.Awaiting = append([]Span{}, t.timing.Awaiting...)
+	timing.Processes = append([]ProcessTiming{}, t.timing.Processes...)
+	return timing
+}
@@ -0,0 +1,80 @@
+package mllint
+
+import (
+	"encoding/json"
+	"io"
+	"time"
+)
+
+// TraceEvent is an event in the Chrome trace event format, see https://docs.google.com/document/d/1CvAClvFfyA5R-PhYUmn5OOQtYMH4h6I0nSsKchNAySU
+type TraceEvent struct {
+	Name      string            `json:"name"`
+	Category  string            `json:"cat,omitempty"`
+	Phase     string            `json:"ph"`
+	Timestamp int64             `json:"ts"`
+	Duration  int64             `json:"dur,omitempty"`
+	ProcessID int               `json:"pid"`
+	ThreadID  int               `json:"tid"`
+	Args      map[string]string `json:"args,omitempty"`
+}
+
+// Trace is a trace in the Chrome trace event format's JSON object format.
+type Trace struct {
+	TraceEvents     []TraceEvent `json:"traceEvents"`
+	DisplayTimeUnit string       `json:"displayTimeUnit"`
+}
+
+// WriteChromeTrace writes the given task timings to w as a trace in the Chrome trace event format,
+// which can be viewed in `chrome://tracing` or https://ui.perfetto.dev. Each task is displayed on its own track,
+// showing when it was queued, running, awaiting its child tasks and running external processes.
+func WriteChromeTrace(w io.Writer, timings []TaskTiming) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(NewChromeTrace(timings))
+}
+
+// NewChromeTrace converts the given task timings to a trace in the Chrome trace event format, see WriteChromeTrace.
+func NewChromeTrace(timings []TaskTiming) Trace {
+	trace := Trace{TraceEvents: []TraceEvent{}, DisplayTimeUnit: "ms"}
+	if len(timings) == 0 {
+		return trace
+	}
+
+	// timestamps are in microseconds, relative to the moment the first task was scheduled.
+	origin := timings[0].Scheduled
+	for _, timing := range timings {
+		if timing.Scheduled.Before(origin) {
+			origin = timing.Scheduled
+		}
+	}
+	micros := func(t time.Time) int64 {
+		return t.Sub(origin).Microseconds()
+	}
+	span := func(name, category string, tid int, start, end time.Time, args map[string]string) TraceEvent {
+		return TraceEvent{Name: name, Category: category, Phase: "X", Timestamp: micros(start), Duration: end.Sub(start).Microseconds(), ProcessID: 1, ThreadID: tid, Args: args}
+	}
+
+	for i, timing := range timings {
+		tid := i + 1
+		trace.TraceEvents = append(trace.TraceEvents, TraceEvent{Name: "thread_name", Phase: "M", ProcessID: 1, ThreadID: tid, Args: map[string]string{"name": timing.Name}})
+
+		if timing.Started.After(timing.Scheduled) {
+			trace.TraceEvents = append(trace.TraceEvents, span("queued", "queue", tid, timing.Scheduled, timing.Started, nil))
+		}
+
+		args := map[string]string{"id": timing.ID}
+		if timing.ParentID != "" {
+			args["parent"] = timing.ParentID
+		}
+		trace.TraceEvents = append(trace.TraceEvents, span(timing.Name, "task", tid, timing.Started, timing.Completed, args))
+
+		for _, awaiting := range timing.Awaiting {
+			trace.TraceEvents = append(trace.TraceEvents, span("awaiting child tasks", "awaiting", tid, awaiting.Start, awaiting.End, nil))
+		}
+		for _, process := range timing.Processes {
+			trace.TraceEvents = append(trace.TraceEvents, span(process.Command, "process", tid, process.Start, process.End, nil))
+		}
+	}
+
+	return trace
+}
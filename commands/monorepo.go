@@ -0,0 +1,243 @@
+package commands
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/hashicorp/go-multierror"
+
+	"github.com/bvobart/mllint/api"
+	"github.com/bvobart/mllint/categories"
+	"github.com/bvobart/mllint/commands/mllint"
+	"github.com/bvobart/mllint/config"
+	"github.com/bvobart/mllint/linters"
+	"github.com/bvobart/mllint/setools/git"
+	"github.com/bvobart/mllint/utils"
+	"github.com/bvobart/mllint/utils/markdown"
+)
+
+var ErrNoSubProjects = errors.New("no sub-projects found")
+
+// Files that mark the folder they are in as the root of a sub-project in a monorepo.
+var subProjectMarkers = []string{"pyproject.toml", "setup.py", string(config.TypeYAML)}
+
+// subProject is a sub-project of a monorepo that is linted with --monorepo.
+type subProject struct {
+	// path of the sub-project relative to the root of the monorepo
+	name     string
+	report   api.ProjectReport
+	timeouts map[string]time.Duration
+	// number of rules disabled in the sub-project's configuration
+	rulesDisabled int
+	tasks         []*mllint.RunnerTask
+}
+
+// runMonorepo lints each of the sub-projects in rc.ProjectR.Dir with their own configuration, which inherits from rc.Config,
+// running all their linters on the same runner, then prints a combined report.
+func (rc *runCommand) runMonorepo(globalTimeout time.Duration, runnerJobs int) error {
+	dirs, err := findSubProjects(rc.ProjectR.Dir)
+	if err != nil {
+		return fmt.Errorf("failed to find sub-projects: %w", err)
+	}
+	if len(dirs) == 0 {
+		return fmt.Errorf("%w in %s, expected folders with a %s, %s or %s file", ErrNoSubProjects, formatInlineCode(rc.ProjectR.Dir), formatInlineCode("pyproject.toml"), formatInlineCode("setup.py"), formatInlineCode(".mllint.yml"))
+	}
+
+	ctx, cancel := createContext(globalTimeout)
+	defer cancel()
+
+	rc.ProjectR.Git = git.MakeGitInfo(ctx, git.ForProject(rc.ProjectR.Project), rc.ProjectR.Dir)
+	subProjects := make([]*subProject, len(dirs))
+	for i, dir := range dirs {
+		if subProjects[i], err = prepareSubProject(ctx, rc.ProjectR.Dir, dir, rc.Config); err != nil {
+			return fmt.Errorf("sub-project %s: %w", formatInlineCode(dir), err)
+		}
+		shush(func() {
+			color.Green("Found sub-project   %s (config: %s)", color.HiWhiteString(subProjects[i].name), subProjects[i].report.ConfigType)
+		})
+	}
+	shush(func() { fmt.Print("---\n\n") })
+
+	// start the runner and do all linting of all sub-projects at once
+	closeProgress, err := rc.startRunner(runnerJobs)
+	if err != nil {
+		return err
+	}
+	defer closeProgress()
+
+	for _, sub := range subProjects {
+		sub.tasks = scheduleLinters(ctx, rc.Runner, sub.report.Project, sub.report.Linters, sub.timeouts, sub.report.Config.Runner.Weights, sub.name+" - ")
+	}
+
+	rulesFailed, rulesDisabled := 0, 0
+	rc.SubProjects = make([]api.ProjectReport, len(subProjects))
+	for i, sub := range subProjects {
+		sub.report.Reports, sub.report.Errors = collectReports(rc.Runner, sub.tasks...)
+		removeDisabledRules(sub.report.Reports, sub.report.Project)
+		overrideWeights(sub.report.Reports, sub.report.Config.Rules.Overrides)
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			sub.report.Errors = multierror.Append(sub.report.Errors, fmt.Errorf("mllint %w after %s, so this report is incomplete", mllint.ErrTimedOut, globalTimeout))
+		}
+
+		rc.SubProjects[i] = sub.report
+		rulesFailed += countRulesFailed(sub.report.Reports)
+		rulesDisabled += sub.rulesDisabled
+	}
+
+	if errors.Is(ctx.Err(), context.Canceled) {
+		rc.Runner.Close()
+		return ErrInterrupted
+	}
+
+	rc.Runner.Close()
+
+	// convert the reports of all sub-projects to Markdown
+	output := markdown.FromMonorepo(rc.ProjectR, rc.SubProjects)
+	return rc.printReport(output, rulesFailed, rulesDisabled)
+}
+
+// findSubProjects finds the folders in the given root directory that contain a pyproject.toml, setup.py or .mllint.yml file,
+// ignoring the root directory itself, as well as any folders nested inside other sub-projects or ignored by the repository's .gitignore files.
+// Returns the absolute paths to these folders, sorted alphabetically.
+func findSubProjects(root string) ([]string, error) {
+	markers, err := utils.FindFilesInDir(root, func(filename string) bool {
+		return filepath.Dir(filename) != "." && isSubProjectMarker(filepath.Base(filename))
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	dirs := []string{}
+	for _, marker := range markers {
+		if dir := filepath.Dir(marker); !isInAnyOf(dir, dirs) {
+			dirs = append(dirs, dir)
+		}
+	}
+
+	// a sub-project's own folders are found before its marker files, so only remove nested sub-projects once all of them are known.
+	subProjects := []string{}
+	for i, dir := range dirs {
+		others := append(append([]string{}, dirs[:i]...), dirs[i+1:]...)
+		if !isInAnyOf(dir, others) {
+			subProjects = append(subProjects, dir)
+		}
+	}
+
+	return utils.Filenames(subProjects).Prefix(root), nil
+}
+
+func isSubProjectMarker(name string) bool {
+	for _, marker := range subProjectMarkers {
+		if name == marker {
+			return true
+		}
+	}
+	return false
+}
+
+// isInAnyOf returns whether the given relative path is equal to or inside of any of the given folders.
+func isInAnyOf(path string, folders []string) bool {
+	for _, folder := range folders {
+		if path == folder || strings.HasPrefix(path, folder+string(filepath.Separator)) {
+			return true
+		}
+	}
+	return false
+}
+
+// prepareSubProject parses the configuration of the sub-project in the given directory, on top of the configuration of the monorepo's root,
+// then creates and configures the linters for the sub-project and runs the pre-analysis checks on it.
+func prepareSubProject(ctx context.Context, root string, dir string, rootConfig *config.Config) (*subProject, error) {
+	conf, configType, err := config.ParseFromDirWithBase(dir, rootConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	// the global timeout and number of jobs of the root's configuration apply to all sub-projects.
+	_, timeouts, err := parseTimeouts(conf.Timeouts)
+	if err != nil {
+		return nil, fmt.Errorf("invalid timeouts configuration: %w", err)
+	}
+	if _, err := git.BackendByName(conf.Git.Backend); err != nil {
+		return nil, fmt.Errorf("invalid Git configuration: %w", err)
+	}
+	if err := checkWeights(conf.Runner.Weights); err != nil {
+		return nil, fmt.Errorf("invalid runner configuration: %w", err)
+	}
+
+	// each sub-project gets its own linters, such that they can each be configured with the sub-project's configuration.
+	projectLinters := linters.NewByCategory()
+	if err := linters.Configure(projectLinters, conf); err != nil {
+		return nil, err
+	}
+	disabledRules := disableRules(projectLinters, conf.Rules.Disabled)
+
+	name, err := filepath.Rel(root, dir)
+	if err != nil {
+		name = dir
+	}
+
+	sub := &subProject{name: name, timeouts: timeouts, rulesDisabled: len(disabledRules)}
+	sub.report.Dir = dir
+	sub.report.DisabledRules = disabledRules
+	sub.report.Config = *conf
+	sub.report.ConfigType = configType
+	sub.report.Linters = projectLinters
+	if err := runPreAnalysisChecks(ctx, &sub.report.Project); err != nil {
+		return nil, fmt.Errorf("failed to run pre-analysis checks: %w", err)
+	}
+	return sub, nil
+}
+
+// disableRules returns the slugs of the rules of the given linters of a sub-project that are referenced by the given slugs, either by their
+// category's slug or by their own slug, see linters.MatchRules. The linters whose rules are all disabled are removed, such that they are not run at all.
+// Unlike linters.DisableAll, this does not disable the rules themselves, since rules are shared between the linters of all sub-projects,
+// so the returned slugs should be set as the sub-project's api.Project.DisabledRules instead, which the linters check before analysing a rule.
+func disableRules(projectLinters map[api.Category]api.Linter, slugs []string) map[string]bool {
+	disabled := map[string]bool{}
+	for _, slug := range slugs {
+		if cat, found := categories.BySlug[slug]; found {
+			if linter, found := projectLinters[cat]; found {
+				for _, rule := range linter.Rules() {
+					disabled[rule.Slug] = true
+				}
+			}
+			continue
+		}
+
+		if cat, found := linters.GetCategory(slug); found {
+			if linter, found := projectLinters[cat]; found {
+				for _, rule := range linters.MatchRules(linter.Rules(), slug) {
+					disabled[rule.Slug] = true
+				}
+			}
+		}
+	}
+
+	project := api.Project{DisabledRules: disabled}
+	for cat, linter := range projectLinters {
+		if project.AllRulesDisabled(linter) {
+			delete(projectLinters, cat)
+		}
+	}
+	return disabled
+}
+
+// removeDisabledRules removes the scores and details of all rules that are disabled for the given project from the given reports,
+// in case a linter still scored them, e.g. because it checks several rules at once.
+func removeDisabledRules(reports map[api.Category]api.Report, project api.Project) {
+	for _, report := range reports {
+		for rule := range report.Scores {
+			rule := rule
+			if project.RuleDisabled(&rule) {
+				delete(report.Scores, rule)
+				delete(report.Details, rule)
+			}
+		}
+	}
+}
@@ -0,0 +1,298 @@
+package commands_test
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/bvobart/mllint/api"
+	"github.com/bvobart/mllint/categories"
+	"github.com/bvobart/mllint/commands"
+	"github.com/bvobart/mllint/config"
+	"github.com/bvobart/mllint/linters"
+	"github.com/bvobart/mllint/linters/versioncontrol"
+)
+
+func writeFiles(t *testing.T, dir string, files map[string]string) {
+	for filename, contents := range files {
+		file := path.Join(dir, filename)
+		require.NoError(t, os.MkdirAll(path.Dir(file), 0755))
+		require.NoError(t, ioutil.WriteFile(file, []byte(contents), 0644))
+	}
+}
+
+func TestFindSubProjects(t *testing.T) {
+	tests := []struct {
+		name     string
+		files    map[string]string
+		expected []string
+	}{
+		{
+			name:     "NoSubProjects",
+			files:    map[string]string{"pyproject.toml": "", "src/main.py": ""},
+			expected: []string{},
+		},
+		{
+			name: "SubProjects",
+			files: map[string]string{
+				"pyproject.toml":              "",
+				"training/pyproject.toml":     "",
+				"serving/setup.py":            "",
+				"tools/linting/.mllint.yml":   "",
+				"docs/index.md":               "",
+				"training/src/train.py":       "",
+				"serving/src/serve/server.py": "",
+			},
+			expected: []string{"serving", "tools/linting", "training"},
+		},
+		{
+			name: "NestedSubProjects",
+			files: map[string]string{
+				"training/pyproject.toml":          "",
+				"training/a-folder/setup.py":       "",
+				"training/plugins/gpu/setup.py":    "",
+				"training/plugins/gpu/.mllint.yml": "",
+				"serving/setup.py":                 "",
+			},
+			expected: []string{"serving", "training"},
+		},
+		{
+			name: "IgnoredSubProjects",
+			files: map[string]string{
+				".gitignore":                      "/vendor/\n",
+				"training/pyproject.toml":         "",
+				"vendor/some-package/setup.py":    "",
+				"venv/lib/some-package/setup.py":  "",
+				".hidden/some-project/setup.py":   "",
+				"training/.tox/py38/lib/setup.py": "",
+			},
+			expected: []string{"training"},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			writeFiles(t, dir, tt.files)
+
+			expected := []string{}
+			for _, sub := range tt.expected {
+				expected = append(expected, path.Join(dir, sub))
+			}
+
+			subProjects, err := commands.FindSubProjects(dir)
+			require.NoError(t, err)
+			require.Equal(t, expected, subProjects)
+		})
+	}
+}
+
+func TestDisableRules(t *testing.T) {
+	vcRules := len(linters.ByCategory[categories.VersionControl].Rules())
+	testingRules := len(linters.ByCategory[categories.Testing].Rules())
+
+	tests := []struct {
+		name            string
+		slugs           []string
+		expectedRemoved []api.Category
+		expectedCount   int
+		expectedSlugs   []string
+	}{
+		{
+			name:          "Nothing",
+			slugs:         []string{},
+			expectedCount: 0,
+		},
+		{
+			name:            "Category",
+			slugs:           []string{"version-control"},
+			expectedRemoved: []api.Category{categories.VersionControl},
+			expectedCount:   vcRules,
+			expectedSlugs:   []string{versioncontrol.RuleGit.Slug, versioncontrol.RuleNoSecrets.Slug},
+		},
+		{
+			name:          "Rules",
+			slugs:         []string{"version-control/code/git-no-big-files", "version-control/code/git-no-models"},
+			expectedCount: 2,
+			expectedSlugs: []string{versioncontrol.RuleGitNoBigFiles.Slug, versioncontrol.RuleGitNoModels.Slug},
+		},
+		{
+			name:          "RulePrefix",
+			slugs:         []string{"version-control/code/git-no-"},
+			expectedCount: 3,
+			expectedSlugs: []string{versioncontrol.RuleGitNoBigFiles.Slug, versioncontrol.RuleGitNoModels.Slug, versioncontrol.RuleGitNoData.Slug},
+		},
+		{
+			name:          "ExactRule",
+			slugs:         []string{"version-control/code/git"},
+			expectedCount: 1,
+			expectedSlugs: []string{versioncontrol.RuleGit.Slug},
+		},
+		{
+			name:            "AllRulesOfCategory",
+			slugs:           []string{"testing/"},
+			expectedRemoved: []api.Category{categories.Testing},
+			expectedCount:   testingRules,
+		},
+		{
+			name:            "CategoryAndUnknownSlugs",
+			slugs:           []string{"testing", "non-existent", "non-existent/rule"},
+			expectedRemoved: []api.Category{categories.Testing},
+			expectedCount:   testingRules,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			projectLinters := linters.NewByCategory()
+			disabled := commands.DisableRules(projectLinters, tt.slugs)
+			require.Len(t, disabled, tt.expectedCount)
+			for _, slug := range tt.expectedSlugs {
+				require.True(t, disabled[slug], slug)
+			}
+
+			for cat := range linters.ByCategory {
+				_, found := projectLinters[cat]
+				require.Equal(t, !containsCategory(tt.expectedRemoved, cat), found, cat.Slug)
+			}
+
+			// the rules themselves are not disabled, as they are shared between all sub-projects.
+			for _, linter := range linters.ByCategory {
+				for _, rule := range linter.Rules() {
+					require.False(t, rule.Disabled, rule.Slug)
+				}
+			}
+		})
+	}
+}
+
+func containsCategory(cats []api.Category, cat api.Category) bool {
+	for _, c := range cats {
+		if c.Slug == cat.Slug {
+			return true
+		}
+	}
+	return false
+}
+
+func TestRemoveDisabledRules(t *testing.T) {
+	tests := []struct {
+		name     string
+		disabled map[string]bool
+		expected []*api.Rule
+	}{
+		{
+			name:     "Nothing",
+			disabled: nil,
+			expected: []*api.Rule{&versioncontrol.RuleGit, &versioncontrol.RuleGitNoBigFiles, &versioncontrol.RuleGitNoModels},
+		},
+		{
+			name:     "Rule",
+			disabled: map[string]bool{versioncontrol.RuleGitNoBigFiles.Slug: true},
+			expected: []*api.Rule{&versioncontrol.RuleGit, &versioncontrol.RuleGitNoModels},
+		},
+		{
+			name:     "Rules",
+			disabled: map[string]bool{versioncontrol.RuleGitNoBigFiles.Slug: true, versioncontrol.RuleGitNoModels.Slug: true},
+			expected: []*api.Rule{&versioncontrol.RuleGit},
+		},
+		{
+			name:     "All",
+			disabled: map[string]bool{versioncontrol.RuleGit.Slug: true, versioncontrol.RuleGitNoBigFiles.Slug: true, versioncontrol.RuleGitNoModels.Slug: true},
+			expected: []*api.Rule{},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			report := api.NewReport()
+			for _, rule := range []*api.Rule{&versioncontrol.RuleGit, &versioncontrol.RuleGitNoBigFiles, &versioncontrol.RuleGitNoModels} {
+				report.Scores[*rule] = 100
+				report.Details[*rule] = "details of " + rule.Slug
+			}
+			reports := map[api.Category]api.Report{categories.VersionControl: report}
+
+			commands.RemoveDisabledRules(reports, api.Project{DisabledRules: tt.disabled})
+			require.Len(t, report.Scores, len(tt.expected))
+			require.Len(t, report.Details, len(tt.expected))
+			for _, rule := range tt.expected {
+				require.Contains(t, report.Scores, *rule)
+				require.Contains(t, report.Details, *rule)
+			}
+		})
+	}
+}
+
+func TestPrepareSubProject(t *testing.T) {
+	rootConfig := config.Default()
+	rootConfig.Rules.Disabled = []string{"testing"}
+	rootConfig.Git.MaxFileSize = 1000
+
+	tests := []struct {
+		name                  string
+		files                 map[string]string
+		expectedType          config.FileType
+		expectedDisabled      []string
+		expectedMaxFileSize   uint64
+		expectedRulesDisabled int
+	}{
+		{
+			name:                  "Inherited",
+			files:                 map[string]string{"training/setup.py": ""},
+			expectedType:          config.TypeDefault,
+			expectedDisabled:      []string{"testing"},
+			expectedMaxFileSize:   1000,
+			expectedRulesDisabled: len(linters.ByCategory[categories.Testing].Rules()),
+		},
+		{
+			name: "DisabledRulesAdded",
+			files: map[string]string{
+				"training/.mllint.yml": "rules:\n  disabled:\n    - version-control/code/git-no-big-files\n",
+			},
+			expectedType:          config.TypeYAML,
+			expectedDisabled:      []string{"testing", "version-control/code/git-no-big-files"},
+			expectedMaxFileSize:   1000,
+			expectedRulesDisabled: len(linters.ByCategory[categories.Testing].Rules()) + 1,
+		},
+		{
+			name: "Overridden",
+			files: map[string]string{
+				"training/.mllint.yml": "git:\n  maxFileSize: 2000\n",
+			},
+			expectedType:          config.TypeYAML,
+			expectedDisabled:      []string{"testing"},
+			expectedMaxFileSize:   2000,
+			expectedRulesDisabled: len(linters.ByCategory[categories.Testing].Rules()),
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			root := t.TempDir()
+			writeFiles(t, root, tt.files)
+
+			report, name, rulesDisabled, err := commands.PrepareSubProject(context.Background(), root, path.Join(root, "training"), rootConfig)
+			require.NoError(t, err)
+			require.Equal(t, "training", name)
+			require.Equal(t, path.Join(root, "training"), report.Dir)
+			require.Equal(t, tt.expectedType, report.ConfigType)
+			require.Equal(t, tt.expectedDisabled, report.Config.Rules.Disabled)
+			require.Equal(t, tt.expectedMaxFileSize, report.Config.Git.MaxFileSize)
+			require.Equal(t, tt.expectedRulesDisabled, rulesDisabled)
+			require.Len(t, report.DisabledRules, tt.expectedRulesDisabled)
+			require.NotContains(t, report.Linters, categories.Testing)
+
+			// the root's configuration is not modified by that of the sub-project.
+			require.Equal(t, []string{"testing"}, rootConfig.Rules.Disabled)
+			require.EqualValues(t, 1000, rootConfig.Git.MaxFileSize)
+		})
+	}
+}
@@ -32,6 +32,13 @@ func NewRootCommand() *cobra.Command {
 	SetOutputFlag(cmd)
 	SetForceFlag(cmd)
 	SetProgressPlainFlag(cmd)
+	SetProgressJSONFlag(cmd)
+	SetJobsFlag(cmd)
+	SetSerialFlag(cmd)
+	SetNoCacheFlag(cmd)
+	SetChangesFlags(cmd)
+	SetProfileFlags(cmd)
+	SetMonorepoFlag(cmd)
 
 	cmd.AddCommand(NewRunCommand())
 	cmd.AddCommand(NewListCommand())
@@ -39,6 +46,7 @@ func NewRootCommand() *cobra.Command {
 	cmd.AddCommand(NewRenderCommand())
 	cmd.AddCommand(NewVersionCommand())
 	cmd.AddCommand(NewDescribeCommand())
+	cmd.AddCommand(NewCacheCommand())
 	return cmd
 }
 
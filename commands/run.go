@@ -1,8 +1,14 @@
 package commands
 
 import (
+	"context"
 	"errors"
 	"fmt"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"syscall"
+	"time"
 
 	"github.com/fatih/color"
 	"github.com/hashicorp/go-multierror"
@@ -14,14 +20,18 @@ import (
 	"github.com/bvobart/mllint/config"
 	"github.com/bvobart/mllint/linters"
 	"github.com/bvobart/mllint/setools/cqlinters"
+	"github.com/bvobart/mllint/setools/datavc"
 	"github.com/bvobart/mllint/setools/depmanagers"
 	"github.com/bvobart/mllint/setools/git"
 	"github.com/bvobart/mllint/utils"
+	"github.com/bvobart/mllint/utils/cache"
 	"github.com/bvobart/mllint/utils/markdown"
 )
 
 var ErrNotAFolder = errors.New("not a folder")
 var ErrOutputFileAlreadyExists = errors.New("output file already exists")
+var ErrInterrupted = errors.New("interrupted")
+var ErrNotAGitRepository = errors.New("not a Git repository")
 
 func NewRunCommand() *cobra.Command {
 	runner := runCommand{}
@@ -37,6 +47,13 @@ func NewRunCommand() *cobra.Command {
 	SetOutputFlag(cmd)
 	SetForceFlag(cmd)
 	SetProgressPlainFlag(cmd)
+	SetProgressJSONFlag(cmd)
+	SetJobsFlag(cmd)
+	SetSerialFlag(cmd)
+	SetNoCacheFlag(cmd)
+	SetChangesFlags(cmd)
+	SetProfileFlags(cmd)
+	SetMonorepoFlag(cmd)
 	return cmd
 }
 
@@ -44,23 +61,39 @@ type runCommand struct {
 	ProjectR api.ProjectReport
 	Config   *config.Config
 	Runner   *mllint.MLLintRunner
+	// the sub-projects that were linted with --monorepo, in which case ProjectR only describes the monorepo's root.
+	SubProjects []api.ProjectReport
 }
 
 // Runs pre-analysis checks:
 // - Retrieve some info about project's Git state
 // - Detect dependency managers used in the project
 // - Detect code quality linters used in the project
-// - Detect the Python files in the project repository.
-func (rc *runCommand) runPreAnalysisChecks() error {
-	rc.ProjectR.Git = git.MakeGitInfo(rc.ProjectR.Dir)
-	rc.ProjectR.DepManagers = depmanagers.Detect(rc.ProjectR.Project)
-	rc.ProjectR.CQLinters = cqlinters.Detect(rc.ProjectR.Project)
+// - Detect data version control tools used in the project
+// - Detect the Python files in the project repository, restricting them to the changed files with --changed-since or --staged.
+// - Detect the Jupyter Notebooks in the project repository.
+func runPreAnalysisChecks(ctx context.Context, project *api.Project) error {
+	project.Git = git.MakeGitInfo(ctx, git.ForProject(*project), project.Dir)
+	project.DepManagers = depmanagers.Detect(*project)
+	project.CQLinters = cqlinters.Detect(*project)
+	project.DataVCs = datavc.Detect(*project)
+
+	// only analyse the files selected by the configuration, in addition to those not ignored by the project's .gitignore files.
+	filter := project.FileFilter()
+	pyfiles, err := filter.FindFilesByExt(project.Dir, ".py")
+	if err != nil {
+		return err
+	}
+	project.PythonFiles = pyfiles.Prefix(project.Dir)
+	if err = restrictToChanges(ctx, project); err != nil {
+		return err
+	}
 
-	pyfiles, err := utils.FindPythonFilesIn(rc.ProjectR.Dir)
+	notebooks, err := filter.FindFilesByExt(project.Dir, ".ipynb")
 	if err != nil {
 		return err
 	}
-	rc.ProjectR.PythonFiles = pyfiles.Prefix(rc.ProjectR.Dir)
+	project.Notebooks = notebooks.Prefix(project.Dir)
 
 	return nil
 }
@@ -70,6 +103,9 @@ func (rc *runCommand) RunLint(cmd *cobra.Command, args []string) error {
 	if err != nil {
 		return err
 	}
+	if err = checkChangesFlags(); err != nil {
+		return err
+	}
 
 	rc.ProjectR = api.ProjectReport{}
 	rc.ProjectR.Dir, err = parseProjectDir(args)
@@ -85,6 +121,30 @@ func (rc *runCommand) RunLint(cmd *cobra.Command, args []string) error {
 	rc.ProjectR.Config = *rc.Config
 	shush(func() { fmt.Print("---\n\n") })
 
+	// check the backend used to access the project's Git repository, which the linters select with git.ForProject.
+	if _, err = git.BackendByName(rc.Config.Git.Backend); err != nil {
+		return fmt.Errorf("invalid Git configuration: %w", err)
+	}
+
+	globalTimeout, linterTimeouts, err := parseTimeouts(rc.Config.Timeouts)
+	if err != nil {
+		return fmt.Errorf("invalid timeouts configuration: %w", err)
+	}
+
+	runnerJobs, err := parseJobs(rc.Config.Runner)
+	if err != nil {
+		return fmt.Errorf("invalid runner configuration: %w", err)
+	}
+
+	cache.Default = nil
+	if !noCache {
+		cache.Default = createCache()
+	}
+
+	if monorepo {
+		return rc.runMonorepo(globalTimeout, runnerJobs)
+	}
+
 	// configure all linters with config
 	if err = linters.ConfigureAll(rc.Config); err != nil {
 		return err
@@ -93,24 +153,87 @@ func (rc *runCommand) RunLint(cmd *cobra.Command, args []string) error {
 	// disable any rules from config.
 	// This is done after configuring each linter, such that any rules arising from the configuration (e.g. custom rules) can also be disabled.
 	rulesDisabled := linters.DisableAll(rc.Config.Rules.Disabled)
+	rc.ProjectR.Linters = linters.ByCategory
+
+	ctx, cancel := createContext(globalTimeout)
+	defer cancel()
 
 	// run pre-analysis checks
-	if err = rc.runPreAnalysisChecks(); err != nil {
+	if err = runPreAnalysisChecks(ctx, &rc.ProjectR.Project); err != nil {
 		return fmt.Errorf("failed to run pre-analysis checks: %w", err)
 	}
 
 	// start the runner and do all linting
-	progress := createRunnerProgress()
-	rc.Runner = mllint.NewMLLintRunner(progress)
-	rc.Runner.Start()
+	closeProgress, err := rc.startRunner(runnerJobs)
+	if err != nil {
+		return err
+	}
+	defer closeProgress()
 
-	tasks := scheduleLinters(rc.Runner, rc.ProjectR.Project, linters.ByCategory)
+	tasks := scheduleLinters(ctx, rc.Runner, rc.ProjectR.Project, rc.ProjectR.Linters, linterTimeouts, rc.Config.Runner.Weights, "")
 	rc.ProjectR.Reports, rc.ProjectR.Errors = collectReports(rc.Runner, tasks...)
+	overrideWeights(rc.ProjectR.Reports, rc.Config.Rules.Overrides)
+
+	if errors.Is(ctx.Err(), context.Canceled) {
+		rc.Runner.Close()
+		return ErrInterrupted
+	}
+	if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		rc.ProjectR.Errors = multierror.Append(rc.ProjectR.Errors, fmt.Errorf("mllint %w after %s, so this report is incomplete", mllint.ErrTimedOut, globalTimeout))
+	}
+
+	rc.Runner.Close()
 
 	// convert project report to Markdown
 	output := markdown.FromProject(rc.ProjectR)
+	return rc.printReport(output, countRulesFailed(rc.ProjectR.Reports), rulesDisabled)
+}
 
-	rc.Runner.Close()
+// createContext creates the context with which all linters are run, which is cancelled when mllint is interrupted or exceeds its global timeout,
+// killing any processes that the linters started. Call the returned function to release the context's resources.
+func createContext(globalTimeout time.Duration) (context.Context, context.CancelFunc) {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	if globalTimeout <= 0 {
+		return ctx, stop
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, globalTimeout)
+	return ctx, func() {
+		cancel()
+		stop()
+	}
+}
+
+// startRunner creates and starts rc.Runner with the progress output selected by the command's flags.
+// The returned function closes the file given with --progress-json, which should happen after the runner is closed.
+func (rc *runCommand) startRunner(runnerJobs int) (func(), error) {
+	progress := createRunnerProgress()
+	closeProgress := func() {}
+	if progressJSON != "" {
+		jsonProgress, closeJSONProgress, err := createJSONProgress()
+		if err != nil {
+			return nil, err
+		}
+		progress = mllint.CombineProgress(progress, jsonProgress)
+		closeProgress = closeJSONProgress
+	}
+
+	rc.Runner = mllint.NewMLLintRunner(progress, mllint.Jobs(runnerJobs))
+	rc.Runner.Start()
+	return closeProgress, nil
+}
+
+// printReport appends the profile to the Markdown report when requested, then writes the report to the output selected by the command's flags,
+// followed by a summary of the amount of rules that failed or were disabled. Call this once the runner is closed.
+func (rc *runCommand) printReport(output string, rulesFailed int, rulesDisabled int) error {
+	if profile {
+		output += markdown.FromProfile(rc.Runner.Timings())
+	}
+	if profileTrace != "" {
+		if err := writeProfileTrace(rc.Runner.Timings()); err != nil {
+			return err
+		}
+	}
 
 	if outputToStdout() {
 		fmt.Println(output)
@@ -125,7 +248,6 @@ func (rc *runCommand) RunLint(cmd *cobra.Command, args []string) error {
 
 	shush(func() { fmt.Println("---") })
 
-	rulesFailed := countRulesFailed(rc.ProjectR.Reports)
 	if rulesDisabled > 0 {
 		printSkipped(rulesDisabled)
 	}
@@ -151,15 +273,161 @@ func createRunnerProgress() mllint.RunnerProgress {
 	return mllint.NewBasicRunnerProgress()
 }
 
-func scheduleLinters(runner mllint.Runner, project api.Project, linters map[api.Category]api.Linter) []*mllint.RunnerTask {
+// createJSONProgress creates a RunnerProgress that writes JSON events to the file given with --progress-json, or to stderr if that is `-`.
+// The returned function closes the file, which should happen after the runner is closed.
+func createJSONProgress() (mllint.RunnerProgress, func(), error) {
+	if progressJSON == "-" {
+		return mllint.NewJSONRunnerProgress(os.Stderr), func() {}, nil
+	}
+
+	file, err := os.Create(progressJSON)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create progress file: %w", err)
+	}
+	return mllint.NewJSONRunnerProgress(file), func() { file.Close() }, nil
+}
+
+// writeProfileTrace writes the given task timings as a Chrome trace to the file given with --profile-trace.
+func writeProfileTrace(timings []mllint.TaskTiming) error {
+	file, err := os.Create(profileTrace)
+	if err != nil {
+		return fmt.Errorf("failed to create profile trace file: %w", err)
+	}
+	defer file.Close()
+
+	if err := mllint.WriteChromeTrace(file, timings); err != nil {
+		return fmt.Errorf("failed to write profile trace: %w", err)
+	}
+	return nil
+}
+
+// restrictToChanges restricts the project's Python files to those that changed since the Git ref given with --changed-since,
+// or to those that are staged when using --staged. Does nothing if neither flag is used.
+func restrictToChanges(ctx context.Context, project *api.Project) error {
+	if changedSince == "" && !staged {
+		return nil
+	}
+	backend := git.ForProject(*project)
+	if !backend.Detect(ctx, project.Dir) {
+		return fmt.Errorf("%w: can only lint changed files of projects in a Git repository", ErrNotAGitRepository)
+	}
+
+	var changed []string
+	var err error
+	var description string
+	if staged {
+		changed, err = backend.ListStagedFiles(ctx, project.Dir)
+		description = "staged files"
+	} else {
+		changed, err = backend.ListChangedFiles(ctx, project.Dir, changedSince)
+		description = "files changed since `" + changedSince + "`"
+	}
+	if err != nil {
+		return err
+	}
+
+	// the changed files are relative to the root of the Git repository, which may be a parent of the project's directory.
+	root := resolveSymlinks(backend.GetGitRoot(ctx, project.Dir))
+	dir := resolveSymlinks(project.Dir)
+	isChanged := make(map[string]bool, len(changed))
+	for _, filename := range changed {
+		isChanged[filepath.Join(root, filepath.FromSlash(filename))] = true
+	}
+
+	project.Partial = &api.PartialScope{Description: description, AllPythonFiles: project.PythonFiles}
+	project.PythonFiles = project.PythonFiles.Filter(func(filename string) bool {
+		relpath, err := filepath.Rel(project.Dir, filename)
+		return err == nil && isChanged[filepath.Join(dir, relpath)]
+	})
+	return nil
+}
+
+func resolveSymlinks(filename string) string {
+	if resolved, err := filepath.EvalSymlinks(filename); err == nil {
+		return resolved
+	}
+	return filename
+}
+
+// createCache creates the cache in which linters store their results, or returns nil if mllint can't determine where to store it.
+func createCache() *cache.Cache {
+	dir, err := cache.UserDir()
+	if err != nil {
+		shush(func() {
+			color.Yellow("Warning: not caching any results, as the cache directory could not be determined: %s", err)
+		})
+		return nil
+	}
+
+	cache.Version = version
+	return cache.New(dir)
+}
+
+// parseTimeouts parses the global timeout and the timeouts of individual linters from mllint's configuration.
+func parseTimeouts(conf config.TimeoutsConfig) (time.Duration, map[string]time.Duration, error) {
+	global, err := mllint.ParseTimeout(conf.Global)
+	if err != nil {
+		return 0, nil, fmt.Errorf("global timeout: %w", err)
+	}
+
+	linterTimeouts := make(map[string]time.Duration, len(conf.Linters))
+	for name, timeout := range conf.Linters {
+		if linterTimeouts[name], err = mllint.ParseTimeout(timeout); err != nil {
+			return 0, nil, fmt.Errorf("timeout of linter '%s': %w", name, err)
+		}
+	}
+	return global, linterTimeouts, nil
+}
+
+// parseJobs determines the number of job slots of mllint's runner from the --jobs and --serial flags and mllint's configuration,
+// and checks that the configured weights of linters are valid. Returns 0 when the runner should use its default.
+func parseJobs(conf config.RunnerConfig) (int, error) {
+	if err := checkWeights(conf.Weights); err != nil {
+		return 0, err
+	}
+
+	if serial {
+		return 1, nil
+	}
+	if jobs < 0 {
+		return 0, fmt.Errorf("--jobs must not be negative, but was %d", jobs)
+	}
+	if jobs > 0 {
+		return jobs, nil
+	}
+	if conf.Jobs < 0 {
+		return 0, fmt.Errorf("jobs must not be negative, but was %d", conf.Jobs)
+	}
+	return conf.Jobs, nil
+}
+
+// checkWeights checks that the configured weights of linters are valid.
+func checkWeights(weights map[string]int) error {
+	for name, weight := range weights {
+		if weight < 1 {
+			return fmt.Errorf("weight of linter '%s' must be at least 1, but was %d", name, weight)
+		}
+	}
+	return nil
+}
+
+// scheduleLinters schedules tasks on the runner to run each of the given linters on the project.
+// When namePrefix is not empty, it is prepended to the name of each task and of the tasks they schedule themselves,
+// e.g. to tell apart the linters of different projects.
+func scheduleLinters(ctx context.Context, runner mllint.Runner, project api.Project, linters map[api.Category]api.Linter, timeouts map[string]time.Duration, weights map[string]int, namePrefix string) []*mllint.RunnerTask {
 	tasks := make([]*mllint.RunnerTask, 0, len(linters))
 	for cat, linter := range linters {
 		if len(linter.Rules()) == 0 {
 			continue
 		}
 
+		options := []mllint.TaskOption{mllint.Timeout(timeouts[cat.Slug]), mllint.Weight(weights[cat.Slug])}
+		if namePrefix != "" {
+			options = append(options, mllint.NamePrefix(namePrefix))
+		}
+
 		// use cat.Slug as ID so we can retrieve the category from categories.BySlug later, see collectReports(..)
-		task := runner.RunLinter(cat.Slug, linter, project)
+		task := runner.RunLinter(ctx, cat.Slug, linter, project, options...)
 		tasks = append(tasks, task)
 	}
 	return tasks
@@ -182,6 +450,13 @@ func collectReports(runner mllint.Runner, tasks ...*mllint.RunnerTask) (map[api.
 	return reports, err
 }
 
+// overrideWeights overrides the weights of the rules in the given reports with the weights configured in `rules.overrides`.
+func overrideWeights(reports map[api.Category]api.Report, overrides map[string]config.RuleOverride) {
+	for _, report := range reports {
+		report.OverrideWeights(overrides)
+	}
+}
+
 func countRulesFailed(reports map[api.Category]api.Report) int {
 	rulesFailed := 0
 	for _, report := range reports {
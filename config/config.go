@@ -4,7 +4,6 @@ import (
 	"fmt"
 	"io"
 	"io/ioutil"
-	"os"
 	"path"
 
 	"github.com/pelletier/go-toml"
@@ -15,10 +14,28 @@ import (
 
 // Config describes the structure of an `.mllint.yml` file
 type Config struct {
+	// Configurations that this configuration extends, applied in order before the configuration itself.
+	// Each is either the name of one of mllint's built-in presets (`strict`, `research` or `production`),
+	// or the path to a YAML or TOML configuration file, either absolute or relative to the configuration file's folder,
+	// e.g. to share an organisation-wide configuration between projects. See `mllint config` for how they are merged.
+	Extends []string `yaml:"extends" toml:"extends"`
+
+	// Patterns of the files and folders that mllint analyses, in `.gitignore` syntax relative to the project's directory,
+	// e.g. `src/` or `**/*_pb2.py`. Files ignored by the project's `.gitignore` files are never analysed.
+	// When Include is not empty, only files matching at least one of its patterns are analysed.
+	// Files and folders matching any of the Exclude patterns are not analysed, even if they match an Include pattern.
+	Include []string `yaml:"include" toml:"include"`
+	Exclude []string `yaml:"exclude" toml:"exclude"`
+
 	Rules       RuleConfig        `yaml:"rules" toml:"rules"`
 	Git         GitConfig         `yaml:"git" toml:"git"`
 	CodeQuality CodeQualityConfig `yaml:"code-quality" toml:"code-quality"`
 	Testing     TestingConfig     `yaml:"testing" toml:"testing"`
+	Notebooks   NotebooksConfig   `yaml:"notebooks" toml:"notebooks"`
+	Secrets     SecretsConfig     `yaml:"secrets" toml:"secrets"`
+	CI          CIConfig          `yaml:"ci" toml:"ci"`
+	Timeouts    TimeoutsConfig    `yaml:"timeouts" toml:"timeouts"`
+	Runner      RunnerConfig      `yaml:"runner" toml:"runner"`
 }
 
 //---------------------------------------------------------------------------------------
@@ -27,6 +44,20 @@ type Config struct {
 type RuleConfig struct {
 	Disabled []string     `yaml:"disabled" toml:"disabled"`
 	Custom   []CustomRule `yaml:"custom" toml:"custom"`
+
+	// Overrides of the weights and parameters of individual rules, keyed by the rule's slug, e.g. `code-quality/pylint/no-issues`.
+	// Use `mllint describe <slug>` to see which parameters a rule has.
+	Overrides map[string]RuleOverride `yaml:"overrides" toml:"overrides"`
+}
+
+// RuleOverride contains the configuration that overrides the weight and parameters of a rule.
+type RuleOverride struct {
+	// Weight of the rule's score within its category, instead of the rule's default weight. Must not be negative.
+	// A weight of 0 means that the rule is still checked, but does not count towards its category's score.
+	Weight *float64 `yaml:"weight,omitempty" toml:"weight,omitempty"`
+
+	// Values of the rule's parameters, keyed by the parameter's name, e.g. `maxLoCperMsg`.
+	Params map[string]float64 `yaml:"params,omitempty" toml:"params,omitempty"`
 }
 
 // CustomRule contains the configuration for custom rules
@@ -36,6 +67,13 @@ type CustomRule struct {
 	Details string  `yaml:"details" toml:"details"`
 	Weight  float64 `yaml:"weight" toml:"weight"`
 	Run     string  `yaml:"run" toml:"run"`
+
+	// Maximum duration of the rule's `run` command, e.g. `30s`, after which it is killed. Empty means no timeout.
+	Timeout string `yaml:"timeout" toml:"timeout"`
+
+	// Whether to cache the output of the rule's `run` command, such that it is only run again when the files that mllint analyses change.
+	// Disabled by default, since the command may also read files that mllint does not consider, e.g. hidden, gitignored or virtualenv files.
+	Cache bool `yaml:"cache" toml:"cache"`
 }
 
 //---------------------------------------------------------------------------------------
@@ -45,6 +83,31 @@ type GitConfig struct {
 	// Maximum size of files in bytes tolerated by the 'git-no-big-files' linter
 	// Default is 10 MB
 	MaxFileSize uint64 `yaml:"maxFileSize" toml:"maxFileSize"`
+
+	// Backend that mllint uses to access Git repositories: 'native' uses an in-process Git implementation,
+	// 'exec' runs the 'git' binary, 'auto' uses 'native' and falls back to 'exec' when needed.
+	// Default is 'auto'
+	Backend string `yaml:"backend" toml:"backend"`
+
+	// Folders, relative to the project's root, in which the project stores its model outputs and checkpoints,
+	// which the project's '.gitignore' should ignore. The 'outputs' and 'checkpoints' folders are also checked when they exist.
+	OutputDirs []string `yaml:"outputDirs" toml:"outputDirs"`
+
+	// Settings for the rules that analyse the project's recent Git history.
+	History GitHistoryConfig `yaml:"history" toml:"history"`
+}
+
+// GitHistoryConfig contains the configuration for the rules that analyse the project's recent Git history.
+type GitHistoryConfig struct {
+	// Number of most recent commits to analyse. Default is 100
+	Window uint64 `yaml:"window" toml:"window"`
+
+	// Regular expression that the subject (first line) of each commit message should match.
+	// Empty means that commit messages should follow the Conventional Commits specification, e.g. `feat(data): add preprocessing step`
+	MessagePattern string `yaml:"messagePattern" toml:"messagePattern"`
+
+	// Maximum amount of lines that a single commit may change. Default is 1000
+	MaxCommitSize uint64 `yaml:"maxCommitSize" toml:"maxCommitSize"`
 }
 
 //---------------------------------------------------------------------------------------
@@ -68,6 +131,9 @@ type TestingConfig struct {
 
 	// Settings about the rules for checking project test coverage.
 	Coverage TestCoverage `yaml:"coverage" toml:"coverage"`
+
+	// Settings for letting mllint run the project's tests itself, instead of reading pre-generated reports.
+	Run TestingRun `yaml:"run" toml:"run"`
 }
 
 type TestingTargets struct {
@@ -102,16 +168,106 @@ type TestCoverageTargets struct {
 	Line float64 `yaml:"line" toml:"line"`
 }
 
+type TestingRun struct {
+	// Command to run the project's tests with, e.g. `pytest --cov=src`. Running tests is opt-in: leave this empty to use
+	// the reports configured with `testing.report` and `testing.coverage.report` instead.
+	// The placeholders `{junit}` and `{coverage}` are replaced with the paths where mllint expects the JUnit and Cobertura XML reports.
+	Command string `yaml:"command" toml:"command"`
+
+	// Maximum duration of the test command, e.g. `10m` or `90s`, after which it is killed. Defaults to `30m`, `0` means no timeout.
+	Timeout string `yaml:"timeout" toml:"timeout"`
+
+	// Additional environment variables to run the test command with.
+	Env map[string]string `yaml:"env" toml:"env"`
+}
+
+//---------------------------------------------------------------------------------------
+
+// NotebooksConfig contains the configuration for the rules in the Jupyter Notebooks category.
+type NotebooksConfig struct {
+	// Maximum size of notebook files in bytes. Default is 1 MB
+	MaxFileSize uint64 `yaml:"maxFileSize" toml:"maxFileSize"`
+
+	// Maximum fraction of the project's lines of code that may be in notebooks rather than in Python modules,
+	// as a number between 0 and 1. Default is 0.5
+	MaxCodeRatio float64 `yaml:"maxCodeRatio" toml:"maxCodeRatio"`
+
+	// Whether to extract the code cells of notebooks and run the configured code quality linters on them. Default is false
+	Lint bool `yaml:"lint" toml:"lint"`
+}
+
+//---------------------------------------------------------------------------------------
+
+// SecretsConfig contains the configuration for detecting secrets and credentials in the project's files.
+type SecretsConfig struct {
+	// Regular expressions matching strings that should not be reported as secrets, e.g. example or test credentials.
+	Allowlist []string `yaml:"allowlist" toml:"allowlist"`
+
+	// Glob patterns of files that should not be scanned for secrets, relative to the project's root, e.g. `tests/fixtures/*`
+	IgnoreFiles []string `yaml:"ignoreFiles" toml:"ignoreFiles"`
+
+	// Minimum Shannon entropy (bits per character) of a value assigned to a secret-sounding name, e.g. `api_key`,
+	// for it to be reported as a possible secret. Default is 3.5
+	MinEntropy float64 `yaml:"minEntropy" toml:"minEntropy"`
+}
+
+//---------------------------------------------------------------------------------------
+
+// CIConfig contains the configuration for the rules in the Continuous Integration category.
+type CIConfig struct {
+	// Name or URL of an external CI system that runs the project's CI pipeline, for projects whose CI configuration
+	// does not live in the project's repository, e.g. `https://jenkins.example.com/job/my-project`. Empty means that
+	// mllint only detects the CI providers whose configuration files are in the project's repository.
+	External string `yaml:"external" toml:"external"`
+}
+
+//---------------------------------------------------------------------------------------
+
+// TimeoutsConfig contains the maximum durations that mllint and its linters may run for, e.g. `10m` or `90s`,
+// after which they are cancelled, killing any processes they started, and reported as errors. Empty means no timeout.
+type TimeoutsConfig struct {
+	// Maximum duration of the whole mllint run.
+	Global string `yaml:"global" toml:"global"`
+
+	// Maximum durations of individual linters, keyed by the slug of their category (e.g. `version-control`)
+	// or by the name of a code quality linter (e.g. `pylint`). See `rules.custom` for setting timeouts on custom rules.
+	Linters map[string]string `yaml:"linters" toml:"linters"`
+}
+
+//---------------------------------------------------------------------------------------
+
+// RunnerConfig contains the configuration of how many of mllint's linters are run in parallel.
+type RunnerConfig struct {
+	// Number of parallel job slots, i.e. the number of linters that are run in parallel when each occupies one slot.
+	// Use 1 to run all linters serially, e.g. for debugging. Default is 0, which means the number of logical CPU cores.
+	Jobs int `yaml:"jobs" toml:"jobs"`
+
+	// Number of job slots that individual linters occupy while running, keyed by the slug of their category (e.g. `testing`),
+	// the name of a code quality linter (e.g. `pylint`) or the slug of a custom rule. Linters that are not listed occupy 1 slot.
+	// Unless configured otherwise, `pylint` and `mypy` occupy 2 slots, as these are the most CPU and memory intensive.
+	Weights map[string]int `yaml:"weights" toml:"weights"`
+}
+
 //---------------------------------------------------------------------------------------
 
 func Default() *Config {
 	return &Config{
+		Extends: []string{},
+		Include: []string{},
+		Exclude: []string{},
 		Rules: RuleConfig{
-			Disabled: []string{},
-			Custom:   []CustomRule{},
+			Disabled:  []string{},
+			Custom:    []CustomRule{},
+			Overrides: map[string]RuleOverride{},
 		},
 		Git: GitConfig{
 			MaxFileSize: 10_000_000, // 10 MB
+			Backend:     "auto",
+			OutputDirs:  []string{},
+			History: GitHistoryConfig{
+				Window:        100,
+				MaxCommitSize: 1000,
+			},
 		},
 		CodeQuality: CodeQualityConfig{
 			Linters: []string{"pylint", "mypy", "black", "isort", "bandit"},
@@ -129,6 +285,27 @@ func Default() *Config {
 					Line: 80,
 				},
 			},
+			Run: TestingRun{
+				Timeout: "30m",
+				Env:     map[string]string{},
+			},
+		},
+		Notebooks: NotebooksConfig{
+			MaxFileSize:  1_000_000, // 1 MB
+			MaxCodeRatio: 0.5,
+			Lint:         false,
+		},
+		Secrets: SecretsConfig{
+			Allowlist:   []string{},
+			IgnoreFiles: []string{},
+			MinEntropy:  3.5,
+		},
+		Timeouts: TimeoutsConfig{
+			Linters: map[string]string{},
+		},
+		Runner: RunnerConfig{
+			Jobs:    0,
+			Weights: map[string]int{},
 		},
 	}
 }
@@ -156,52 +333,87 @@ func (t FileType) String() string {
 // If an `.mllint.yml` file is present, then this will be used,
 // otherwise, if a `pyproject.toml` file is present, then this will be used,
 // otherwise, the default config is returned.
+// The configurations that the file extends are resolved and applied before the file itself.
 // The returned FileType will be either config.TypeYAML, config.TypeTOML, or config.TypeDefault.
 func ParseFromDir(projectdir string) (*Config, FileType, error) {
-	yamlFile := path.Join(projectdir, string(TypeYAML))
-	if utils.FileExists(yamlFile) {
-		file, err := os.Open(yamlFile)
-		if err != nil {
-			return nil, "", fmt.Errorf("failed to open YAML config file '%s': %w", yamlFile, err)
-		}
-		conf, err := ParseYAML(file)
-		if err != nil {
-			return nil, "", fmt.Errorf("failed to parse YAML config file '%s': %w", yamlFile, err)
-		}
-		return conf, TypeYAML, nil
+	return ParseFromDirWithBase(projectdir, Default())
+}
+
+// ParseFromDirWithBase parses the mllint config from the given project directory like ParseFromDir,
+// but applies the project's configuration file to a copy of the given base config instead of to the default config,
+// e.g. such that the sub-projects of a monorepo inherit the configuration in the root of the repository.
+// If the project has no configuration file, then a copy of the base config is returned, along with config.TypeDefault.
+func ParseFromDirWithBase(projectdir string, base *Config) (*Config, FileType, error) {
+	conf, typee, _, err := parseFromDir(projectdir, base)
+	return conf, typee, err
+}
+
+// ParseFromDirWithOrigins parses the mllint config from the given project directory like ParseFromDir,
+// but also returns where each of the config's values came from.
+func ParseFromDirWithOrigins(projectdir string) (*Config, FileType, Origins, error) {
+	return parseFromDir(projectdir, Default())
+}
+
+func parseFromDir(projectdir string, base *Config) (*Config, FileType, Origins, error) {
+	typee := TypeDefault
+	if utils.FileExists(path.Join(projectdir, string(TypeYAML))) {
+		typee = TypeYAML
+	} else if utils.FileExists(path.Join(projectdir, string(TypeTOML))) {
+		typee = TypeTOML
 	}
 
-	tomlFile := path.Join(projectdir, string(TypeTOML))
-	if utils.FileExists(tomlFile) {
-		file, err := os.Open(tomlFile)
-		if err != nil {
-			return nil, "", fmt.Errorf("failed to open TOML config from '%s': %w", tomlFile, err)
-		}
-		conf, err := ParseTOML(file)
-		if err != nil {
-			return nil, "", fmt.Errorf("failed to parse TOML config from '%s': %w", tomlFile, err)
-		}
-		return conf, TypeTOML, nil
+	if typee == TypeDefault {
+		conf, err := base.Clone()
+		return conf, TypeDefault, Origins{}, err
 	}
 
-	return Default(), TypeDefault, nil
-}
+	filename := path.Join(projectdir, string(typee))
+	contents, err := readConfigFile(filename, typee)
+	if err != nil {
+		return nil, "", nil, err
+	}
 
-// ParseYAML parses the YAML config from the given reader (tip: *os.File implements io.Reader)
-func ParseYAML(reader io.Reader) (*Config, error) {
-	contents, err := ioutil.ReadAll(reader)
+	r := resolver{projectdir: projectdir}
+	extends, err := r.resolve(filename, string(typee), contents, typee, projectdir)
 	if err != nil {
-		return nil, err
+		return nil, "", nil, fmt.Errorf("failed to resolve the configurations extended by '%s': %w", filename, err)
+	}
+
+	values, err := toValues(base)
+	if err != nil {
+		return nil, "", nil, err
+	}
+	origins := Origins{}
+	for _, layer := range r.layers {
+		mergeValues(values, layer.values, "", layer.source, origins)
+	}
+
+	conf, err := fromValues(values, base)
+	if err != nil {
+		return nil, "", nil, fmt.Errorf("failed to merge the configurations extended by '%s': %w", filename, err)
 	}
+	conf.Extends = extends
+	return conf, typee, origins, nil
+}
 
+// ParseYAML parses the YAML config from the given reader (tip: *os.File implements io.Reader)
+func ParseYAML(reader io.Reader) (*Config, error) {
 	config := Default()
-	if err := yaml.Unmarshal(contents, config); err != nil {
+	if err := parseYAMLInto(reader, config); err != nil {
 		return nil, err
 	}
-
 	return config, nil
 }
 
+// parseYAMLInto parses the YAML config from the given reader into the given config, overwriting any settings that it specifies.
+func parseYAMLInto(reader io.Reader, config *Config) error {
+	contents, err := ioutil.ReadAll(reader)
+	if err != nil {
+		return err
+	}
+	return yaml.Unmarshal(contents, config)
+}
+
 type pyprojectTOML struct {
 	Tool struct {
 		Mllint *Config `toml:"mllint"`
@@ -210,18 +422,37 @@ type pyprojectTOML struct {
 
 // ParseYAML parses the TOML config from the given reader (tip: *os.File implements io.Reader)
 func ParseTOML(reader io.Reader) (*Config, error) {
+	config := Default()
+	if err := parseTOMLInto(reader, config); err != nil {
+		return nil, err
+	}
+	return config, nil
+}
+
+// parseTOMLInto parses the TOML config from the given reader into the given config, overwriting any settings that it specifies.
+func parseTOMLInto(reader io.Reader, config *Config) error {
 	contents, err := ioutil.ReadAll(reader)
 	if err != nil {
-		return nil, err
+		return err
 	}
 
 	tomlFile := pyprojectTOML{}
-	tomlFile.Tool.Mllint = Default()
-	if err := toml.Unmarshal(contents, &tomlFile); err != nil {
+	tomlFile.Tool.Mllint = config
+	return toml.Unmarshal(contents, &tomlFile)
+}
+
+// Clone returns a deep copy of the config.
+func (conf *Config) Clone() (*Config, error) {
+	contents, err := conf.YAML()
+	if err != nil {
 		return nil, err
 	}
 
-	return tomlFile.Tool.Mllint, nil
+	clone := &Config{}
+	if err := yaml.Unmarshal(contents, clone); err != nil {
+		return nil, err
+	}
+	return clone, nil
 }
 
 //---------------------------------------------------------------------------------------
@@ -59,11 +59,79 @@ rules:
       run: python ./scripts/mllint-test-rule.py
 `
 
+const yamlNotebooks = `
+notebooks:
+  maxFileSize: 5000000
+  maxCodeRatio: 0.2
+  lint: true
+`
+
+const yamlSecrets = `
+secrets:
+  allowlist: ["^AKIA.*EXAMPLE$"]
+  ignoreFiles: ["tests/fixtures/*"]
+  minEntropy: 4.2
+`
+
+const yamlGitBackend = `
+git:
+  backend: native
+`
+
+const yamlGitHistory = `
+git:
+  history:
+    window: 50
+    messagePattern: "^\\[[A-Z]+-[0-9]+\\] "
+    maxCommitSize: 500
+`
+
+const yamlCIExternal = `
+ci:
+  external: https://jenkins.example.com/job/my-project
+`
+
+const yamlTimeouts = `
+timeouts:
+  global: 30m
+  linters:
+    pylint: 10m
+    version-control: 2m
+rules:
+  custom:
+    - name: Custom Test Rule
+      slug: custom/test-rule
+      run: python ./scripts/mllint-test-rule.py
+      timeout: 30s
+`
+
+const yamlRunner = `
+runner:
+  jobs: 4
+  weights:
+    mypy: 3
+    bandit: 2
+`
+
+const yamlFiles = `
+include:
+  - src/
+  - tests/
+exclude:
+  - "**/*_pb2.py"
+`
+
 const yamlInvalid = `
 rules:
   disabled: nothing
 `
 
+const tomlFiles = `
+[tool.mllint]
+include = ["src/", "tests/"]
+exclude = ["**/*_pb2.py"]
+`
+
 const tomlRulesDisabled = `
 [tool.mllint]
   [tool.mllint.rules]
@@ -102,6 +170,55 @@ weight = 420.0
 run = "python ./scripts/mllint-test-rule.py"
 `
 
+const tomlNotebooks = `
+[tool.mllint.notebooks]
+maxFileSize = 5000000
+maxCodeRatio = 0.2
+lint = true
+`
+
+const tomlSecrets = `
+[tool.mllint.secrets]
+allowlist = ["^AKIA.*EXAMPLE$"]
+ignoreFiles = ["tests/fixtures/*"]
+minEntropy = 4.2
+`
+
+const tomlGitBackend = `
+[tool.mllint.git]
+backend = "native"
+`
+
+const tomlGitHistory = `
+[tool.mllint.git.history]
+window = 50
+messagePattern = '^\[[A-Z]+-[0-9]+\] '
+maxCommitSize = 500
+`
+
+const tomlCIExternal = `
+[tool.mllint.ci]
+external = "https://jenkins.example.com/job/my-project"
+`
+
+const tomlTimeouts = `
+[tool.mllint.timeouts]
+global = "30m"
+linters = { pylint = "10m", version-control = "2m" }
+
+[[tool.mllint.rules.custom]]
+name = "Custom Test Rule"
+slug = "custom/test-rule"
+run = "python ./scripts/mllint-test-rule.py"
+timeout = "30s"
+`
+
+const tomlRunner = `
+[tool.mllint.runner]
+jobs = 4
+weights = { mypy = 3, bandit = 2 }
+`
+
 const tomlInvalid = `
 [tool.mllint.rules]
 disabled = "nothing"
@@ -182,6 +299,101 @@ func TestParseYAML(t *testing.T) {
 			}(),
 			Err: nil,
 		},
+		{
+			Name: "YamlNotebooks",
+			File: strings.NewReader(yamlNotebooks),
+			Expected: func() *config.Config {
+				c := config.Default()
+				c.Notebooks.MaxFileSize = 5_000_000
+				c.Notebooks.MaxCodeRatio = 0.2
+				c.Notebooks.Lint = true
+				return c
+			}(),
+			Err: nil,
+		},
+		{
+			Name: "YamlSecrets",
+			File: strings.NewReader(yamlSecrets),
+			Expected: func() *config.Config {
+				c := config.Default()
+				c.Secrets.Allowlist = []string{"^AKIA.*EXAMPLE$"}
+				c.Secrets.IgnoreFiles = []string{"tests/fixtures/*"}
+				c.Secrets.MinEntropy = 4.2
+				return c
+			}(),
+			Err: nil,
+		},
+		{
+			Name: "YamlGitBackend",
+			File: strings.NewReader(yamlGitBackend),
+			Expected: func() *config.Config {
+				c := config.Default()
+				c.Git.Backend = "native"
+				return c
+			}(),
+			Err: nil,
+		},
+		{
+			Name: "YamlCIExternal",
+			File: strings.NewReader(yamlCIExternal),
+			Expected: func() *config.Config {
+				c := config.Default()
+				c.CI.External = "https://jenkins.example.com/job/my-project"
+				return c
+			}(),
+			Err: nil,
+		},
+		{
+			Name: "YamlTimeouts",
+			File: strings.NewReader(yamlTimeouts),
+			Expected: func() *config.Config {
+				c := config.Default()
+				c.Timeouts.Global = "30m"
+				c.Timeouts.Linters = map[string]string{"pylint": "10m", "version-control": "2m"}
+				c.Rules.Custom = []config.CustomRule{{
+					Name:    "Custom Test Rule",
+					Slug:    "custom/test-rule",
+					Run:     "python ./scripts/mllint-test-rule.py",
+					Timeout: "30s",
+				}}
+				return c
+			}(),
+			Err: nil,
+		},
+		{
+			Name: "YamlFiles",
+			File: strings.NewReader(yamlFiles),
+			Expected: func() *config.Config {
+				c := config.Default()
+				c.Include = []string{"src/", "tests/"}
+				c.Exclude = []string{"**/*_pb2.py"}
+				return c
+			}(),
+			Err: nil,
+		},
+		{
+			Name: "YamlRunner",
+			File: strings.NewReader(yamlRunner),
+			Expected: func() *config.Config {
+				c := config.Default()
+				c.Runner.Jobs = 4
+				c.Runner.Weights = map[string]int{"mypy": 3, "bandit": 2}
+				return c
+			}(),
+			Err: nil,
+		},
+		{
+			Name: "YamlGitHistory",
+			File: strings.NewReader(yamlGitHistory),
+			Expected: func() *config.Config {
+				c := config.Default()
+				c.Git.History.Window = 50
+				c.Git.History.MessagePattern = `^\[[A-Z]+-[0-9]+\] `
+				c.Git.History.MaxCommitSize = 500
+				return c
+			}(),
+			Err: nil,
+		},
 		{
 			Name:     "YamlError",
 			File:     strings.NewReader(yamlInvalid),
@@ -283,6 +495,101 @@ func TestParseTOML(t *testing.T) {
 			}(),
 			Err: nil,
 		},
+		{
+			Name: "TomlNotebooks",
+			File: strings.NewReader(tomlNotebooks),
+			Expected: func() *config.Config {
+				c := config.Default()
+				c.Notebooks.MaxFileSize = 5_000_000
+				c.Notebooks.MaxCodeRatio = 0.2
+				c.Notebooks.Lint = true
+				return c
+			}(),
+			Err: nil,
+		},
+		{
+			Name: "TomlSecrets",
+			File: strings.NewReader(tomlSecrets),
+			Expected: func() *config.Config {
+				c := config.Default()
+				c.Secrets.Allowlist = []string{"^AKIA.*EXAMPLE$"}
+				c.Secrets.IgnoreFiles = []string{"tests/fixtures/*"}
+				c.Secrets.MinEntropy = 4.2
+				return c
+			}(),
+			Err: nil,
+		},
+		{
+			Name: "TomlGitBackend",
+			File: strings.NewReader(tomlGitBackend),
+			Expected: func() *config.Config {
+				c := config.Default()
+				c.Git.Backend = "native"
+				return c
+			}(),
+			Err: nil,
+		},
+		{
+			Name: "TomlCIExternal",
+			File: strings.NewReader(tomlCIExternal),
+			Expected: func() *config.Config {
+				c := config.Default()
+				c.CI.External = "https://jenkins.example.com/job/my-project"
+				return c
+			}(),
+			Err: nil,
+		},
+		{
+			Name: "TomlTimeouts",
+			File: strings.NewReader(tomlTimeouts),
+			Expected: func() *config.Config {
+				c := config.Default()
+				c.Timeouts.Global = "30m"
+				c.Timeouts.Linters = map[string]string{"pylint": "10m", "version-control": "2m"}
+				c.Rules.Custom = []config.CustomRule{{
+					Name:    "Custom Test Rule",
+					Slug:    "custom/test-rule",
+					Run:     "python ./scripts/mllint-test-rule.py",
+					Timeout: "30s",
+				}}
+				return c
+			}(),
+			Err: nil,
+		},
+		{
+			Name: "TomlFiles",
+			File: strings.NewReader(tomlFiles),
+			Expected: func() *config.Config {
+				c := config.Default()
+				c.Include = []string{"src/", "tests/"}
+				c.Exclude = []string{"**/*_pb2.py"}
+				return c
+			}(),
+			Err: nil,
+		},
+		{
+			Name: "TomlRunner",
+			File: strings.NewReader(tomlRunner),
+			Expected: func() *config.Config {
+				c := config.Default()
+				c.Runner.Jobs = 4
+				c.Runner.Weights = map[string]int{"mypy": 3, "bandit": 2}
+				return c
+			}(),
+			Err: nil,
+		},
+		{
+			Name: "TomlGitHistory",
+			File: strings.NewReader(tomlGitHistory),
+			Expected: func() *config.Config {
+				c := config.Default()
+				c.Git.History.Window = 50
+				c.Git.History.MessagePattern = `^\[[A-Z]+-[0-9]+\] `
+				c.Git.History.MaxCommitSize = 500
+				return c
+			}(),
+			Err: nil,
+		},
 		{
 			Name:     "TomlError",
 			File:     strings.NewReader(tomlInvalid),
@@ -392,3 +699,271 @@ func TestConfigTypeString(t *testing.T) {
 	require.Equal(t, string(config.TypeYAML), config.TypeYAML.String())
 	require.Equal(t, string(config.TypeTOML), config.TypeTOML.String())
 }
+
+func TestParseFromDirWithBase(t *testing.T) {
+	base := config.Default()
+	base.Rules.Disabled = []string{"testing"}
+	base.Git.MaxFileSize = 5
+
+	writeFile := func(t *testing.T, dir, filename, contents string) {
+		require.NoError(t, os.WriteFile(path.Join(dir, filename), []byte(contents), 0644))
+	}
+
+	t.Run("YAML", func(t *testing.T) {
+		dir := t.TempDir()
+		writeFile(t, dir, ".mllint.yml", "git:\n  maxFileSize: 7\n")
+
+		conf, typee, err := config.ParseFromDirWithBase(dir, base)
+		require.NoError(t, err)
+		require.Equal(t, config.TypeYAML, typee)
+		require.Equal(t, []string{"testing"}, conf.Rules.Disabled)
+		require.EqualValues(t, 7, conf.Git.MaxFileSize)
+	})
+
+	t.Run("TOML", func(t *testing.T) {
+		dir := t.TempDir()
+		writeFile(t, dir, "pyproject.toml", "[tool.mllint.git]\nmaxFileSize = 9\n")
+
+		conf, typee, err := config.ParseFromDirWithBase(dir, base)
+		require.NoError(t, err)
+		require.Equal(t, config.TypeTOML, typee)
+		require.Equal(t, []string{"testing"}, conf.Rules.Disabled)
+		require.EqualValues(t, 9, conf.Git.MaxFileSize)
+	})
+
+	t.Run("TOMLWithoutMllint", func(t *testing.T) {
+		dir := t.TempDir()
+		writeFile(t, dir, "pyproject.toml", "[tool.poetry]\nname = \"sub-project\"\n")
+
+		conf, typee, err := config.ParseFromDirWithBase(dir, base)
+		require.NoError(t, err)
+		require.Equal(t, config.TypeTOML, typee)
+		require.Equal(t, base, conf)
+	})
+
+	t.Run("NoConfig", func(t *testing.T) {
+		conf, typee, err := config.ParseFromDirWithBase(t.TempDir(), base)
+		require.NoError(t, err)
+		require.Equal(t, config.TypeDefault, typee)
+		require.Equal(t, base, conf)
+		require.NotSame(t, base, conf)
+	})
+
+	// the base config itself is never modified.
+	require.EqualValues(t, 5, base.Git.MaxFileSize)
+}
+
+func TestParseFromDirExtends(t *testing.T) {
+	writeFile := func(t *testing.T, dir, filename, contents string) {
+		require.NoError(t, os.MkdirAll(path.Dir(path.Join(dir, filename)), 0755))
+		require.NoError(t, os.WriteFile(path.Join(dir, filename), []byte(contents), 0644))
+	}
+
+	t.Run("Preset", func(t *testing.T) {
+		dir := t.TempDir()
+		writeFile(t, dir, ".mllint.yml", "extends: [strict]\ngit:\n  maxFileSize: 7\n")
+
+		conf, typee, origins, err := config.ParseFromDirWithOrigins(dir)
+		require.NoError(t, err)
+		require.Equal(t, config.TypeYAML, typee)
+		require.Equal(t, []string{"strict"}, conf.Extends)
+		require.EqualValues(t, 7, conf.Git.MaxFileSize)
+		require.EqualValues(t, 500, conf.Git.History.MaxCommitSize)
+		require.EqualValues(t, 90, conf.Testing.Coverage.Targets.Line)
+		require.Equal(t, ".mllint.yml", origins.Of("git.maxFileSize"))
+		require.Equal(t, "preset:strict", origins.Of("git.history.maxCommitSize"))
+		require.Equal(t, config.OriginDefault, origins.Of("git.backend"))
+	})
+
+	t.Run("PresetExtendsPreset", func(t *testing.T) {
+		dir := t.TempDir()
+		writeFile(t, dir, ".mllint.yml", "extends: [production]\n")
+
+		conf, _, origins, err := config.ParseFromDirWithOrigins(dir)
+		require.NoError(t, err)
+		require.Equal(t, "30m", conf.Timeouts.Global)
+		require.EqualValues(t, 90, conf.Testing.Coverage.Targets.Line)
+		require.Equal(t, "preset:production", origins.Of("timeouts.global"))
+		require.Equal(t, "preset:strict", origins.Of("testing.coverage.targets.line"))
+	})
+
+	t.Run("Files", func(t *testing.T) {
+		dir := t.TempDir()
+		writeFile(t, dir, "../org/base.yml", "extends: [research]\nrules:\n  disabled: [testing, notebooks]\ncode-quality:\n  linters: [pylint, mypy]\n")
+		writeFile(t, dir, "shared.toml", "[tool.mllint.git]\nmaxFileSize = 9\n[tool.mllint.secrets]\nallowlist = ['example']\n")
+		writeFile(t, dir, ".mllint.yml", `
+extends:
+  - ../org/base.yml
+  - shared.toml
+rules:
+  disabled: [testing, ci/use]
+code-quality:
+  linters: [black]
+`)
+
+		conf, _, origins, err := config.ParseFromDirWithOrigins(dir)
+		require.NoError(t, err)
+		require.Equal(t, []string{"../org/base.yml", "shared.toml"}, conf.Extends)
+
+		// rules.disabled is combined, without duplicates, while code-quality.linters is replaced.
+		require.Equal(t, []string{"ci", "version-control/code/commit-messages", "version-control/code/commit-size", "testing", "notebooks", "ci/use"}, conf.Rules.Disabled)
+		require.Equal(t, []string{"black"}, conf.CodeQuality.Linters)
+		require.Equal(t, []string{"example"}, conf.Secrets.Allowlist)
+		require.EqualValues(t, 9, conf.Git.MaxFileSize)
+		require.EqualValues(t, 50, conf.Testing.Coverage.Targets.Line)
+
+		require.Equal(t, "preset:research", origins.Of("rules.disabled[0]"))
+		require.Equal(t, path.Join(path.Dir(dir), "org/base.yml"), origins.Of("rules.disabled[3]"))
+		require.Equal(t, ".mllint.yml", origins.Of("rules.disabled[5]"))
+		require.Equal(t, ".mllint.yml", origins.Of("code-quality.linters"))
+		require.Equal(t, "shared.toml", origins.Of("git.maxFileSize"))
+		require.Equal(t, "shared.toml", origins.Of("secrets.allowlist[0]"))
+	})
+
+	t.Run("TOML", func(t *testing.T) {
+		dir := t.TempDir()
+		writeFile(t, dir, "pyproject.toml", "[tool.mllint]\nextends = ['research']\n[tool.mllint.rules]\ndisabled = ['testing']\n")
+
+		conf, typee, err := config.ParseFromDir(dir)
+		require.NoError(t, err)
+		require.Equal(t, config.TypeTOML, typee)
+		require.Equal(t, []string{"research"}, conf.Extends)
+		require.Equal(t, []string{"ci", "version-control/code/commit-messages", "version-control/code/commit-size", "testing"}, conf.Rules.Disabled)
+	})
+
+	t.Run("Inherited", func(t *testing.T) {
+		dir := t.TempDir()
+		writeFile(t, dir, ".mllint.yml", "rules:\n  disabled: [ci]\n")
+		base := config.Default()
+		base.Rules.Disabled = []string{"testing"}
+
+		conf, _, err := config.ParseFromDirWithBase(dir, base)
+		require.NoError(t, err)
+		require.Equal(t, []string{"testing", "ci"}, conf.Rules.Disabled)
+	})
+
+	t.Run("UnknownPreset", func(t *testing.T) {
+		dir := t.TempDir()
+		writeFile(t, dir, ".mllint.yml", "extends: [lenient]\n")
+
+		_, _, err := config.ParseFromDir(dir)
+		require.True(t, errors.Is(err, config.ErrUnknownPreset))
+		require.Contains(t, err.Error(), "production, research, strict")
+	})
+
+	t.Run("MissingFile", func(t *testing.T) {
+		dir := t.TempDir()
+		writeFile(t, dir, ".mllint.yml", "extends: [missing.yml]\n")
+
+		_, _, err := config.ParseFromDir(dir)
+		require.True(t, errors.Is(err, os.ErrNotExist))
+	})
+
+	t.Run("InvalidFile", func(t *testing.T) {
+		dir := t.TempDir()
+		writeFile(t, dir, "base.yml", "git:\n  maxFileSize: big\n")
+		writeFile(t, dir, ".mllint.yml", "extends: [base.yml]\n")
+
+		_, _, err := config.ParseFromDir(dir)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "failed to parse YAML config file")
+	})
+
+	t.Run("Cycle", func(t *testing.T) {
+		dir := t.TempDir()
+		writeFile(t, dir, "a.yml", "extends: [b.yml]\n")
+		writeFile(t, dir, "b.yml", "extends: [a.yml]\n")
+		writeFile(t, dir, ".mllint.yml", "extends: [a.yml]\n")
+
+		_, _, err := config.ParseFromDir(dir)
+		require.True(t, errors.Is(err, config.ErrExtendsCycle))
+	})
+}
+
+func TestPresets(t *testing.T) {
+	require.Equal(t, []string{"production", "research", "strict"}, config.Presets())
+
+	for _, preset := range config.Presets() {
+		dir := t.TempDir()
+		require.NoError(t, os.WriteFile(path.Join(dir, ".mllint.yml"), []byte("extends: ["+preset+"]\n"), 0644))
+
+		conf, _, err := config.ParseFromDir(dir)
+		require.NoError(t, err, preset)
+		require.NotEqual(t, config.Default(), conf, preset)
+	}
+}
+
+func TestYAMLWithOrigins(t *testing.T) {
+	conf := config.Default()
+	conf.Git.MaxFileSize = 7
+	conf.Rules.Disabled = []string{"ci", "testing"}
+	origins := config.Origins{"git.maxFileSize": ".mllint.yml", "rules.disabled[1]": "preset:research"}
+
+	output, err := conf.YAMLWithOrigins(origins)
+	require.NoError(t, err)
+	require.Contains(t, string(output), "maxFileSize: 7 # from .mllint.yml\n")
+	require.Contains(t, string(output), "- ci\n")
+	require.Contains(t, string(output), "- testing # from preset:research\n")
+
+	// the config itself is the same as without origins.
+	parsed, err := config.ParseYAML(strings.NewReader(string(output)))
+	require.NoError(t, err)
+	require.Equal(t, conf, parsed)
+}
+
+func TestParseRuleOverrides(t *testing.T) {
+	weight := 3.0
+	expected := map[string]config.RuleOverride{
+		"version-control/code/git":      {Weight: &weight},
+		"code-quality/pylint/no-issues": {Params: map[string]float64{"maxLoCperMsg": 20}},
+	}
+
+	t.Run("YAML", func(t *testing.T) {
+		conf, err := config.ParseYAML(strings.NewReader(`
+rules:
+  overrides:
+    version-control/code/git:
+      weight: 3
+    code-quality/pylint/no-issues:
+      params:
+        maxLoCperMsg: 20
+`))
+		require.NoError(t, err)
+		require.Equal(t, expected, conf.Rules.Overrides)
+	})
+
+	t.Run("TOML", func(t *testing.T) {
+		conf, err := config.ParseTOML(strings.NewReader(`
+[tool.mllint.rules.overrides."version-control/code/git"]
+weight = 3.0
+[tool.mllint.rules.overrides."code-quality/pylint/no-issues".params]
+maxLoCperMsg = 20.0
+`))
+		require.NoError(t, err)
+		require.Equal(t, expected, conf.Rules.Overrides)
+	})
+
+	t.Run("Extends", func(t *testing.T) {
+		dir := t.TempDir()
+		require.NoError(t, os.WriteFile(path.Join(dir, "base.yml"), []byte("rules:\n  overrides:\n    version-control/code/git:\n      weight: 3\n"), 0644))
+		require.NoError(t, os.WriteFile(path.Join(dir, ".mllint.yml"), []byte("extends: [base.yml]\nrules:\n  overrides:\n    code-quality/pylint/no-issues:\n      params:\n        maxLoCperMsg: 20\n"), 0644))
+
+		conf, _, err := config.ParseFromDir(dir)
+		require.NoError(t, err)
+		require.Equal(t, expected, conf.Rules.Overrides)
+	})
+}
+
+func TestParseFromDirEmptySections(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(path.Join(dir, ".mllint.yml"), []byte("git:\ntesting:\n  coverage:\nexclude:\nrules: {disabled: [ci]}\n"), 0644))
+
+	expected := config.Default()
+	expected.Rules.Disabled = []string{"ci"}
+
+	conf, _, origins, err := config.ParseFromDirWithOrigins(dir)
+	require.NoError(t, err)
+	require.Equal(t, expected, conf)
+	require.Equal(t, config.OriginDefault, origins.Of("git"))
+	require.Equal(t, config.OriginDefault, origins.Of("exclude"))
+}
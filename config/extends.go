@@ -0,0 +1,344 @@
+package config
+
+import (
+	"bytes"
+	"embed"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/pelletier/go-toml"
+	"gopkg.in/yaml.v3"
+)
+
+var (
+	ErrUnknownPreset = errors.New("unknown preset")
+	ErrExtendsCycle  = errors.New("configuration extends itself")
+)
+
+//go:embed presets/*.yml
+var presets embed.FS
+
+// matches the names of presets, as opposed to paths to configuration files.
+var presetNameRegex = regexp.MustCompile(`^[a-z][a-z0-9-]*$`)
+
+// Paths of the lists in a configuration whose items are appended to the items of the configurations that it extends,
+// instead of replacing them, such that e.g. a project can disable rules on top of the ones disabled by an organisation-wide configuration.
+// Any other list, e.g. `code-quality.linters`, replaces the list of the configurations that it extends.
+var appendedLists = map[string]bool{
+	"include":             true,
+	"exclude":             true,
+	"rules.disabled":      true,
+	"rules.custom":        true,
+	"secrets.allowlist":   true,
+	"secrets.ignoreFiles": true,
+}
+
+// Presets returns the names of mllint's built-in presets, sorted alphabetically.
+func Presets() []string {
+	entries, _ := presets.ReadDir("presets")
+	names := make([]string, len(entries))
+	for i, entry := range entries {
+		names[i] = strings.TrimSuffix(entry.Name(), ".yml")
+	}
+	sort.Strings(names)
+	return names
+}
+
+//---------------------------------------------------------------------------------------
+
+// OriginDefault is the origin of the values of a configuration that were not set by any configuration file.
+const OriginDefault = "default"
+
+// Origins records where each value of a resolved configuration came from, i.e. the configuration file or preset that set it,
+// keyed by the value's path in the configuration, e.g. `git.maxFileSize`. The items of lists that are appended to,
+// such as `rules.disabled`, each have their own origin, e.g. `rules.disabled[1]`.
+type Origins map[string]string
+
+// Of returns the origin of the value at the given path, which is OriginDefault if it was not set by any configuration file.
+func (origins Origins) Of(path string) string {
+	if origin, ok := origins[path]; ok {
+		return origin
+	}
+	return OriginDefault
+}
+
+//---------------------------------------------------------------------------------------
+
+// layer contains the values set by a single configuration file or preset.
+type layer struct {
+	source string
+	values map[string]interface{}
+}
+
+// resolver resolves the configurations extended by a project's configuration file into layers, in the order in which they are applied.
+type resolver struct {
+	projectdir string
+	// identifiers of the configurations that are currently being resolved, used to detect cycles.
+	resolving []string
+	layers    []layer
+}
+
+// resolve reads the configuration with the given contents, then resolves the configurations it extends, before adding it as a layer itself.
+// Returns the configurations that it extends.
+func (r *resolver) resolve(id string, source string, contents []byte, typee FileType, dir string) ([]string, error) {
+	for i, other := range r.resolving {
+		if other == id {
+			return nil, fmt.Errorf("%w: %s", ErrExtendsCycle, strings.Join(append(r.resolving[i:], id), " -> "))
+		}
+	}
+	r.resolving = append(r.resolving, id)
+	defer func() { r.resolving = r.resolving[:len(r.resolving)-1] }()
+
+	values, err := readValues(contents, typee)
+	if err != nil {
+		return nil, err
+	}
+
+	extends := toStrings(values["extends"])
+	delete(values, "extends")
+	for _, ref := range extends {
+		if err := r.resolveExtends(ref, dir); err != nil {
+			return nil, err
+		}
+	}
+
+	r.layers = append(r.layers, layer{source, values})
+	return extends, nil
+}
+
+// resolveExtends resolves the preset or configuration file referenced by an `extends` in a configuration file in the given directory.
+// Presets, which are embedded in mllint, have no directory, so they can only extend other presets.
+func (r *resolver) resolveExtends(ref string, dir string) error {
+	if presetNameRegex.MatchString(ref) {
+		contents, err := presets.ReadFile("presets/" + ref + ".yml")
+		if err != nil {
+			return fmt.Errorf("%w '%s', expected one of: %s", ErrUnknownPreset, ref, strings.Join(Presets(), ", "))
+		}
+		if _, err := r.resolve("preset:"+ref, "preset:"+ref, contents, TypeYAML, ""); err != nil {
+			return fmt.Errorf("failed to resolve preset '%s': %w", ref, err)
+		}
+		return nil
+	}
+
+	if dir == "" {
+		return fmt.Errorf("presets can only extend other presets, not '%s'", ref)
+	}
+
+	filename := ref
+	if !filepath.IsAbs(filename) {
+		filename = filepath.Join(dir, filename)
+	}
+	typee := TypeYAML
+	if filepath.Ext(filename) == ".toml" {
+		typee = TypeTOML
+	}
+
+	contents, err := readConfigFile(filename, typee)
+	if err != nil {
+		return err
+	}
+	if _, err := r.resolve(filename, r.sourceName(filename), contents, typee, filepath.Dir(filename)); err != nil {
+		return fmt.Errorf("failed to resolve '%s': %w", ref, err)
+	}
+	return nil
+}
+
+// sourceName returns the name of the configuration file with the given filename, relative to the project's directory if possible.
+func (r *resolver) sourceName(filename string) string {
+	if rel, err := filepath.Rel(r.projectdir, filename); err == nil && !strings.HasPrefix(rel, "..") {
+		return rel
+	}
+	return filename
+}
+
+// readConfigFile reads the configuration file with the given filename and checks that it is a valid configuration file of the given type.
+func readConfigFile(filename string, typee FileType) ([]byte, error) {
+	contents, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open %s config file '%s': %w", typeName(typee), filename, err)
+	}
+
+	// parsing the file onto a config first ensures that any errors in it are reported with the file's own line numbers.
+	if typee == TypeTOML {
+		err = parseTOMLInto(bytes.NewReader(contents), Default())
+	} else {
+		err = parseYAMLInto(bytes.NewReader(contents), Default())
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse %s config file '%s': %w", typeName(typee), filename, err)
+	}
+	return contents, nil
+}
+
+func typeName(typee FileType) string {
+	if typee == TypeTOML {
+		return "TOML"
+	}
+	return "YAML"
+}
+
+// readValues reads the values set by the given contents of a configuration file of the given type,
+// which for TOML files are the values in the file's `[tool.mllint]` section.
+func readValues(contents []byte, typee FileType) (map[string]interface{}, error) {
+	if typee == TypeTOML {
+		tree, err := toml.LoadBytes(contents)
+		if err != nil {
+			return nil, err
+		}
+		if mllint, ok := tree.GetPath([]string{"tool", "mllint"}).(*toml.Tree); ok {
+			return mllint.ToMap(), nil
+		}
+		return map[string]interface{}{}, nil
+	}
+
+	values := map[string]interface{}{}
+	if err := yaml.Unmarshal(contents, &values); err != nil {
+		return nil, err
+	}
+	return values, nil
+}
+
+func toStrings(value interface{}) []string {
+	items, _ := value.([]interface{})
+	strs := make([]string, 0, len(items))
+	for _, item := range items {
+		strs = append(strs, fmt.Sprint(item))
+	}
+	return strs
+}
+
+//---------------------------------------------------------------------------------------
+
+// mergeValues merges the values of src into dst, recording the given source as the origin of each value that src sets.
+// Maps are merged recursively, the items of the lists in appendedLists are appended unless dst already contains them,
+// while any other values of src replace those in dst. Empty values in src, e.g. a section that is only a key, do not change dst.
+func mergeValues(dst map[string]interface{}, src map[string]interface{}, prefix string, source string, origins Origins) {
+	for key, value := range src {
+		path := key
+		if prefix != "" {
+			path = prefix + "." + key
+		}
+
+		switch value := value.(type) {
+		case nil:
+			continue
+
+		case map[string]interface{}:
+			existing, ok := dst[key].(map[string]interface{})
+			if !ok {
+				existing = map[string]interface{}{}
+				dst[key] = existing
+			}
+			mergeValues(existing, value, path, source, origins)
+
+		case []interface{}:
+			if !appendedLists[path] {
+				dst[key] = value
+				origins[path] = source
+				continue
+			}
+
+			existing, _ := dst[key].([]interface{})
+			for _, item := range value {
+				if !containsValue(existing, item) {
+					origins[fmt.Sprintf("%s[%d]", path, len(existing))] = source
+					existing = append(existing, item)
+				}
+			}
+			dst[key] = existing
+
+		default:
+			dst[key] = value
+			origins[path] = source
+		}
+	}
+}
+
+func containsValue(items []interface{}, value interface{}) bool {
+	for _, item := range items {
+		if reflect.DeepEqual(item, value) {
+			return true
+		}
+	}
+	return false
+}
+
+// toValues converts the given config into the values that it sets.
+func toValues(conf *Config) (map[string]interface{}, error) {
+	contents, err := conf.YAML()
+	if err != nil {
+		return nil, err
+	}
+	values := map[string]interface{}{}
+	if err := yaml.Unmarshal(contents, &values); err != nil {
+		return nil, err
+	}
+	delete(values, "extends")
+	return values, nil
+}
+
+// fromValues converts the given values into a copy of the given base config, such that any settings missing from the values keep the base's settings.
+func fromValues(values map[string]interface{}, base *Config) (*Config, error) {
+	contents, err := yaml.Marshal(values)
+	if err != nil {
+		return nil, err
+	}
+	conf, err := base.Clone()
+	if err != nil {
+		return nil, err
+	}
+	if err := yaml.Unmarshal(contents, conf); err != nil {
+		return nil, err
+	}
+	return conf, nil
+}
+
+//---------------------------------------------------------------------------------------
+
+// YAMLWithOrigins returns the config in YAML format, like YAML, but with a comment after each value that was not set by
+// the default configuration, stating the configuration file or preset that it came from.
+func (conf *Config) YAMLWithOrigins(origins Origins) ([]byte, error) {
+	doc := yaml.Node{}
+	if err := doc.Encode(conf); err != nil {
+		return nil, err
+	}
+	annotateOrigins(&doc, "", origins)
+	return yaml.Marshal(&doc)
+}
+
+func annotateOrigins(node *yaml.Node, path string, origins Origins) {
+	switch node.Kind {
+	case yaml.MappingNode:
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			key, value := node.Content[i], node.Content[i+1]
+			childPath := key.Value
+			if path != "" {
+				childPath = path + "." + key.Value
+			}
+			if value.Kind == yaml.MappingNode || (value.Kind == yaml.SequenceNode && appendedLists[childPath]) {
+				annotateOrigins(value, childPath, origins)
+			} else if origin, ok := origins[childPath]; ok {
+				key.LineComment = "from " + origin
+			}
+		}
+
+	case yaml.SequenceNode:
+		for i, item := range node.Content {
+			origin, ok := origins[fmt.Sprintf("%s[%d]", path, i)]
+			if !ok {
+				continue
+			}
+			// comments on mappings inside lists, e.g. custom rules, are only printed when they are on the mapping's first key.
+			if item.Kind == yaml.MappingNode && len(item.Content) > 0 {
+				item = item.Content[0]
+			}
+			item.LineComment = "from " + origin
+		}
+	}
+}
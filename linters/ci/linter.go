@@ -1,30 +1,58 @@
 package ci
 
 import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+
 	"github.com/bvobart/mllint/api"
+	"github.com/bvobart/mllint/config"
 	"github.com/bvobart/mllint/setools/ciproviders"
 	"github.com/bvobart/mllint/setools/git"
+	"github.com/bvobart/mllint/utils/markdowngen"
 )
 
+// Matches commands that run a project's tests, e.g. `pytest`, `python -m unittest`, `tox` or `make test`
+var testCommandPattern = regexp.MustCompile(`(^|[\s/])(pytest|py\.test|unittest|nose2?|tox|nox|green)(\s|$)|\bmake\s+(\S+\s+)*test\b|\bcoverage\s+run\b`)
+
+// Matches commands that merely install tools with a package manager, rather than running them, e.g. `pip install pylint`,
+// `python -m pip install -U mypy` or `poetry add --dev mypy`, but not e.g. `mypy --install-types src` or `git add report.md`
+var installCommandPattern = regexp.MustCompile(`(^|[\s/])(pip3?|python3?\s+-m\s+pip|poetry|pipenv|pipx|pdm|conda|mamba|micromamba|uv(\s+pip)?|npm|yarn|apt(-get)?|brew)\s+(-\S+\s+)*(install|add)(\s|$)|\bsetup\.py\s+(\S+\s+)*install(\s|$)`)
+
 func NewLinter() api.Linter {
 	return &CILinter{}
 }
 
-type CILinter struct{}
+type CILinter struct {
+	// Names of the code quality linters that the project is configured to use, e.g. `pylint` and `mypy`
+	CQLinters []string
+	// Name or URL of the external CI system that the project is configured to use, if any.
+	External string
+}
 
 func (l *CILinter) Name() string {
 	return "Continuous Integration (CI)"
 }
 
 func (l *CILinter) Rules() []*api.Rule {
-	return []*api.Rule{&RuleUseCI}
+	return []*api.Rule{&RuleUseCI, &RuleRunsTests, &RuleRunsCodeQuality, &RuleRunsMllint, &RuleCachesDependencies, &RuleTriggersOnPullRequests}
 }
 
-func (l *CILinter) LintProject(project api.Project) (api.Report, error) {
+func (l *CILinter) Configure(conf *config.Config) error {
+	l.CQLinters = conf.CodeQuality.Linters
+	l.External = conf.CI.External
+	return nil
+}
+
+func (l *CILinter) LintProject(ctx context.Context, project api.Project) (api.Report, error) {
 	report := api.NewReport()
+	backend := git.ForProject(project)
 	report.Scores[RuleUseCI] = 0
 
-	providers := ciproviders.Detect(project.Dir)
+	// CI configuration lives in the root of the project's Git repository, which is not necessarily the project's own folder.
+	root := backend.GetGitRoot(ctx, project.Dir)
+	providers := ciproviders.Detect(root)
 	if len(providers) > 0 {
 		report.Scores[RuleUseCI] = 100
 	}
@@ -32,10 +60,173 @@ func (l *CILinter) LintProject(project api.Project) (api.Report, error) {
 	for _, provider := range providers {
 		// if the repo is not tracking the CI config file, then they're not really using CI,
 		// they're merely trying to define it, which is at least a step in the right direction.
-		if !git.IsTracking(project.Dir, provider.ConfigFile(project.Dir)) {
+		if !backend.IsTracking(ctx, project.Dir, provider.ConfigFile(root)) {
 			report.Scores[RuleUseCI] = 25
 		}
 	}
 
-	return report, nil
+	if len(providers) == 0 {
+		// the project's CI configuration is not in its repository, so only its existence can be checked.
+		if l.External != "" {
+			report.Scores[RuleUseCI] = 100
+			report.Details[RuleUseCI] = fmt.Sprintf("Your project's configuration declares that its CI runs on an external CI system: %s", l.External)
+		}
+		return report, nil
+	}
+
+	pipelines, err := ciproviders.Pipelines(root, providers)
+	if len(pipelines) == 0 {
+		return report, err
+	}
+
+	l.lintTests(pipelines, &report)
+	l.lintCodeQuality(pipelines, &report)
+	l.lintMllint(pipelines, &report)
+	l.lintCaching(pipelines, &report)
+	l.lintTriggers(pipelines, &report)
+	return report, err
+}
+
+func (l *CILinter) lintTests(pipelines []ciproviders.Pipeline, report *api.Report) {
+	locations := findSteps(pipelines, func(step ciproviders.Step) bool {
+		return anyCommand(step, testCommandPattern.MatchString)
+	})
+
+	report.Scores[RuleRunsTests] = 0
+	if len(locations) == 0 {
+		report.Details[RuleRunsTests] = "None of the jobs in your project's CI pipelines " + listFiles(pipelines) + " seem to run your project's tests, e.g. using `pytest`."
+		return
+	}
+
+	report.Scores[RuleRunsTests] = 100
+	report.Details[RuleRunsTests] = "Your project's tests are run in:\n\n" + listLocations(locations)
+}
+
+func (l *CILinter) lintCodeQuality(pipelines []ciproviders.Pipeline, report *api.Report) {
+	if len(l.CQLinters) == 0 {
+		return
+	}
+
+	found := []interface{}{}
+	missing := []string{}
+	for _, linter := range l.CQLinters {
+		pattern := regexp.MustCompile(`(^|[^\w-])` + regexp.QuoteMeta(linter) + `([^\w-]|$)`)
+		locations := findSteps(pipelines, func(step ciproviders.Step) bool {
+			// e.g. `uses: psf/black@stable` in GitHub Actions
+			return pattern.MatchString(step.Uses) || anyCommand(step, pattern.MatchString)
+		})
+
+		if len(locations) == 0 {
+			missing = append(missing, "`"+linter+"`")
+		} else {
+			found = append(found, fmt.Sprintf("`%s` in %s", linter, locations[0]))
+		}
+	}
+
+	report.Scores[RuleRunsCodeQuality] = 100 * float64(len(found)) / float64(len(l.CQLinters))
+	details := strings.Builder{}
+	if len(found) > 0 {
+		details.WriteString("Your project's CI pipelines run the following code quality linters:\n\n")
+		details.WriteString(markdowngen.List(found))
+	}
+	if len(missing) > 0 {
+		if len(found) > 0 {
+			details.WriteString("\n")
+		}
+		details.WriteString(fmt.Sprintf("None of the jobs in your project's CI pipelines %s seem to run %s.", listFiles(pipelines), strings.Join(missing, ", ")))
+	}
+	report.Details[RuleRunsCodeQuality] = details.String()
+}
+
+func (l *CILinter) lintMllint(pipelines []ciproviders.Pipeline, report *api.Report) {
+	pattern := regexp.MustCompile(`(^|[\s/])mllint(\s|$)|mllint-action`)
+	locations := findSteps(pipelines, func(step ciproviders.Step) bool {
+		return pattern.MatchString(step.Uses) || anyCommand(step, pattern.MatchString)
+	})
+
+	report.Scores[RuleRunsMllint] = 0
+	if len(locations) > 0 {
+		report.Scores[RuleRunsMllint] = 100
+		report.Details[RuleRunsMllint] = "`mllint` is run in:\n\n" + listLocations(locations)
+	}
+}
+
+func (l *CILinter) lintCaching(pipelines []ciproviders.Pipeline, report *api.Report) {
+	caches := []interface{}{}
+	for _, pipeline := range pipelines {
+		for _, job := range pipeline.Jobs {
+			if len(job.Caches) > 0 {
+				caches = append(caches, fmt.Sprintf("%s caches `%s`", ciproviders.Location{Pipeline: pipeline, Job: job}, strings.Join(job.Caches, "`, `")))
+			}
+		}
+	}
+
+	report.Scores[RuleCachesDependencies] = 0
+	if len(caches) == 0 {
+		report.Details[RuleCachesDependencies] = "None of the jobs in your project's CI pipelines " + listFiles(pipelines) + " cache their dependencies."
+		return
+	}
+
+	report.Scores[RuleCachesDependencies] = 100
+	report.Details[RuleCachesDependencies] = "Your project's CI pipelines cache dependencies in:\n\n" + markdowngen.List(caches)
+}
+
+func (l *CILinter) lintTriggers(pipelines []ciproviders.Pipeline, report *api.Report) {
+	triggered := []interface{}{}
+	for _, pipeline := range pipelines {
+		if pipeline.HasTrigger(ciproviders.TriggerPullRequest) {
+			triggered = append(triggered, "`"+pipeline.File+"`")
+		}
+	}
+
+	report.Scores[RuleTriggersOnPullRequests] = 0
+	if len(triggered) == 0 {
+		report.Details[RuleTriggersOnPullRequests] = "None of your project's CI pipelines " + listFiles(pipelines) + " run on pull requests or merge requests."
+		return
+	}
+
+	report.Scores[RuleTriggersOnPullRequests] = 100
+	report.Details[RuleTriggersOnPullRequests] = "The following CI pipelines run on pull requests or merge requests:\n\n" + markdowngen.List(triggered)
+}
+
+// findSteps returns the locations of all steps in the given pipelines that match the given predicate.
+func findSteps(pipelines []ciproviders.Pipeline, matches func(step ciproviders.Step) bool) []ciproviders.Location {
+	locations := []ciproviders.Location{}
+	for _, pipeline := range pipelines {
+		for _, job := range pipeline.Jobs {
+			for _, step := range job.Steps {
+				if matches(step) {
+					locations = append(locations, ciproviders.Location{Pipeline: pipeline, Job: job, Step: step})
+				}
+			}
+		}
+	}
+	return locations
+}
+
+// anyCommand returns whether any of the commands in the given step, that are not merely installing something, match the given predicate.
+func anyCommand(step ciproviders.Step, matches func(command string) bool) bool {
+	for _, command := range step.Commands() {
+		if !installCommandPattern.MatchString(command) && matches(command) {
+			return true
+		}
+	}
+	return false
+}
+
+func listLocations(locations []ciproviders.Location) string {
+	items := []interface{}{}
+	for _, location := range locations {
+		items = append(items, location.String())
+	}
+	return markdowngen.List(items)
+}
+
+// listFiles lists the files that define the given pipelines, e.g. "(`.github/workflows/ci.yml`, `.gitlab-ci.yml`)"
+func listFiles(pipelines []ciproviders.Pipeline) string {
+	files := []string{}
+	for _, pipeline := range pipelines {
+		files = append(files, "`"+pipeline.File+"`")
+	}
+	return "(" + strings.Join(files, ", ") + ")"
 }
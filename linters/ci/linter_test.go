@@ -1,6 +1,7 @@
 package ci_test
 
 import (
+	"context"
 	"io/ioutil"
 	"os"
 	"path"
@@ -9,6 +10,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/bvobart/mllint/api"
+	"github.com/bvobart/mllint/config"
 	"github.com/bvobart/mllint/linters/ci"
 	"github.com/bvobart/mllint/setools/ciproviders"
 	"github.com/bvobart/mllint/utils/exec"
@@ -19,7 +21,7 @@ const tmpkey = "mllint-tests-ci"
 func createTestGitDir(t *testing.T, name string) string {
 	dir, err := ioutil.TempDir(os.TempDir(), tmpkey+"-"+name)
 	require.NoError(t, err)
-	_, err = exec.CommandOutput(dir, "git", "init")
+	_, err = exec.CommandOutput(context.Background(), dir, "git", "init")
 	require.NoError(t, err)
 	return dir
 }
@@ -27,14 +29,14 @@ func createTestGitDir(t *testing.T, name string) string {
 func TestCILinter(t *testing.T) {
 	linter := ci.NewLinter()
 	require.Equal(t, "Continuous Integration (CI)", linter.Name())
-	require.Equal(t, []*api.Rule{&ci.RuleUseCI}, linter.Rules())
+	require.Equal(t, []*api.Rule{&ci.RuleUseCI, &ci.RuleRunsTests, &ci.RuleRunsCodeQuality, &ci.RuleRunsMllint, &ci.RuleCachesDependencies, &ci.RuleTriggersOnPullRequests}, linter.Rules())
 
 	t.Run("None", func(t *testing.T) {
 		dir := createTestGitDir(t, "")
 		project := api.Project{Dir: dir}
 		defer os.RemoveAll(dir)
 
-		report, err := linter.LintProject(project)
+		report, err := linter.LintProject(context.Background(), project)
 		require.NoError(t, err)
 
 		score, found := report.Scores[ci.RuleUseCI]
@@ -48,10 +50,26 @@ func TestCILinter(t *testing.T) {
 		defer os.RemoveAll(dir)
 
 		require.NoError(t, ioutil.WriteFile(ciproviders.Azure{}.ConfigFile(dir), []byte("\n"), 0644))
-		_, err := exec.CommandOutput(dir, "git", "add", ".")
+		_, err := exec.CommandOutput(context.Background(), dir, "git", "add", ".")
 		require.NoError(t, err)
 
-		report, err := linter.LintProject(project)
+		report, err := linter.LintProject(context.Background(), project)
+		require.NoError(t, err)
+		require.EqualValues(t, 100, report.Scores[ci.RuleUseCI])
+	})
+
+	t.Run("GitHubActions/SubProject", func(t *testing.T) {
+		dir := createTestGitDir(t, "ghactions-sub")
+		project := api.Project{Dir: path.Join(dir, "sub")}
+		defer os.RemoveAll(dir)
+
+		require.NoError(t, os.MkdirAll(project.Dir, 0755))
+		require.NoError(t, os.MkdirAll(ciproviders.GHActions{}.ConfigFile(dir), 0755))
+		require.NoError(t, ioutil.WriteFile(path.Join(ciproviders.GHActions{}.ConfigFile(dir), "workflow.yml"), []byte("\n"), 0644))
+		_, err := exec.CommandOutput(context.Background(), dir, "git", "add", ".")
+		require.NoError(t, err)
+
+		report, err := linter.LintProject(context.Background(), project)
 		require.NoError(t, err)
 		require.EqualValues(t, 100, report.Scores[ci.RuleUseCI])
 	})
@@ -63,10 +81,10 @@ func TestCILinter(t *testing.T) {
 
 		require.NoError(t, os.MkdirAll(ciproviders.GHActions{}.ConfigFile(dir), 0755))
 		require.NoError(t, ioutil.WriteFile(path.Join(ciproviders.GHActions{}.ConfigFile(dir), "workflow.yml"), []byte("\n"), 0644))
-		_, err := exec.CommandOutput(dir, "git", "add", ".")
+		_, err := exec.CommandOutput(context.Background(), dir, "git", "add", ".")
 		require.NoError(t, err)
 
-		report, err := linter.LintProject(project)
+		report, err := linter.LintProject(context.Background(), project)
 		require.NoError(t, err)
 		require.EqualValues(t, 100, report.Scores[ci.RuleUseCI])
 	})
@@ -77,10 +95,10 @@ func TestCILinter(t *testing.T) {
 		defer os.RemoveAll(dir)
 
 		require.NoError(t, ioutil.WriteFile(ciproviders.Gitlab{}.ConfigFile(dir), []byte("\n"), 0644))
-		_, err := exec.CommandOutput(dir, "git", "add", ".")
+		_, err := exec.CommandOutput(context.Background(), dir, "git", "add", ".")
 		require.NoError(t, err)
 
-		report, err := linter.LintProject(project)
+		report, err := linter.LintProject(context.Background(), project)
 		require.NoError(t, err)
 		require.EqualValues(t, 100, report.Scores[ci.RuleUseCI])
 	})
@@ -91,10 +109,10 @@ func TestCILinter(t *testing.T) {
 		defer os.RemoveAll(dir)
 
 		require.NoError(t, ioutil.WriteFile(ciproviders.Travis{}.ConfigFile(dir), []byte("\n"), 0644))
-		_, err := exec.CommandOutput(dir, "git", "add", ".")
+		_, err := exec.CommandOutput(context.Background(), dir, "git", "add", ".")
 		require.NoError(t, err)
 
-		report, err := linter.LintProject(project)
+		report, err := linter.LintProject(context.Background(), project)
 		require.NoError(t, err)
 		require.EqualValues(t, 100, report.Scores[ci.RuleUseCI])
 	})
@@ -106,8 +124,142 @@ func TestCILinter(t *testing.T) {
 
 		require.NoError(t, ioutil.WriteFile(ciproviders.Gitlab{}.ConfigFile(dir), []byte("\n"), 0644))
 
-		report, err := linter.LintProject(project)
+		report, err := linter.LintProject(context.Background(), project)
 		require.NoError(t, err)
 		require.EqualValues(t, 25, report.Scores[ci.RuleUseCI])
 	})
 }
+
+const fullWorkflow = `
+name: CI
+on: [push, pull_request]
+jobs:
+  test:
+    runs-on: ubuntu-latest
+    steps:
+      - uses: actions/checkout@v3
+      - uses: actions/setup-python@v4
+        with:
+          cache: pip
+      - run: pip install -r requirements.txt pylint mypy mllint
+      - name: Run tests
+        run: python -m pytest --cov=src
+  lint:
+    runs-on: ubuntu-latest
+    steps:
+      - run: pylint src && mypy src
+      - uses: psf/black@stable
+      - run: mllint --output report.md
+`
+
+const poorWorkflow = `
+on:
+  push:
+    branches: [main]
+jobs:
+  build:
+    runs-on: ubuntu-latest
+    steps:
+      - run: pip install pytest pylint
+      - run: python setup.py build
+`
+
+const installLikeWorkflow = `
+on: [push]
+jobs:
+  build:
+    runs-on: ubuntu-latest
+    steps:
+      - run: python -m pip install --upgrade pylint isort
+      - run: poetry add --group dev black
+      - run: pytest tests/install
+      - run: mypy --install-types --non-interactive src
+      - run: black --check . || git add -A
+`
+
+func TestCIPipelines(t *testing.T) {
+	linter := &ci.CILinter{}
+	conf := config.Default()
+	conf.CodeQuality.Linters = []string{"pylint", "mypy", "black", "isort"}
+	require.NoError(t, linter.Configure(conf))
+
+	writeWorkflow := func(t *testing.T, contents string) string {
+		dir := createTestGitDir(t, "pipelines")
+		workflows := ciproviders.GHActions{}.ConfigFile(dir)
+		require.NoError(t, os.MkdirAll(workflows, 0755))
+		require.NoError(t, ioutil.WriteFile(path.Join(workflows, "ci.yml"), []byte(contents), 0644))
+		return dir
+	}
+
+	t.Run("Full", func(t *testing.T) {
+		dir := writeWorkflow(t, fullWorkflow)
+		defer os.RemoveAll(dir)
+
+		report, err := linter.LintProject(context.Background(), api.Project{Dir: dir})
+		require.NoError(t, err)
+		require.EqualValues(t, 100, report.Scores[ci.RuleRunsTests])
+		require.Contains(t, report.Details[ci.RuleRunsTests], "step `Run tests` of job `test` in `.github/workflows/ci.yml`")
+		require.EqualValues(t, 75, report.Scores[ci.RuleRunsCodeQuality])
+		require.Contains(t, report.Details[ci.RuleRunsCodeQuality], "`pylint` in job `lint` in `.github/workflows/ci.yml`")
+		require.Contains(t, report.Details[ci.RuleRunsCodeQuality], "seem to run `isort`.")
+		require.EqualValues(t, 100, report.Scores[ci.RuleRunsMllint])
+		require.Contains(t, report.Details[ci.RuleRunsMllint], "job `lint` in `.github/workflows/ci.yml`")
+		require.EqualValues(t, 100, report.Scores[ci.RuleCachesDependencies])
+		require.Contains(t, report.Details[ci.RuleCachesDependencies], "job `test` in `.github/workflows/ci.yml` caches `pip`")
+		require.EqualValues(t, 100, report.Scores[ci.RuleTriggersOnPullRequests])
+	})
+
+	t.Run("Poor", func(t *testing.T) {
+		dir := writeWorkflow(t, poorWorkflow)
+		defer os.RemoveAll(dir)
+
+		report, err := linter.LintProject(context.Background(), api.Project{Dir: dir})
+		require.NoError(t, err)
+		require.EqualValues(t, 0, report.Scores[ci.RuleRunsTests])
+		require.Equal(t, "None of the jobs in your project's CI pipelines (`.github/workflows/ci.yml`) seem to run your project's tests, e.g. using `pytest`.", report.Details[ci.RuleRunsTests])
+		require.EqualValues(t, 0, report.Scores[ci.RuleRunsCodeQuality])
+		require.EqualValues(t, 0, report.Scores[ci.RuleRunsMllint])
+		require.EqualValues(t, 0, report.Scores[ci.RuleCachesDependencies])
+		require.EqualValues(t, 0, report.Scores[ci.RuleTriggersOnPullRequests])
+	})
+
+	t.Run("InstallLikeArguments", func(t *testing.T) {
+		dir := writeWorkflow(t, installLikeWorkflow)
+		defer os.RemoveAll(dir)
+
+		// only commands that install packages with a package manager are ignored, not commands that merely mention installing or adding.
+		report, err := linter.LintProject(context.Background(), api.Project{Dir: dir})
+		require.NoError(t, err)
+		require.EqualValues(t, 100, report.Scores[ci.RuleRunsTests])
+		require.EqualValues(t, 50, report.Scores[ci.RuleRunsCodeQuality])
+		require.Contains(t, report.Details[ci.RuleRunsCodeQuality], "`mypy` in job `build`")
+		require.Contains(t, report.Details[ci.RuleRunsCodeQuality], "`black` in job `build`")
+		require.NotContains(t, report.Details[ci.RuleRunsCodeQuality], "`pylint` in job")
+	})
+
+	t.Run("Invalid", func(t *testing.T) {
+		dir := writeWorkflow(t, "jobs: [\n")
+		defer os.RemoveAll(dir)
+
+		report, err := linter.LintProject(context.Background(), api.Project{Dir: dir})
+		require.Error(t, err)
+		require.Contains(t, report.Scores, ci.RuleUseCI)
+		require.NotContains(t, report.Scores, ci.RuleRunsTests)
+	})
+}
+
+func TestCIExternal(t *testing.T) {
+	linter := &ci.CILinter{}
+	conf := config.Default()
+	conf.CI.External = "https://jenkins.example.com/job/my-project"
+	require.NoError(t, linter.Configure(conf))
+
+	dir := createTestGitDir(t, "external")
+	defer os.RemoveAll(dir)
+
+	report, err := linter.LintProject(context.Background(), api.Project{Dir: dir})
+	require.NoError(t, err)
+	require.EqualValues(t, 100, report.Scores[ci.RuleUseCI])
+	require.Contains(t, report.Details[ci.RuleUseCI], "https://jenkins.example.com/job/my-project")
+	require.Len(t, report.Scores, 1)
+}
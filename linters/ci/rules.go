@@ -14,13 +14,95 @@ To learn more about what CI is, does and entails, see the description of categor
 
 Implementing CI requires picking a CI provider that will run the automated builds and tests. 
 There are many CI providers available and you will have to make your own decision on which fits you best,
-but %s currently recognises four CI providers, namely:
+but %s currently recognises the following CI providers:
 - [Azure DevOps Pipelines](https://docs.microsoft.com/en-us/azure/devops/pipelines/?view=azure-devops)
+- [Bitbucket Pipelines](https://support.atlassian.com/bitbucket-cloud/docs/get-started-with-bitbucket-pipelines/)
+- [Buildkite](https://buildkite.com/docs/pipelines)
+- [CircleCI](https://circleci.com/docs/)
+- [Drone CI](https://docs.drone.io/)
 - [GitHub Actions](https://docs.github.com/en/actions)
 - [Gitlab CI](https://docs.gitlab.com/ee/ci/)
+- [Jenkins](https://www.jenkins.io/doc/book/pipeline/jenkinsfile/)
 - [Travis CI](https://docs.travis-ci.com/)
+- [Woodpecker CI](https://woodpecker-ci.org/docs/intro)
 
-Follow your CI provider's respective 'Getting Started' guide and set your project up with a pipeline to build, test and lint your project.`,
-		"`ci`", "`mllint`"), // TODO: add a ref to a to be implemented rule on how the structure of your pipelines should be.
+Follow your CI provider's respective 'Getting Started' guide and set your project up with a pipeline to build, test and lint your project.
+The other rules in category %s check what your pipeline should do.
+
+If your project's CI pipeline is configured outside of your project's repository, e.g. in a Jenkins job that is not defined by a %s,
+then you can declare the CI system that your project uses in your %s configuration, such that this rule passes:
+%s`,
+		"`ci`", "`mllint`", "`ci`", "`Jenkinsfile`", "`mllint`", "```yaml\nci:\n  external: https://jenkins.example.com/job/my-project\n```"),
+	Weight: 1,
+}
+
+var RuleRunsTests = api.Rule{
+	Slug: "ci/runs-tests",
+	Name: "CI pipeline runs the project's tests",
+	Details: `Having a CI pipeline is only useful when it actually checks something. The most important thing that your CI pipeline should check,
+is whether all of your project's tests pass, such that you find out about anything that your changes broke before they are merged.
+
+This rule checks whether any of the jobs in your CI pipelines runs your project's tests, i.e. runs e.g. ` + "`pytest`, `python -m unittest`, `tox`, `nox` or `make test`" + `.
+For example, in GitHub Actions:
+` + "```yaml" + `
+jobs:
+  test:
+    runs-on: ubuntu-latest
+    steps:
+      - uses: actions/checkout@v3
+      - uses: actions/setup-python@v4
+        with:
+          python-version: "3.10"
+          cache: pip
+      - run: pip install -r requirements.txt
+      - run: pytest --junitxml=tests-report.xml --cov=src --cov-report=xml
+` + "```",
+	Weight: 1,
+}
+
+var RuleRunsCodeQuality = api.Rule{
+	Slug: "ci/runs-code-quality",
+	Name: "CI pipeline runs the project's code quality linters",
+	Details: `Code quality linters, such as Pylint, Mypy and Black, are most effective when they are run automatically on every change to your project,
+such that issues are caught before they are merged, rather than only when someone remembers to run them.
+
+This rule checks whether the jobs in your CI pipelines run each of the code quality linters that are configured in ` + "`code-quality.linters`" + ` in your ` + "`mllint`" + ` configuration.
+The score is the percentage of these linters that your CI pipelines run, either as a command, e.g. ` + "`pylint src`" + `, or as a reusable action, e.g. ` + "`uses: psf/black@stable`" + `.`,
+	Weight: 1,
+}
+
+var RuleRunsMllint = api.Rule{
+	Slug: "ci/runs-mllint",
+	Name: "CI pipeline runs mllint",
+	Details: `Running ` + "`mllint`" + ` in your CI pipeline allows you to keep track of how your project's quality develops with every change,
+and to catch regressions in e.g. your project's version control, dependency management or code quality before they are merged.
+
+This rule checks whether any of the jobs in your CI pipelines runs ` + "`mllint`" + `, e.g.:
+` + "```yaml" + `
+      - run: pip install mllint
+      - run: mllint --output mllint-report.md
+` + "```",
+	Weight: 1,
+}
+
+var RuleCachesDependencies = api.Rule{
+	Slug: "ci/caches-dependencies",
+	Name: "CI pipeline caches dependencies",
+	Details: `Installing your project's dependencies from scratch in every CI run is slow and wastes resources, especially for ML projects
+with large dependencies such as PyTorch or TensorFlow. All CI providers allow you to cache files between runs, e.g. pip's download cache or your virtual environment.
+
+This rule checks whether any of the jobs in your CI pipelines caches files between runs, e.g. using ` + "`cache: pip`" + ` with ` + "`actions/setup-python`" + ` or ` + "`actions/cache`" + ` in GitHub Actions,
+` + "`cache: paths:`" + ` in GitLab CI, the ` + "`Cache@2`" + ` task in Azure DevOps, or ` + "`cache: pip`" + ` in Travis CI.`,
+	Weight: 1,
+}
+
+var RuleTriggersOnPullRequests = api.Rule{
+	Slug: "ci/triggers-on-pull-requests",
+	Name: "CI pipeline runs on pull requests",
+	Details: `Your CI pipeline should check every change before it is merged into your project's main branch, which means that it should run on every pull request (or merge request).
+
+This rule checks whether any of your CI pipelines is triggered by pull requests, e.g. ` + "`on: pull_request`" + ` in GitHub Actions,
+a rule such as ` + "`if: $CI_PIPELINE_SOURCE == \"merge_request_event\"`" + ` in GitLab CI, or not disabling PR triggers with ` + "`pr: none`" + ` in Azure DevOps.
+Travis CI builds pull requests by default.`,
 	Weight: 1,
 }
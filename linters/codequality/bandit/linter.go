@@ -1,6 +1,7 @@
 package bandit
 
 import (
+	"context"
 	"fmt"
 	"math"
 	"strconv"
@@ -10,8 +11,15 @@ import (
 	"github.com/bvobart/mllint/utils/markdowngen"
 )
 
-// No Bandit messages = 100%, 1 Bandit message per 50 lines of code = 50%, 1 Bandit message per 25 lines of code = 0%
-const maxLoCperMsg = 25
+// By default, no Bandit messages = 100%, 1 Bandit message per 50 lines of code = 50%, 1 Bandit message per 25 lines of code = 0%
+var ParamMaxLoCperMsg = api.RuleParam{
+	Slug:        RuleNoIssues.Slug,
+	Name:        "maxLoCperMsg",
+	Description: "The rule scores 0% when Bandit reports 1 message per this many lines of code. Increase it to tolerate fewer messages.",
+	Default:     25,
+	Min:         1,
+	Max:         1000,
+}
 
 func NewLinter() api.Linter {
 	return &BanditLinter{}
@@ -23,15 +31,19 @@ func (l *BanditLinter) Name() string {
 	return "Bandit"
 }
 
+func (l *BanditLinter) RuleParams() []api.RuleParam {
+	return []api.RuleParam{ParamMaxLoCperMsg}
+}
+
 func (l *BanditLinter) Rules() []*api.Rule {
 	return []*api.Rule{&RuleNoIssues}
 }
 
-func (l *BanditLinter) LintProject(project api.Project) (api.Report, error) {
+func (l *BanditLinter) LintProject(ctx context.Context, project api.Project) (api.Report, error) {
 	report := api.NewReport()
 	linter := cqlinters.ByType[cqlinters.TypeBandit]
 
-	if RuleNoIssues.Disabled {
+	if project.RuleDisabled(&RuleNoIssues) {
 		return report, nil
 	}
 
@@ -47,13 +59,13 @@ func (l *BanditLinter) LintProject(project api.Project) (api.Report, error) {
 		report.Details[RuleNoIssues] = "No Python files were found in the project's repository"
 	}
 
-	results, err := linter.Run(project)
+	results, err := cqlinters.RunCached(ctx, linter, project)
 	if err != nil {
 		return report, fmt.Errorf("Bandit failed to run: %w", err)
 	}
 
 	// calculate score
-	report.Scores[RuleNoIssues] = 100 - 100*math.Min(1, float64(len(results)*maxLoCperMsg)/float64(loc))
+	report.Scores[RuleNoIssues] = 100 - 100*math.Min(1, float64(len(results))*ParamMaxLoCperMsg.Value(project)/float64(loc))
 	if len(results) == 0 {
 		report.Details[RuleNoIssues] = "Congratulations, Bandit is happy with your project!"
 	} else {
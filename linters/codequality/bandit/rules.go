@@ -13,5 +13,6 @@ This rule checks whether Bandit finds any security issues in your project.
 
 For configuring Bandit's settings, such as which directories to exclude and which rules to enable / disable,
 create a ` + "`.bandit`" + `file at the root of your project. See [Bandit's documentation](https://github.com/PyCQA/bandit#per-project-command-line-args) to learn more.`,
-	Weight: 1,
+	Weight:  1,
+	PerFile: true,
 }
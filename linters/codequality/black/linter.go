@@ -1,6 +1,7 @@
 package black
 
 import (
+	"context"
 	"fmt"
 	"strconv"
 
@@ -23,11 +24,11 @@ func (l *BlackLinter) Rules() []*api.Rule {
 	return []*api.Rule{&RuleNoIssues}
 }
 
-func (l *BlackLinter) LintProject(project api.Project) (api.Report, error) {
+func (l *BlackLinter) LintProject(ctx context.Context, project api.Project) (api.Report, error) {
 	report := api.NewReport()
 	linter := cqlinters.ByType[cqlinters.TypeBlack]
 
-	if RuleNoIssues.Disabled {
+	if project.RuleDisabled(&RuleNoIssues) {
 		return report, nil
 	}
 
@@ -42,7 +43,7 @@ func (l *BlackLinter) LintProject(project api.Project) (api.Report, error) {
 		report.Details[RuleNoIssues] = "No Python files were found in the project's repository"
 	}
 
-	results, err := linter.Run(project)
+	results, err := cqlinters.RunCached(ctx, linter, project)
 	if err != nil {
 		return report, fmt.Errorf("Black failed to run: %w", err)
 	}
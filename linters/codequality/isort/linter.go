@@ -1,6 +1,7 @@
 package isort
 
 import (
+	"context"
 	"fmt"
 	"strconv"
 
@@ -23,11 +24,11 @@ func (l *ISortLinter) Rules() []*api.Rule {
 	return []*api.Rule{&RuleNoIssues, &RuleIsConfigured}
 }
 
-func (l *ISortLinter) LintProject(project api.Project) (api.Report, error) {
+func (l *ISortLinter) LintProject(ctx context.Context, project api.Project) (api.Report, error) {
 	report := api.NewReport()
 	linter := cqlinters.ByType[cqlinters.TypeISort]
 
-	if !RuleIsConfigured.Disabled {
+	if !project.RuleDisabled(&RuleIsConfigured) {
 		if linter.IsProperlyConfigured(project) {
 			report.Scores[RuleIsConfigured] = 100
 		} else {
@@ -36,7 +37,7 @@ func (l *ISortLinter) LintProject(project api.Project) (api.Report, error) {
 		}
 	}
 
-	if RuleNoIssues.Disabled {
+	if project.RuleDisabled(&RuleNoIssues) {
 		return report, nil
 	}
 
@@ -51,7 +52,7 @@ func (l *ISortLinter) LintProject(project api.Project) (api.Report, error) {
 		report.Details[RuleNoIssues] = "No Python files were found in the project's repository"
 	}
 
-	results, err := linter.Run(project)
+	results, err := cqlinters.RunCached(ctx, linter, project)
 	if err != nil {
 		return report, fmt.Errorf("isort failed to run: %w", err)
 	}
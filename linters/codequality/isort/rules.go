@@ -12,7 +12,8 @@ var RuleNoIssues = api.Rule{
 	Details: fmt.Sprintf(`> [%s](https://github.com/PyCQA/isort) is a Python utility / library to sort imports alphabetically, and automatically separated into sections and by type. It provides a command line utility, Python library and plugins for various editors to quickly sort all your imports.
 
 This rule checks whether %s finds any files it would fix in your project.`, "`isort`", "`isort`"),
-	Weight: 1,
+	Weight:  1,
+	PerFile: true,
 }
 
 var RuleIsConfigured = api.Rule{
@@ -1,6 +1,11 @@
 package codequality
 
 import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
 	"github.com/hashicorp/go-multierror"
 
 	"github.com/bvobart/mllint/api"
@@ -13,6 +18,7 @@ import (
 	"github.com/bvobart/mllint/linters/codequality/mypy"
 	"github.com/bvobart/mllint/linters/codequality/pylint"
 	"github.com/bvobart/mllint/setools/cqlinters"
+	"github.com/bvobart/mllint/setools/precommit"
 	"github.com/bvobart/mllint/utils/markdowngen"
 )
 
@@ -39,12 +45,22 @@ func toMap(all []pair) map[api.CQLinterType]api.Linter {
 
 var sublinters = toMap(all)
 
+// Number of the runner's job slots that the most CPU and memory intensive linters occupy, unless configured otherwise.
+var defaultWeights = map[api.CQLinterType]int{
+	cqlinters.TypePylint: 2,
+	cqlinters.TypeMypy:   2,
+}
+
 func NewLinter() api.ConfigurableLinter {
 	return &CQLinter{}
 }
 
 type CQLinter struct {
 	Linters []api.CQLinter
+	// Maximum durations of running each of the linters, if configured.
+	Timeouts map[api.CQLinterType]time.Duration
+	// Number of the runner's job slots that each of the linters occupies while running.
+	Weights map[api.CQLinterType]int
 	runner  mllint.Runner
 }
 
@@ -53,28 +69,59 @@ func (l *CQLinter) Name() string {
 }
 
 func (l *CQLinter) Rules() []*api.Rule {
-	rules := []*api.Rule{&RuleUseLinters, &RuleLintersInstalled}
+	rules := []*api.Rule{&RuleUseLinters, &RuleLintersInstalled, &RulePreCommitLinters, &RulePreCommitPinned}
 	for _, l := range all {
 		rules = append(rules, l.Linter.Rules()...)
 	}
 	return rules
 }
 
+func (l *CQLinter) RuleParams() []api.RuleParam {
+	params := []api.RuleParam{}
+	for _, l := range all {
+		params = append(params, api.RuleParamsOf(l.Linter)...)
+	}
+	return params
+}
+
 func (l *CQLinter) SetRunner(r mllint.Runner) {
 	l.runner = r
 }
 
 func (l *CQLinter) Configure(conf *config.Config) (err error) {
 	l.Linters, err = cqlinters.FromConfig(conf.CodeQuality)
-	return err
+	if err != nil {
+		return err
+	}
+
+	l.Timeouts = map[api.CQLinterType]time.Duration{}
+	l.Weights = map[api.CQLinterType]int{}
+	for _, linter := range l.Linters {
+		l.Weights[linter.Type()] = defaultWeights[linter.Type()]
+		if weight, ok := conf.Runner.Weights[string(linter.Type())]; ok {
+			l.Weights[linter.Type()] = weight
+		}
+
+		timeout, err := mllint.ParseTimeout(conf.Timeouts.Linters[string(linter.Type())])
+		if err != nil {
+			return fmt.Errorf("timeout of linter %s: %w", linter, err)
+		}
+		l.Timeouts[linter.Type()] = timeout
+	}
+	return nil
 }
 
-func (l *CQLinter) LintProject(project api.Project) (api.Report, error) {
+func (l *CQLinter) LintProject(ctx context.Context, project api.Project) (api.Report, error) {
 	report := api.NewReport()
+	var multiErr *multierror.Error
+	if err := l.lintPreCommit(project, &report); err != nil {
+		multiErr = multierror.Append(multiErr, err)
+	}
+
 	detectedLinters := project.CQLinters
 	desiredLinters := l.Linters
 	if len(desiredLinters) == 0 {
-		return report, nil
+		return report, multiErr.ErrorOrNil()
 	}
 
 	missingLinters := findMissing(desiredLinters, detectedLinters)
@@ -100,15 +147,15 @@ func (l *CQLinter) LintProject(project api.Project) (api.Report, error) {
 		}
 
 		mlLinter, ok := sublinters[desiredLinter.Type()]
-		if !ok {
+		if !ok || project.AllRulesDisabled(mlLinter) {
 			continue
 		}
 
 		displayName := "Code Quality - " + mlLinter.Name()
-		tasks = append(tasks, l.runner.RunLinter(desiredLinter.String(), mlLinter, project, mllint.DisplayName(displayName)))
+		tasks = append(tasks, l.runner.RunLinter(ctx, desiredLinter.String(), mlLinter, project, mllint.DisplayName(displayName),
+			mllint.Timeout(l.Timeouts[desiredLinter.Type()]), mllint.Weight(l.Weights[desiredLinter.Type()])))
 	}
 
-	var multiErr *multierror.Error
 	subReports := []api.Report{}
 	mllint.ForEachTask(l.runner.CollectTasks(tasks...), func(task *mllint.RunnerTask, result mllint.LinterResult) {
 		if result.Err != nil {
@@ -121,6 +168,58 @@ func (l *CQLinter) LintProject(project api.Project) (api.Report, error) {
 	return api.MergeReports(report, subReports...), multiErr.ErrorOrNil()
 }
 
+// lintPreCommit checks the project's pre-commit configuration, if it has one,
+// for hooks that run the desired linters and for hook repositories that are not pinned to a fixed revision.
+func (l *CQLinter) lintPreCommit(project api.Project, report *api.Report) error {
+	conf, err := precommit.ReadConfig(project.Dir)
+	if errors.Is(err, precommit.ErrNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	if len(l.Linters) > 0 {
+		missing := []interface{}{}
+		for _, linter := range l.Linters {
+			if !conf.HasHook(linter.DependencyName()) {
+				missing = append(missing, linter)
+			}
+		}
+
+		report.Scores[RulePreCommitLinters] = 100 * (1 - float64(len(missing))/float64(len(l.Linters)))
+		if len(missing) > 0 {
+			report.Details[RulePreCommitLinters] = fmt.Sprintf("None of the hooks in your project's `%s` seem to run the following linters:\n\n%s", conf.Filename, markdowngen.List(missing))
+		}
+	}
+
+	remote := 0
+	for _, repo := range conf.Repos {
+		if !repo.IsLocal() {
+			remote++
+		}
+	}
+	if remote == 0 {
+		return nil
+	}
+
+	unpinned := conf.UnpinnedRepos()
+	report.Scores[RulePreCommitPinned] = 100 * (1 - float64(len(unpinned))/float64(remote))
+	if len(unpinned) > 0 {
+		items := []interface{}{}
+		for _, repo := range unpinned {
+			rev := repo.Rev
+			if rev == "" {
+				rev = "(none)"
+			}
+			items = append(items, fmt.Sprintf("`%s` at `%s`", repo.URL, rev))
+		}
+		report.Details[RulePreCommitPinned] = fmt.Sprintf("The following hook repositories in your project's `%s` are not pinned to a version tag or commit hash:\n\n%s\nUse `pre-commit autoupdate` to pin them to their latest version tags.",
+			conf.Filename, markdowngen.List(items))
+	}
+	return nil
+}
+
 func contains(linters []api.CQLinter, target api.CQLinter) bool {
 	for _, l := range linters {
 		if l == target {
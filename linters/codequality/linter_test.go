@@ -0,0 +1,123 @@
+package codequality_test
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"path"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/bvobart/mllint/api"
+	"github.com/bvobart/mllint/commands/mllint"
+	"github.com/bvobart/mllint/config"
+	"github.com/bvobart/mllint/linters/codequality"
+	"github.com/bvobart/mllint/utils/exec"
+)
+
+func TestLintPreCommit(t *testing.T) {
+	// none of the linters seem installed, such that only the pre-commit configuration is analysed.
+	exec.LookPath = func(file string) (string, error) { return "", errors.New("not installed") }
+	defer func() { exec.LookPath = exec.DefaultLookPath }()
+
+	conf := config.Default()
+	conf.CodeQuality.Linters = []string{"pylint", "black"}
+	linter := codequality.NewLinter().(*codequality.CQLinter)
+	require.NoError(t, linter.Configure(conf))
+	linter.SetRunner(mllint.NewMLLintRunner(nil))
+
+	tests := []struct {
+		name              string
+		preCommit         string
+		expectedLinters   *float64
+		expectedPinned    *float64
+		expectedDetails   []string
+		unexpectedDetails []string
+	}{
+		{
+			name: "NoPreCommit",
+		},
+		{
+			name: "HooksPresentAndPinned",
+			preCommit: `repos:
+  - repo: https://github.com/psf/black
+    rev: 23.1.0
+    hooks:
+      - id: black
+  - repo: https://github.com/pycqa/pylint
+    rev: 6e2418c5521b7d606e72914dced3253f9ace1205
+    hooks:
+      - id: pylint
+`,
+			expectedLinters: score(100),
+			expectedPinned:  score(100),
+		},
+		{
+			name: "HookMissingAndUnpinned",
+			preCommit: `repos:
+  - repo: https://github.com/psf/black
+    rev: main
+    hooks:
+      - id: black-jupyter
+  - repo: https://github.com/pre-commit/pre-commit-hooks
+    rev: v4.4.0
+    hooks:
+      - id: trailing-whitespace
+`,
+			expectedLinters:   score(50),
+			expectedPinned:    score(50),
+			expectedDetails:   []string{"Pylint", "`https://github.com/psf/black` at `main`"},
+			unexpectedDetails: []string{"pre-commit-hooks"},
+		},
+		{
+			name: "OnlyLocalHooks",
+			preCommit: `repos:
+  - repo: local
+    hooks:
+      - id: pylint
+        entry: poetry run pylint
+      - id: black
+        entry: poetry run black
+`,
+			expectedLinters: score(100),
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			if tt.preCommit != "" {
+				require.NoError(t, ioutil.WriteFile(path.Join(dir, ".pre-commit-config.yaml"), []byte(tt.preCommit), 0644))
+			}
+
+			report, err := linter.LintProject(context.Background(), api.Project{Dir: dir})
+			require.NoError(t, err)
+			requireScore(t, tt.expectedLinters, report, codequality.RulePreCommitLinters)
+			requireScore(t, tt.expectedPinned, report, codequality.RulePreCommitPinned)
+
+			details := report.Details[codequality.RulePreCommitLinters] + report.Details[codequality.RulePreCommitPinned]
+			for _, expected := range tt.expectedDetails {
+				require.Contains(t, details, expected)
+			}
+			for _, unexpected := range tt.unexpectedDetails {
+				require.NotContains(t, details, unexpected)
+			}
+		})
+	}
+}
+
+func score(s float64) *float64 {
+	return &s
+}
+
+// requireScore checks that the report has the expected score for the given rule, or no score at all if expected is nil.
+func requireScore(t *testing.T, expected *float64, report api.Report, rule api.Rule) {
+	if expected == nil {
+		require.NotContains(t, report.Scores, rule)
+		return
+	}
+	require.Contains(t, report.Scores, rule)
+	require.EqualValues(t, *expected, report.Scores[rule])
+}
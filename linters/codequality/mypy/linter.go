@@ -1,6 +1,7 @@
 package mypy
 
 import (
+	"context"
 	"fmt"
 	"math"
 
@@ -11,7 +12,14 @@ import (
 
 // Maximum number of lines of code per Mypy message reported.
 // Increasing this means that users are expected to have less code smells per line of code.
-const maxLoCperMsg = 10
+var ParamMaxLoCperMsg = api.RuleParam{
+	Slug:        RuleNoIssues.Slug,
+	Name:        "maxLoCperMsg",
+	Description: "The rule scores 0% when Mypy reports 1 message per this many lines of code. Increase it to tolerate fewer messages.",
+	Default:     10,
+	Min:         1,
+	Max:         1000,
+}
 
 func NewLinter() api.Linter {
 	return &MypyLinter{}
@@ -23,15 +31,19 @@ func (l *MypyLinter) Name() string {
 	return "Mypy"
 }
 
+func (l *MypyLinter) RuleParams() []api.RuleParam {
+	return []api.RuleParam{ParamMaxLoCperMsg}
+}
+
 func (l *MypyLinter) Rules() []*api.Rule {
 	return []*api.Rule{&RuleNoIssues}
 }
 
-func (l *MypyLinter) LintProject(project api.Project) (api.Report, error) {
+func (l *MypyLinter) LintProject(ctx context.Context, project api.Project) (api.Report, error) {
 	report := api.NewReport()
 	linter := cqlinters.ByType[cqlinters.TypeMypy]
 
-	if RuleNoIssues.Disabled {
+	if project.RuleDisabled(&RuleNoIssues) {
 		return report, nil
 	}
 
@@ -47,13 +59,13 @@ func (l *MypyLinter) LintProject(project api.Project) (api.Report, error) {
 		report.Details[RuleNoIssues] = "No Python code was found in the project's repository"
 	}
 
-	results, err := linter.Run(project)
+	results, err := cqlinters.RunCached(ctx, linter, project)
 	if err != nil {
 		return report, fmt.Errorf("Mypy failed to run: %w", err)
 	}
 
 	// calculate score. No Mypy messages = 100%, 1 Mypy message per 20 lines of code = 50%, 1 Mypy message per 10 lines of code = 0%
-	report.Scores[RuleNoIssues] = 100 - 100*math.Min(1, float64(len(results)*maxLoCperMsg)/float64(loc))
+	report.Scores[RuleNoIssues] = 100 - 100*math.Min(1, float64(len(results))*ParamMaxLoCperMsg.Value(project)/float64(loc))
 	if len(results) == 0 {
 		report.Details[RuleNoIssues] = "Congratulations, Mypy is happy with your project!"
 	} else {
@@ -26,7 +26,8 @@ To learn more about how type-checking works and how to use it in Python, see:
 - https://realpython.com/python-type-checking/
 `,
 		"`score = 100 - 100 * min(1, 10 * number of msgs / lines of code)`"),
-	Weight: 1,
+	Weight:  1,
+	PerFile: true,
 }
 
 // var RuleIsConfigured = api.Rule{
@@ -1,6 +1,7 @@
 package pylint
 
 import (
+	"context"
 	"fmt"
 	"math"
 
@@ -11,7 +12,14 @@ import (
 
 // Maximum number of lines of code per Pylint message reported.
 // Increasing this means that users are expected to have less code smells per line of code.
-const maxLoCperMsg = 10
+var ParamMaxLoCperMsg = api.RuleParam{
+	Slug:        RuleNoIssues.Slug,
+	Name:        "maxLoCperMsg",
+	Description: "The rule scores 0% when Pylint reports 1 message per this many lines of code. Increase it to tolerate fewer messages.",
+	Default:     10,
+	Min:         1,
+	Max:         1000,
+}
 
 func NewLinter() api.Linter {
 	return &PylintLinter{}
@@ -23,16 +31,20 @@ func (l *PylintLinter) Name() string {
 	return "Pylint"
 }
 
+func (l *PylintLinter) RuleParams() []api.RuleParam {
+	return []api.RuleParam{ParamMaxLoCperMsg}
+}
+
 func (l *PylintLinter) Rules() []*api.Rule {
 	return []*api.Rule{&RuleNoIssues, &RuleIsConfigured}
 }
 
-func (l *PylintLinter) LintProject(project api.Project) (api.Report, error) {
+func (l *PylintLinter) LintProject(ctx context.Context, project api.Project) (api.Report, error) {
 	report := api.NewReport()
 	linter := cqlinters.ByType[cqlinters.TypePylint]
 
 	// check if there is a configuration for Pylint
-	if !RuleIsConfigured.Disabled {
+	if !project.RuleDisabled(&RuleIsConfigured) {
 		if linter.IsConfigured(project) {
 			report.Scores[RuleIsConfigured] = 100
 		} else {
@@ -40,7 +52,7 @@ func (l *PylintLinter) LintProject(project api.Project) (api.Report, error) {
 		}
 	}
 
-	if RuleNoIssues.Disabled {
+	if project.RuleDisabled(&RuleNoIssues) {
 		return report, nil
 	}
 
@@ -60,13 +72,13 @@ func (l *PylintLinter) LintProject(project api.Project) (api.Report, error) {
 	}
 
 	// actually run Pylint
-	results, err := linter.Run(project)
+	results, err := cqlinters.RunCached(ctx, linter, project)
 	if err != nil {
 		return report, fmt.Errorf("Pylint failed to run: %w", err)
 	}
 
 	// calculate score. No Pylint messages = 100%, 1 Pylint message per 20 lines of code = 50%, 1 Pylint message per 10 lines of code = 0%
-	report.Scores[RuleNoIssues] = 100 - 100*math.Min(1, float64(len(results)*maxLoCperMsg)/float64(loc))
+	report.Scores[RuleNoIssues] = 100 - 100*math.Min(1, float64(len(results))*ParamMaxLoCperMsg.Value(project)/float64(loc))
 	if len(results) == 0 {
 		report.Details[RuleNoIssues] = "Congratulations, Pylint is happy with your project!"
 	} else {
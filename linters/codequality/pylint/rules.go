@@ -21,7 +21,8 @@ More specifically, in pseudocode, %s.
 
 Note that the measured amount of lines of code includes any non-hidden Python files in the repository, including those that are ignored by Pylint.`,
 		"`score = 100 - 100 * min(1, 10 * number of msgs / lines of code)`"),
-	Weight: 1,
+	Weight:  1,
+	PerFile: true,
 }
 
 var RuleIsConfigured = api.Rule{
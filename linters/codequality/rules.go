@@ -81,3 +81,32 @@ but a more recommended way is to install them into a virtualenv, then activating
 Poetry and Pipenv do this automatically, simply install them as development dependencies (%s) and run e.g. %s to open a shell in which to run mllint.`, "`--dev`", "`poetry shell`"),
 	Weight: 1,
 }
+
+var RulePreCommitLinters = api.Rule{
+	Name: "Pre-commit hooks should run all code quality linters",
+	Slug: "code-quality/pre-commit-linters",
+	Details: `[pre-commit](https://pre-commit.com/) is a framework for managing Git hooks, which your project uses to run tools on the files
+that you are about to commit, every time you make a commit. This makes it a great way of enforcing that the code going into your repository
+is always linted, even before it reaches CI.
+
+Since your project has a ` + "`.pre-commit-config.yaml`" + `, this rule checks that its hooks run each of the code quality linters that mllint is configured to use.
+Hooks can either come from the linter's own repository, e.g. ` + "`black` from `https://github.com/psf/black`" + `,
+or be ` + "`local`" + ` hooks whose ` + "`entry`" + ` runs the linter, e.g. ` + "`poetry run pylint`" + `.
+
+The score of this rule is the percentage of configured linters that are run by a pre-commit hook.
+This rule is only checked when your project has a pre-commit configuration.`,
+	Weight: 1,
+}
+
+var RulePreCommitPinned = api.Rule{
+	Name: "Pre-commit hook repositories should be pinned to a fixed revision",
+	Slug: "code-quality/pre-commit-pinned",
+	Details: `Each repository of hooks in your project's ` + "`.pre-commit-config.yaml`" + ` specifies a ` + "`rev`" + ` of that repository to use.
+When this is a branch name like ` + "`master`" + ` or ` + "`stable`" + `, the hooks that you and your collaborators run may change without notice,
+depending on when pre-commit happened to install them. Instead, pin each repository to a version tag (e.g. ` + "`v4.4.0`" + `) or a commit hash,
+then use ` + "`pre-commit autoupdate`" + ` to update them deliberately.
+
+The score of this rule is the percentage of (non-` + "`local`" + `) hook repositories that are pinned to a version tag or commit hash.
+This rule is only checked when your project has a pre-commit configuration.`,
+	Weight: 1,
+}
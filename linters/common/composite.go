@@ -1,6 +1,7 @@
 package common
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/bvobart/mllint/api"
@@ -32,6 +33,14 @@ func (l *CompositeLinter) Rules() []*api.Rule {
 	return l.rules
 }
 
+func (l *CompositeLinter) RuleParams() []api.RuleParam {
+	params := []api.RuleParam{}
+	for _, linter := range l.linters {
+		params = append(params, api.RuleParamsOf(linter)...)
+	}
+	return params
+}
+
 func (l *CompositeLinter) SetRunner(r mllint.Runner) {
 	l.runner = r
 }
@@ -48,11 +57,15 @@ func (l *CompositeLinter) Configure(conf *config.Config) error {
 	return nil
 }
 
-func (l *CompositeLinter) LintProject(project api.Project) (api.Report, error) {
-	tasks := make([]*mllint.RunnerTask, len(l.linters))
+func (l *CompositeLinter) LintProject(ctx context.Context, project api.Project) (api.Report, error) {
+	tasks := make([]*mllint.RunnerTask, 0, len(l.linters))
 	for i, linter := range l.linters {
+		if project.AllRulesDisabled(linter) {
+			continue
+		}
+
 		displayName := l.name + " - " + linter.Name()
-		tasks[i] = l.runner.RunLinter(fmt.Sprint(i), linter, project, mllint.DisplayName(displayName))
+		tasks = append(tasks, l.runner.RunLinter(ctx, fmt.Sprint(i), linter, project, mllint.DisplayName(displayName)))
 	}
 
 	var err *multierror.Error
@@ -1,6 +1,7 @@
 package common_test
 
 import (
+	"context"
 	"errors"
 	"strings"
 	"testing"
@@ -63,7 +64,7 @@ func (l *testLinter) Configure(_ *config.Config) error {
 	return nil
 }
 
-func (l *testLinter) LintProject(project api.Project) (api.Report, error) {
+func (l *testLinter) LintProject(ctx context.Context, project api.Project) (api.Report, error) {
 	return l.report, l.lintErr
 }
 
@@ -160,7 +161,7 @@ func TestCompositeLinterLintProject(t *testing.T) {
 
 	// When: compLinter.LintProject is called
 	project := api.Project{Dir: "test"}
-	report, err := compLinter.LintProject(project)
+	report, err := compLinter.LintProject(context.Background(), project)
 	require.NoError(t, err)
 
 	// Then: expect that the report contains the scores and details from the expected reports above,
@@ -196,8 +197,28 @@ func TestCompositeLinterLintProjectErr(t *testing.T) {
 	compLinter.SetRunner(runner)
 
 	project := api.Project{Dir: "test"}
-	_, err := compLinter.LintProject(project)
+	_, err := compLinter.LintProject(context.Background(), project)
 	require.Error(t, err)
 	require.ErrorIs(t, err, lintErr)
 	require.True(t, strings.Contains(err.Error(), linter2.Name()))
 }
+
+func TestCompositeLinterLintProjectDisabledRules(t *testing.T) {
+	lintErr := errors.New("test error")
+	report1 := api.Report{Scores: map[api.Rule]float64{testRule1: 100}, Details: map[api.Rule]string{}}
+	linter1 := &testLinter{name: "linter1", rules: []*api.Rule{&testRule1, &testRule2}, report: report1}
+	linter2 := &testLinter{name: "linter2", rules: []*api.Rule{&testRule3, &testRule4}, lintErr: lintErr}
+
+	runner := mllint.NewMLLintRunner(nil)
+	runner.Start()
+	defer runner.Close()
+
+	compLinter := common.NewCompositeLinter(name, linter1, linter2)
+	compLinter.SetRunner(runner)
+
+	// linter2 is not run at all, as all of its rules are disabled for the project, while linter1 still has an enabled rule.
+	project := api.Project{Dir: "test", DisabledRules: map[string]bool{testRule2.Slug: true, testRule3.Slug: true, testRule4.Slug: true}}
+	report, err := compLinter.LintProject(context.Background(), project)
+	require.NoError(t, err)
+	require.Equal(t, report1.Scores, report.Scores)
+}
@@ -1,12 +1,15 @@
 package custom
 
 import (
+	"context"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/bvobart/mllint/api"
 	"github.com/bvobart/mllint/commands/mllint"
 	"github.com/bvobart/mllint/config"
+	"github.com/bvobart/mllint/utils/cache"
 	"github.com/bvobart/mllint/utils/exec"
 	"gopkg.in/yaml.v3"
 
@@ -23,6 +26,8 @@ func NewLinter() mllint.ConfigurableLinterWithRunner {
 type CustomLinter struct {
 	customRules map[config.CustomRule]*api.Rule
 	rules       []*api.Rule
+	timeouts    map[config.CustomRule]time.Duration
+	weights     map[string]int
 	runner      mllint.Runner
 }
 
@@ -33,11 +38,19 @@ func (l *CustomLinter) Name() string {
 func (l *CustomLinter) Configure(conf *config.Config) error {
 	l.rules = make([]*api.Rule, 0, len(conf.Rules.Custom))
 	l.customRules = make(map[config.CustomRule]*api.Rule, len(conf.Rules.Custom))
+	l.timeouts = make(map[config.CustomRule]time.Duration, len(conf.Rules.Custom))
+	l.weights = conf.Runner.Weights
 
 	for _, customRule := range conf.Rules.Custom {
+		timeout, err := mllint.ParseTimeout(customRule.Timeout)
+		if err != nil {
+			return fmt.Errorf("custom rule `%s` has an invalid timeout: %w", customRule.Slug, err)
+		}
+
 		rule := api.NewCustomRule(customRule)
 		l.rules = append(l.rules, &rule)
 		l.customRules[customRule] = &rule
+		l.timeouts[customRule] = timeout
 	}
 
 	return nil
@@ -51,14 +64,18 @@ func (l *CustomLinter) SetRunner(runner mllint.Runner) {
 	l.runner = runner
 }
 
-func (l *CustomLinter) LintProject(project api.Project) (api.Report, error) {
+func (l *CustomLinter) LintProject(ctx context.Context, project api.Project) (api.Report, error) {
 	report := api.NewReport()
 
 	// create linters from each of the rules and schedule each of them for execution on the mllint.Runner
 	tasks := []*mllint.RunnerTask{}
 	for customRule, rule := range l.customRules {
+		if project.RuleDisabled(rule) {
+			continue
+		}
+
 		customLinter := customRuleLinter{customRule, *rule}
-		task := l.runner.RunLinter(rule.Slug, &customLinter, project)
+		task := l.runner.RunLinter(ctx, rule.Slug, &customLinter, project, mllint.Timeout(l.timeouts[customRule]), mllint.Weight(l.weights[rule.Slug]))
 		tasks = append(tasks, task)
 	}
 
@@ -95,7 +112,7 @@ func (l *customRuleLinter) Name() string {
 func (l *customRuleLinter) Rules() []*api.Rule { return []*api.Rule{&l.rule} }
 
 // runs the custom rule definition's `run` command in the project's root directory and parses the result as YAML ()
-func (l *customRuleLinter) LintProject(project api.Project) (api.Report, error) {
+func (l *customRuleLinter) LintProject(ctx context.Context, project api.Project) (api.Report, error) {
 	report := api.NewReport()
 
 	cmdparts, err := shlex.Split(l.customRule.Run)
@@ -103,9 +120,17 @@ func (l *customRuleLinter) LintProject(project api.Project) (api.Report, error)
 		return report, fmt.Errorf("custom rule `%s` has invalid run command `%s`: %w", l.customRule.Slug, l.customRule.Run, err)
 	}
 
-	output, err := exec.CommandCombinedOutput(project.Dir, cmdparts[0], cmdparts[1:]...)
-	if err != nil {
-		return report, fmt.Errorf("custom rule `%s` was run, but exited with an error: %w.%s", l.customRule.Slug, err, formatOutput(output))
+	key, keyErr := l.cacheKey(project)
+	var output []byte
+	if keyErr != nil || cache.Default.Get(key, &output) != nil {
+		output, err = exec.CommandCombinedOutput(ctx, project.Dir, cmdparts[0], cmdparts[1:]...)
+		if err != nil {
+			return report, fmt.Errorf("custom rule `%s` was run, but exited with an error: %w.%s", l.customRule.Slug, err, formatOutput(output))
+		}
+		if keyErr == nil {
+			// failing to store the output only means that the rule will be run again next time.
+			_ = cache.Default.Put(key, output)
+		}
 	}
 
 	var result customRuleResult
@@ -118,6 +143,26 @@ func (l *customRuleLinter) LintProject(project api.Project) (api.Report, error)
 	return report, nil
 }
 
+// cacheKey creates the key under which the output of running this custom rule on the given project is cached.
+// The key includes the names and contents of all files that mllint analyses in the project, which is why custom rules
+// have to opt in to caching: their command may also read e.g. gitignored files, whose changes would then not be noticed.
+func (l *customRuleLinter) cacheKey(project api.Project) (*cache.Key, error) {
+	if cache.Default == nil || !l.customRule.Cache {
+		return nil, cache.ErrMiss
+	}
+
+	files, err := project.FileFilter().FindFiles(project.Dir, func(string) bool { return true })
+	if err != nil {
+		return nil, err
+	}
+
+	key := cache.NewKey("custom", l.customRule.Slug, l.customRule.Run, project.Dir)
+	if err := key.AddFiles(files.Prefix(project.Dir)...); err != nil {
+		return nil, err
+	}
+	return key, nil
+}
+
 // formats the output of an executed command such that it can be appended to an error.
 // Returns an empty string if the output is empty, returns a single line with inline code block if the trimmed output is a single line,
 // returns with a multiline code block if the trimmed output is multi-line.
@@ -1,6 +1,9 @@
 package custom_test
 
 import (
+	"context"
+	"os"
+	"path"
 	"testing"
 
 	"github.com/bvobart/mllint/api"
@@ -8,6 +11,7 @@ import (
 	"github.com/bvobart/mllint/config"
 	"github.com/bvobart/mllint/linters/custom"
 	"github.com/bvobart/mllint/linters/testutils"
+	"github.com/bvobart/mllint/utils/cache"
 	"github.com/hashicorp/go-multierror"
 	"github.com/stretchr/testify/require"
 )
@@ -98,6 +102,23 @@ func TestCustomLinter(t *testing.T) {
 				require.Contains(t, err.Error(), "custom rule `custom/error-rule-5` executed successfully, but the output was not a valid YAML or JSON object: yaml: did not find expected key. Output: `score 100, details: \"\" }`")
 			},
 		},
+		{
+			Name: "TimedOut",
+			Dir:  ".",
+			Options: testutils.NewOptions().WithConfig(func() *config.Config {
+				conf := config.Default()
+				conf.Rules.Custom = append(conf.Rules.Custom, createTimeoutRule())
+				return conf
+			}()),
+			Expect: func(t *testing.T, report api.Report, err error) {
+				rule := api.NewCustomRule(createTimeoutRule())
+
+				require.Equal(t, []*api.Rule{&rule}, linter.Rules())
+				require.ErrorIs(t, err, mllint.ErrTimedOut)
+				require.Contains(t, err.Error(), "Custom Rule - Timeout Rule timed out after 100ms")
+				require.NotContains(t, report.Scores, rule)
+			},
+		},
 	})
 
 	runner := mllint.NewMLLintRunner(nil)
@@ -128,6 +149,80 @@ func createCustomRule2() config.CustomRule {
 	}
 }
 
+func createTimeoutRule() config.CustomRule {
+	return config.CustomRule{
+		Name:    "Timeout Rule",
+		Slug:    "custom/timeout-rule",
+		Details: "This rule's command takes longer than its timeout, so it is killed, along with the `sleep` started by the shell",
+		Weight:  420,
+		Run:     `sh -c 'sleep 10; echo "score: 100"'`,
+		Timeout: "100ms",
+	}
+}
+
+func TestCustomLinterInvalidTimeout(t *testing.T) {
+	conf := config.Default()
+	rule := createCustomRule1()
+	rule.Timeout = "forever"
+	conf.Rules.Custom = append(conf.Rules.Custom, rule)
+
+	err := custom.NewLinter().Configure(conf)
+	require.ErrorIs(t, err, mllint.ErrInvalidTimeout)
+	require.EqualError(t, err, "custom rule `custom/rule-1` has an invalid timeout: invalid timeout: `forever`")
+}
+
+func TestCustomLinterCache(t *testing.T) {
+	defer func(original *cache.Cache) { cache.Default = original }(cache.Default)
+	cache.Default = cache.New(t.TempDir())
+
+	// a rule whose output differs every time it is run, so we can tell whether its output came from the cache.
+	customRule := config.CustomRule{
+		Name: "Nanoseconds Rule",
+		Slug: "custom/nanoseconds-rule",
+		Run:  `sh -c 'echo "{ score: 100, details: \"$(date +%N)\" }"'`,
+	}
+	uncachedRule := customRule
+	uncachedRule.Slug = "custom/uncached-nanoseconds-rule"
+	customRule.Cache = true
+
+	conf := config.Default()
+	conf.Rules.Custom = append(conf.Rules.Custom, customRule, uncachedRule)
+	rule := api.NewCustomRule(customRule)
+	uncached := api.NewCustomRule(uncachedRule)
+
+	runner := mllint.NewMLLintRunner(nil)
+	runner.Start()
+	defer runner.Close()
+
+	linter := custom.NewLinter()
+	linter.SetRunner(runner)
+	require.NoError(t, linter.Configure(conf))
+
+	dir := t.TempDir()
+	project := api.Project{Dir: dir}
+	uncachedDetails := []string{}
+	lint := func() string {
+		report, err := linter.LintProject(context.Background(), project)
+		require.NoError(t, err)
+		require.EqualValues(t, 100, report.Scores[rule])
+		uncachedDetails = append(uncachedDetails, report.Details[uncached])
+		return report.Details[rule]
+	}
+
+	first := lint()
+	require.Equal(t, first, lint())
+	// custom rules that do not opt in to caching are run every time.
+	require.NotEqual(t, uncachedDetails[0], uncachedDetails[1])
+
+	require.NoError(t, os.WriteFile(path.Join(dir, "main.py"), []byte("print('hello')\n"), 0644))
+	second := lint()
+	require.NotEqual(t, first, second)
+	require.Equal(t, second, lint())
+
+	cache.Default = nil
+	require.NotEqual(t, second, lint())
+}
+
 func createErrorRule1() config.CustomRule {
 	return config.CustomRule{
 		Name:    "Error Rule 1",
@@ -1,6 +1,7 @@
 package dependencymgmt
 
 import (
+	"context"
 	"fmt"
 	"math"
 	"strings"
@@ -28,7 +29,7 @@ func (l *DependenciesLinter) Rules() []*api.Rule {
 	return []*api.Rule{&RuleUse, &RuleSingle, &RuleUseDev}
 }
 
-func (l *DependenciesLinter) LintProject(project api.Project) (api.Report, error) {
+func (l *DependenciesLinter) LintProject(ctx context.Context, project api.Project) (api.Report, error) {
 	report := api.NewReport()
 	managers := project.DepManagers
 
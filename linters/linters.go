@@ -1,9 +1,13 @@
 package linters
 
 import (
+	"errors"
 	"fmt"
+	"sort"
 	"strings"
 
+	"github.com/hashicorp/go-multierror"
+
 	"github.com/bvobart/mllint/api"
 	"github.com/bvobart/mllint/categories"
 	"github.com/bvobart/mllint/config"
@@ -12,6 +16,7 @@ import (
 	"github.com/bvobart/mllint/linters/codequality"
 	"github.com/bvobart/mllint/linters/custom"
 	"github.com/bvobart/mllint/linters/dependencymgmt"
+	"github.com/bvobart/mllint/linters/notebooks"
 	"github.com/bvobart/mllint/linters/testing"
 	"github.com/bvobart/mllint/linters/versioncontrol"
 )
@@ -19,13 +24,21 @@ import (
 //---------------------------------------------------------------------------------------
 
 // ByCategory contains a linter for each implemented category.
-var ByCategory = map[api.Category]api.Linter{
-	categories.VersionControl:        versioncontrol.NewLinter(),
-	categories.DependencyMgmt:        dependencymgmt.NewLinter(),
-	categories.ContinuousIntegration: ci.NewLinter(),
-	categories.CodeQuality:           codequality.NewLinter(),
-	categories.Testing:               testing.NewLinter(),
-	categories.Custom:                custom.NewLinter(),
+var ByCategory = NewByCategory()
+
+// NewByCategory creates a new linter for each implemented category, e.g. to lint several projects that each have their own configuration.
+// Note that the linters' rules (other than custom rules) are shared with those in ByCategory, so disabling a rule disables it for all of them.
+// To disable rules for only one project, use api.Project.DisabledRules instead.
+func NewByCategory() map[api.Category]api.Linter {
+	return map[api.Category]api.Linter{
+		categories.VersionControl:        versioncontrol.NewLinter(),
+		categories.DependencyMgmt:        dependencymgmt.NewLinter(),
+		categories.ContinuousIntegration: ci.NewLinter(),
+		categories.CodeQuality:           codequality.NewLinter(),
+		categories.Notebooks:             notebooks.NewLinter(),
+		categories.Testing:               testing.NewLinter(),
+		categories.Custom:                custom.NewLinter(),
+	}
 }
 
 // Disabled contains all the linters for categories that have been disabled using Disable or DisableAll.
@@ -35,7 +48,12 @@ var Disabled = map[api.Category]api.Linter{}
 
 // Configures all the linters in linters.ByCategory with the given config.
 func ConfigureAll(conf *config.Config) error {
-	for cat, linter := range ByCategory {
+	return Configure(ByCategory, conf)
+}
+
+// Configure configures each of the given linters with the given config, then validates the config's rule overrides against their rules.
+func Configure(linters map[api.Category]api.Linter, conf *config.Config) error {
+	for cat, linter := range linters {
 		configurable, ok := linter.(api.Configurable)
 		if ok {
 			if err := configurable.Configure(conf); err != nil {
@@ -43,7 +61,100 @@ func ConfigureAll(conf *config.Config) error {
 			}
 		}
 	}
-	return nil
+	return ValidateOverrides(linters, conf.Rules.Overrides)
+}
+
+//---------------------------------------------------------------------------------------
+
+var ErrInvalidOverride = errors.New("invalid rule override")
+
+// ValidateOverrides checks that each of the given rule overrides refers to a rule of one of the given linters by its exact slug,
+// that it does not set a negative weight and that it only sets parameters that the rule has, within their valid ranges.
+// The linters should already be configured, such that the overrides of custom rules can also be validated.
+func ValidateOverrides(linters map[api.Category]api.Linter, overrides map[string]config.RuleOverride) error {
+	if len(overrides) == 0 {
+		return nil
+	}
+
+	rules := map[string]bool{}
+	params := map[string]map[string]api.RuleParam{}
+	for _, linter := range linters {
+		for _, rule := range linter.Rules() {
+			rules[rule.Slug] = true
+		}
+		for _, param := range api.RuleParamsOf(linter) {
+			if params[param.Slug] == nil {
+				params[param.Slug] = map[string]api.RuleParam{}
+			}
+			params[param.Slug][param.Name] = param
+		}
+	}
+
+	slugs := make([]string, 0, len(overrides))
+	for slug := range overrides {
+		slugs = append(slugs, slug)
+	}
+	sort.Strings(slugs)
+
+	var errs *multierror.Error
+	for _, slug := range slugs {
+		override := overrides[slug]
+		if !rules[slug] {
+			errs = multierror.Append(errs, fmt.Errorf("%w: no rule found with slug '%s'", ErrInvalidOverride, slug))
+			continue
+		}
+
+		if override.Weight != nil && *override.Weight < 0 {
+			errs = multierror.Append(errs, fmt.Errorf("%w: weight of rule '%s' must not be negative, but was %g", ErrInvalidOverride, slug, *override.Weight))
+		}
+
+		names := make([]string, 0, len(override.Params))
+		for name := range override.Params {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+
+		for _, name := range names {
+			param, ok := params[slug][name]
+			if !ok {
+				errs = multierror.Append(errs, fmt.Errorf("%w: rule '%s' has no parameter '%s'%s", ErrInvalidOverride, slug, name, describeParamNames(params[slug])))
+				continue
+			}
+			if err := param.Validate(override.Params[name]); err != nil {
+				errs = multierror.Append(errs, fmt.Errorf("%w: %s", ErrInvalidOverride, err))
+			}
+		}
+	}
+	return errs.ErrorOrNil()
+}
+
+func describeParamNames(params map[string]api.RuleParam) string {
+	if len(params) == 0 {
+		return ", it has no parameters"
+	}
+
+	names := make([]string, 0, len(params))
+	for name := range params {
+		names = append(names, "'"+name+"'")
+	}
+	sort.Strings(names)
+	return ", expected one of: " + strings.Join(names, ", ")
+}
+
+// FindRuleParams returns the parameters of the rule with the given slug, as checked by the linters in linters.ByCategory.
+func FindRuleParams(slug string) []api.RuleParam {
+	linter, ok := GetLinter(slug)
+	if !ok {
+		return []api.RuleParam{}
+	}
+
+	params := []api.RuleParam{}
+	for _, param := range api.RuleParamsOf(linter) {
+		if param.Slug == slug {
+			params = append(params, param)
+		}
+	}
+	return params
 }
 
 //---------------------------------------------------------------------------------------
@@ -95,19 +206,35 @@ func DisableCategory(cat api.Category) int {
 // DisableRule disables a rule on the linter by means of the rule's slug.
 // The slug used here should be the same as one of the `linter.Rules()[i].Slug`
 func DisableRule(linter api.Linter, slug string) int {
-	nDisabled := 0
-	for _, rule := range linter.Rules() {
+	rules := MatchRules(linter.Rules(), slug)
+	for _, rule := range rules {
+		rule.Disable()
+	}
+	return len(rules)
+}
+
+// MatchRules returns those of the given rules that are referenced by the given slug.
+// When the slug is exactly the slug of one of the rules, only that rule is returned, such that e.g. `version-control/code/git`
+// does not also match `version-control/code/git-no-big-files`. Otherwise, all rules whose slug starts with the given slug are returned.
+func MatchRules(rules []*api.Rule, slug string) []*api.Rule {
+	for _, rule := range rules {
+		if rule.Slug == slug {
+			return []*api.Rule{rule}
+		}
+	}
+
+	matched := []*api.Rule{}
+	for _, rule := range rules {
 		if strings.HasPrefix(rule.Slug, slug) {
-			rule.Disable()
-			nDisabled++
+			matched = append(matched, rule)
 		}
 	}
-	return nDisabled
+	return matched
 }
 
 //---------------------------------------------------------------------------------------
 
-// FindRules finds all rules that match (start with) the given slug.
+// FindRules finds all rules that match (start with) the given slug, see MatchRules.
 // E.g. `version-control/data` will return all the rules corresponding to data version control.
 func FindRules(slug string) []*api.Rule {
 	cat, ok := GetCategory(slug)
@@ -124,13 +251,7 @@ func FindRules(slug string) []*api.Rule {
 		return linter.Rules()
 	}
 
-	foundRules := []*api.Rule{}
-	for _, rule := range linter.Rules() {
-		if strings.HasPrefix(rule.Slug, slug) {
-			foundRules = append(foundRules, rule)
-		}
-	}
-	return foundRules
+	return MatchRules(linter.Rules(), slug)
 }
 
 //---------------------------------------------------------------------------------------
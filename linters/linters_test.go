@@ -1,6 +1,7 @@
 package linters_test
 
 import (
+	"context"
 	"errors"
 	"testing"
 
@@ -13,6 +14,7 @@ import (
 	"github.com/bvobart/mllint/config"
 	"github.com/bvobart/mllint/linters"
 	"github.com/bvobart/mllint/linters/common"
+	"github.com/bvobart/mllint/linters/versioncontrol"
 )
 
 func TestDisableIgnore(t *testing.T) {
@@ -160,6 +162,40 @@ func TestDisableRulePartialSlug(t *testing.T) {
 	require.True(t, mockRules[3].Disabled)
 }
 
+func TestDisableRuleExactSlug(t *testing.T) {
+	mockctl := gomock.NewController(t)
+	mockLinter := mock_api.NewMockLinter(mockctl)
+	mockRules := []*api.Rule{
+		{Slug: "cat/git"},
+		{Slug: "cat/git-no-big-files"},
+		{Slug: "cat/git-no-models"},
+	}
+	mockLinter.EXPECT().Rules().Times(1).Return(mockRules)
+
+	require.Equal(t, 1, linters.DisableRule(mockLinter, "cat/git"))
+
+	require.True(t, mockRules[0].Disabled)
+	require.False(t, mockRules[1].Disabled)
+	require.False(t, mockRules[2].Disabled)
+}
+
+func TestDisableRuleVersionControl(t *testing.T) {
+	linter := versioncontrol.NewLinter()
+	defer versioncontrol.RuleGit.Enable()
+	defer versioncontrol.RuleNoSecrets.Enable()
+
+	require.Equal(t, 1, linters.DisableRule(linter, "version-control/code/git"))
+	require.True(t, versioncontrol.RuleGit.Disabled)
+	require.False(t, versioncontrol.RuleGitNoBigFiles.Disabled)
+	require.False(t, versioncontrol.RuleGitNoModels.Disabled)
+	require.False(t, versioncontrol.RuleGitNoData.Disabled)
+	require.False(t, versioncontrol.RuleGitignore.Disabled)
+
+	require.Equal(t, 1, linters.DisableRule(linter, "version-control/code/no-secrets"))
+	require.True(t, versioncontrol.RuleNoSecrets.Disabled)
+	require.False(t, versioncontrol.RuleNoSecretsInHistory.Disabled)
+}
+
 func TestGetRule(t *testing.T) {
 	rules1 := []*api.Rule{
 		{Name: "Test Rule 1", Slug: "version-control/test-rule-1"},
@@ -252,3 +288,55 @@ func TestFindRules(t *testing.T) {
 		require.Equal(t, rules2[:2], rules)
 	})
 }
+
+func TestValidateOverrides(t *testing.T) {
+	rule1 := api.Rule{Slug: "test/rule-1"}
+	rule2 := api.Rule{Slug: "test/rule-2"}
+	param := api.RuleParam{Slug: rule1.Slug, Name: "penalty", Default: 25, Min: 0, Max: 100}
+	testLinters := map[api.Category]api.Linter{
+		{Name: "test"}: &paramLinter{rules: []*api.Rule{&rule1, &rule2}, params: []api.RuleParam{param}},
+	}
+
+	weight, negative := 2.0, -1.0
+	require.NoError(t, linters.ValidateOverrides(testLinters, nil))
+	require.NoError(t, linters.ValidateOverrides(testLinters, map[string]config.RuleOverride{
+		rule1.Slug: {Weight: &weight, Params: map[string]float64{"penalty": 50}},
+		rule2.Slug: {Weight: &weight},
+	}))
+
+	err := linters.ValidateOverrides(testLinters, map[string]config.RuleOverride{
+		"test/unknown": {Weight: &weight},
+		rule1.Slug:     {Weight: &negative, Params: map[string]float64{"penalty": 150, "other": 1}},
+		rule2.Slug:     {Params: map[string]float64{"penalty": 1}},
+	})
+	require.ErrorIs(t, err, linters.ErrInvalidOverride)
+	require.Contains(t, err.Error(), "no rule found with slug 'test/unknown'")
+	require.Contains(t, err.Error(), "weight of rule 'test/rule-1' must not be negative, but was -1")
+	require.Contains(t, err.Error(), "rule 'test/rule-1' has no parameter 'other', expected one of: 'penalty'")
+	require.Contains(t, err.Error(), "parameter 'penalty' of rule 'test/rule-1' must be between 0 and 100, but was 150")
+	require.Contains(t, err.Error(), "rule 'test/rule-2' has no parameter 'penalty', it has no parameters")
+}
+
+func TestValidateOverridesCompositeLinter(t *testing.T) {
+	rule := api.Rule{Slug: "test/rule"}
+	param := api.RuleParam{Slug: rule.Slug, Name: "penalty", Min: 0, Max: 100}
+	composite := common.NewCompositeLinter("composite", &paramLinter{rules: []*api.Rule{&rule}, params: []api.RuleParam{param}})
+	require.Equal(t, []api.RuleParam{param}, api.RuleParamsOf(composite))
+
+	testLinters := map[api.Category]api.Linter{{Name: "test"}: composite}
+	require.NoError(t, linters.ValidateOverrides(testLinters, map[string]config.RuleOverride{
+		rule.Slug: {Params: map[string]float64{"penalty": 10}},
+	}))
+}
+
+type paramLinter struct {
+	rules  []*api.Rule
+	params []api.RuleParam
+}
+
+func (l *paramLinter) Name() string                { return "param-linter" }
+func (l *paramLinter) Rules() []*api.Rule          { return l.rules }
+func (l *paramLinter) RuleParams() []api.RuleParam { return l.params }
+func (l *paramLinter) LintProject(ctx context.Context, project api.Project) (api.Report, error) {
+	return api.NewReport(), nil
+}
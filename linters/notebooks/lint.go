@@ -0,0 +1,163 @@
+package notebooks
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"math"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/hashicorp/go-multierror"
+
+	"github.com/bvobart/mllint/api"
+	"github.com/bvobart/mllint/setools/cqlinters"
+	"github.com/bvobart/mllint/setools/jupyter"
+	"github.com/bvobart/mllint/utils"
+	"github.com/bvobart/mllint/utils/markdowngen"
+)
+
+// Maximum number of lines of code per linter issue reported, see also the Pylint linter in the Code Quality category.
+var ParamMaxLoCperIssue = api.RuleParam{
+	Slug:        RuleCodeQuality.Slug,
+	Name:        "maxLoCperIssue",
+	Description: "The rule scores 0% when the code quality linters report 1 issue per this many lines of code in notebooks. Increase it to tolerate fewer issues.",
+	Default:     10,
+	Min:         1,
+	Max:         1000,
+}
+
+// extractedNotebook is a notebook whose code cells have been extracted into a Python script at Script.
+type extractedNotebook struct {
+	Filename string
+	Script   string
+	Code     jupyter.ExtractedCode
+}
+
+// ScoreRuleCodeQuality extracts the code cells of all notebooks into a temporary directory, runs the configured
+// and installed code quality linters on the resulting scripts and maps the issues back to the notebooks' cells.
+func (l *NotebooksLinter) ScoreRuleCodeQuality(ctx context.Context, report *api.Report, project api.Project) error {
+	loc := 0
+	for _, nb := range l.notebooks {
+		loc += nb.Notebook.CountLoC()
+	}
+	if loc == 0 {
+		report.Scores[RuleCodeQuality] = 100
+		report.Details[RuleCodeQuality] = "No code was found in the project's notebooks."
+		return nil
+	}
+
+	dir, err := ioutil.TempDir(os.TempDir(), "mllint-notebooks-")
+	if err != nil {
+		return fmt.Errorf("failed to create temporary directory for extracting notebooks: %w", err)
+	}
+	defer os.RemoveAll(dir)
+
+	extracted, err := l.extractNotebooks(dir)
+	if err != nil {
+		return err
+	}
+
+	scripts := make(utils.Filenames, len(extracted))
+	for i, nb := range extracted {
+		scripts[i] = nb.Script
+	}
+	nbProject := project
+	nbProject.Dir = dir
+	nbProject.PythonFiles = scripts
+	nbProject.Notebooks = nil
+
+	var multiErr *multierror.Error
+	issues := []interface{}{}
+	linted := []interface{}{}
+	for _, linter := range l.CQLinters {
+		if !linter.IsInstalled() {
+			continue
+		}
+
+		results, err := linter.Run(ctx, nbProject)
+		if err != nil {
+			multiErr = multierror.Append(multiErr, fmt.Errorf("%s failed to run on notebooks: %w", linter, err))
+			continue
+		}
+
+		linted = append(linted, linter)
+		for _, result := range results {
+			issues = append(issues, mapResult(dir, extracted, linter, result))
+		}
+	}
+
+	if len(linted) == 0 {
+		report.Scores[RuleCodeQuality] = 0
+		report.Details[RuleCodeQuality] = "None of the configured code quality linters are installed, so we could not analyse the code in your notebooks."
+		return multiErr.ErrorOrNil()
+	}
+
+	report.Scores[RuleCodeQuality] = 100 - 100*math.Min(1, float64(len(issues))*ParamMaxLoCperIssue.Value(project)/float64(loc))
+	if len(issues) == 0 {
+		report.Details[RuleCodeQuality] = "Congratulations, the following linters are happy with the code in your notebooks:\n\n" + markdowngen.List(linted)
+	} else {
+		report.Details[RuleCodeQuality] = fmt.Sprintf("The code quality linters reported **%d** issues with the code in your notebooks:\n\n", len(issues)) + markdowngen.List(issues)
+	}
+	return multiErr.ErrorOrNil()
+}
+
+// writes the code of each notebook to a Python script in the given directory, mirroring the notebooks' paths within the project.
+func (l *NotebooksLinter) extractNotebooks(dir string) ([]extractedNotebook, error) {
+	extracted := make([]extractedNotebook, 0, len(l.notebooks))
+	for _, nb := range l.notebooks {
+		script := filepath.Join(dir, strings.TrimSuffix(nb.Filename, ".ipynb")+".py")
+		if err := os.MkdirAll(filepath.Dir(script), 0755); err != nil {
+			return nil, fmt.Errorf("failed to extract code from notebook '%s': %w", nb.Filename, err)
+		}
+
+		code := nb.Notebook.ExtractCode()
+		if err := ioutil.WriteFile(script, []byte(code.Code), 0644); err != nil {
+			return nil, fmt.Errorf("failed to extract code from notebook '%s': %w", nb.Filename, err)
+		}
+
+		extracted = append(extracted, extractedNotebook{nb.Filename, script, code})
+	}
+	return extracted, nil
+}
+
+// mapResult formats a linter's result such that it refers to the notebook (and cell) it originates from, instead of the extracted script.
+func mapResult(dir string, extracted []extractedNotebook, linter api.CQLinter, result api.CQLinterResult) string {
+	var filename, message string
+	var line int
+	switch msg := result.(type) {
+	case cqlinters.PylintMessage:
+		filename, line, message = msg.Path, int(msg.Line), fmt.Sprintf("_(%s)_ %s", msg.MessageID, msg.Message)
+	case cqlinters.MypyMessage:
+		filename, line, message = msg.Filename, msg.Line, strings.Title(msg.Severity)+": "+msg.Message
+	case cqlinters.BanditMessage:
+		filename, line, message = msg.Filename, int(msg.Line), fmt.Sprintf("_(%s, severity: %s, confidence: %s)_ %s", msg.TestID, msg.Severity, msg.Confidence, msg.Text)
+	case cqlinters.ISortProblem:
+		filename, message = msg.Path, msg.Message
+	default:
+		// we don't know where the result refers to, so just replace the scripts' paths with the notebooks' paths.
+		message = result.String()
+		for _, nb := range extracted {
+			relpath, _ := filepath.Rel(dir, nb.Script)
+			message = strings.ReplaceAll(message, nb.Script, nb.Filename)
+			message = strings.ReplaceAll(message, relpath, nb.Filename)
+		}
+		return fmt.Sprintf("%s: %s", linter, message)
+	}
+
+	if !filepath.IsAbs(filename) {
+		filename = filepath.Join(dir, filename)
+	}
+	for _, nb := range extracted {
+		if filepath.Clean(filename) != nb.Script {
+			continue
+		}
+
+		if location, ok := nb.Code.Locate(line); ok {
+			return fmt.Sprintf("`%s` (%s) - %s: %s", nb.Filename, location, linter, message)
+		}
+		return fmt.Sprintf("`%s` - %s: %s", nb.Filename, linter, message)
+	}
+	return fmt.Sprintf("%s: %s", linter, result.String())
+}
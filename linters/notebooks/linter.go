@@ -0,0 +1,253 @@
+package notebooks
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"os"
+	"regexp"
+	"strings"
+
+	"github.com/dustin/go-humanize"
+	"github.com/hashicorp/go-multierror"
+
+	"github.com/bvobart/mllint/api"
+	"github.com/bvobart/mllint/categories"
+	"github.com/bvobart/mllint/config"
+	"github.com/bvobart/mllint/setools/cqlinters"
+	"github.com/bvobart/mllint/setools/jupyter"
+	"github.com/bvobart/mllint/utils"
+	"github.com/bvobart/mllint/utils/markdowngen"
+)
+
+var ErrInvalidMaxCodeRatio = errors.New("notebooks.maxCodeRatio must be a number between 0 and 1")
+
+func NewLinter() api.ConfigurableLinter {
+	return &NotebooksLinter{}
+}
+
+type NotebooksLinter struct {
+	Config config.NotebooksConfig
+	// Code quality linters to run on the notebooks' code cells when `notebooks.lint` is enabled.
+	CQLinters []api.CQLinter
+
+	// the project's notebooks that could be parsed.
+	notebooks []notebookFile
+}
+
+// notebookFile is a parsed notebook along with its filename, relative to the project's root.
+type notebookFile struct {
+	Filename string
+	Notebook *jupyter.Notebook
+	Size     int64
+}
+
+func (l *NotebooksLinter) Name() string {
+	return categories.Notebooks.Name
+}
+
+func (l *NotebooksLinter) Configure(conf *config.Config) (err error) {
+	l.Config = conf.Notebooks
+	if l.Config.MaxCodeRatio < 0 || l.Config.MaxCodeRatio > 1 {
+		return fmt.Errorf("%w, but was %.2f", ErrInvalidMaxCodeRatio, l.Config.MaxCodeRatio)
+	}
+
+	l.CQLinters, err = cqlinters.FromConfig(conf.CodeQuality)
+	return err
+}
+
+func (l *NotebooksLinter) RuleParams() []api.RuleParam {
+	return []api.RuleParam{ParamMaxLoCperIssue}
+}
+
+func (l *NotebooksLinter) Rules() []*api.Rule {
+	return []*api.Rule{&RuleNoOutputs, &RuleNoImages, &RuleExecutionOrder, &RuleFileSize, &RuleNoAbsolutePaths, &RuleCodeInModules, &RuleCodeQuality}
+}
+
+func (l *NotebooksLinter) LintProject(ctx context.Context, project api.Project) (api.Report, error) {
+	report := api.NewReport()
+
+	var multiErr *multierror.Error
+	l.notebooks = []notebookFile{}
+	for _, filename := range project.Notebooks {
+		notebook, err := jupyter.ParseFile(filename)
+		if err != nil {
+			multiErr = multierror.Append(multiErr, err)
+			continue
+		}
+
+		info, err := os.Stat(filename)
+		if err != nil {
+			multiErr = multierror.Append(multiErr, err)
+			continue
+		}
+
+		l.notebooks = append(l.notebooks, notebookFile{utils.RelativePath(project.Dir, filename), notebook, info.Size()})
+	}
+
+	l.ScoreRuleNoOutputs(&report)
+	l.ScoreRuleNoImages(&report)
+	l.ScoreRuleExecutionOrder(&report)
+	l.ScoreRuleFileSize(&report)
+	l.ScoreRuleNoAbsolutePaths(&report)
+	l.ScoreRuleCodeInModules(&report, project)
+
+	if l.Config.Lint && !project.RuleDisabled(&RuleCodeQuality) {
+		if err := l.ScoreRuleCodeQuality(ctx, &report, project); err != nil {
+			multiErr = multierror.Append(multiErr, err)
+		}
+	}
+
+	return report, multiErr.ErrorOrNil()
+}
+
+//---------------------------------------------------------------------------------------
+
+func (l *NotebooksLinter) ScoreRuleNoOutputs(report *api.Report) {
+	rows := []string{}
+	for _, nb := range l.notebooks {
+		if n := nb.Notebook.CountOutputs(); n > 0 {
+			rows = append(rows, fmt.Sprintf("`%s` | %d", nb.Filename, n))
+		}
+	}
+
+	report.Scores[RuleNoOutputs] = l.percentageWithout(len(rows))
+	if len(rows) > 0 {
+		report.Details[RuleNoOutputs] = fmt.Sprintf("The following %d notebooks contain cell outputs:\n\nNotebook | Outputs\n---------|--------:\n%s\n\n", len(rows), strings.Join(rows, "\n")) +
+			"Clear them using `jupyter nbconvert --clear-output --inplace` and consider using `nbstripout` to strip them automatically when committing."
+	}
+}
+
+func (l *NotebooksLinter) ScoreRuleNoImages(report *api.Report) {
+	rows := []string{}
+	for _, nb := range l.notebooks {
+		if n := nb.Notebook.CountImages(); n > 0 {
+			rows = append(rows, fmt.Sprintf("`%s` | %d", nb.Filename, n))
+		}
+	}
+
+	report.Scores[RuleNoImages] = l.percentageWithout(len(rows))
+	if len(rows) > 0 {
+		report.Details[RuleNoImages] = fmt.Sprintf("The following %d notebooks contain embedded images:\n\nNotebook | Images\n---------|-------:\n%s", len(rows), strings.Join(rows, "\n"))
+	}
+}
+
+func (l *NotebooksLinter) ScoreRuleExecutionOrder(report *api.Report) {
+	rows := []string{}
+	for _, nb := range l.notebooks {
+		if !nb.Notebook.IsExecutedInOrder() {
+			rows = append(rows, fmt.Sprintf("`%s` | %s", nb.Filename, formatExecutionCounts(nb.Notebook.ExecutionCounts())))
+		}
+	}
+
+	report.Scores[RuleExecutionOrder] = l.percentageWithout(len(rows))
+	if len(rows) > 0 {
+		report.Details[RuleExecutionOrder] = fmt.Sprintf("The cells of the following %d notebooks were not executed in order:\n\nNotebook | Execution counts\n---------|-----------------\n%s\n\n", len(rows), strings.Join(rows, "\n")) +
+			"Use **Kernel > Restart & Run All** to check that these notebooks run from top to bottom."
+	}
+}
+
+func formatExecutionCounts(counts []int) string {
+	strs := make([]string, len(counts))
+	for i, count := range counts {
+		strs[i] = fmt.Sprint(count)
+	}
+	return strings.Join(strs, ", ")
+}
+
+func (l *NotebooksLinter) ScoreRuleFileSize(report *api.Report) {
+	rows := []string{}
+	for _, nb := range l.notebooks {
+		if uint64(nb.Size) > l.Config.MaxFileSize {
+			rows = append(rows, fmt.Sprintf("`%s` | %s", nb.Filename, humanize.Bytes(uint64(nb.Size))))
+		}
+	}
+
+	report.Scores[RuleFileSize] = l.percentageWithout(len(rows))
+	if len(rows) > 0 {
+		report.Details[RuleFileSize] = fmt.Sprintf("The following %d notebooks are larger than the maximum size of **%s**:\n\nNotebook | Size\n---------|-----:\n%s", len(rows), humanize.Bytes(l.Config.MaxFileSize), strings.Join(rows, "\n"))
+	}
+}
+
+// matches string literals containing absolute paths to common user, data or mount locations, Windows drive paths, or paths in the user's home folder.
+var regexAbsolutePath = regexp.MustCompile(`["']((?:/(?:home|Users|root|mnt|media|data|content|kaggle|opt|srv|tmp|var)/|[A-Za-z]:[\\/]|~/)[^"'\n]*)["']`)
+
+func (l *NotebooksLinter) ScoreRuleNoAbsolutePaths(report *api.Report) {
+	nbWithPaths := 0
+	found := []interface{}{}
+	for _, nb := range l.notebooks {
+		paths := findAbsolutePaths(nb.Notebook)
+		for _, p := range paths {
+			found = append(found, fmt.Sprintf("`%s` (%s): `%s`", nb.Filename, p.Location, p.Path))
+		}
+		if len(paths) > 0 {
+			nbWithPaths++
+		}
+	}
+
+	report.Scores[RuleNoAbsolutePaths] = l.percentageWithout(nbWithPaths)
+	if len(found) > 0 {
+		report.Details[RuleNoAbsolutePaths] = fmt.Sprintf("Found %d hard-coded absolute paths in %d notebooks:\n\n", len(found), nbWithPaths) + markdowngen.List(found)
+	}
+}
+
+type absolutePath struct {
+	Location jupyter.CellLine
+	Path     string
+}
+
+func findAbsolutePaths(notebook *jupyter.Notebook) []absolutePath {
+	paths := []absolutePath{}
+	for i, cell := range notebook.Cells {
+		if cell.CellType != jupyter.CellTypeCode {
+			continue
+		}
+
+		for j, line := range cell.Source.Lines() {
+			if strings.HasPrefix(strings.TrimSpace(line), "#") {
+				continue
+			}
+			for _, match := range regexAbsolutePath.FindAllStringSubmatch(line, -1) {
+				paths = append(paths, absolutePath{jupyter.CellLine{Cell: i + 1, Line: j + 1}, match[1]})
+			}
+		}
+	}
+	return paths
+}
+
+func (l *NotebooksLinter) ScoreRuleCodeInModules(report *api.Report, project api.Project) {
+	notebookLoC := 0
+	for _, nb := range l.notebooks {
+		notebookLoC += nb.Notebook.CountLoC()
+	}
+	moduleLoC := int(project.AllPythonFiles().CountLoC())
+
+	if notebookLoC == 0 {
+		report.Scores[RuleCodeInModules] = 100
+		return
+	}
+
+	ratio := float64(notebookLoC) / float64(notebookLoC+moduleLoC)
+	score := 100.0
+	if ratio > l.Config.MaxCodeRatio {
+		score = 100 * (1 - ratio) / (1 - l.Config.MaxCodeRatio)
+	}
+	report.Scores[RuleCodeInModules] = math.Max(0, score)
+
+	details := fmt.Sprintf("Your project has **%d** lines of code in notebooks and **%d** lines of code in Python modules, so **%.1f%%** of your project's code is in notebooks", notebookLoC, moduleLoC, 100*ratio)
+	if ratio > l.Config.MaxCodeRatio {
+		details += fmt.Sprintf(", while at most **%.1f%%** should be. Consider moving code from your notebooks into Python modules and importing it in your notebooks instead.", 100*l.Config.MaxCodeRatio)
+	} else {
+		details += "."
+	}
+	report.Details[RuleCodeInModules] = details
+}
+
+// returns the percentage of notebooks that are not among the given number of offending notebooks, or 100 if there are no notebooks.
+func (l *NotebooksLinter) percentageWithout(offending int) float64 {
+	if len(l.notebooks) == 0 {
+		return 100
+	}
+	return 100 * float64(len(l.notebooks)-offending) / float64(len(l.notebooks))
+}
@@ -0,0 +1,186 @@
+package notebooks_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/bvobart/mllint/api"
+	"github.com/bvobart/mllint/categories"
+	"github.com/bvobart/mllint/config"
+	"github.com/bvobart/mllint/linters/notebooks"
+	"github.com/bvobart/mllint/linters/testutils"
+	"github.com/bvobart/mllint/utils/exec"
+)
+
+func TestName(t *testing.T) {
+	require.Equal(t, categories.Notebooks.Name, notebooks.NewLinter().Name())
+}
+
+func TestRules(t *testing.T) {
+	linter := notebooks.NewLinter()
+	require.Equal(t, []*api.Rule{
+		&notebooks.RuleNoOutputs, &notebooks.RuleNoImages, &notebooks.RuleExecutionOrder, &notebooks.RuleFileSize,
+		&notebooks.RuleNoAbsolutePaths, &notebooks.RuleCodeInModules, &notebooks.RuleCodeQuality,
+	}, linter.Rules())
+}
+
+func TestConfigure(t *testing.T) {
+	linter := notebooks.NewLinter()
+	conf := config.Default()
+	require.NoError(t, linter.Configure(conf))
+
+	conf.Notebooks.MaxCodeRatio = 1.5
+	require.ErrorIs(t, linter.Configure(conf), notebooks.ErrInvalidMaxCodeRatio)
+	conf.Notebooks.MaxCodeRatio = -0.5
+	require.ErrorIs(t, linter.Configure(conf), notebooks.ErrInvalidMaxCodeRatio)
+}
+
+func TestNotebooksLinter(t *testing.T) {
+	linter := notebooks.NewLinter()
+	suite := testutils.NewLinterTestSuite(linter, []testutils.LinterTest{
+		{
+			Name:    "NoNotebooks",
+			Dir:     ".",
+			Options: testutils.NewOptions().WithConfig(config.Default()),
+			Expect: func(t *testing.T, report api.Report, err error) {
+				require.NoError(t, err)
+				for _, rule := range linter.Rules() {
+					if rule != &notebooks.RuleCodeQuality {
+						require.EqualValues(t, 100, report.Scores[*rule], rule.Slug)
+					}
+				}
+				require.NotContains(t, report.Scores, notebooks.RuleCodeQuality)
+			},
+		},
+		{
+			Name:    "CleanNotebooks",
+			Dir:     "test-resources/clean",
+			Options: testutils.NewOptions().DetectPythonFiles().DetectNotebooks().WithConfig(config.Default()),
+			Expect: func(t *testing.T, report api.Report, err error) {
+				require.NoError(t, err)
+				require.EqualValues(t, 100, report.Scores[notebooks.RuleNoOutputs])
+				require.EqualValues(t, 100, report.Scores[notebooks.RuleNoImages])
+				require.EqualValues(t, 100, report.Scores[notebooks.RuleExecutionOrder])
+				require.EqualValues(t, 100, report.Scores[notebooks.RuleFileSize])
+				require.EqualValues(t, 100, report.Scores[notebooks.RuleNoAbsolutePaths])
+				require.EqualValues(t, 100, report.Scores[notebooks.RuleCodeInModules])
+				require.Contains(t, report.Details[notebooks.RuleCodeInModules], "**3** lines of code in notebooks and **6** lines of code in Python modules")
+			},
+		},
+		{
+			Name:    "DirtyNotebooks",
+			Dir:     "test-resources/dirty",
+			Options: testutils.NewOptions().DetectPythonFiles().DetectNotebooks().WithConfig(config.Default()),
+			Expect: func(t *testing.T, report api.Report, err error) {
+				require.NoError(t, err)
+				require.EqualValues(t, 50, report.Scores[notebooks.RuleNoOutputs])
+				require.Contains(t, report.Details[notebooks.RuleNoOutputs], "`analysis.ipynb` | 2")
+				require.EqualValues(t, 50, report.Scores[notebooks.RuleNoImages])
+				require.Contains(t, report.Details[notebooks.RuleNoImages], "`analysis.ipynb` | 2")
+				require.EqualValues(t, 50, report.Scores[notebooks.RuleExecutionOrder])
+				require.Contains(t, report.Details[notebooks.RuleExecutionOrder], "`analysis.ipynb` | 3, 1")
+				require.EqualValues(t, 100, report.Scores[notebooks.RuleFileSize])
+				require.EqualValues(t, 50, report.Scores[notebooks.RuleNoAbsolutePaths])
+				require.Contains(t, report.Details[notebooks.RuleNoAbsolutePaths], "`analysis.ipynb` (cell 2, line 5): `/home/alice/data/train.csv`")
+				require.InDelta(t, 44.44, report.Scores[notebooks.RuleCodeInModules], 0.01)
+				require.Contains(t, report.Details[notebooks.RuleCodeInModules], "**7** lines of code in notebooks and **2** lines of code in Python modules")
+				require.Contains(t, report.Details[notebooks.RuleCodeInModules], "at most **50.0%** should be")
+			},
+		},
+		{
+			Name: "FileSizeLimit",
+			Dir:  "test-resources/dirty",
+			Options: testutils.NewOptions().DetectPythonFiles().DetectNotebooks().WithConfig(func() *config.Config {
+				c := config.Default()
+				c.Notebooks.MaxFileSize = 500
+				c.Notebooks.MaxCodeRatio = 1
+				return c
+			}()),
+			Expect: func(t *testing.T, report api.Report, err error) {
+				require.NoError(t, err)
+				require.EqualValues(t, 50, report.Scores[notebooks.RuleFileSize])
+				require.Contains(t, report.Details[notebooks.RuleFileSize], "maximum size of **500 B**")
+				require.Contains(t, report.Details[notebooks.RuleFileSize], "`analysis.ipynb` | 1.2 kB")
+				require.EqualValues(t, 100, report.Scores[notebooks.RuleCodeInModules])
+			},
+		},
+		{
+			Name:    "InvalidNotebook",
+			Dir:     "test-resources/invalid",
+			Options: testutils.NewOptions().DetectNotebooks().WithConfig(config.Default()),
+			Expect: func(t *testing.T, report api.Report, err error) {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), "broken.ipynb")
+				require.EqualValues(t, 100, report.Scores[notebooks.RuleNoOutputs])
+				require.EqualValues(t, 0, report.Scores[notebooks.RuleCodeInModules])
+			},
+		},
+	})
+	suite.RunAll(t)
+}
+
+func TestNotebooksLinterCodeQuality(t *testing.T) {
+	defer func() {
+		exec.LookPath = exec.DefaultLookPath
+		exec.CommandCombinedOutput = exec.DefaultCommandCombinedOutput
+	}()
+
+	conf := config.Default()
+	conf.Notebooks.Lint = true
+	conf.CodeQuality.Linters = []string{"pylint"}
+	linter := notebooks.NewLinter()
+	require.NoError(t, linter.Configure(conf))
+
+	project := api.Project{
+		Dir:       "test-resources/dirty",
+		Notebooks: []string{"test-resources/dirty/analysis.ipynb", "test-resources/dirty/clean.ipynb"},
+	}
+
+	t.Run("NotInstalled", func(t *testing.T) {
+		exec.LookPath = func(file string) (string, error) { return "", errors.New("not found") }
+
+		report, err := linter.LintProject(context.Background(), project)
+		require.NoError(t, err)
+		require.EqualValues(t, 0, report.Scores[notebooks.RuleCodeQuality])
+		require.Contains(t, report.Details[notebooks.RuleCodeQuality], "None of the configured code quality linters are installed")
+	})
+
+	t.Run("PylintIssuesMappedToCells", func(t *testing.T) {
+		exec.LookPath = func(file string) (string, error) { return file, nil }
+		exec.CommandCombinedOutput = func(_ context.Context, dir string, name string, args ...string) ([]byte, error) {
+			require.Equal(t, "pylint", name)
+			require.Len(t, args, 4) // -f json + the scripts extracted from both notebooks
+			script, err := filepath.Rel(dir, args[2])
+			require.NoError(t, err)
+			require.Equal(t, "analysis.py", script)
+			return []byte(fmt.Sprintf(`[
+				{"type": "convention", "message": "Missing module docstring", "message-id": "C0114", "path": "%s", "line": 1, "column": 0},
+				{"type": "warning", "message": "Unused variable 'df'", "message-id": "W0612", "path": "%s", "line": 6, "column": 0}
+			]`, script, args[2])), errors.New("exit status 20")
+		}
+
+		report, err := linter.LintProject(context.Background(), project)
+		require.NoError(t, err)
+		require.EqualValues(t, 0, report.Scores[notebooks.RuleCodeQuality]) // 2 issues in 7 lines of code
+		require.Contains(t, report.Details[notebooks.RuleCodeQuality], "reported **2** issues")
+		require.Contains(t, report.Details[notebooks.RuleCodeQuality], "`analysis.ipynb` - Pylint: _(C0114)_ Missing module docstring")
+		require.Contains(t, report.Details[notebooks.RuleCodeQuality], "`analysis.ipynb` (cell 2, line 5) - Pylint: _(W0612)_ Unused variable 'df'")
+	})
+
+	t.Run("NoIssues", func(t *testing.T) {
+		exec.LookPath = func(file string) (string, error) { return file, nil }
+		exec.CommandCombinedOutput = func(_ context.Context, dir string, name string, args ...string) ([]byte, error) {
+			return []byte("[]"), nil
+		}
+
+		report, err := linter.LintProject(context.Background(), project)
+		require.NoError(t, err)
+		require.EqualValues(t, 100, report.Scores[notebooks.RuleCodeQuality])
+		require.Contains(t, report.Details[notebooks.RuleCodeQuality], "Congratulations")
+	})
+}
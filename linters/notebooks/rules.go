@@ -0,0 +1,139 @@
+package notebooks
+
+import "github.com/bvobart/mllint/api"
+
+var RuleNoOutputs = api.Rule{
+	Name: "Notebooks are committed without cell outputs",
+	Slug: "notebooks/no-outputs",
+	Details: `Every time you run a cell in a Jupyter Notebook, its outputs (printed text, tables, plots, error traces) are stored in the notebook file.
+When such a notebook is committed to Git, these outputs end up in your repository's history, which has several downsides:
+- Every re-run of the notebook changes the notebook file, even if you did not change any code, making it hard to see what actually changed.
+- Outputs may contain (samples of) your data, which you might not want to be part of your repository.
+- Outputs bloat your repository, especially when they contain plots, see also rule ` + "`notebooks/no-embedded-images`" + `.
+
+This rule therefore checks whether the notebooks in your project have been committed without any cell outputs.
+The score is the percentage of notebooks that do not contain any outputs.
+
+To clear the outputs of a notebook, use **Cell > All Output > Clear** in Jupyter, or run:
+` + "```sh" + `
+jupyter nbconvert --clear-output --inplace path/to/notebook.ipynb
+` + "```" + `
+
+To make sure that you never accidentally commit outputs again, we recommend using [nbstripout](https://github.com/kynan/nbstripout),
+which can be installed as a Git filter using ` + "`nbstripout --install`" + `, or as a [pre-commit](https://pre-commit.com/) hook.`,
+	Weight: 1,
+}
+
+var RuleNoImages = api.Rule{
+	Name: "Notebooks do not contain embedded images",
+	Slug: "notebooks/no-embedded-images",
+	Details: `Plots that are displayed in a Jupyter Notebook, as well as images that are pasted into its markdown cells,
+are stored inside the notebook file as base64-encoded data. Such embedded images quickly make notebook files very large,
+and since Git stores every version of every file, they keep bloating your repository long after they have been removed.
+
+This rule checks whether the notebooks in your project contain any embedded images, either in the outputs of code cells or as attachments of markdown cells.
+The score is the percentage of notebooks that do not contain any embedded images.
+
+Clear the outputs of your notebooks before committing them (see rule ` + "`notebooks/no-outputs`" + `)
+and store any images that you want to include in your project's documentation as separate files, or generate them from your code instead.`,
+	Weight: 1,
+}
+
+var RuleExecutionOrder = api.Rule{
+	Name: "Notebooks are executed in order",
+	Slug: "notebooks/execution-order",
+	Details: `Jupyter Notebooks allow you to execute cells in any order you like, as many times as you like.
+While this is great for experimenting, it also means that a notebook's results may depend on an order of execution that cannot be seen from the notebook itself,
+making these results hard to reproduce. The execution counts next to each cell (` + "`In [3]:`" + `) reveal in which order the cells were last executed.
+
+This rule checks whether the execution counts of the code cells in each of your project's notebooks are strictly increasing from top to bottom,
+i.e., whether the notebook was last executed in order. Notebooks without any execution counts (e.g. because their outputs were cleared) pass this rule.
+The score is the percentage of notebooks that were executed in order.
+
+Before committing a notebook, use **Kernel > Restart & Run All** to verify that it runs from top to bottom, then clear its outputs.`,
+	Weight: 1,
+}
+
+var RuleFileSize = api.Rule{
+	Name: "Notebooks are not too large",
+	Slug: "notebooks/file-size",
+	Details: `Large notebook files are slow to load, hard to review and bloat your Git repository.
+Notebooks usually become large because they contain outputs and embedded images (see rules ` + "`notebooks/no-outputs` and `notebooks/no-embedded-images`" + `),
+or because they simply contain too much code, which is better placed in Python modules (see rule ` + "`notebooks/code-in-modules`" + `).
+
+This rule checks whether each of your project's notebooks is smaller than the configured maximum file size, which is 1 MB by default.
+The score is the percentage of notebooks that are within this limit. You can configure the maximum size (in bytes) as follows:
+
+` + "```yaml" + `
+notebooks:
+  maxFileSize: 1000000 # 1 MB
+` + "```" + `
+
+or equivalent TOML:
+` + "```toml" + `
+[tool.mllint.notebooks]
+maxFileSize = 1000000 # 1 MB
+` + "```",
+	Weight: 1,
+}
+
+var RuleNoAbsolutePaths = api.Rule{
+	Name: "Notebooks do not use hard-coded absolute paths",
+	Slug: "notebooks/no-absolute-paths",
+	Details: `Notebooks often load data or save models using file paths such as ` + "`/home/alice/project/data/train.csv` or `C:\\Users\\bob\\data.csv`" + `.
+Such hard-coded absolute paths only exist on the machine of whoever wrote the notebook, meaning that nobody else can run the notebook without first editing it.
+
+This rule checks whether the code cells of your project's notebooks contain any string literals with absolute paths to common user, data or mount locations,
+as well as paths starting with ` + "`~/`" + `. The score is the percentage of notebooks that do not contain any such paths.
+
+Instead, use paths relative to your project's root, e.g. ` + "`data/train.csv`" + `, or make the paths configurable, e.g. using environment variables or a configuration file.`,
+	Weight: 1,
+}
+
+var RuleCodeInModules = api.Rule{
+	Name: "Most of the project's code is in Python modules, not in notebooks",
+	Slug: "notebooks/code-in-modules",
+	Details: `Notebooks are great for exploration and for presenting results, but code in notebooks is hard to reuse, test, lint and review.
+As an ML project matures, its code should move from notebooks into Python modules, which the notebooks can then import.
+
+This rule compares the lines of code in the code cells of your project's notebooks with the lines of code in your project's Python files.
+By default, at most half of your project's code may be in notebooks. When more of the code is in notebooks, the score decreases linearly,
+down to 0% when all of the project's code is in notebooks. You can configure the maximum fraction of code in notebooks as follows:
+
+` + "```yaml" + `
+notebooks:
+  maxCodeRatio: 0.5 # number between 0 and 1
+` + "```" + `
+
+or equivalent TOML:
+` + "```toml" + `
+[tool.mllint.notebooks]
+maxCodeRatio = 0.5 # number between 0 and 1
+` + "```",
+	Weight: 1,
+}
+
+var RuleCodeQuality = api.Rule{
+	Name: "Code quality linters report no issues with the notebooks' code",
+	Slug: "notebooks/code-quality",
+	Details: `The code quality linters that ` + "`mllint`" + ` runs on your project (see the Code Quality category) cannot analyse Jupyter Notebooks directly.
+When enabled, ` + "`mllint`" + ` extracts the code cells of each of your project's notebooks into a Python script, runs the configured and installed code quality linters on these scripts,
+and maps the issues that they report back to the notebook cells that they originate from. IPython magics and shell commands (e.g. ` + "`%matplotlib inline` or `!pip install`" + `) are ignored.
+
+As with the Pylint rule in the Code Quality category, the score for this rule is determined by the number of reported issues per line of code in your notebooks:
+` + "`score = 100 - 100 * min(1, 10 * number of issues / lines of code)`" + `.
+
+This rule is only checked when enabled in your configuration, since it runs each of the linters a second time:
+
+` + "```yaml" + `
+notebooks:
+  lint: true
+` + "```" + `
+
+or equivalent TOML:
+` + "```toml" + `
+[tool.mllint.notebooks]
+lint = true
+` + "```",
+	Weight: 1,
+}
@@ -0,0 +1,10 @@
+import numpy as np
+
+
+def train(x, y):
+    weights = np.linalg.lstsq(x, y, rcond=None)[0]
+    return weights
+
+
+def predict(weights, x):
+    return x @ weights
@@ -1,6 +1,7 @@
 package template
 
 import (
+	"context"
 	"errors"
 
 	"github.com/bvobart/mllint/api"
@@ -22,7 +23,7 @@ func (l *Linter) Rules() []*api.Rule {
 	return []*api.Rule{&RuleSomething} // add all the rules that your linter may report on here.
 }
 
-func (l *Linter) LintProject(project api.Project) (api.Report, error) {
+func (l *Linter) LintProject(ctx context.Context, project api.Project) (api.Report, error) {
 	report := api.NewReport()
 
 	// Implement me by doing your checks and appending scores and details to the report,
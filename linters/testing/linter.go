@@ -1,11 +1,13 @@
 package testing
 
 import (
+	"context"
 	"encoding/xml"
 	"errors"
 	"fmt"
 	"io"
 	"math"
+	"os"
 	"path"
 	"strings"
 
@@ -31,6 +33,21 @@ func NewLinter() api.ConfigurableLinter {
 type TestingLinter struct {
 	Config    config.TestingConfig
 	TestFiles utils.Filenames
+
+	// results of running the project's tests, only when configured with `testing.run`.
+	run *testRun
+	// the project's coverage report, read before any of the rules are scored.
+	coverage *coverageReport
+}
+
+// coverageReport is the result of reading the project's Cobertura XML coverage report.
+type coverageReport struct {
+	// name of the coverage report's file
+	name string
+	// the parsed coverage report, nil if it was not provided or could not be read.
+	parsed *cobertura.Coverage
+	// Markdown-formatted explanation of why the coverage report could not be read.
+	problem string
 }
 
 func (l *TestingLinter) Name() string {
@@ -44,32 +61,53 @@ func (l *TestingLinter) Configure(conf *config.Config) error {
 	} else if l.Config.Coverage.Targets.Line < 0 {
 		return fmt.Errorf("%w: %.1f", ErrCoverageTargetTooLow, l.Config.Coverage.Targets.Line)
 	}
+	if l.Config.Run.Command != "" {
+		if _, _, err := l.parseRunConfig(); err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
 func (l *TestingLinter) Rules() []*api.Rule {
-	return []*api.Rule{&RuleHasTests, &RuleTestsPass, &RuleTestCoverage, &RuleTestsFolder}
+	return []*api.Rule{&RuleHasTests, &RuleTestsPass, &RuleTestCoverage, &RuleTestsFolder, &RuleModulesTested}
 }
 
-func (l *TestingLinter) LintProject(project api.Project) (api.Report, error) {
+func (l *TestingLinter) LintProject(ctx context.Context, project api.Project) (api.Report, error) {
 	report := api.NewReport()
 
-	l.TestFiles = project.PythonFiles.Filter(func(filename string) bool {
-		return strings.HasSuffix(filename, "_test.py") || strings.HasPrefix(path.Base(filename), "test_")
-	})
+	l.TestFiles = project.AllPythonFiles().Filter(isTestFile)
+	l.run = nil
+
+	// run the project's tests when configured to do so, but only if any of the rules that use the results are enabled.
+	if l.Config.Run.Command != "" && (!project.RuleDisabled(&RuleTestsPass) || !project.RuleDisabled(&RuleTestCoverage)) {
+		reportsDir, err := createReportsDir()
+		if err != nil {
+			return report, fmt.Errorf("failed to create temporary directory for test reports: %w", err)
+		}
+		defer os.RemoveAll(reportsDir)
+
+		l.run = l.runTests(ctx, project, reportsDir)
+	}
+	l.coverage = l.readCoverageReport(project)
 
 	l.ScoreRuleHasTests(&report, project)
 	l.ScoreRuleTestsFolder(&report, project)
 	l.ScoreRuleTestsPass(&report, project)
 	l.ScoreRuleTestCoverage(&report, project)
+	l.ScoreRuleModulesTested(&report, project)
 
 	return report, nil
 }
 
+func isTestFile(filename string) bool {
+	return strings.HasSuffix(filename, "_test.py") || strings.HasPrefix(path.Base(filename), "test_")
+}
+
 //---------------------------------------------------------------------------------------
 
 func (l *TestingLinter) ScoreRuleHasTests(report *api.Report, project api.Project) {
-	if len(project.PythonFiles) == 0 {
+	if len(project.AllPythonFiles()) == 0 {
 		report.Scores[RuleHasTests] = 0
 		return
 	}
@@ -84,7 +122,7 @@ func (l *TestingLinter) ScoreRuleHasTests(report *api.Report, project api.Projec
 	// determine expected and actual ratio of test files vs. other Python files
 	ratioTotal := float64(l.Config.Targets.Ratio.Tests + l.Config.Targets.Ratio.Other)
 	expectedRatio := float64(l.Config.Targets.Ratio.Tests) / ratioTotal
-	actualRatio := float64(numTests) / float64(len(project.PythonFiles))
+	actualRatio := float64(numTests) / float64(len(project.AllPythonFiles()))
 	// score is basically: actual ratio / expected ratio
 	report.Scores[RuleHasTests] = math.Min(100*actualRatio/expectedRatio, 100)
 
@@ -106,7 +144,7 @@ This equates to **%s%%** of Python files in your project being tests, which meet
 //---------------------------------------------------------------------------------------
 
 func (l *TestingLinter) ScoreRuleTestsFolder(report *api.Report, project api.Project) {
-	if len(project.PythonFiles) == 0 {
+	if len(project.AllPythonFiles()) == 0 {
 		report.Scores[RuleTestsFolder] = 0
 		return
 	}
@@ -150,14 +188,28 @@ func isInTestsFolder(projectdir, testFile string) bool {
 //---------------------------------------------------------------------------------------
 
 func (l *TestingLinter) ScoreRuleTestsPass(report *api.Report, project api.Project) {
-	if l.Config.Report == "" {
+	reportName := l.Config.Report
+	junitReportPath := path.Join(project.Dir, l.Config.Report)
+	runDetails := ""
+
+	if l.run != nil {
+		reportName = path.Base(l.run.JUnitReport)
+		junitReportPath = l.run.JUnitReport
+		runDetails = "\n\n" + l.run.formatOutput()
+
+		if !utils.FileExists(junitReportPath) {
+			report.Scores[RuleTestsPass] = 0
+			report.Details[RuleTestsPass] = "`mllint` ran your project's tests, but the test command did not produce a JUnit XML test report.\n\n" +
+				"Make sure that your test command writes its JUnit report to the `{junit}` placeholder (or the `$MLLINT_JUNIT_REPORT` environment variable), " +
+				"e.g. `pytest --junitxml={junit}`." + runDetails
+			return
+		}
+	} else if l.Config.Report == "" {
 		report.Scores[RuleTestsPass] = 0
-		report.Details[RuleTestsPass] = "No test report was provided.\n\nPlease update the `testing.report` setting in your project's `mllint` configuration to specify the path to your project's test report.\n\n" + howToMakeJUnitXML
+		report.Details[RuleTestsPass] = "No test report was provided.\n\nPlease update the `testing.report` setting in your project's `mllint` configuration to specify the path to your project's test report, " +
+			"or configure `testing.run` to let `mllint` run your tests.\n\n" + howToMakeJUnitXML
 		return
-	}
-
-	junitReportPath := path.Join(project.Dir, l.Config.Report)
-	if !utils.FileExists(junitReportPath) {
+	} else if !utils.FileExists(junitReportPath) {
 		report.Scores[RuleTestsPass] = 0
 		report.Details[RuleTestsPass] = fmt.Sprintf("A test report was provided, namely `%s`, but this file could not be found.\n\nPlease update the `testing.report` setting in your project's `mllint` configuration to fix the path to your project's test report. Remember that this path must be relative to the root of your project directory.", l.Config.Report)
 		return
@@ -170,7 +222,7 @@ func (l *TestingLinter) ScoreRuleTestsPass(report *api.Report, project api.Proje
 
 %s
 
-Please make sure your test report file is a valid JUnit XML file. %s`, l.Config.Report, "```\n"+err.Error()+"\n```", howToMakeJUnitXML)
+Please make sure your test report file is a valid JUnit XML file. %s`, reportName, "```\n"+err.Error()+"\n```", howToMakeJUnitXML) + runDetails
 		return
 	}
 
@@ -183,7 +235,7 @@ Please make sure your test report file is a valid JUnit XML file. %s`, l.Config.
 
 	if totalTests == 0 {
 		report.Scores[RuleTestsPass] = 0
-		report.Details[RuleTestsPass] = fmt.Sprintf(`No tests were run, according to the provided test report file `+"`%s`"+`. Don't be shy, implement some tests!`, l.Config.Report)
+		report.Details[RuleTestsPass] = fmt.Sprintf(`No tests were run, according to the provided test report file `+"`%s`"+`. Don't be shy, implement some tests!`, reportName) + runDetails
 		return
 	}
 
@@ -200,23 +252,39 @@ Please make sure your test report file is a valid JUnit XML file. %s`, l.Config.
 	} else {
 		report.Details[RuleTestsPass] = fmt.Sprintf("Oh my, only **%d** out of **%d** tests in your project passed... You can do better, right? Good luck fixing those tests!", passedTests, totalTests)
 	}
+
+	// include the output of the test command when the tests did not all pass
+	if passedTests < totalTests || (l.run != nil && l.run.Err != nil) {
+		report.Details[RuleTestsPass] += runDetails
+	}
 }
 
 //---------------------------------------------------------------------------------------
 
-func (l *TestingLinter) ScoreRuleTestCoverage(report *api.Report, project api.Project) {
-	if l.Config.Coverage.Report == "" {
-		report.Scores[RuleTestCoverage] = 0
-		report.Details[RuleTestCoverage] = "No test coverage report was provided.\n\nPlease update the `testing.coverage.report` setting in your project's `mllint` configuration to specify the path to your project's test coverage report.\n\n" + howToMakeCoverageXML
-		return
-	}
-
-	covReportFile, err := utils.OpenFile(project.Dir, l.Config.Coverage.Report)
-	if err != nil {
-		report.Scores[RuleTestCoverage] = 0
-		report.Details[RuleTestCoverage] = fmt.Sprintf("A test coverage report was provided, namely `%s`, but this file could not be found or opened (error: `%s`).\n\nPlease update the `testing.coverage.report` setting in your project's `mllint` configuration to fix the path to your project's test report. Remember that this path must be relative to the root of your project directory.", l.Config.Coverage.Report, err.Error())
-		return
+// reads the project's coverage report, either the one generated by running the project's tests, or the one configured in `testing.coverage.report`.
+func (l *TestingLinter) readCoverageReport(project api.Project) *coverageReport {
+	result := &coverageReport{name: l.Config.Coverage.Report}
+	var covReportFile *os.File
+	var err error
+
+	if l.run != nil {
+		result.name = path.Base(l.run.CoverageReport)
+		covReportFile, err = os.Open(l.run.CoverageReport)
+		if err != nil {
+			result.problem = "`mllint` ran your project's tests, but the test command did not produce a Cobertura XML coverage report.\n\n" +
+				"Make sure that your test command writes its coverage report to the `{coverage}` placeholder (or the `$MLLINT_COVERAGE_REPORT` environment variable), " +
+				"e.g. `pytest --cov=src --cov-report=xml:{coverage}`.\n\n" + l.run.formatOutput()
+			return result
+		}
+	} else if l.Config.Coverage.Report == "" {
+		result.problem = "No test coverage report was provided.\n\nPlease update the `testing.coverage.report` setting in your project's `mllint` configuration to specify the path to your project's test coverage report, " +
+			"or configure `testing.run` to let `mllint` run your tests.\n\n" + howToMakeCoverageXML
+		return result
+	} else if covReportFile, err = utils.OpenFile(project.Dir, l.Config.Coverage.Report); err != nil {
+		result.problem = fmt.Sprintf("A test coverage report was provided, namely `%s`, but this file could not be found or opened (error: `%s`).\n\nPlease update the `testing.coverage.report` setting in your project's `mllint` configuration to fix the path to your project's test report. Remember that this path must be relative to the root of your project directory.", l.Config.Coverage.Report, err.Error())
+		return result
 	}
+	defer covReportFile.Close()
 
 	var covReport cobertura.Coverage
 	covReportData, err := io.ReadAll(covReportFile)
@@ -224,15 +292,26 @@ func (l *TestingLinter) ScoreRuleTestCoverage(report *api.Report, project api.Pr
 		err = xml.Unmarshal(covReportData, &covReport)
 	}
 	if err != nil {
-		report.Scores[RuleTestCoverage] = 0
-		report.Details[RuleTestCoverage] = fmt.Sprintf(`A test report file `+"`%s`"+` was provided and found, but there was an error parsing the Cobertura XML contents:
+		result.problem = fmt.Sprintf(`A test report file `+"`%s`"+` was provided and found, but there was an error parsing the Cobertura XML contents:
 
 %s
 
-Please make sure your test report file is a valid Cobertura-compatible XML file. %s`, l.Config.Report, "```\n"+err.Error()+"\n```", howToMakeCoverageXML)
+Please make sure your test report file is a valid Cobertura-compatible XML file. %s`, result.name, "```\n"+err.Error()+"\n```", howToMakeCoverageXML)
+		return result
+	}
+
+	result.parsed = &covReport
+	return result
+}
+
+func (l *TestingLinter) ScoreRuleTestCoverage(report *api.Report, project api.Project) {
+	if l.coverage.parsed == nil {
+		report.Scores[RuleTestCoverage] = 0
+		report.Details[RuleTestCoverage] = l.coverage.problem
 		return
 	}
 
+	covReport := l.coverage.parsed
 	totalLines := covReport.NumLines()
 	hitLines := covReport.NumLinesWithHits()
 	hitRate := 100 * float64(hitLines) / float64(totalLines) // percentage of lines covered.
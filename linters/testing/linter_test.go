@@ -2,6 +2,7 @@ package testing_test
 
 import (
 	"fmt"
+	"os"
 	"path"
 	stdtesting "testing"
 
@@ -16,7 +17,7 @@ import (
 func TestTestingLinter(t *stdtesting.T) {
 	linter := testing.NewLinter()
 	require.Equal(t, "Testing", linter.Name())
-	require.Equal(t, []*api.Rule{&testing.RuleHasTests, &testing.RuleTestsPass, &testing.RuleTestCoverage, &testing.RuleTestsFolder}, linter.Rules())
+	require.Equal(t, []*api.Rule{&testing.RuleHasTests, &testing.RuleTestsPass, &testing.RuleTestCoverage, &testing.RuleTestsFolder, &testing.RuleModulesTested}, linter.Rules())
 
 	suite := testutils.NewLinterTestSuite(linter, []testutils.LinterTest{
 		{
@@ -35,6 +36,7 @@ func TestTestingLinter(t *stdtesting.T) {
 				require.Contains(t, report.Details[testing.RuleTestCoverage], "update the `testing.coverage.report` setting")
 
 				require.EqualValues(t, 0, report.Scores[testing.RuleTestsFolder])
+				require.NotContains(t, report.Scores, testing.RuleModulesTested)
 			},
 		},
 		{
@@ -361,6 +363,129 @@ func TestTestingLinter(t *stdtesting.T) {
 	suite.RunAll(t)
 }
 
+func TestTestingLinterModulesTested(t *stdtesting.T) {
+	linter := testing.NewLinter()
+	suite := testutils.NewLinterTestSuite(linter, []testutils.LinterTest{
+		{
+			Name:    "NoTestFiles",
+			Dir:     "test-resources/mapping",
+			Options: testutils.NewOptions().UsePythonFiles(utils.Filenames{"test-resources/mapping/src/pkg/model.py"}),
+			Expect: func(t *stdtesting.T, report api.Report, err error) {
+				require.NoError(t, err)
+				require.EqualValues(t, 0, report.Scores[testing.RuleModulesTested])
+				require.Contains(t, report.Details[testing.RuleModulesTested], "**0** out of **1** source modules")
+				require.Contains(t, report.Details[testing.RuleModulesTested], "- src/pkg/model.py")
+			},
+		},
+		{
+			Name:    "NoSourceModules",
+			Dir:     "test-resources/mapping",
+			Options: testutils.NewOptions().UsePythonFiles(utils.Filenames{"test-resources/mapping/src/pkg/__init__.py", "test-resources/mapping/tests/test_model.py"}),
+			Expect: func(t *stdtesting.T, report api.Report, err error) {
+				require.NoError(t, err)
+				require.NotContains(t, report.Scores, testing.RuleModulesTested)
+				require.NotContains(t, report.Details, testing.RuleModulesTested)
+			},
+		},
+		{
+			Name:    "TwoOutOfThree",
+			Dir:     "test-resources/mapping",
+			Options: testutils.NewOptions().DetectPythonFiles(),
+			Expect: func(t *stdtesting.T, report api.Report, err error) {
+				require.NoError(t, err)
+				require.InDelta(t, 66.67, report.Scores[testing.RuleModulesTested], 0.01)
+				details := report.Details[testing.RuleModulesTested]
+				require.Contains(t, details, "**2** out of **3** source modules")
+				require.Contains(t, details, "`src/pkg/model.py` | `tests/test_model.py`\n")
+				require.Contains(t, details, "`src/pkg/data.py` | `tests/test_data.py`\n")
+				require.Contains(t, details, "`src/pkg/unused.py` | —\n")
+				require.Contains(t, details, "**not** imported by any of your tests:\n\n- src/pkg/unused.py\n")
+				require.NotContains(t, details, "__init__.py")
+			},
+		},
+		{
+			Name: "TwoOutOfThree/WithCoverage",
+			Dir:  "test-resources/mapping",
+			Options: testutils.NewOptions().DetectPythonFiles().WithConfig(func() *config.Config {
+				c := config.Default()
+				c.Testing.Coverage.Report = "coverage.xml"
+				return c
+			}()),
+			Expect: func(t *stdtesting.T, report api.Report, err error) {
+				require.NoError(t, err)
+				require.InDelta(t, 66.67, report.Scores[testing.RuleModulesTested], 0.01)
+				details := report.Details[testing.RuleModulesTested]
+				require.Contains(t, details, "Module | Tests | Line coverage")
+				require.Contains(t, details, "`src/pkg/model.py` | `tests/test_model.py` | 100.0%\n")
+				require.Contains(t, details, "`src/pkg/data.py` | `tests/test_data.py` | 50.0%\n")
+				require.Contains(t, details, "`src/pkg/unused.py` | — | —\n")
+			},
+		},
+	})
+
+	suite.DefaultOptions().WithConfig(config.Default())
+	suite.RunAll(t)
+}
+
+func TestParseImports(t *stdtesting.T) {
+	imports, err := testing.ParseImports("test-resources/mapping/tests/test_model.py", "tests/test_model.py")
+	require.NoError(t, err)
+	require.Equal(t, []string{"os", "pkg.model"}, imports)
+
+	imports, err = testing.ParseImports("test-resources/mapping/tests/test_data.py", "tests/test_data.py")
+	require.NoError(t, err)
+	require.Equal(t, []string{"pkg", "pkg.data"}, imports)
+
+	_, err = testing.ParseImports("test-resources/mapping/tests/non-existant.py", "tests/non-existant.py")
+	require.Error(t, err)
+}
+
+func TestImportNames(t *stdtesting.T) {
+	require.Equal(t, []string{"src.pkg.model", "pkg.model"}, testing.ImportNames("test-resources/mapping", "src/pkg/model.py"))
+	require.Equal(t, []string{"scripts.train", "train"}, testing.ImportNames("test-resources/mapping", "scripts/train.py"))
+	require.Equal(t, []string{"script"}, testing.ImportNames("test-resources/mapping", "script.py"))
+}
+
+func TestMapTestsToModules(t *stdtesting.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"src/pkg/__init__.py":   "",
+		"src/pkg/utils.py":      "",
+		"src/pkg/model.py":      "",
+		"src/pkg/test_model.py": "from . import model\n",
+		"src/other/utils.py":    "",
+		"scripts/utils.py":      "",
+		"tests/test_utils.py":   "import utils\nfrom pkg import utils as pkg_utils\n",
+		// a parenthesis outside of an import statement does not swallow the imports that follow it.
+		"tests/test_other.py": "OPEN = '('\nfrom other import utils\nCLOSE = ')'\n",
+	}
+	for filename, contents := range files {
+		require.NoError(t, os.MkdirAll(path.Dir(path.Join(dir, filename)), 0755))
+		require.NoError(t, os.WriteFile(path.Join(dir, filename), []byte(contents), 0644))
+	}
+
+	abs := func(filenames ...string) utils.Filenames {
+		return utils.Filenames(filenames).Prefix(dir)
+	}
+	sourceFiles := abs("src/pkg/utils.py", "src/pkg/model.py", "src/other/utils.py", "scripts/utils.py")
+	testFiles := abs("src/pkg/test_model.py", "tests/test_utils.py", "tests/test_other.py")
+
+	// `import utils` only refers to the modules named `utils` that are not inside a package.
+	mapping := testing.MapTestsToModules(dir, sourceFiles, testFiles)
+	require.Equal(t, testing.ModuleTests{
+		path.Join(dir, "src/pkg/utils.py"):   abs("tests/test_utils.py"),
+		path.Join(dir, "src/pkg/model.py"):   abs("src/pkg/test_model.py"),
+		path.Join(dir, "src/other/utils.py"): abs("tests/test_other.py", "tests/test_utils.py"),
+		path.Join(dir, "scripts/utils.py"):   abs("tests/test_utils.py"),
+	}, mapping)
+}
+
+func TestModuleName(t *stdtesting.T) {
+	require.Equal(t, "src.pkg.model", testing.ModuleName("src/pkg/model.py"))
+	require.Equal(t, "src.pkg", testing.ModuleName("src/pkg/__init__.py"))
+	require.Equal(t, "script", testing.ModuleName("script.py"))
+}
+
 func TestTestingLinterConfigure(t *stdtesting.T) {
 	linter := testing.NewLinter()
 	conf := config.Default()
@@ -370,6 +495,110 @@ func TestTestingLinterConfigure(t *stdtesting.T) {
 	require.ErrorIs(t, linter.Configure(conf), testing.ErrCoverageTargetTooLow)
 	conf.Testing.Coverage.Targets.Line = 200
 	require.ErrorIs(t, linter.Configure(conf), testing.ErrCoverageTargetTooHigh)
+
+	conf = config.Default()
+	conf.Testing.Run.Command = "pytest"
+	require.NoError(t, linter.Configure(conf))
+	conf.Testing.Run.Timeout = "not a duration"
+	require.ErrorIs(t, linter.Configure(conf), testing.ErrInvalidRunTimeout)
+	conf.Testing.Run.Timeout = "0"
+	require.NoError(t, linter.Configure(conf))
+	conf.Testing.Run.Timeout = ""
+	conf.Testing.Run.Command = `pytest "unterminated`
+	require.ErrorIs(t, linter.Configure(conf), testing.ErrInvalidRunCommand)
+}
+
+func TestTestingLinterRun(t *stdtesting.T) {
+	linter := testing.NewLinter()
+	files := createPythonFilenames(16).Concat(createPythonTestFilenames(4))
+	withRunConfig := func(command string, timeout string, env map[string]string) *config.Config {
+		c := config.Default()
+		c.Testing.Run.Command = command
+		c.Testing.Run.Timeout = timeout
+		c.Testing.Run.Env = env
+		return c
+	}
+
+	suite := testutils.NewLinterTestSuite(linter, []testutils.LinterTest{
+		{
+			Name: "Placeholders",
+			Dir:  "test-resources",
+			Options: testutils.NewOptions().UsePythonFiles(files).
+				WithConfig(withRunConfig(`sh -c "cp junit-passed-all.xml {junit} && cp coverage-50.xml {coverage}"`, "", nil)),
+			Expect: func(t *stdtesting.T, report api.Report, err error) {
+				require.NoError(t, err)
+				require.EqualValues(t, 100, report.Scores[testing.RuleTestsPass])
+				require.Contains(t, report.Details[testing.RuleTestsPass], "all **4** tests in your project passed")
+				require.NotContains(t, report.Details[testing.RuleTestsPass], "Test command:")
+				require.EqualValues(t, 62.5, report.Scores[testing.RuleTestCoverage])
+				require.Contains(t, report.Details[testing.RuleTestCoverage], "achieved **50.0%** line test coverage")
+			},
+		},
+		{
+			Name: "EnvironmentVariables",
+			Dir:  "test-resources",
+			Options: testutils.NewOptions().UsePythonFiles(files).
+				WithConfig(withRunConfig(`sh -c 'test "$FOO" = bar && cp junit-passed-all.xml "$MLLINT_JUNIT_REPORT"'`, "1m", map[string]string{"FOO": "bar"})),
+			Expect: func(t *stdtesting.T, report api.Report, err error) {
+				require.NoError(t, err)
+				require.EqualValues(t, 100, report.Scores[testing.RuleTestsPass])
+				require.EqualValues(t, 0, report.Scores[testing.RuleTestCoverage])
+				require.Contains(t, report.Details[testing.RuleTestCoverage], "did not produce a Cobertura XML coverage report")
+			},
+		},
+		{
+			Name: "FailedWithOutput",
+			Dir:  "test-resources",
+			Options: testutils.NewOptions().UsePythonFiles(files).
+				WithConfig(withRunConfig(`sh -c "echo 'FAILED tests/file1_test.py::test_parse_post'; cp junit-failed-all.xml {junit}; exit 1"`, "", nil)),
+			Expect: func(t *stdtesting.T, report api.Report, err error) {
+				require.NoError(t, err)
+				require.EqualValues(t, 0, report.Scores[testing.RuleTestsPass])
+				require.Contains(t, report.Details[testing.RuleTestsPass], "**None** of the 4 tests in your project passed")
+				require.Contains(t, report.Details[testing.RuleTestsPass], "Test command: `sh -c")
+				require.Contains(t, report.Details[testing.RuleTestsPass], "exit status 1")
+				require.Contains(t, report.Details[testing.RuleTestsPass], "FAILED tests/file1_test.py::test_parse_post")
+			},
+		},
+		{
+			Name: "NoReportProduced",
+			Dir:  "test-resources",
+			Options: testutils.NewOptions().UsePythonFiles(files).
+				WithConfig(withRunConfig("echo hello", "", nil)),
+			Expect: func(t *stdtesting.T, report api.Report, err error) {
+				require.NoError(t, err)
+				require.EqualValues(t, 0, report.Scores[testing.RuleTestsPass])
+				require.Contains(t, report.Details[testing.RuleTestsPass], "did not produce a JUnit XML test report")
+				require.Contains(t, report.Details[testing.RuleTestsPass], "hello")
+				require.EqualValues(t, 0, report.Scores[testing.RuleTestCoverage])
+			},
+		},
+		{
+			Name: "Timeout",
+			Dir:  "test-resources",
+			Options: testutils.NewOptions().UsePythonFiles(files).
+				WithConfig(withRunConfig("sleep 5", "100ms", nil)),
+			Expect: func(t *stdtesting.T, report api.Report, err error) {
+				require.NoError(t, err)
+				require.EqualValues(t, 0, report.Scores[testing.RuleTestsPass])
+				require.Contains(t, report.Details[testing.RuleTestsPass], "test command timed out after 100ms")
+			},
+		},
+		{
+			Name: "PytestArgumentsAdded",
+			Dir:  "test-resources",
+			Options: testutils.NewOptions().UsePythonFiles(files).
+				WithConfig(withRunConfig("./bin/pytest --cov=src", "", nil)),
+			Expect: func(t *stdtesting.T, report api.Report, err error) {
+				require.NoError(t, err)
+				require.EqualValues(t, 50, report.Scores[testing.RuleTestsPass])
+				require.Contains(t, report.Details[testing.RuleTestsPass], "fake pytest was run with: --cov=src --junitxml=")
+				require.Contains(t, report.Details[testing.RuleTestsPass], "--cov-report=xml:")
+				require.Contains(t, report.Details[testing.RuleTestCoverage], "Wow! Congratulations!")
+			},
+		},
+	})
+	suite.RunAll(t)
 }
 
 func createPythonFilenames(n int) utils.Filenames {
@@ -0,0 +1,283 @@
+package testing
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/bvobart/gocover-cobertura/cobertura"
+
+	"github.com/bvobart/mllint/api"
+	"github.com/bvobart/mllint/utils"
+	"github.com/bvobart/mllint/utils/markdowngen"
+)
+
+// Python files that are not considered to be source modules that need tests of their own.
+var ignoredSourceModules = map[string]bool{
+	"__init__.py": true,
+	"__main__.py": true,
+	"setup.py":    true,
+	"conftest.py": true,
+}
+
+// ModuleTests maps a source module (filename) to the test files that import it.
+type ModuleTests map[string]utils.Filenames
+
+// Untested returns the source modules that are not imported by any test, sorted by filename.
+func (m ModuleTests) Untested() utils.Filenames {
+	untested := utils.Filenames{}
+	for module, tests := range m {
+		if len(tests) == 0 {
+			untested = append(untested, module)
+		}
+	}
+	sort.Strings(untested)
+	return untested
+}
+
+// Modules returns all source modules in this mapping, sorted by filename.
+func (m ModuleTests) Modules() utils.Filenames {
+	modules := make(utils.Filenames, 0, len(m))
+	for module := range m {
+		modules = append(modules, module)
+	}
+	sort.Strings(modules)
+	return modules
+}
+
+//---------------------------------------------------------------------------------------
+
+func (l *TestingLinter) ScoreRuleModulesTested(report *api.Report, project api.Project) {
+	sourceFiles := project.AllPythonFiles().Filter(func(filename string) bool {
+		return !isTestFile(filename) && !ignoredSourceModules[path.Base(filename)]
+	})
+	if len(sourceFiles) == 0 {
+		return
+	}
+
+	mapping := MapTestsToModules(project.Dir, sourceFiles, l.TestFiles)
+	untested := mapping.Untested()
+	nTested := len(sourceFiles) - len(untested)
+	report.Scores[RuleModulesTested] = 100 * float64(nTested) / float64(len(sourceFiles))
+
+	details := strings.Builder{}
+	details.WriteString(fmt.Sprintf("**%d** out of **%d** source modules in your project are imported by at least one test file.\n\n", nTested, len(sourceFiles)))
+	details.WriteString(l.mappingTable(project.Dir, mapping))
+	if len(untested) > 0 {
+		details.WriteString("\nThe following source modules are **not** imported by any of your tests:\n\n")
+		details.WriteString(markdowngen.ListFiles(untested.RelativeTo(project.Dir)))
+	}
+	report.Details[RuleModulesTested] = details.String()
+}
+
+func (l *TestingLinter) mappingTable(projectdir string, mapping ModuleTests) string {
+	table := strings.Builder{}
+	coverage := l.coverage.parsed
+	if coverage != nil {
+		table.WriteString("Module | Tests | Line coverage\n")
+		table.WriteString("-------|-------|--------------:\n")
+	} else {
+		table.WriteString("Module | Tests\n")
+		table.WriteString("-------|------\n")
+	}
+
+	for _, module := range mapping.Modules() {
+		tests := mapping[module].RelativeTo(projectdir)
+		testsStr := "—"
+		if len(tests) > 0 {
+			testsStr = "`" + strings.Join(tests, "`, `") + "`"
+		}
+
+		table.WriteString(fmt.Sprintf("`%s` | %s", utils.RelativePath(projectdir, module), testsStr))
+		if coverage != nil {
+			table.WriteString(" | " + formatFileCoverage(coverage, utils.RelativePath(projectdir, module)))
+		}
+		table.WriteString("\n")
+	}
+	return table.String()
+}
+
+// formats the line coverage of a source file according to a coverage report, or a dash if the file is not in the report.
+func formatFileCoverage(covReport *cobertura.Coverage, filename string) string {
+	for _, pkg := range covReport.Packages {
+		for _, class := range pkg.Classes {
+			if class.Filename == "" || !(filename == class.Filename || strings.HasSuffix(filename, "/"+class.Filename)) {
+				continue
+			}
+			if class.NumLines() == 0 {
+				return "—"
+			}
+			return fmt.Sprintf("%.1f%%", 100*class.HitRate())
+		}
+	}
+	return "—"
+}
+
+//---------------------------------------------------------------------------------------
+
+// MapTestsToModules maps each of the given source files to the test files that import it.
+// Files that cannot be read are treated as if they do not import anything.
+func MapTestsToModules(projectdir string, sourceFiles utils.Filenames, testFiles utils.Filenames) ModuleTests {
+	mapping := make(ModuleTests, len(sourceFiles))
+	moduleNames := make(map[string][]string, len(sourceFiles))
+	for _, sourceFile := range sourceFiles {
+		mapping[sourceFile] = utils.Filenames{}
+		moduleNames[sourceFile] = ImportNames(projectdir, utils.RelativePath(projectdir, sourceFile))
+	}
+
+	for _, testFile := range testFiles {
+		imports, err := ParseImports(testFile, utils.RelativePath(projectdir, testFile))
+		if err != nil {
+			continue
+		}
+
+		for sourceFile, names := range moduleNames {
+			if importsModule(imports, names) {
+				mapping[sourceFile] = append(mapping[sourceFile], testFile)
+			}
+		}
+	}
+
+	for _, tests := range mapping {
+		sort.Strings(tests)
+	}
+	return mapping
+}
+
+// ModuleName converts the path to a Python file (relative to the project root) to its dotted module name,
+// e.g. `src/pkg/module.py` becomes `src.pkg.module` and `src/pkg/__init__.py` becomes `src.pkg`.
+func ModuleName(filename string) string {
+	filename = strings.TrimSuffix(filepath.ToSlash(filename), ".py")
+	filename = strings.TrimSuffix(filename, "/__init__")
+	return strings.ReplaceAll(filename, "/", ".")
+}
+
+// ImportNames returns the dotted names with which the Python file at the given path (relative to the project root) can be imported.
+// Since we do not know which folder is on the Python path, this is its module name relative to the project root, or relative to
+// any folder in it that is not a package itself, i.e. has no `__init__.py`, such as `src`.
+// E.g. if `src/pkg` is a package, then `src/pkg/module.py` can be imported as `src.pkg.module` or `pkg.module`, but not as `module`.
+func ImportNames(projectdir string, filename string) []string {
+	parts := strings.Split(ModuleName(filename), ".")
+	names := []string{strings.Join(parts, ".")}
+	for i := 1; i < len(parts); i++ {
+		if !utils.FileExists(filepath.Join(projectdir, filepath.Join(parts[:i]...), "__init__.py")) {
+			names = append(names, strings.Join(parts[i:], "."))
+		}
+	}
+	return names
+}
+
+// importsModule returns true if any of the imported names is one of the names with which the module can be imported, see ImportNames.
+func importsModule(imports []string, names []string) bool {
+	for _, imported := range imports {
+		for _, name := range names {
+			if imported == name {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+var (
+	regexImport      = regexp.MustCompile(`^\s*import\s+(.+)$`)
+	regexFromImport  = regexp.MustCompile(`^\s*from\s+(\.*[\w.]*)\s+import\s+(.+)$`)
+	regexImportStart = regexp.MustCompile(`^\s*(import|from)\s`)
+)
+
+// ParseImports reads the Python file at the given location and returns the dotted names of all the modules
+// that it (possibly) imports. For `from a.b import c`, both `a.b` and `a.b.c` are returned, since `c` may be a module.
+// Relative imports are resolved using relpath, the path of the file relative to the project root.
+func ParseImports(filename string, relpath string) ([]string, error) {
+	file, err := os.Open(filename)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	imports := []string{}
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := stripComment(scanner.Text())
+
+		// join lines of imports that span multiple lines, using either parentheses or backslashes
+		for regexImportStart.MatchString(line) && ((strings.Contains(line, "(") && !strings.Contains(line, ")")) || strings.HasSuffix(line, "\\")) {
+			if !scanner.Scan() {
+				break
+			}
+			line = strings.TrimSuffix(line, "\\") + " " + stripComment(scanner.Text())
+		}
+
+		if match := regexFromImport.FindStringSubmatch(line); match != nil {
+			module := resolveRelativeImport(match[1], relpath)
+			if module != "" {
+				imports = append(imports, module)
+			}
+			for _, name := range splitImportedNames(match[2]) {
+				if name == "*" {
+					continue
+				}
+				if module == "" {
+					imports = append(imports, name)
+				} else {
+					imports = append(imports, module+"."+name)
+				}
+			}
+			continue
+		}
+
+		if match := regexImport.FindStringSubmatch(line); match != nil {
+			imports = append(imports, splitImportedNames(match[1])...)
+		}
+	}
+
+	return imports, scanner.Err()
+}
+
+func stripComment(line string) string {
+	if index := strings.Index(line, "#"); index != -1 {
+		line = line[:index]
+	}
+	return strings.TrimRight(line, " \t")
+}
+
+// splits e.g. `(a as b, c,\n d)` into `[a, c, d]`
+func splitImportedNames(names string) []string {
+	names = strings.NewReplacer("(", " ", ")", " ", "\\", " ").Replace(names)
+	res := []string{}
+	for _, name := range strings.Split(names, ",") {
+		fields := strings.Fields(name)
+		if len(fields) > 0 {
+			res = append(res, fields[0])
+		}
+	}
+	return res
+}
+
+// resolves a module name such as `..pkg.module` relative to the package of the file at relpath.
+// Returns the module name unchanged if it is not a relative import.
+func resolveRelativeImport(module string, relpath string) string {
+	level := len(module) - len(strings.TrimLeft(module, "."))
+	if level == 0 {
+		return module
+	}
+
+	pkg := path.Dir(filepath.ToSlash(relpath))
+	for i := 1; i < level; i++ {
+		pkg = path.Dir(pkg)
+	}
+
+	parts := []string{}
+	if pkg != "." && pkg != "/" {
+		parts = append(parts, strings.ReplaceAll(strings.Trim(pkg, "/"), "/", "."))
+	}
+	if rest := module[level:]; rest != "" {
+		parts = append(parts, rest)
+	}
+	return strings.Join(parts, ".")
+}
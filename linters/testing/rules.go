@@ -43,7 +43,7 @@ var RuleTestsPass = api.Rule{
 	Name: "Project passes all of its automated tests",
 	Slug: "testing/pass",
 	Details: `Of course, the point of having automated tests is to ensure that they pass.
-While ` + "`mllint`" + ` will **not run** your tests as part of its static analysis, ` + "`mllint`" + ` expects you to run these on your own terms
+By default, ` + "`mllint`" + ` will **not run** your tests as part of its static analysis, ` + "`mllint`" + ` expects you to run these on your own terms
 and provide a the filenames to a JUnit-compatible XML test report and a Cobertura-compatible XML coverage
 report in your project's ` + "`mllint`" + ` configuration. Specifically for this rule, the JUnit test report is analysed.
 
@@ -61,7 +61,8 @@ or equivalent TOML:
 [tool.mllint.testing]
 report = "tests-report.xml"
 ` + "```" + `
-`,
+
+` + howToRunTests,
 	Weight: 1,
 }
 
@@ -87,7 +88,7 @@ we are looking for suggestions on what novel forms of ML code coverage should be
 
 ---
 
-While ` + "`mllint`" + ` will **not run** your tests as part of its static analysis, ` + "`mllint`" + ` expects you to run these on your own terms
+By default, ` + "`mllint`" + ` will **not run** your tests as part of its static analysis, ` + "`mllint`" + ` expects you to run these on your own terms
 and provide a the filenames to a JUnit-compatible XML test report and a Cobertura-compatible XML coverage
 report in your project's ` + "`mllint`" + ` configuration. Specifically for this rule, the Cobertura-compatible coverage report is analysed.
 
@@ -111,7 +112,8 @@ targets = { line = 80.0 }
 
 # Note: unlike YAML, TOML distinguishes between floats and integers, so be sure to use 80.0 instead of 80
 ` + "```" + `
-`,
+
+` + howToRunTests,
 	Weight: 1,
 }
 
@@ -124,3 +126,44 @@ and should be placed in a folder called ` + "`tests`" + ` at the root of your pr
 This rule therefore simply checks whether all test files in your projects are indeed in this ` + "`tests`" + ` folder at the root of your project.`,
 	Weight: 1,
 }
+
+var RuleModulesTested = api.Rule{
+	Name: "Every source module is imported by at least one test",
+	Slug: "testing/modules-tested",
+	Details: `While ` + "`testing/has-tests`" + ` checks the overall ratio of test files to other Python files in your project,
+it does not tell you _which_ parts of your project are left untested. This rule maps each of your project's source modules
+(i.e. each Python file that is not a test, excluding ` + "`__init__.py`, `__main__.py`, `setup.py` and `conftest.py`" + `)
+to the test files that import it, and lists the source modules that are not imported by any test at all.
+
+A test file is considered to test a module when it imports that module, either directly (` + "`import pkg.module`" + `)
+or from its package (` + "`from pkg import module`" + ` or ` + "`from pkg.module import something`" + `).
+Since ` + "`mllint`" + ` does not know which folder your tests put on the Python path, an import of ` + "`pkg.module`" + `
+also matches a module located at e.g. ` + "`src/pkg/module.py`" + `.
+
+The score for this rule is the percentage of source modules that are imported by at least one test. Projects without any source modules are not scored.
+When a test coverage report is configured (see ` + "`testing/coverage`" + `), the report for this rule also shows each module's line coverage,
+such that you can cross-check which modules are imported by tests but still poorly covered by them.`,
+	Weight: 1,
+}
+
+const howToRunTests = "Alternatively, you can let `mllint` run your tests itself by configuring the command to run them with in `testing.run`. " +
+	"`mllint` then runs this command in your project's root directory and analyses the reports that it generates. " +
+	"Use the `{junit}` and `{coverage}` placeholders (or the `$MLLINT_JUNIT_REPORT` and `$MLLINT_COVERAGE_REPORT` environment variables) " +
+	"to tell your test command where to write its reports. When using `pytest` without placeholders, `mllint` adds the arguments for this automatically." + `
+
+` + "```yaml" + `
+testing:
+  run:
+    command: pytest --junitxml={junit} --cov=src --cov-report=xml:{coverage}
+    timeout: 10m # default is 30m, use 0 for no timeout
+    env:
+      SOME_VARIABLE: some-value
+` + "```" + `
+
+or equivalent TOML:
+` + "```toml" + `
+[tool.mllint.testing.run]
+command = "pytest --junitxml={junit} --cov=src --cov-report=xml:{coverage}"
+timeout = "10m"
+env = { SOME_VARIABLE = "some-value" }
+` + "```\n"
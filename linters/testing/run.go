@@ -0,0 +1,162 @@
+package testing
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/shlex"
+
+	"github.com/bvobart/mllint/api"
+	"github.com/bvobart/mllint/utils/exec"
+	"github.com/bvobart/mllint/utils/markdowngen"
+)
+
+var ErrInvalidRunCommand = errors.New("invalid test command")
+var ErrInvalidRunTimeout = errors.New("invalid test command timeout")
+
+const (
+	placeholderJUnit    = "{junit}"
+	placeholderCoverage = "{coverage}"
+
+	// maximum number of lines of the test command's output to include in a report.
+	maxOutputLines = 50
+)
+
+// testRun contains the results of running the project's tests with the command configured in `testing.run`.
+type testRun struct {
+	// the test command as it was executed, i.e. with the placeholders replaced.
+	Command []string
+	// absolute paths to the location where the JUnit and Cobertura XML reports should have been written.
+	JUnitReport    string
+	CoverageReport string
+	// the combined stdout and stderr output of the test command.
+	Output []byte
+	// error returned by the test command, e.g. because it exited with a non-zero exit code or timed out.
+	Err error
+}
+
+// parses the `testing.run` configuration, returning the command parts and timeout.
+func (l *TestingLinter) parseRunConfig() ([]string, time.Duration, error) {
+	cmdparts, err := shlex.Split(l.Config.Run.Command)
+	if err != nil {
+		return nil, 0, fmt.Errorf("%w `%s`: %s", ErrInvalidRunCommand, l.Config.Run.Command, err.Error())
+	}
+	if len(cmdparts) == 0 {
+		return nil, 0, fmt.Errorf("%w: command is empty", ErrInvalidRunCommand)
+	}
+
+	var timeout time.Duration
+	if l.Config.Run.Timeout != "" {
+		timeout, err = time.ParseDuration(l.Config.Run.Timeout)
+		if err != nil || timeout < 0 {
+			return nil, 0, fmt.Errorf("%w: `%s`", ErrInvalidRunTimeout, l.Config.Run.Timeout)
+		}
+	}
+
+	return cmdparts, timeout, nil
+}
+
+// runTests runs the project's tests with the command configured in `testing.run` in the project's directory,
+// writing the JUnit and coverage reports into the given (temporary) directory.
+func (l *TestingLinter) runTests(parent context.Context, project api.Project, reportsDir string) *testRun {
+	run := &testRun{
+		JUnitReport:    path.Join(reportsDir, "tests-report.xml"),
+		CoverageReport: path.Join(reportsDir, "coverage.xml"),
+	}
+
+	cmdparts, timeout, err := l.parseRunConfig()
+	if err != nil {
+		run.Err = err
+		return run
+	}
+	run.Command = prepareTestCommand(cmdparts, run.JUnitReport, run.CoverageReport)
+
+	env := []string{"MLLINT_JUNIT_REPORT=" + run.JUnitReport, "MLLINT_COVERAGE_REPORT=" + run.CoverageReport}
+	for key, value := range l.Config.Run.Env {
+		env = append(env, key+"="+value)
+	}
+	sort.Strings(env[2:])
+
+	ctx := parent
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+
+	run.Output, run.Err = exec.CommandCombinedOutputEnv(ctx, project.Dir, env, run.Command[0], run.Command[1:]...)
+	if parent.Err() == nil && ctx.Err() == context.DeadlineExceeded {
+		run.Err = fmt.Errorf("test command timed out after %s", timeout)
+	}
+	return run
+}
+
+// prepareTestCommand replaces the report placeholders in the test command. When the command does not contain
+// any placeholders but runs `pytest`, the arguments for generating the JUnit (and coverage) reports are added automatically.
+func prepareTestCommand(cmdparts []string, junitReport string, coverageReport string) []string {
+	command := make([]string, len(cmdparts))
+	hasPlaceholders := false
+	for i, part := range cmdparts {
+		if strings.Contains(part, placeholderJUnit) || strings.Contains(part, placeholderCoverage) {
+			hasPlaceholders = true
+		}
+		command[i] = strings.NewReplacer(placeholderJUnit, junitReport, placeholderCoverage, coverageReport).Replace(part)
+	}
+
+	if hasPlaceholders || !isPytestCommand(cmdparts) {
+		return command
+	}
+
+	command = append(command, "--junitxml="+junitReport)
+	for _, part := range cmdparts {
+		if part == "--cov" || strings.HasPrefix(part, "--cov=") {
+			command = append(command, "--cov-report=xml:"+coverageReport)
+			break
+		}
+	}
+	return command
+}
+
+func isPytestCommand(cmdparts []string) bool {
+	for i, part := range cmdparts {
+		if path.Base(part) == "pytest" || part == "py.test" || (part == "-m" && i+1 < len(cmdparts) && cmdparts[i+1] == "pytest") {
+			return true
+		}
+	}
+	return false
+}
+
+// formats the command and (the tail of) its output such that it can be included in a report's details.
+func (run *testRun) formatOutput() string {
+	details := strings.Builder{}
+	details.WriteString(fmt.Sprintf("Test command: `%s`\n\n", strings.Join(run.Command, " ")))
+	if run.Err != nil {
+		details.WriteString(fmt.Sprintf("Error: `%s`\n\n", run.Err.Error()))
+	}
+
+	output := strings.TrimSpace(string(run.Output))
+	if output == "" {
+		return details.String()
+	}
+
+	lines := strings.Split(output, "\n")
+	if len(lines) > maxOutputLines {
+		details.WriteString(fmt.Sprintf("Output (last %d lines):\n\n", maxOutputLines))
+		lines = lines[len(lines)-maxOutputLines:]
+	} else {
+		details.WriteString("Output:\n\n")
+	}
+	details.WriteString(markdowngen.CodeBlock(strings.Join(lines, "\n")))
+	return details.String()
+}
+
+func createReportsDir() (string, error) {
+	return ioutil.TempDir(os.TempDir(), "mllint-test-reports-")
+}
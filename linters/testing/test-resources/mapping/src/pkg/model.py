@@ -0,0 +1,2 @@
+def train():
+    return 42
@@ -0,0 +1,7 @@
+from pkg import (
+    data,
+)
+
+
+def test_load():
+    assert data.load() == []
@@ -0,0 +1,6 @@
+import os
+import pkg.model as model  # the model under test
+
+
+def test_train():
+    assert model.train() == 42
@@ -13,6 +13,7 @@ type LinterTestOptions struct {
 	detectPythonFiles bool
 	detectDepManagers bool
 	detectCQLinters   bool
+	detectNotebooks   bool
 	usePythonFiles    utils.Filenames
 	useDepManagers    api.DependencyManagerList
 	useCQLinters      []api.CQLinter
@@ -37,6 +38,11 @@ func (opts *LinterTestOptions) DetectCQLinters() *LinterTestOptions {
 	return opts
 }
 
+func (opts *LinterTestOptions) DetectNotebooks() *LinterTestOptions {
+	opts.detectNotebooks = true
+	return opts
+}
+
 func (opts *LinterTestOptions) UsePythonFiles(files utils.Filenames) *LinterTestOptions {
 	opts.usePythonFiles = files
 	return opts
@@ -1,6 +1,7 @@
 package testutils
 
 import (
+	"context"
 	"fmt"
 	"testing"
 
@@ -51,7 +52,7 @@ func (suite *LinterTestSuite) RunAll(t *testing.T) {
 			project := api.Project{Dir: test.Dir}
 			suite.applyOptions(t, test.Options, &project)
 
-			report, err := suite.linter.LintProject(project)
+			report, err := suite.linter.LintProject(context.Background(), project)
 			test.Expect(t, report, err)
 		})
 	}
@@ -62,6 +63,7 @@ func (suite *LinterTestSuite) RunAll(t *testing.T) {
 // applies the default and test's options to the project that will be passed to LintProject.
 func (suite *LinterTestSuite) applyOptions(t *testing.T, testOptions *LinterTestOptions, project *api.Project) {
 	suite.applyPythonFilesOptions(t, testOptions, project)
+	suite.applyNotebooksOptions(t, testOptions, project)
 	suite.applyDepManagerOptions(t, testOptions, project)
 	suite.applyCQLinterOptions(t, testOptions, project)
 	suite.applyConfigOption(t, testOptions)
@@ -88,6 +90,14 @@ func (suite *LinterTestSuite) applyPythonFilesOptions(t *testing.T, testOptions
 	}
 }
 
+func (suite *LinterTestSuite) applyNotebooksOptions(t *testing.T, testOptions *LinterTestOptions, project *api.Project) {
+	if suite.defaultOpts != nil && suite.defaultOpts.detectNotebooks || testOptions != nil && testOptions.detectNotebooks {
+		notebooks, err := utils.FindIPynbFilesIn(project.Dir)
+		require.NoError(t, err, "failed to find notebooks in test project")
+		project.Notebooks = notebooks.Prefix(project.Dir)
+	}
+}
+
 func (suite *LinterTestSuite) applyDepManagerOptions(t *testing.T, testOptions *LinterTestOptions, project *api.Project) {
 	if testOptions != nil && len(testOptions.useDepManagers) > 0 {
 		project.DepManagers = testOptions.useDepManagers
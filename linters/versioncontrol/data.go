@@ -0,0 +1,57 @@
+package versioncontrol
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/bvobart/mllint/api"
+	"github.com/bvobart/mllint/utils/markdowngen"
+)
+
+// DataLinter is a linter that checks whether the project versions its data using any data version control tool,
+// e.g. DVC, Git LFS, git-annex, lakeFS or Pachyderm, and whether it is using that tool effectively.
+// Relates to best practice 'Use Versioning for Data, Model, Configurations and Training Script'
+// See https://se-ml.github.io/best_practices/02-data_version/
+type DataLinter struct{}
+
+func (l *DataLinter) Name() string {
+	return "Data Version Control"
+}
+
+func (l *DataLinter) Rules() []*api.Rule {
+	return []*api.Rule{&RuleDataVersioned, &RuleDataHasRemote, &RuleDataHasFiles}
+}
+
+func (l *DataLinter) LintProject(ctx context.Context, project api.Project) (api.Report, error) {
+	report := api.NewReport()
+	report.Scores[RuleDataVersioned] = 0
+	if len(project.DataVCs) == 0 {
+		return report, nil
+	}
+
+	tools := []string{}
+	for _, tool := range project.DataVCs {
+		tools = append(tools, "**"+tool.Type().String()+"**")
+	}
+	report.Scores[RuleDataVersioned] = 100
+	report.Details[RuleDataVersioned] = fmt.Sprintf("Your project is using %s to version its data.", strings.Join(tools, ", "))
+
+	report.Scores[RuleDataHasRemote] = 0
+	if project.DataVCs.HasRemote() {
+		report.Scores[RuleDataHasRemote] = 100
+	}
+
+	report.Scores[RuleDataHasFiles] = 0
+	files := []interface{}{}
+	for _, tool := range project.DataVCs {
+		for _, file := range tool.Files() {
+			files = append(files, fmt.Sprintf("`%s` (%s)", file, tool.Type()))
+		}
+	}
+	if len(files) > 0 {
+		report.Scores[RuleDataHasFiles] = 100
+		report.Details[RuleDataHasFiles] = "Your project is versioning the following data:\n\n" + markdowngen.List(files)
+	}
+	return report, nil
+}
@@ -0,0 +1,62 @@
+package versioncontrol_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/bvobart/mllint/api"
+	"github.com/bvobart/mllint/linters/versioncontrol"
+	"github.com/bvobart/mllint/setools/datavc"
+)
+
+func TestDataName(t *testing.T) {
+	linter := &versioncontrol.DataLinter{}
+	require.Equal(t, "Data Version Control", linter.Name())
+}
+
+func TestDataRules(t *testing.T) {
+	linter := &versioncontrol.DataLinter{}
+	require.Equal(t, []*api.Rule{&versioncontrol.RuleDataVersioned, &versioncontrol.RuleDataHasRemote, &versioncontrol.RuleDataHasFiles}, linter.Rules())
+}
+
+func TestDataLinter(t *testing.T) {
+	linter := &versioncontrol.DataLinter{}
+
+	t.Run("NoDataVersionControl", func(t *testing.T) {
+		report, err := linter.LintProject(context.Background(), api.Project{Dir: t.TempDir()})
+		require.NoError(t, err)
+		require.EqualValues(t, 0, report.Scores[versioncontrol.RuleDataVersioned])
+		require.NotContains(t, report.Scores, versioncontrol.RuleDataHasRemote)
+		require.NotContains(t, report.Scores, versioncontrol.RuleDataHasFiles)
+	})
+
+	t.Run("NoRemoteNoFiles", func(t *testing.T) {
+		project := api.Project{Dir: t.TempDir()}
+		project.DataVCs = api.DataVersionControlList{datavc.LakeFS{Project: project, Folders: []string{}}}
+
+		report, err := linter.LintProject(context.Background(), project)
+		require.NoError(t, err)
+		require.EqualValues(t, 100, report.Scores[versioncontrol.RuleDataVersioned])
+		require.Equal(t, "Your project is using **lakeFS** to version its data.", report.Details[versioncontrol.RuleDataVersioned])
+		require.EqualValues(t, 0, report.Scores[versioncontrol.RuleDataHasRemote])
+		require.EqualValues(t, 0, report.Scores[versioncontrol.RuleDataHasFiles])
+	})
+
+	t.Run("MultipleTools", func(t *testing.T) {
+		project := api.Project{Dir: t.TempDir()}
+		project.DataVCs = api.DataVersionControlList{
+			datavc.LakeFS{Project: project, Folders: []string{"data/raw"}},
+			datavc.Pachyderm{Project: project, Specs: map[string]datavc.PipelineSpec{}},
+		}
+
+		report, err := linter.LintProject(context.Background(), project)
+		require.NoError(t, err)
+		require.EqualValues(t, 100, report.Scores[versioncontrol.RuleDataVersioned])
+		require.Equal(t, "Your project is using **lakeFS**, **Pachyderm** to version its data.", report.Details[versioncontrol.RuleDataVersioned])
+		require.EqualValues(t, 100, report.Scores[versioncontrol.RuleDataHasRemote])
+		require.EqualValues(t, 100, report.Scores[versioncontrol.RuleDataHasFiles])
+		require.Equal(t, "Your project is versioning the following data:\n\n- `data/raw` (lakeFS)\n", report.Details[versioncontrol.RuleDataHasFiles])
+	})
+}
@@ -1,12 +1,18 @@
 package versioncontrol
 
 import (
+	"context"
+	"fmt"
+	"os"
 	"path"
+	"sort"
+	"strings"
 
 	"github.com/bvobart/mllint/api"
 	"github.com/bvobart/mllint/setools/dvc"
 	"github.com/bvobart/mllint/setools/git"
 	"github.com/bvobart/mllint/utils"
+	"github.com/bvobart/mllint/utils/markdowngen"
 )
 
 // DVCLinter is a linter that checks whether the project is using DVC and using it correctly and effectively.
@@ -19,49 +25,51 @@ func (l *DVCLinter) Name() string {
 }
 
 func (l *DVCLinter) Rules() []*api.Rule {
-	return []*api.Rule{&RuleDVC, &RuleDVCIsInstalled, &RuleCommitDVCFolder, &RuleDVCHasRemote, &RuleDVCHasFiles, &RuleCommitDVCLock}
+	return []*api.Rule{&RuleDVC, &RuleDVCIsInstalled, &RuleCommitDVCFolder, &RuleDVCHasRemote, &RuleDVCRemoteNotLocal, &RuleDVCHasFiles, &RuleCommitDVCLock,
+		&RuleDVCPipeline, &RuleDVCStagesDepsOuts, &RuleDVCParams, &RuleDVCMetrics, &RuleDVCLockInSync}
 }
 
-func (l *DVCLinter) LintProject(project api.Project) (api.Report, error) {
+func (l *DVCLinter) LintProject(ctx context.Context, project api.Project) (api.Report, error) {
 	report := api.NewReport()
-	report.Scores[RuleDVC] = 0
+	backend := git.ForProject(project)
+	// Test whether 'dvc init' was run by checking whether a .dvc/config exists, which is also how DVC is detected (see datavc.TypeDVC).
+	// Projects that do not use DVC are scored by the DataLinter instead, which already penalises projects that do not version their data at all.
+	if !utils.FileExists(path.Join(project.Dir, ".dvc", "config")) {
+		return report, nil
+	}
+
+	report.Scores[RuleDVC] = 100
 	report.Scores[RuleDVCIsInstalled] = 0
 	report.Scores[RuleCommitDVCFolder] = 0
 	report.Scores[RuleDVCHasRemote] = 0
 	report.Scores[RuleDVCHasFiles] = 0
 
-	// Test whether 'dvc init' was run by checking whether a .dvc/config exists.
-	if utils.FileExists(path.Join(project.Dir, ".dvc", "config")) {
-		report.Scores[RuleDVC] = 100
-	} else {
-		return report, nil
-	}
-
-	if git.IsTracking(project.Dir, ".dvc") {
+	if backend.IsTracking(ctx, project.Dir, ".dvc") {
 		report.Scores[RuleCommitDVCFolder] = 100
 	}
 
-	// Test whether DVC is installed. If it is not, then the other rules below cannot be checked, so we return.
+	// Analyse the project's pipeline, which does not require DVC to be installed.
+	l.lintPipeline(project, &report)
+
+	// Test whether DVC is installed.
 	if dvc.IsInstalled() {
 		report.Scores[RuleDVCIsInstalled] = 100
-	} else {
-		return report, nil
 	}
 
-	// Test whether a remote has been configured: `dvc remote list`
-	if !RuleDVCHasRemote.Disabled && len(dvc.Remotes(project.Dir)) > 0 {
-		report.Scores[RuleDVCHasRemote] = 100
+	// Test whether a remote has been configured, by reading DVC's config files.
+	if !project.RuleDisabled(&RuleDVCHasRemote) {
+		l.lintRemotes(project, &report)
 	}
 
-	// Test whether there are any files being tracked with DVC: `dvc list . -R --dvc-only`
-	if !RuleDVCHasFiles.Disabled && len(dvc.Files(project.Dir)) > 0 {
+	// Test whether there are any files being tracked with DVC, i.e. outputs of .dvc files or the outputs recorded in dvc.lock
+	if !project.RuleDisabled(&RuleDVCHasFiles) && len(dvc.Files(project.Dir)) > 0 {
 		report.Scores[RuleDVCHasFiles] = 100
 	}
 
 	// Check whether the user has committed their dvc.lock file.
 	if utils.FileExists(path.Join(project.Dir, "dvc.lock")) {
 		report.Scores[RuleCommitDVCLock] = 0
-		if git.IsTracking(project.Dir, "dvc.lock") {
+		if backend.IsTracking(ctx, project.Dir, "dvc.lock") {
 			report.Scores[RuleCommitDVCLock] = 100
 		}
 	}
@@ -69,8 +77,134 @@ func (l *DVCLinter) LintProject(project api.Project) (api.Report, error) {
 	return report, nil
 }
 
+// lintRemotes checks whether the project has any DVC remotes configured, and whether these are not all local remotes.
+func (l *DVCLinter) lintRemotes(project api.Project, report *api.Report) {
+	config, err := dvc.ReadConfig(project.Dir)
+	if err != nil {
+		report.Details[RuleDVCHasRemote] = fmt.Sprintf("DVC's configuration could not be read:\n\n```\n%s\n```", err.Error())
+		return
+	}
+	if len(config.Remotes) == 0 {
+		return
+	}
+
+	remotes := []interface{}{}
+	for _, name := range config.RemoteNames() {
+		remote := config.Remotes[name]
+		defaultNote := ""
+		if name == config.DefaultRemote {
+			defaultNote = " (default)"
+		}
+		remotes = append(remotes, fmt.Sprintf("`%s`%s - type **%s** - `%s`", name, defaultNote, remote.Type(), remote.URL))
+	}
+
+	report.Scores[RuleDVCHasRemote] = 100
+	report.Details[RuleDVCHasRemote] = "Your project has the following DVC remotes configured:\n\n" + markdowngen.List(remotes)
+
+	report.Scores[RuleDVCRemoteNotLocal] = 100
+	if config.OnlyLocalRemotes() {
+		report.Scores[RuleDVCRemoteNotLocal] = 0
+		report.Details[RuleDVCRemoteNotLocal] = "All of your project's DVC remotes are folders on your local filesystem:\n\n" + markdowngen.List(remotes)
+	}
+}
+
+// lintPipeline checks the project's DVC pipeline, as defined in `dvc.yaml`, and whether it is in sync with `dvc.lock`.
+// The rules that inspect the pipeline's stages are only scored when the project has a pipeline.
+func (l *DVCLinter) lintPipeline(project api.Project, report *api.Report) {
+	report.Scores[RuleDVCPipeline] = 0
+	pipeline, err := dvc.ParsePipeline(project.Dir)
+	if os.IsNotExist(err) {
+		return
+	}
+	if err != nil {
+		report.Details[RuleDVCPipeline] = fmt.Sprintf("Your project's `%s` could not be parsed:\n\n```\n%s\n```", dvc.PipelineFile, err.Error())
+		return
+	}
+	if len(pipeline.Stages) == 0 {
+		report.Details[RuleDVCPipeline] = fmt.Sprintf("Your project has a `%s`, but it does not define any stages.", dvc.PipelineFile)
+		return
+	}
+	report.Scores[RuleDVCPipeline] = 100
+
+	// every stage should declare both dependencies and outputs.
+	incomplete := []interface{}{}
+	for _, name := range pipeline.StageNames() {
+		stage := pipeline.Stages[name]
+		switch {
+		case !stage.HasDeps() && !stage.HasOuts():
+			incomplete = append(incomplete, fmt.Sprintf("`%s` has no dependencies and no outputs", name))
+		case !stage.HasDeps():
+			incomplete = append(incomplete, fmt.Sprintf("`%s` has no dependencies", name))
+		case !stage.HasOuts():
+			incomplete = append(incomplete, fmt.Sprintf("`%s` has no outputs", name))
+		}
+	}
+	report.Scores[RuleDVCStagesDepsOuts] = 100 * float64(len(pipeline.Stages)-len(incomplete)) / float64(len(pipeline.Stages))
+	if len(incomplete) > 0 {
+		report.Details[RuleDVCStagesDepsOuts] = "The following stages of your pipeline do not declare all of their dependencies and outputs:\n\n" + markdowngen.List(incomplete)
+	}
+
+	// hyperparameters should be stored in params.yaml
+	hasParamsFile := utils.FileExists(path.Join(project.Dir, dvc.ParamsFile))
+	report.Scores[RuleDVCParams] = 0
+	switch {
+	case hasParamsFile && pipeline.UsesParamsFile():
+		report.Scores[RuleDVCParams] = 100
+	case hasParamsFile:
+		report.Details[RuleDVCParams] = fmt.Sprintf("Your project has a `%s`, but none of your pipeline's stages declare any parameters from it.", dvc.ParamsFile)
+	default:
+		report.Details[RuleDVCParams] = fmt.Sprintf("Your project does not have a `%s` file.", dvc.ParamsFile)
+	}
+
+	// metrics and plots should be declared.
+	report.Scores[RuleDVCMetrics] = 0
+	if pipeline.HasMetrics() {
+		report.Scores[RuleDVCMetrics] = 100
+	}
+
+	// dvc.lock should be in sync with dvc.yaml
+	report.Scores[RuleDVCLockInSync] = 0
+	lock, err := dvc.ParseLock(project.Dir)
+	if os.IsNotExist(err) {
+		report.Details[RuleDVCLockInSync] = fmt.Sprintf("Your project does not have a `%s` file, which means that your pipeline has never been run. Run it using `dvc repro`.", dvc.LockFile)
+		return
+	}
+	if err != nil {
+		report.Details[RuleDVCLockInSync] = fmt.Sprintf("Your project's `%s` could not be parsed:\n\n```\n%s\n```", dvc.LockFile, err.Error())
+		return
+	}
+
+	diff := dvc.CompareLock(project.Dir, pipeline, lock)
+	inSync := len(pipeline.Stages) - len(diff.Missing) - len(diff.Changed)
+	report.Scores[RuleDVCLockInSync] = 100 * float64(inSync) / float64(len(pipeline.Stages)+len(diff.Extra))
+	if !diff.InSync() {
+		report.Details[RuleDVCLockInSync] = buildLockDiffDetails(diff)
+	}
+}
+
+func buildLockDiffDetails(diff dvc.LockDiff) string {
+	problems := []interface{}{}
+	for _, name := range diff.Missing {
+		problems = append(problems, fmt.Sprintf("`%s` is defined in `%s`, but has never been run", name, dvc.PipelineFile))
+	}
+	for _, name := range diff.Extra {
+		problems = append(problems, fmt.Sprintf("`%s` is recorded in `%s`, but no longer defined in `%s`", name, dvc.LockFile, dvc.PipelineFile))
+	}
+
+	changed := []string{}
+	for name := range diff.Changed {
+		changed = append(changed, name)
+	}
+	sort.Strings(changed)
+	for _, name := range changed {
+		problems = append(problems, fmt.Sprintf("`%s` has changed since it was last run: %s", name, strings.Join(diff.Changed[name], ", ")))
+	}
+
+	return fmt.Sprintf("Your project's `%s` is not in sync with your `%s`:\n\n%s\nRun `dvc repro` to run the stages that are out of date, then commit the updated `%s`.",
+		dvc.LockFile, dvc.PipelineFile, markdowngen.List(problems), dvc.LockFile)
+}
+
 // Ideas for future DVC linting rules:
-// - Check whether the user has defined any DVC pipelines (repo has a dvc.yaml file with non-empty stages)
 // - Check what kinds of pipelines and stages the user has defined, check if there's a cleaning stage, training stage, testing stage, etc.
 // - something related to experiment tracking?
 
@@ -1,6 +1,8 @@
 package versioncontrol_test
 
 import (
+	"context"
+	"crypto/md5"
 	"fmt"
 	"io/ioutil"
 	"os"
@@ -11,6 +13,7 @@ import (
 
 	"github.com/bvobart/mllint/api"
 	"github.com/bvobart/mllint/linters/versioncontrol"
+	"github.com/bvobart/mllint/setools/datavc"
 	"github.com/bvobart/mllint/utils/exec"
 )
 
@@ -27,8 +30,14 @@ func TestDVCRules(t *testing.T) {
 		&versioncontrol.RuleDVCIsInstalled,
 		&versioncontrol.RuleCommitDVCFolder,
 		&versioncontrol.RuleDVCHasRemote,
+		&versioncontrol.RuleDVCRemoteNotLocal,
 		&versioncontrol.RuleDVCHasFiles,
 		&versioncontrol.RuleCommitDVCLock,
+		&versioncontrol.RuleDVCPipeline,
+		&versioncontrol.RuleDVCStagesDepsOuts,
+		&versioncontrol.RuleDVCParams,
+		&versioncontrol.RuleDVCMetrics,
+		&versioncontrol.RuleDVCLockInSync,
 	}, rules)
 }
 
@@ -36,15 +45,19 @@ func TestDVCLinter(t *testing.T) {
 	linter := &versioncontrol.DVCLinter{}
 
 	t.Run("no dvc", func(t *testing.T) {
+		// projects without any data version control are only penalised by the DataLinter.
 		project := api.Project{Dir: "test-resources/dvc"}
-		report, err := linter.LintProject(project)
+		report, err := linter.LintProject(context.Background(), project)
 		require.NoError(t, err)
+		require.Empty(t, report.Scores)
+	})
 
-		require.EqualValues(t, 0, report.Scores[versioncontrol.RuleDVC])
-		require.EqualValues(t, 0, report.Scores[versioncontrol.RuleDVCIsInstalled])
-		require.EqualValues(t, 0, report.Scores[versioncontrol.RuleCommitDVCFolder])
-		require.EqualValues(t, 0, report.Scores[versioncontrol.RuleDVCHasRemote])
-		require.EqualValues(t, 0, report.Scores[versioncontrol.RuleDVCHasFiles])
+	t.Run("other data version control tool", func(t *testing.T) {
+		project := api.Project{Dir: "test-resources/dvc"}
+		project.DataVCs = api.DataVersionControlList{datavc.LakeFS{Project: project}}
+		report, err := linter.LintProject(context.Background(), project)
+		require.NoError(t, err)
+		require.Empty(t, report.Scores)
 	})
 
 	t.Run("commit DVC folder", func(t *testing.T) {
@@ -61,7 +74,7 @@ func TestDVCLinter(t *testing.T) {
 		exec.CommandOutput = exec.DefaultCommandOutput
 
 		project := api.Project{Dir: dir}
-		report, err := linter.LintProject(project)
+		report, err := linter.LintProject(context.Background(), project)
 		require.NoError(t, err)
 
 		// Then:
@@ -86,7 +99,7 @@ func TestDVCLinter(t *testing.T) {
 		exec.CommandOutput = exec.DefaultCommandOutput
 
 		project := api.Project{Dir: dir}
-		report, err := linter.LintProject(project)
+		report, err := linter.LintProject(context.Background(), project)
 		require.NoError(t, err)
 
 		// Then:
@@ -102,7 +115,7 @@ func TestDVCLinter(t *testing.T) {
 		exec.CommandOutput = exec.DefaultCommandOutput
 
 		project := api.Project{Dir: "test-resources/dvc/dvc-init"}
-		report, err := linter.LintProject(project)
+		report, err := linter.LintProject(context.Background(), project)
 		require.NoError(t, err)
 
 		// Then:
@@ -116,17 +129,10 @@ func TestDVCLinter(t *testing.T) {
 	t.Run("DVC folder committed and DVC installed, but no remotes and no files", func(t *testing.T) {
 		dir := "test-resources/dvc/dvc-init"
 		exec.LookPath = func(file string) (string, error) { return "", nil }
-		exec.CommandOutput = func(cmddir, name string, args ...string) ([]byte, error) {
-			if name == "git" {
-				return exec.DefaultCommandOutput(cmddir, name, args...)
-			}
-			require.Equal(t, dir, cmddir)
-			require.Equal(t, name, "dvc")
-			return []byte("\n"), nil
-		}
+		exec.CommandOutput = exec.DefaultCommandOutput
 
 		project := api.Project{Dir: dir}
-		report, err := linter.LintProject(project)
+		report, err := linter.LintProject(context.Background(), project)
 		require.NoError(t, err)
 
 		// Then:
@@ -134,60 +140,45 @@ func TestDVCLinter(t *testing.T) {
 		require.EqualValues(t, 100, report.Scores[versioncontrol.RuleDVCIsInstalled])
 		require.EqualValues(t, 100, report.Scores[versioncontrol.RuleCommitDVCFolder])
 		require.EqualValues(t, 0, report.Scores[versioncontrol.RuleDVCHasRemote])
+		require.NotContains(t, report.Scores, versioncontrol.RuleDVCRemoteNotLocal)
 		require.EqualValues(t, 0, report.Scores[versioncontrol.RuleDVCHasFiles])
 	})
 
-	t.Run("DVC with remotes configured but no files", func(t *testing.T) {
+	t.Run("DVC with only local remotes configured but no files, and DVC not installed", func(t *testing.T) {
 		dir := "test-resources/dvc/dvc-init"
-		exec.LookPath = func(file string) (string, error) { return "", nil }
-		exec.CommandOutput = func(cmddir, name string, args ...string) ([]byte, error) {
-			if name == "git" {
-				return exec.DefaultCommandOutput(cmddir, name, args...)
-			}
-
-			require.Equal(t, dir, cmddir)
-			require.Equal(t, name, "dvc")
-
-			if args[0] == "remote" && args[1] == "list" {
-				return []byte("testremote			/path/to/remote\n"), nil
-			}
-			return []byte("\n"), nil
-		}
+		defer writeUntrackedFiles(t, dir, map[string]string{
+			".dvc/config.local": "['remote \"testremote\"']\n    url = /path/to/remote\n",
+		})()
+		exec.LookPath = func(file string) (string, error) { return "", fmt.Errorf("dvc not on path or something") }
+		exec.CommandOutput = exec.DefaultCommandOutput
 
 		project := api.Project{Dir: dir}
-		report, err := linter.LintProject(project)
+		report, err := linter.LintProject(context.Background(), project)
 		require.NoError(t, err)
 
 		// Then:
 		require.EqualValues(t, 100, report.Scores[versioncontrol.RuleDVC])
-		require.EqualValues(t, 100, report.Scores[versioncontrol.RuleDVCIsInstalled])
+		require.EqualValues(t, 0, report.Scores[versioncontrol.RuleDVCIsInstalled])
 		require.EqualValues(t, 100, report.Scores[versioncontrol.RuleCommitDVCFolder])
 		require.EqualValues(t, 100, report.Scores[versioncontrol.RuleDVCHasRemote])
+		require.Equal(t, "Your project has the following DVC remotes configured:\n\n- `testremote` - type **local** - `/path/to/remote`\n", report.Details[versioncontrol.RuleDVCHasRemote])
+		require.EqualValues(t, 0, report.Scores[versioncontrol.RuleDVCRemoteNotLocal])
+		require.Contains(t, report.Details[versioncontrol.RuleDVCRemoteNotLocal], "- `testremote` - type **local** - `/path/to/remote`")
 		require.EqualValues(t, 0, report.Scores[versioncontrol.RuleDVCHasFiles])
 	})
 
-	t.Run("DVC with remotes and files configured", func(t *testing.T) {
+	t.Run("DVC with remotes and files configured and uncommitted dvc.lock", func(t *testing.T) {
 		dir := "test-resources/dvc/dvc-init"
+		defer writeUntrackedFiles(t, dir, map[string]string{
+			".dvc/config.local": "[core]\n    remote = storage\n['remote \"storage\"']\n    url = s3://mybucket/dvcstore\n['remote \"testremote\"']\n    url = /path/to/remote\n",
+			"data.csv.dvc":      "outs:\n- md5: a304afb96060aad90176268345e10355\n  path: data.csv\n",
+			"dvc.lock":          "\n",
+		})()
 		exec.LookPath = func(file string) (string, error) { return "", nil }
-		exec.CommandOutput = func(cmddir, name string, args ...string) ([]byte, error) {
-			if name == "git" {
-				return exec.DefaultCommandOutput(cmddir, name, args...)
-			}
-
-			require.Equal(t, dir, cmddir)
-			require.Equal(t, name, "dvc")
-
-			if args[0] == "remote" && args[1] == "list" {
-				return []byte("testremote			/path/to/remote\n"), nil
-			}
-			if args[0] == "list" && args[1] == "." {
-				return []byte("dvcfile1\ndvcfile2\n"), nil
-			}
-			return []byte("\n"), nil
-		}
+		exec.CommandOutput = exec.DefaultCommandOutput
 
 		project := api.Project{Dir: dir}
-		report, err := linter.LintProject(project)
+		report, err := linter.LintProject(context.Background(), project)
 		require.NoError(t, err)
 
 		// Then:
@@ -195,35 +186,25 @@ func TestDVCLinter(t *testing.T) {
 		require.EqualValues(t, 100, report.Scores[versioncontrol.RuleDVCIsInstalled])
 		require.EqualValues(t, 100, report.Scores[versioncontrol.RuleCommitDVCFolder])
 		require.EqualValues(t, 100, report.Scores[versioncontrol.RuleDVCHasRemote])
+		require.Equal(t, "Your project has the following DVC remotes configured:\n\n"+
+			"- `storage` (default) - type **s3** - `s3://mybucket/dvcstore`\n"+
+			"- `testremote` - type **local** - `/path/to/remote`\n", report.Details[versioncontrol.RuleDVCHasRemote])
+		require.EqualValues(t, 100, report.Scores[versioncontrol.RuleDVCRemoteNotLocal])
 		require.EqualValues(t, 100, report.Scores[versioncontrol.RuleDVCHasFiles])
+		require.EqualValues(t, 0, report.Scores[versioncontrol.RuleCommitDVCLock])
 	})
 
-	t.Run("DVC with remotes and files configured and uncommitted dvc.lock", func(t *testing.T) {
-		dir := "test-resources/dvc/dvc-init"
-		lockfilePath := path.Join(dir, "dvc.lock")
-		require.NoError(t, ioutil.WriteFile(lockfilePath, []byte("\n"), 0644))
-		defer os.Remove(lockfilePath)
-
+	t.Run("DVC Perfect Score", func(t *testing.T) {
+		dir := "test-resources/dvc/dvc-lock-committed"
+		defer writeUntrackedFiles(t, dir, map[string]string{
+			".dvc/config.local": "['remote \"storage\"']\n    url = gs://mybucket/dvcstore\n",
+			"data.csv.dvc":      "outs:\n- md5: a304afb96060aad90176268345e10355\n  path: data.csv\n",
+		})()
 		exec.LookPath = func(file string) (string, error) { return "", nil }
-		exec.CommandOutput = func(cmddir, name string, args ...string) ([]byte, error) {
-			if name == "git" {
-				return exec.DefaultCommandOutput(cmddir, name, args...)
-			}
-
-			require.Equal(t, dir, cmddir)
-			require.Equal(t, name, "dvc")
-
-			if args[0] == "remote" && args[1] == "list" {
-				return []byte("testremote			/path/to/remote\n"), nil
-			}
-			if args[0] == "list" && args[1] == "." {
-				return []byte("dvcfile1\ndvcfile2\n"), nil
-			}
-			return []byte("\n"), nil
-		}
+		exec.CommandOutput = exec.DefaultCommandOutput
 
 		project := api.Project{Dir: dir}
-		report, err := linter.LintProject(project)
+		report, err := linter.LintProject(context.Background(), project)
 		require.NoError(t, err)
 
 		// Then:
@@ -231,40 +212,125 @@ func TestDVCLinter(t *testing.T) {
 		require.EqualValues(t, 100, report.Scores[versioncontrol.RuleDVCIsInstalled])
 		require.EqualValues(t, 100, report.Scores[versioncontrol.RuleCommitDVCFolder])
 		require.EqualValues(t, 100, report.Scores[versioncontrol.RuleDVCHasRemote])
+		require.EqualValues(t, 100, report.Scores[versioncontrol.RuleDVCRemoteNotLocal])
 		require.EqualValues(t, 100, report.Scores[versioncontrol.RuleDVCHasFiles])
-		require.EqualValues(t, 0, report.Scores[versioncontrol.RuleCommitDVCLock])
+		require.EqualValues(t, 100, report.Scores[versioncontrol.RuleCommitDVCLock])
 	})
+}
 
-	t.Run("DVC Perfect Score", func(t *testing.T) {
-		dir := "test-resources/dvc/dvc-lock-committed"
-		exec.LookPath = func(file string) (string, error) { return "", nil }
-		exec.CommandOutput = func(cmddir, name string, args ...string) ([]byte, error) {
-			if name == "git" {
-				return exec.DefaultCommandOutput(cmddir, name, args...)
-			}
-
-			require.Equal(t, dir, cmddir)
-			require.Equal(t, name, "dvc")
-
-			if args[0] == "remote" && args[1] == "list" {
-				return []byte("testremote			/path/to/remote\n"), nil
-			}
-			if args[0] == "list" && args[1] == "." {
-				return []byte("dvcfile1\ndvcfile2\n"), nil
-			}
-			return []byte("\n"), nil
+// writes the given files into the given directory, which are not tracked by Git. Returns a function that removes them again.
+func writeUntrackedFiles(t *testing.T, dir string, files map[string]string) func() {
+	for filename, contents := range files {
+		require.NoError(t, ioutil.WriteFile(path.Join(dir, filename), []byte(contents), 0644))
+	}
+	return func() {
+		for filename := range files {
+			os.Remove(path.Join(dir, filename))
 		}
+	}
+}
 
-		project := api.Project{Dir: dir}
-		report, err := linter.LintProject(project)
+const dvcPipeline = `stages:
+  prepare:
+    cmd: python prepare.py
+    deps: [prepare.py]
+    outs: [data/prepared]
+  train:
+    cmd: python train.py
+    deps: [train.py, data/prepared]
+    params: [train.lr]
+    outs: [model.pkl]
+  evaluate:
+    cmd: python evaluate.py
+    deps: [model.pkl]
+    metrics:
+      - metrics.json:
+          cache: false
+  notify:
+    cmd: echo done
+`
+
+func TestDVCPipeline(t *testing.T) {
+	linter := &versioncontrol.DVCLinter{}
+	exec.LookPath = func(file string) (string, error) { return "", fmt.Errorf("dvc not on path or something") }
+	defer func() { exec.LookPath = exec.DefaultLookPath }()
+
+	writeFiles := func(t *testing.T, files map[string]string) string {
+		dir := t.TempDir()
+		files[".dvc/config"] = "\n"
+		for filename, contents := range files {
+			require.NoError(t, os.MkdirAll(path.Dir(path.Join(dir, filename)), 0755))
+			require.NoError(t, ioutil.WriteFile(path.Join(dir, filename), []byte(contents), 0644))
+		}
+		return dir
+	}
+
+	t.Run("NoPipeline", func(t *testing.T) {
+		report, err := linter.LintProject(context.Background(), api.Project{Dir: writeFiles(t, map[string]string{})})
 		require.NoError(t, err)
+		require.EqualValues(t, 0, report.Scores[versioncontrol.RuleDVCPipeline])
+		require.NotContains(t, report.Scores, versioncontrol.RuleDVCStagesDepsOuts)
+		require.NotContains(t, report.Scores, versioncontrol.RuleDVCLockInSync)
+	})
 
-		// Then:
-		require.EqualValues(t, 100, report.Scores[versioncontrol.RuleDVC])
-		require.EqualValues(t, 100, report.Scores[versioncontrol.RuleDVCIsInstalled])
-		require.EqualValues(t, 100, report.Scores[versioncontrol.RuleCommitDVCFolder])
-		require.EqualValues(t, 100, report.Scores[versioncontrol.RuleDVCHasRemote])
-		require.EqualValues(t, 100, report.Scores[versioncontrol.RuleDVCHasFiles])
-		require.EqualValues(t, 100, report.Scores[versioncontrol.RuleCommitDVCLock])
+	t.Run("InvalidPipeline", func(t *testing.T) {
+		report, err := linter.LintProject(context.Background(), api.Project{Dir: writeFiles(t, map[string]string{"dvc.yaml": "stages: [[["})})
+		require.NoError(t, err)
+		require.EqualValues(t, 0, report.Scores[versioncontrol.RuleDVCPipeline])
+		require.Contains(t, report.Details[versioncontrol.RuleDVCPipeline], "could not be parsed")
+	})
+
+	t.Run("NoLock", func(t *testing.T) {
+		report, err := linter.LintProject(context.Background(), api.Project{Dir: writeFiles(t, map[string]string{"dvc.yaml": dvcPipeline})})
+		require.NoError(t, err)
+		require.EqualValues(t, 100, report.Scores[versioncontrol.RuleDVCPipeline])
+		require.EqualValues(t, 75, report.Scores[versioncontrol.RuleDVCStagesDepsOuts])
+		require.Equal(t, "The following stages of your pipeline do not declare all of their dependencies and outputs:\n\n"+
+			"- `notify` has no dependencies and no outputs\n", report.Details[versioncontrol.RuleDVCStagesDepsOuts])
+		require.EqualValues(t, 0, report.Scores[versioncontrol.RuleDVCParams])
+		require.Contains(t, report.Details[versioncontrol.RuleDVCParams], "does not have a `params.yaml`")
+		require.EqualValues(t, 100, report.Scores[versioncontrol.RuleDVCMetrics])
+		require.EqualValues(t, 0, report.Scores[versioncontrol.RuleDVCLockInSync])
+		require.Contains(t, report.Details[versioncontrol.RuleDVCLockInSync], "never been run")
+	})
+
+	t.Run("LockOutOfSync", func(t *testing.T) {
+		dir := writeFiles(t, map[string]string{
+			"dvc.yaml":    dvcPipeline,
+			"params.yaml": "train:\n  lr: 0.01\n",
+			// DVC 2 computes the MD5 of text files after converting Windows line endings.
+			"prepare.py": "print('preparing')\r\n",
+			"train.py":   "print('training')\n",
+			"dvc.lock": `schema: '2.0'
+stages:
+  prepare:
+    cmd: python prepare.py
+    deps:
+    - path: prepare.py
+      md5: ` + fmt.Sprintf("%x", md5.Sum([]byte("print('preparing')\n"))) + `
+    outs:
+    - path: data/prepared
+      md5: 3863d0e317dee0a55c4e59d2ec0eef33.dir
+  train:
+    cmd: python train.py --fast
+    deps:
+    - path: train.py
+      hash: md5
+      md5: 0123456789abcdef0123456789abcdef
+  featurize:
+    cmd: python featurize.py
+`,
+		})
+
+		report, err := linter.LintProject(context.Background(), api.Project{Dir: dir})
+		require.NoError(t, err)
+		require.EqualValues(t, 100, report.Scores[versioncontrol.RuleDVCParams])
+		require.EqualValues(t, 20, report.Scores[versioncontrol.RuleDVCLockInSync])
+		require.Equal(t, "Your project's `dvc.lock` is not in sync with your `dvc.yaml`:\n\n"+
+			"- `evaluate` is defined in `dvc.yaml`, but has never been run\n"+
+			"- `notify` is defined in `dvc.yaml`, but has never been run\n"+
+			"- `featurize` is recorded in `dvc.lock`, but no longer defined in `dvc.yaml`\n"+
+			"- `train` has changed since it was last run: command changed, dependency `data/prepared` added, dependency `train.py` modified\n"+
+			"\nRun `dvc repro` to run the stages that are out of date, then commit the updated `dvc.lock`.", report.Details[versioncontrol.RuleDVCLockInSync])
 	})
 }
@@ -1,8 +1,10 @@
 package versioncontrol
 
 import (
+	"context"
 	"fmt"
 	"math"
+	"sort"
 	"strings"
 
 	"github.com/dustin/go-humanize"
@@ -12,7 +14,38 @@ import (
 	"github.com/bvobart/mllint/setools/git"
 )
 
-const penaltyPerLargeFile = 25 // percent
+var ParamPenaltyPerLargeFile = api.RuleParam{
+	Slug:        RuleGitNoBigFiles.Slug,
+	Name:        "penaltyPerLargeFile",
+	Description: "Percentage that is subtracted from the rule's score for each large file in the project's Git history.",
+	Default:     25,
+	Min:         0,
+	Max:         100,
+}
+
+var ParamPenaltyPerModel = api.RuleParam{
+	Slug:        RuleGitNoModels.Slug,
+	Name:        "penaltyPerArtifact",
+	Description: "Percentage that is subtracted from the rule's score for each model artifact in the project's Git history.",
+	Default:     25,
+	Min:         0,
+	Max:         100,
+}
+
+var ParamPenaltyPerDataset = api.RuleParam{
+	Slug:        RuleGitNoData.Slug,
+	Name:        "penaltyPerArtifact",
+	Description: "Percentage that is subtracted from the rule's score for each dataset in the project's Git history.",
+	Default:     25,
+	Min:         0,
+	Max:         100,
+}
+
+// Extensions of files that are model artifacts or datasets, which should not be committed to Git regardless of their size.
+var (
+	modelExtensions = []string{".pt", ".pth", ".h5", ".onnx", ".pkl", ".joblib", ".ckpt"}
+	dataExtensions  = []string{".csv", ".parquet", ".npy"}
+)
 
 type GitLinter struct {
 	MaxFileSize uint64
@@ -23,7 +56,11 @@ func (l *GitLinter) Name() string {
 }
 
 func (l *GitLinter) Rules() []*api.Rule {
-	return []*api.Rule{&RuleGit, &RuleGitNoBigFiles}
+	return []*api.Rule{&RuleGit, &RuleGitNoBigFiles, &RuleGitNoModels, &RuleGitNoData}
+}
+
+func (l *GitLinter) RuleParams() []api.RuleParam {
+	return []api.RuleParam{ParamPenaltyPerLargeFile, ParamPenaltyPerModel, ParamPenaltyPerDataset}
 }
 
 func (l *GitLinter) Configure(conf *config.Config) error {
@@ -31,33 +68,150 @@ func (l *GitLinter) Configure(conf *config.Config) error {
 	return nil
 }
 
-func (l *GitLinter) LintProject(project api.Project) (api.Report, error) {
+func (l *GitLinter) LintProject(ctx context.Context, project api.Project) (api.Report, error) {
 	report := api.NewReport()
+	backend := git.ForProject(project)
 
 	report.Scores[RuleGit] = 100
-	if !git.Detect(project.Dir) {
+	if !backend.Detect(ctx, project.Dir) {
 		report.Scores[RuleGit] = 0
 		return report, nil
 	}
 
-	largeFiles, err := git.FindLargeFilesInHistory(project.Dir, l.MaxFileSize)
+	blobs, err := backend.ListBlobsInHistory(ctx, project.Dir)
 	if err != nil {
 		return api.Report{}, err
 	}
+	lfsPatterns, err := git.ReadLFSPatterns(backend.GetGitRoot(ctx, project.Dir))
+	if err != nil {
+		return api.Report{}, err
+	}
+	// files that are correctly stored using Git LFS are only pointers in the Git history, so they do not count.
+	if blobs, err = excludeLFSPointers(ctx, backend, project.Dir, blobs, lfsPatterns); err != nil {
+		return api.Report{}, err
+	}
 
-	report.Scores[RuleGitNoBigFiles] = math.Max(float64(100-penaltyPerLargeFile*len(largeFiles)), 0)
+	largeFiles := toFileSizes(filterBlobsBySize(blobs, l.MaxFileSize))
+	report.Scores[RuleGitNoBigFiles] = math.Max(100-ParamPenaltyPerLargeFile.Value(project)*float64(len(largeFiles)), 0)
 	if len(largeFiles) > 0 {
-		report.Details[RuleGitNoBigFiles] = l.buildDetails(largeFiles)
+		report.Details[RuleGitNoBigFiles] = l.buildDetails(largeFiles, lfsPatterns)
+	}
+
+	// every version of a file is a separate blob, but each file only counts once.
+	models := uniquePaths(filterBlobsByExtension(blobs, modelExtensions))
+	data := uniquePaths(filterBlobsByExtension(blobs, dataExtensions))
+	commits := map[string]string{}
+	if len(models) > 0 || len(data) > 0 {
+		if commits, err = backend.FindBlobCommits(ctx, project.Dir); err != nil {
+			return api.Report{}, err
+		}
+	}
+
+	report.Scores[RuleGitNoModels] = math.Max(100-ParamPenaltyPerModel.Value(project)*float64(len(models)), 0)
+	if len(models) > 0 {
+		report.Details[RuleGitNoModels] = buildArtifactDetails("model artifacts", models, commits)
+	}
+
+	report.Scores[RuleGitNoData] = math.Max(100-ParamPenaltyPerDataset.Value(project)*float64(len(data)), 0)
+	if len(data) > 0 {
+		report.Details[RuleGitNoData] = buildArtifactDetails("datasets", data, commits)
 	}
 
 	return report, nil
 }
 
-func (l *GitLinter) buildDetails(largeFiles []git.FileSize) string {
+// removes the blobs of files that are tracked with Git LFS according to the project's `.gitattributes` and were committed as LFS pointers.
+// Only the blobs of LFS-tracked files are read, since reading every small blob in the project's history to find pointers would be too slow.
+func excludeLFSPointers(ctx context.Context, backend git.Backend, dir string, blobs []git.Blob, lfsPatterns git.LFSPatterns) ([]git.Blob, error) {
+	if !lfsPatterns.Any() {
+		return blobs, nil
+	}
+
+	tracked := []git.Blob{}
+	for _, blob := range blobs {
+		if lfsPatterns.Matches(blob.Path) {
+			tracked = append(tracked, blob)
+		}
+	}
+	pointers, err := git.FindLFSPointerBlobs(ctx, backend, dir, tracked)
+	if err != nil {
+		return nil, err
+	}
+
+	result := []git.Blob{}
+	for _, blob := range blobs {
+		if !pointers[blob.Hash] {
+			result = append(result, blob)
+		}
+	}
+	return result, nil
+}
+
+func filterBlobsBySize(blobs []git.Blob, threshold uint64) []git.Blob {
+	result := []git.Blob{}
+	for _, blob := range blobs {
+		if blob.Size > threshold {
+			result = append(result, blob)
+		}
+	}
+	return result
+}
+
+// converts the given blobs to FileSizes, sorted by filesize in descending order.
+func toFileSizes(blobs []git.Blob) []git.FileSize {
+	files := []git.FileSize{}
+	for _, blob := range blobs {
+		files = append(files, git.FileSize{Path: blob.Path, CommitHash: blob.Hash, Size: blob.Size})
+	}
+
+	sort.Slice(files, func(i, j int) bool {
+		return files[i].Size > files[j].Size
+	})
+	return files
+}
+
+func filterBlobsByExtension(blobs []git.Blob, extensions []string) []git.Blob {
+	result := []git.Blob{}
+	for _, blob := range blobs {
+		if hasExtension(blob.Path, extensions) {
+			result = append(result, blob)
+		}
+	}
+	return result
+}
+
+// returns one blob for each of the paths of the given blobs, namely the oldest version of that file, since the blobs are listed newest first.
+func uniquePaths(blobs []git.Blob) []git.Blob {
+	result := []git.Blob{}
+	indices := map[string]int{}
+	for _, blob := range blobs {
+		if i, ok := indices[blob.Path]; ok {
+			result[i] = blob
+			continue
+		}
+		indices[blob.Path] = len(result)
+		result = append(result, blob)
+	}
+	return result
+}
+
+func (l *GitLinter) buildDetails(largeFiles []git.FileSize, lfsPatterns git.LFSPatterns) string {
 	details := strings.Builder{}
 	details.WriteString(fmt.Sprintf("Your project's Git history contains the following files that are larger than %s:\n", humanize.Bytes(l.MaxFileSize)))
+	committedWithoutLFS := false
 	for _, file := range largeFiles {
-		details.WriteString(fmt.Sprintf("- **%s** - commit `%s` - `%s`\n", humanize.Bytes(file.Size), file.CommitHash, file.Path))
+		lfsNote := ""
+		if lfsPatterns.Matches(file.Path) {
+			lfsNote = " (**not** stored using Git LFS)"
+			committedWithoutLFS = true
+		}
+		details.WriteString(fmt.Sprintf("- **%s** - commit `%s` - `%s`%s\n", humanize.Bytes(file.Size), file.CommitHash, file.Path, lfsNote))
+	}
+	if committedWithoutLFS {
+		details.WriteString(`
+Some of these files should be stored using Git LFS according to your project's ` + "`.gitattributes`" + `, but were committed directly into Git,
+e.g. because they were committed before they were tracked with Git LFS, or because Git LFS was not installed on the machine they were committed from.
+`)
 	}
 	details.WriteString(`
 These files may not necessarily be in your project right now, but they are still stored inside your project's Git history.
@@ -66,3 +220,24 @@ Thus, whenever your project is downloaded (with ` + "`git clone`" + `), all thes
 See [this StackOverflow answer](https://stackoverflow.com/a/46615578/8059181) to learn how to remove these files from your project's Git history.`)
 	return details.String()
 }
+
+func buildArtifactDetails(kind string, blobs []git.Blob, commits map[string]string) string {
+	details := strings.Builder{}
+	details.WriteString(fmt.Sprintf("Your project's Git history contains the following %s:\n", kind))
+	for _, blob := range blobs {
+		details.WriteString(fmt.Sprintf("- **%s** - commit `%s` - `%s`\n", humanize.Bytes(blob.Size), commitOrBlob(blob, commits), blob.Path))
+	}
+	details.WriteString(`
+These files may not necessarily be in your project right now, but they are still stored inside your project's Git history.
+Version control them with DVC or Git LFS instead, and see [this StackOverflow answer](https://stackoverflow.com/a/46615578/8059181) to learn how to remove these files from your project's Git history.`)
+	return details.String()
+}
+
+// returns the hash of the commit that introduced the given blob, or the hash of the blob itself if that commit is unknown,
+// e.g. because the blob is only referenced by a stash.
+func commitOrBlob(blob git.Blob, commits map[string]string) string {
+	if commit, ok := commits[blob.Hash]; ok {
+		return commit
+	}
+	return blob.Hash
+}
@@ -1,12 +1,18 @@
 package versioncontrol_test
 
 import (
+	"context"
+	"io/ioutil"
 	"os"
+	"path"
+	"strings"
 	"testing"
 
 	"github.com/bvobart/mllint/api"
 	"github.com/bvobart/mllint/config"
 	"github.com/bvobart/mllint/linters/versioncontrol"
+	"github.com/bvobart/mllint/setools/git"
+	"github.com/bvobart/mllint/utils/exec"
 	"github.com/stretchr/testify/require"
 )
 
@@ -17,7 +23,7 @@ func TestGitName(t *testing.T) {
 
 func TestGitRules(t *testing.T) {
 	linter := &versioncontrol.GitLinter{}
-	require.Equal(t, []*api.Rule{&versioncontrol.RuleGit, &versioncontrol.RuleGitNoBigFiles}, linter.Rules())
+	require.Equal(t, []*api.Rule{&versioncontrol.RuleGit, &versioncontrol.RuleGitNoBigFiles, &versioncontrol.RuleGitNoModels, &versioncontrol.RuleGitNoData}, linter.Rules())
 }
 
 func TestGitConfigure(t *testing.T) {
@@ -31,12 +37,12 @@ func TestGitConfigure(t *testing.T) {
 func TestProjectUsesGit(t *testing.T) {
 	linter := &versioncontrol.GitLinter{}
 	project := api.Project{Dir: "."}
-	report, err := linter.LintProject(project)
+	report, err := linter.LintProject(context.Background(), project)
 	require.NoError(t, err)
 	require.EqualValues(t, 100, report.Scores[versioncontrol.RuleGit])
 
 	project = api.Project{Dir: os.TempDir()}
-	report, err = linter.LintProject(project)
+	report, err = linter.LintProject(context.Background(), project)
 	require.NoError(t, err)
 	require.EqualValues(t, 0, report.Scores[versioncontrol.RuleGit])
 }
@@ -47,9 +53,162 @@ func TestGitNoBigFiles(t *testing.T) {
 	}
 
 	project := api.Project{Dir: "."}
-	report, err := linter.LintProject(project)
+	report, err := linter.LintProject(context.Background(), project)
 	require.NoError(t, err)
 	require.EqualValues(t, 100, report.Scores[versioncontrol.RuleGit])
 
 	// TODO: add test for when there are files larger than threshold.
 }
+
+func TestGitNoModelsAndData(t *testing.T) {
+	dir, commits := createGitRepo(t, map[string]string{
+		"train.py":           "print('training')",
+		"models/model.PKL":   "pickled model",
+		"data/train.csv":     "a,b\n1,2\n",
+		"data/features.json": "{}",
+	}, map[string]string{
+		"models/model.PKL": "",
+		"data/train.csv":   "",
+		"models/model.pt":  "torch model",
+	})
+	defer os.RemoveAll(dir)
+
+	linter := &versioncontrol.GitLinter{MaxFileSize: 10_000_000}
+	report, err := linter.LintProject(context.Background(), api.Project{Dir: dir})
+	require.NoError(t, err)
+	require.EqualValues(t, 100, report.Scores[versioncontrol.RuleGitNoBigFiles])
+
+	require.EqualValues(t, 50, report.Scores[versioncontrol.RuleGitNoModels])
+	require.Contains(t, report.Details[versioncontrol.RuleGitNoModels], "- **13 B** - commit `"+commits[0]+"` - `models/model.PKL`")
+	require.Contains(t, report.Details[versioncontrol.RuleGitNoModels], "- **11 B** - commit `"+commits[1]+"` - `models/model.pt`")
+
+	require.EqualValues(t, 75, report.Scores[versioncontrol.RuleGitNoData])
+	require.Contains(t, report.Details[versioncontrol.RuleGitNoData], "- **8 B** - commit `"+commits[0]+"` - `data/train.csv`")
+	require.NotContains(t, report.Details[versioncontrol.RuleGitNoData], "features.json")
+}
+
+func TestGitNoModelsCountsEachFileOnce(t *testing.T) {
+	dir, commits := createGitRepo(t, map[string]string{
+		"models/model.pkl": "first model",
+	}, map[string]string{
+		"models/model.pkl": "second model",
+	}, map[string]string{
+		"models/model.pkl": "third model",
+	})
+	defer os.RemoveAll(dir)
+
+	linter := &versioncontrol.GitLinter{MaxFileSize: 10_000_000}
+	report, err := linter.LintProject(context.Background(), api.Project{Dir: dir})
+	require.NoError(t, err)
+
+	// three versions of the same model are still only one model in the Git history, reported with the commit that introduced it.
+	require.EqualValues(t, 75, report.Scores[versioncontrol.RuleGitNoModels])
+	require.Equal(t, 1, strings.Count(report.Details[versioncontrol.RuleGitNoModels], "- **"))
+	require.Contains(t, report.Details[versioncontrol.RuleGitNoModels], "commit `"+commits[0]+"` - `models/model.pkl`")
+}
+
+// createGitRepo creates a Git repository in a new temporary directory, with a commit for each of the given sets of files.
+// Each set maps filenames to their contents, where empty contents means that the file is deleted in that commit.
+// Returns the directory and the hashes of the commits.
+func createGitRepo(t *testing.T, commits ...map[string]string) (string, []string) {
+	dir, err := ioutil.TempDir(os.TempDir(), "mllint-tests-versioncontrol")
+	require.NoError(t, err)
+	_, err = exec.CommandOutput(context.Background(), dir, "git", "init")
+	require.NoError(t, err)
+
+	hashes := []string{}
+	for i, files := range commits {
+		for filename, contents := range files {
+			file := path.Join(dir, filename)
+			if contents == "" {
+				require.NoError(t, os.Remove(file))
+				continue
+			}
+			require.NoError(t, os.MkdirAll(path.Dir(file), 0755))
+			require.NoError(t, ioutil.WriteFile(file, []byte(contents), 0644))
+		}
+
+		_, err = exec.CommandOutput(context.Background(), dir, "git", "add", "-A")
+		require.NoError(t, err)
+		_, err = exec.CommandOutput(context.Background(), dir, "git", "-c", "user.name=mllint", "-c", "user.email=mllint@example.com", "commit", "-m", "commit "+strings.Repeat("I", i+1))
+		require.NoError(t, err)
+
+		hash, err := git.GetCurrentCommit(context.Background(), dir)
+		require.NoError(t, err)
+		hashes = append(hashes, hash)
+	}
+
+	return dir, hashes
+}
+
+func TestGitLFSPointersExcluded(t *testing.T) {
+	dir, commits := createGitRepo(t, map[string]string{
+		".gitattributes":  "*.pt filter=lfs diff=lfs merge=lfs -text\n",
+		"models/model.pt": strings.Repeat("not a pointer", 100),
+	}, map[string]string{
+		"models/model.pt": lfsPointer,
+	})
+	defer os.RemoveAll(dir)
+
+	linter := &versioncontrol.GitLinter{MaxFileSize: 100}
+	report, err := linter.LintProject(context.Background(), api.Project{Dir: dir})
+	require.NoError(t, err)
+
+	// only the version of the model that was committed before it was stored using Git LFS counts.
+	require.EqualValues(t, 75, report.Scores[versioncontrol.RuleGitNoBigFiles])
+	require.Contains(t, report.Details[versioncontrol.RuleGitNoBigFiles], "- **1.3 kB** - commit `")
+	require.Contains(t, report.Details[versioncontrol.RuleGitNoBigFiles], "`models/model.pt` (**not** stored using Git LFS)")
+	require.Contains(t, report.Details[versioncontrol.RuleGitNoBigFiles], "but were committed directly into Git")
+	require.Equal(t, 1, strings.Count(report.Details[versioncontrol.RuleGitNoBigFiles], "- **"))
+
+	require.EqualValues(t, 75, report.Scores[versioncontrol.RuleGitNoModels])
+	require.Contains(t, report.Details[versioncontrol.RuleGitNoModels], "- **1.3 kB** - commit `"+commits[0]+"` - `models/model.pt`")
+	require.NotContains(t, report.Details[versioncontrol.RuleGitNoModels], commits[1])
+}
+
+func TestGitLFSTrackedLargeFiles(t *testing.T) {
+	large := strings.Repeat("a,b,c\n", 1000)
+	dir, commits := createGitRepo(t, map[string]string{
+		".gitattributes":  "data/** filter=lfs diff=lfs merge=lfs -text\n",
+		"data/train.csv":  large,
+		"data/README.txt": lfsPointer,
+	}, map[string]string{
+		"data/train.csv": lfsPointer,
+		"data/test.csv":  lfsPointer,
+	})
+	defer os.RemoveAll(dir)
+
+	// the LFS pointers are far smaller than the maximum file size, so only the data that was committed without LFS counts.
+	linter := &versioncontrol.GitLinter{MaxFileSize: 5000}
+	report, err := linter.LintProject(context.Background(), api.Project{Dir: dir})
+	require.NoError(t, err)
+
+	require.EqualValues(t, 75, report.Scores[versioncontrol.RuleGitNoBigFiles])
+	require.Contains(t, report.Details[versioncontrol.RuleGitNoBigFiles], "`data/train.csv` (**not** stored using Git LFS)")
+	require.Equal(t, 1, strings.Count(report.Details[versioncontrol.RuleGitNoBigFiles], "- **"))
+
+	require.EqualValues(t, 75, report.Scores[versioncontrol.RuleGitNoData])
+	require.Contains(t, report.Details[versioncontrol.RuleGitNoData], "`data/train.csv`")
+	require.NotContains(t, report.Details[versioncontrol.RuleGitNoData], "`data/test.csv`")
+	require.NotContains(t, report.Details[versioncontrol.RuleGitNoData], commits[1])
+
+	t.Run("SubProject", func(t *testing.T) {
+		dir, _ := createGitRepo(t, map[string]string{
+			".gitattributes":     "*.csv filter=lfs diff=lfs merge=lfs -text\n",
+			"sub/data/train.csv": large,
+			"sub/train.py":       "print('training')",
+		}, map[string]string{
+			"sub/data/train.csv": lfsPointer,
+			"sub/data/test.csv":  lfsPointer,
+		})
+		defer os.RemoveAll(dir)
+
+		report, err := linter.LintProject(context.Background(), api.Project{Dir: path.Join(dir, "sub")})
+		require.NoError(t, err)
+
+		require.EqualValues(t, 75, report.Scores[versioncontrol.RuleGitNoBigFiles])
+		require.Contains(t, report.Details[versioncontrol.RuleGitNoBigFiles], "`sub/data/train.csv` (**not** stored using Git LFS)")
+		require.EqualValues(t, 75, report.Scores[versioncontrol.RuleGitNoData])
+		require.NotContains(t, report.Details[versioncontrol.RuleGitNoData], "`sub/data/test.csv`")
+	})
+}
@@ -0,0 +1,279 @@
+package versioncontrol
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/bvobart/mllint/api"
+	"github.com/bvobart/mllint/config"
+	"github.com/bvobart/mllint/setools/dvc"
+	"github.com/bvobart/mllint/setools/git"
+	"github.com/bvobart/mllint/utils"
+	"github.com/bvobart/mllint/utils/markdowngen"
+)
+
+// ignoreHazard is a kind of file commonly found in ML projects that should be ignored by Git.
+type ignoreHazard struct {
+	Name string
+	// Directories that should be ignored wherever they are in the project, e.g. `__pycache__`
+	Dirs []string
+	// Files that should be ignored wherever they are in the project, e.g. `.env`
+	Files []string
+	// Extensions of files that should be ignored, e.g. `.pyc`
+	Extensions []string
+	// Paths relative to the project's root that should be ignored, e.g. DVC-managed data.
+	Paths []string
+}
+
+// Kinds of files that are commonly generated in ML projects and should not be committed to Git.
+var ignoreHazards = []ignoreHazard{
+	{Name: "Python virtual environments", Dirs: []string{".venv", "venv"}},
+	{Name: "Python bytecode caches", Dirs: []string{"__pycache__"}, Extensions: []string{".pyc"}},
+	{Name: "Jupyter Notebook checkpoints", Dirs: []string{".ipynb_checkpoints"}},
+	{Name: "MLflow runs", Dirs: []string{"mlruns"}},
+	{Name: "Weights & Biases runs", Dirs: []string{"wandb"}},
+	{Name: "PyTorch Lightning logs", Dirs: []string{"lightning_logs"}},
+	{Name: "Environment files", Files: []string{".env"}},
+}
+
+// Folders in which ML projects commonly store their model outputs and checkpoints. These only need to be ignored when they exist.
+var defaultOutputDirs = []string{"outputs", "checkpoints"}
+
+// Lines returns the lines that should be in a `.gitignore` file in order to ignore this hazard.
+func (h ignoreHazard) Lines() []string {
+	lines := []string{}
+	for _, dir := range h.Dirs {
+		lines = append(lines, dir+"/")
+	}
+	lines = append(lines, h.Files...)
+	for _, ext := range h.Extensions {
+		lines = append(lines, "*"+ext)
+	}
+	for _, p := range h.Paths {
+		lines = append(lines, "/"+p)
+	}
+	return lines
+}
+
+// samples returns a path (relative to the project's root) for each of the lines returned by Lines(),
+// that Git should ignore if that line were in the project's `.gitignore`.
+func (h ignoreHazard) samples() []string {
+	samples := []string{}
+	for _, dir := range h.Dirs {
+		samples = append(samples, dir+"/file")
+	}
+	samples = append(samples, h.Files...)
+	for _, ext := range h.Extensions {
+		samples = append(samples, "file"+ext)
+	}
+	for _, p := range h.Paths {
+		// Git also ignores a file when one of its parent directories is ignored, so this works for both files and folders.
+		samples = append(samples, p+"/file")
+	}
+	return samples
+}
+
+// Matches returns whether the given file, relative to the project's root, is one of the files that this hazard describes.
+func (h ignoreHazard) Matches(filename string) bool {
+	parts := strings.Split(filename, "/")
+	for _, dir := range h.Dirs {
+		for _, part := range parts[:len(parts)-1] {
+			if part == dir {
+				return true
+			}
+		}
+	}
+	for _, file := range h.Files {
+		if parts[len(parts)-1] == file {
+			return true
+		}
+	}
+	for _, p := range h.Paths {
+		if filename == p || strings.HasPrefix(filename, p+"/") {
+			return true
+		}
+	}
+	return hasExtension(filename, h.Extensions)
+}
+
+// GitignoreLinter is a linter that checks whether the project's `.gitignore` files cover the files that are commonly generated
+// in ML projects and should not be committed to Git, and that none of these files are currently being tracked.
+type GitignoreLinter struct {
+	OutputDirs []string
+}
+
+func (l *GitignoreLinter) Name() string {
+	return "Gitignore"
+}
+
+func (l *GitignoreLinter) Rules() []*api.Rule {
+	return []*api.Rule{&RuleGitignore}
+}
+
+func (l *GitignoreLinter) Configure(conf *config.Config) error {
+	l.OutputDirs = conf.Git.OutputDirs
+	return nil
+}
+
+func (l *GitignoreLinter) LintProject(ctx context.Context, project api.Project) (api.Report, error) {
+	report := api.NewReport()
+	backend := git.ForProject(project)
+	if !backend.Detect(ctx, project.Dir) {
+		return report, nil
+	}
+
+	trackedFiles, err := backend.ListTrackedFiles(ctx, project.Dir)
+	if err != nil {
+		return report, err
+	}
+	trackedFiles, err = relativeToProject(backend.GetGitRoot(ctx, project.Dir), project.Dir, trackedFiles)
+	if err != nil {
+		return report, err
+	}
+
+	hazards := append([]ignoreHazard{}, ignoreHazards...)
+	if outputDirs := l.findOutputDirs(project.Dir, trackedFiles); len(outputDirs) > 0 {
+		hazards = append(hazards, ignoreHazard{Name: "Model outputs and checkpoints", Paths: outputDirs})
+	}
+	if dvcData := findDVCData(project.Dir, trackedFiles); len(dvcData) > 0 {
+		hazards = append(hazards, ignoreHazard{Name: "DVC-managed data", Paths: dvcData})
+	}
+
+	missingLines := []string{}
+	trackedHazards := []interface{}{}
+	failed := 0
+	for _, hazard := range hazards {
+		missing, err := missingIgnoreLines(ctx, backend, project.Dir, hazard)
+		if err != nil {
+			return report, err
+		}
+		tracked := []string{}
+		for _, file := range trackedFiles {
+			if hazard.Matches(file) {
+				tracked = append(tracked, "`"+file+"`")
+			}
+		}
+
+		if len(missing) > 0 || len(tracked) > 0 {
+			failed++
+		}
+		if len(missing) > 0 {
+			missingLines = append(missingLines, "# "+hazard.Name)
+			missingLines = append(missingLines, missing...)
+		}
+		if len(tracked) > 0 {
+			trackedHazards = append(trackedHazards, fmt.Sprintf("%s: %s", hazard.Name, strings.Join(tracked, ", ")))
+		}
+	}
+
+	report.Scores[RuleGitignore] = 100 * float64(len(hazards)-failed) / float64(len(hazards))
+	if failed > 0 {
+		report.Details[RuleGitignore] = buildGitignoreDetails(missingLines, trackedHazards)
+	}
+	return report, nil
+}
+
+// returns the lines of the given hazard that are missing from the project's `.gitignore` files.
+func missingIgnoreLines(ctx context.Context, backend git.Backend, projectdir string, hazard ignoreHazard) ([]string, error) {
+	lines := hazard.Lines()
+	samples := hazard.samples()
+	absSamples := make([]string, len(samples))
+	for i, sample := range samples {
+		absSamples[i] = path.Join(projectdir, sample)
+	}
+
+	filtered, err := backend.FilterIgnored(ctx, projectdir, absSamples)
+	if err != nil {
+		return nil, err
+	}
+	notIgnored := map[string]bool{}
+	for _, sample := range filtered {
+		notIgnored[sample] = true
+	}
+
+	missing := []string{}
+	for i, sample := range absSamples {
+		if notIgnored[sample] {
+			missing = append(missing, lines[i])
+		}
+	}
+	return missing, nil
+}
+
+// returns the configured output folders, along with those of the default output folders that exist in the project or contain tracked files.
+func (l *GitignoreLinter) findOutputDirs(projectdir string, trackedFiles []string) []string {
+	dirs := []string{}
+	seen := map[string]bool{}
+	for _, dir := range l.OutputDirs {
+		dir = strings.Trim(path.Clean(filepath.ToSlash(dir)), "/")
+		if dir != "" && dir != "." && !seen[dir] {
+			seen[dir] = true
+			dirs = append(dirs, dir)
+		}
+	}
+
+	for _, dir := range defaultOutputDirs {
+		if !seen[dir] && (utils.FolderExists(filepath.Join(projectdir, dir)) || containsFileIn(trackedFiles, dir)) {
+			seen[dir] = true
+			dirs = append(dirs, dir)
+		}
+	}
+	return dirs
+}
+
+// returns whether any of the given files, relative to the project's root, is inside the given folder.
+func containsFileIn(files []string, dir string) bool {
+	for _, file := range files {
+		if strings.HasPrefix(file, dir+"/") {
+			return true
+		}
+	}
+	return false
+}
+
+// finds the paths of the data tracked by DVC, i.e. the outputs of all `.dvc` files in the project, relative to the project's root.
+func findDVCData(projectdir string, trackedFiles []string) []string {
+	paths := []string{}
+	for _, file := range trackedFiles {
+		if path.Ext(file) != dvc.FileExtension || strings.HasPrefix(file, ".dvc/") {
+			continue
+		}
+
+		dvcFile, err := dvc.ParseFile(projectdir, file)
+		if err != nil {
+			continue
+		}
+		paths = append(paths, dvcFile.OutPaths()...)
+	}
+	return paths
+}
+
+// converts the given filenames, relative to the given root of the Git repository, to be relative to the project's directory.
+func relativeToProject(root string, projectdir string, files []string) ([]string, error) {
+	result := []string{}
+	for _, file := range files {
+		relpath, err := filepath.Rel(projectdir, filepath.Join(root, file))
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, filepath.ToSlash(relpath))
+	}
+	return result, nil
+}
+
+func buildGitignoreDetails(missingLines []string, trackedHazards []interface{}) string {
+	details := strings.Builder{}
+	if len(missingLines) > 0 {
+		details.WriteString("Your project's `.gitignore` does not ignore all files that are commonly generated in ML projects. Add the following lines to your project's `.gitignore`:\n\n")
+		details.WriteString("```gitignore\n" + strings.Join(missingLines, "\n") + "\n```\n\n")
+	}
+	if len(trackedHazards) > 0 {
+		details.WriteString("Your project's Git repository is currently tracking the following files that should be ignored:\n\n")
+		details.WriteString(markdowngen.List(trackedHazards))
+		details.WriteString("\nAfter adding them to your `.gitignore`, stop tracking these files with `git rm -r --cached <file>` and commit the result.")
+	}
+	return strings.TrimSpace(details.String())
+}
@@ -0,0 +1,137 @@
+package versioncontrol_test
+
+import (
+	"context"
+	"os"
+	"path"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/bvobart/mllint/api"
+	"github.com/bvobart/mllint/config"
+	"github.com/bvobart/mllint/linters/versioncontrol"
+)
+
+const fullGitignore = `.venv/
+venv/
+__pycache__/
+*.pyc
+.ipynb_checkpoints/
+mlruns/
+wandb/
+lightning_logs/
+/outputs/
+/checkpoints/
+.env
+`
+
+func TestGitignoreName(t *testing.T) {
+	linter := &versioncontrol.GitignoreLinter{}
+	require.Equal(t, "Gitignore", linter.Name())
+}
+
+func TestGitignoreRules(t *testing.T) {
+	linter := &versioncontrol.GitignoreLinter{}
+	require.Equal(t, []*api.Rule{&versioncontrol.RuleGitignore}, linter.Rules())
+}
+
+func TestGitignore(t *testing.T) {
+	linter := &versioncontrol.GitignoreLinter{}
+
+	t.Run("NoGit", func(t *testing.T) {
+		report, err := linter.LintProject(context.Background(), api.Project{Dir: t.TempDir()})
+		require.NoError(t, err)
+		require.NotContains(t, report.Scores, versioncontrol.RuleGitignore)
+	})
+
+	t.Run("Complete", func(t *testing.T) {
+		dir, _ := createGitRepo(t, map[string]string{
+			".gitignore":         fullGitignore,
+			"data/.gitignore":    "/train.csv\n",
+			"data/train.csv.dvc": "outs:\n- md5: 1234\n  path: train.csv\n",
+			"train.py":           "print('training')",
+		})
+		defer os.RemoveAll(dir)
+
+		report, err := linter.LintProject(context.Background(), api.Project{Dir: dir})
+		require.NoError(t, err)
+		require.EqualValues(t, 100, report.Scores[versioncontrol.RuleGitignore])
+		require.NotContains(t, report.Details, versioncontrol.RuleGitignore)
+	})
+
+	t.Run("Missing", func(t *testing.T) {
+		dir, _ := createGitRepo(t, map[string]string{
+			".gitignore":         "venv/\n__pycache__/\n*.pyc\n.ipynb_checkpoints/\nmlruns/\nwandb/\nlightning_logs/\n",
+			"data/train.csv.dvc": "outs:\n- md5: 1234\n  path: train.csv\n",
+			"train.py":           "print('training')",
+		})
+		defer os.RemoveAll(dir)
+
+		report, err := linter.LintProject(context.Background(), api.Project{Dir: dir})
+		require.NoError(t, err)
+		require.EqualValues(t, 100*5/8.0, report.Scores[versioncontrol.RuleGitignore])
+		details := report.Details[versioncontrol.RuleGitignore]
+		require.Contains(t, details, "```gitignore\n# Python virtual environments\n.venv/\n# Environment files\n.env\n# DVC-managed data\n/data/train.csv\n```")
+		require.NotContains(t, details, "currently tracking")
+	})
+
+	t.Run("OutputDirs", func(t *testing.T) {
+		dir, _ := createGitRepo(t, map[string]string{
+			".gitignore": "venv/\n.venv/\n__pycache__/\n*.pyc\n.ipynb_checkpoints/\nmlruns/\nwandb/\nlightning_logs/\n.env\n/outputs/\n",
+			"train.py":   "print('training')",
+		})
+		defer os.RemoveAll(dir)
+		require.NoError(t, os.MkdirAll(path.Join(dir, "checkpoints"), 0755))
+
+		linter := &versioncontrol.GitignoreLinter{}
+		conf := config.Default()
+		conf.Git.OutputDirs = []string{"models/runs/", "./checkpoints"}
+		require.NoError(t, linter.Configure(conf))
+
+		report, err := linter.LintProject(context.Background(), api.Project{Dir: dir})
+		require.NoError(t, err)
+		require.EqualValues(t, 100*7/8.0, report.Scores[versioncontrol.RuleGitignore])
+		details := report.Details[versioncontrol.RuleGitignore]
+		require.Contains(t, details, "```gitignore\n# Model outputs and checkpoints\n/models/runs\n/checkpoints\n```")
+		require.NotContains(t, details, "/outputs")
+	})
+
+	t.Run("SubProject", func(t *testing.T) {
+		dir, _ := createGitRepo(t, map[string]string{
+			"sub/train.py":              "print('training')",
+			"sub/outputs/model/best.pt": "weights",
+		})
+		defer os.RemoveAll(dir)
+		require.NoError(t, os.WriteFile(path.Join(dir, ".gitignore"), []byte(fullGitignore), 0644))
+
+		report, err := linter.LintProject(context.Background(), api.Project{Dir: path.Join(dir, "sub")})
+		require.NoError(t, err)
+		require.EqualValues(t, 100*7/8.0, report.Scores[versioncontrol.RuleGitignore])
+		details := report.Details[versioncontrol.RuleGitignore]
+		require.Contains(t, details, "- Model outputs and checkpoints: `outputs/model/best.pt`\n")
+	})
+
+	t.Run("Tracked", func(t *testing.T) {
+		dir, _ := createGitRepo(t, map[string]string{
+			".gitignore":               "mlruns/\n",
+			".env":                     "WANDB_API_KEY=abc",
+			"src/__pycache__/x.pyc":    "bytecode",
+			"data/train.csv":           "a,b\n1,2\n",
+			"data/train.csv.dvc":       "outs:\n- md5: 1234\n  path: train.csv\n",
+			"train.py":                 "print('training')",
+			"outputs/model/weights.pt": "weights",
+		})
+		defer os.RemoveAll(dir)
+
+		report, err := linter.LintProject(context.Background(), api.Project{Dir: dir})
+		require.NoError(t, err)
+		require.EqualValues(t, 100*1/9.0, report.Scores[versioncontrol.RuleGitignore])
+		details := report.Details[versioncontrol.RuleGitignore]
+		require.Contains(t, details, "- Python bytecode caches: `src/__pycache__/x.pyc`\n")
+		require.Contains(t, details, "- Model outputs and checkpoints: `outputs/model/weights.pt`\n")
+		require.Contains(t, details, "- Environment files: `.env`\n")
+		require.Contains(t, details, "- DVC-managed data: `data/train.csv`\n")
+		require.Contains(t, details, "`git rm -r --cached <file>`")
+	})
+}
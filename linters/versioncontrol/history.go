@@ -0,0 +1,273 @@
+package versioncontrol
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/bvobart/mllint/api"
+	"github.com/bvobart/mllint/config"
+	"github.com/bvobart/mllint/setools/git"
+	"github.com/bvobart/mllint/utils/markdowngen"
+)
+
+// Matches commit subjects following the Conventional Commits specification, e.g. `feat(data): add preprocessing step`
+// See https://www.conventionalcommits.org/
+const conventionalCommitsPattern = `^[a-z]+(\([\w\-./ ]+\))?!?: \S`
+
+// Matches the subjects of commits that were squash-merged from a pull request on GitHub or GitLab, e.g. `Add preprocessing step (#42)`
+var squashMergePattern = regexp.MustCompile(`\((#|!)\d+\)$`)
+
+// Maximum amount of offending commits listed in the details of each rule.
+const maxHistoryOffenders = 10
+
+// Extensions of files that contain code.
+var codeExtensions = []string{".py", ".ipynb"}
+
+// HistoryLinter is a linter that analyses the project's recent Git history, checking the project's commit messages,
+// the size and contents of its commits, whether changes are merged into the default branch, and whether commits are signed.
+type HistoryLinter struct {
+	Config         config.GitHistoryConfig
+	MessagePattern *regexp.Regexp
+}
+
+func (l *HistoryLinter) Name() string {
+	return "Git History"
+}
+
+func (l *HistoryLinter) Rules() []*api.Rule {
+	return []*api.Rule{&RuleCommitMessages, &RuleCommitSize, &RuleMergeWorkflow, &RuleSignedCommits}
+}
+
+func (l *HistoryLinter) Configure(conf *config.Config) (err error) {
+	l.Config = conf.Git.History
+	pattern := l.Config.MessagePattern
+	if pattern == "" {
+		pattern = conventionalCommitsPattern
+	}
+
+	if l.MessagePattern, err = regexp.Compile(pattern); err != nil {
+		return fmt.Errorf("invalid regular expression `%s` in git.history.messagePattern: %w", pattern, err)
+	}
+	return nil
+}
+
+func (l *HistoryLinter) LintProject(ctx context.Context, project api.Project) (api.Report, error) {
+	report := api.NewReport()
+	backend := git.ForProject(project)
+	if !backend.Detect(ctx, project.Dir) {
+		return report, nil
+	}
+	// the repository does not have any commits yet.
+	if _, err := backend.GetCurrentCommit(ctx, project.Dir); err != nil {
+		return report, nil
+	}
+
+	if l.MessagePattern == nil {
+		l.MessagePattern = regexp.MustCompile(conventionalCommitsPattern)
+	}
+
+	commits, err := backend.ListCommits(ctx, project.Dir, git.LogOptions{Limit: int(l.Config.Window)})
+	if err != nil {
+		return api.Report{}, err
+	}
+	l.lintMessages(commits, &report)
+	l.lintSize(commits, &report)
+	l.lintSigned(commits, &report)
+
+	defaultBranch := backend.GetDefaultBranch(ctx, project.Dir)
+	if defaultBranch == "" {
+		defaultBranch = project.Git.Branch
+	}
+	mainline, err := l.listMainline(ctx, backend, project.Dir, defaultBranch)
+	if err != nil {
+		return api.Report{}, err
+	}
+	l.lintMergeWorkflow(defaultBranch, mainline, &report)
+
+	return report, nil
+}
+
+// lists the commits made on the default branch with the given name. When the repository was cloned with `git clone -b <branch>`,
+// the default branch only exists on the `origin` remote, so the commits are listed from `origin/<branch>` instead.
+// Returns no commits when neither exists, e.g. in a shallow clone of another branch, such that the merge workflow is not checked.
+func (l *HistoryLinter) listMainline(ctx context.Context, backend git.Backend, dir string, branch string) ([]git.Commit, error) {
+	refs := []string{branch}
+	if branch != "" {
+		refs = append(refs, "origin/"+branch)
+	}
+
+	for _, ref := range refs {
+		mainline, err := backend.ListCommits(ctx, dir, git.LogOptions{Ref: ref, Limit: int(l.Config.Window), FirstParent: true})
+		if err == nil {
+			return mainline, nil
+		}
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+	}
+	return []git.Commit{}, nil
+}
+
+func (l *HistoryLinter) lintMessages(commits []git.Commit, report *api.Report) {
+	total := 0
+	offenders := []git.Commit{}
+	for _, commit := range commits {
+		if commit.IsMerge() {
+			continue
+		}
+
+		total++
+		if !l.MessagePattern.MatchString(commit.Subject()) {
+			offenders = append(offenders, commit)
+		}
+	}
+	if total == 0 {
+		return
+	}
+
+	report.Scores[RuleCommitMessages] = 100 * float64(total-len(offenders)) / float64(total)
+	details := fmt.Sprintf("%d out of the last %d non-merge commits in your project have a message whose subject matches `%s`.", total-len(offenders), total, l.MessagePattern.String())
+	if len(offenders) > 0 {
+		details += "\n\nThe most recent commits whose subject does not match are:\n\n" + listCommits(offenders, func(c git.Commit) string {
+			return fmt.Sprintf("`%s` - %s", shortHash(c.Hash), c.Subject())
+		})
+	}
+	report.Details[RuleCommitMessages] = details
+}
+
+// commitSizeIssue describes why a commit is considered too large.
+type commitSizeIssue struct {
+	git.Commit
+	Reasons []string
+}
+
+func (l *HistoryLinter) lintSize(commits []git.Commit, report *api.Report) {
+	total := 0
+	offenders := []commitSizeIssue{}
+	for _, commit := range commits {
+		if commit.IsMerge() {
+			continue
+		}
+
+		total++
+		reasons := []string{}
+		if lines := commit.LinesChanged(); uint64(lines) > l.Config.MaxCommitSize {
+			reasons = append(reasons, fmt.Sprintf("changes %d lines", lines))
+		}
+		if code, data := countCodeAndData(commit); code > 0 && data > 0 {
+			reasons = append(reasons, fmt.Sprintf("mixes %d code file(s) with %d data file(s)", code, data))
+		}
+		if len(reasons) > 0 {
+			offenders = append(offenders, commitSizeIssue{commit, reasons})
+		}
+	}
+	if total == 0 {
+		return
+	}
+
+	report.Scores[RuleCommitSize] = 100 * float64(total-len(offenders)) / float64(total)
+	if len(offenders) == 0 {
+		report.Details[RuleCommitSize] = fmt.Sprintf("None of the last %d non-merge commits in your project change more than %d lines or mix code with data.", total, l.Config.MaxCommitSize)
+		return
+	}
+
+	sort.SliceStable(offenders, func(i, j int) bool {
+		return offenders[i].LinesChanged() > offenders[j].LinesChanged()
+	})
+	items := []interface{}{}
+	for i, offender := range offenders {
+		if i == maxHistoryOffenders {
+			break
+		}
+		items = append(items, fmt.Sprintf("`%s` - %s - %s", shortHash(offender.Hash), offender.Subject(), strings.Join(offender.Reasons, ", ")))
+	}
+	report.Details[RuleCommitSize] = fmt.Sprintf("%d out of the last %d non-merge commits in your project change more than %d lines or mix code with data. The largest of these are:\n\n",
+		len(offenders), total, l.Config.MaxCommitSize) + markdowngen.List(items)
+}
+
+func (l *HistoryLinter) lintMergeWorkflow(branch string, mainline []git.Commit, report *api.Report) {
+	total := 0
+	direct := []git.Commit{}
+	for _, commit := range mainline {
+		// the very first commit of a project can only be made directly on the default branch.
+		if commit.Parents == 0 {
+			continue
+		}
+
+		total++
+		if !commit.IsMerge() && !squashMergePattern.MatchString(commit.Subject()) {
+			direct = append(direct, commit)
+		}
+	}
+	if total == 0 {
+		return
+	}
+
+	if branch == "" {
+		branch = "HEAD"
+	}
+	report.Scores[RuleMergeWorkflow] = 100 * float64(total-len(direct)) / float64(total)
+	details := fmt.Sprintf("%d out of the last %d commits on your project's default branch `%s` were merged from another branch.", total-len(direct), total, branch)
+	if len(direct) > 0 {
+		details += "\n\nThe most recent commits that were made directly on the default branch are:\n\n" + listCommits(direct, func(c git.Commit) string {
+			return fmt.Sprintf("`%s` - %s (%s)", shortHash(c.Hash), c.Subject(), c.Author)
+		})
+	}
+	report.Details[RuleMergeWorkflow] = details
+}
+
+func (l *HistoryLinter) lintSigned(commits []git.Commit, report *api.Report) {
+	if len(commits) == 0 {
+		return
+	}
+
+	unsigned := []git.Commit{}
+	for _, commit := range commits {
+		if !commit.Signed {
+			unsigned = append(unsigned, commit)
+		}
+	}
+
+	report.Scores[RuleSignedCommits] = 100 * float64(len(commits)-len(unsigned)) / float64(len(commits))
+	details := fmt.Sprintf("%d out of the last %d commits in your project are signed.", len(commits)-len(unsigned), len(commits))
+	if len(unsigned) > 0 {
+		details += "\n\nThe most recent unsigned commits are:\n\n" + listCommits(unsigned, func(c git.Commit) string {
+			return fmt.Sprintf("`%s` - %s (%s)", shortHash(c.Hash), c.Subject(), c.Author)
+		})
+	}
+	report.Details[RuleSignedCommits] = details
+}
+
+// countCodeAndData counts the amount of code files and data files that the given commit changes.
+func countCodeAndData(commit git.Commit) (code int, data int) {
+	for _, file := range commit.Files {
+		if hasExtension(file.Path, codeExtensions) {
+			code++
+		} else if file.Binary || hasExtension(file.Path, binaryExtensions) {
+			data++
+		}
+	}
+	return code, data
+}
+
+// listCommits formats at most maxHistoryOffenders of the given commits as a Markdown list.
+func listCommits(commits []git.Commit, format func(c git.Commit) string) string {
+	items := []interface{}{}
+	for i, commit := range commits {
+		if i == maxHistoryOffenders {
+			break
+		}
+		items = append(items, format(commit))
+	}
+	return markdowngen.List(items)
+}
+
+func shortHash(hash string) string {
+	if len(hash) > 7 {
+		return hash[:7]
+	}
+	return hash
+}
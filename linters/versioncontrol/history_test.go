@@ -0,0 +1,158 @@
+package versioncontrol_test
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/bvobart/mllint/api"
+	"github.com/bvobart/mllint/config"
+	"github.com/bvobart/mllint/linters/versioncontrol"
+	"github.com/bvobart/mllint/setools/git"
+	"github.com/bvobart/mllint/utils/exec"
+)
+
+func TestHistoryName(t *testing.T) {
+	linter := &versioncontrol.HistoryLinter{}
+	require.Equal(t, "Git History", linter.Name())
+}
+
+func TestHistoryRules(t *testing.T) {
+	linter := &versioncontrol.HistoryLinter{}
+	require.Equal(t, []*api.Rule{&versioncontrol.RuleCommitMessages, &versioncontrol.RuleCommitSize, &versioncontrol.RuleMergeWorkflow, &versioncontrol.RuleSignedCommits}, linter.Rules())
+}
+
+func TestHistoryConfigure(t *testing.T) {
+	linter := &versioncontrol.HistoryLinter{}
+	conf := config.Default()
+	require.NoError(t, linter.Configure(conf))
+	require.Equal(t, conf.Git.History, linter.Config)
+	require.True(t, linter.MessagePattern.MatchString("feat(data): add preprocessing step"))
+	require.False(t, linter.MessagePattern.MatchString("Added preprocessing step"))
+
+	conf.Git.History.MessagePattern = `^\[[A-Z]+-[0-9]+\] `
+	require.NoError(t, linter.Configure(conf))
+	require.True(t, linter.MessagePattern.MatchString("[ML-42] Add preprocessing step"))
+
+	conf.Git.History.MessagePattern = `^(feat`
+	require.Error(t, linter.Configure(conf))
+}
+
+func TestHistory(t *testing.T) {
+	linter := &versioncontrol.HistoryLinter{}
+	require.NoError(t, linter.Configure(config.Default()))
+
+	t.Run("NoGit", func(t *testing.T) {
+		report, err := linter.LintProject(context.Background(), api.Project{Dir: t.TempDir()})
+		require.NoError(t, err)
+		require.Empty(t, report.Scores)
+	})
+
+	t.Run("NoCommits", func(t *testing.T) {
+		dir := t.TempDir()
+		_, err := exec.CommandOutput(context.Background(), dir, "git", "init")
+		require.NoError(t, err)
+
+		report, err := linter.LintProject(context.Background(), api.Project{Dir: dir})
+		require.NoError(t, err)
+		require.Empty(t, report.Scores)
+	})
+
+	t.Run("History", func(t *testing.T) {
+		dir, _ := createGitRepo(t, map[string]string{"train.py": "print('training')\n"})
+		defer os.RemoveAll(dir)
+		branch, err := git.GetCurrentBranch(context.Background(), dir)
+		require.NoError(t, err)
+
+		commitFiles(t, dir, "feat: add preprocessing", map[string]string{"preprocess.py": "print('preprocessing')\n"})
+		commitFiles(t, dir, "update data and code", map[string]string{"train.py": "print('training!')\n", "train.csv": "a,b\n1,2\n"})
+		runGit(t, dir, "checkout", "-b", "feature")
+		commitFiles(t, dir, "fix: typo", map[string]string{"preprocess.py": "print('preprocessing!')\n"})
+		runGit(t, dir, "checkout", branch)
+		runGit(t, dir, "merge", "--no-ff", "-m", "Merge branch 'feature'", "feature")
+		commitFiles(t, dir, "docs: add readme (#12)", map[string]string{"ReadMe.md": "# Project\n"})
+
+		report, err := linter.LintProject(context.Background(), api.Project{Dir: dir, Git: api.GitInfo{Branch: branch}})
+		require.NoError(t, err)
+
+		// 'commit I' and 'update data and code' do not follow Conventional Commits, the merge commit is not checked.
+		require.EqualValues(t, 60, report.Scores[versioncontrol.RuleCommitMessages])
+		require.Contains(t, report.Details[versioncontrol.RuleCommitMessages], "3 out of the last 5 non-merge commits")
+		require.Contains(t, report.Details[versioncontrol.RuleCommitMessages], "update data and code")
+		require.Contains(t, report.Details[versioncontrol.RuleCommitMessages], "commit I")
+
+		require.EqualValues(t, 80, report.Scores[versioncontrol.RuleCommitSize])
+		require.Contains(t, report.Details[versioncontrol.RuleCommitSize], "update data and code - mixes 1 code file(s) with 1 data file(s)")
+
+		// the root commit is not checked, the merge commit and the squash-merged commit count as merged.
+		require.EqualValues(t, 50, report.Scores[versioncontrol.RuleMergeWorkflow])
+		require.Contains(t, report.Details[versioncontrol.RuleMergeWorkflow], "2 out of the last 4 commits on your project's default branch `"+branch+"`")
+		require.Contains(t, report.Details[versioncontrol.RuleMergeWorkflow], "feat: add preprocessing (mllint)")
+		require.NotContains(t, report.Details[versioncontrol.RuleMergeWorkflow], "fix: typo")
+
+		require.EqualValues(t, 0, report.Scores[versioncontrol.RuleSignedCommits])
+		require.Contains(t, report.Details[versioncontrol.RuleSignedCommits], "0 out of the last 6 commits in your project are signed.")
+	})
+
+	t.Run("ClonedFeatureBranch", func(t *testing.T) {
+		remote, _ := createGitRepo(t, map[string]string{"train.py": "print('training')\n"})
+		defer os.RemoveAll(remote)
+		branch, err := git.GetCurrentBranch(context.Background(), remote)
+		require.NoError(t, err)
+		commitFiles(t, remote, "feat: add preprocessing", map[string]string{"preprocess.py": "print('preprocessing')\n"})
+		runGit(t, remote, "checkout", "-b", "feature")
+		commitFiles(t, remote, "fix: typo", map[string]string{"preprocess.py": "print('preprocessing!')\n"})
+		runGit(t, remote, "checkout", branch)
+
+		// a clone of only the feature branch does not have a local copy of the default branch, which is only known as origin/<branch>.
+		dir := t.TempDir()
+		runGit(t, dir, "clone", "-b", "feature", remote, ".")
+
+		report, err := linter.LintProject(context.Background(), api.Project{Dir: dir, Git: api.GitInfo{Branch: "feature"}})
+		require.NoError(t, err)
+		require.EqualValues(t, 0, report.Scores[versioncontrol.RuleMergeWorkflow])
+		require.Contains(t, report.Details[versioncontrol.RuleMergeWorkflow], "0 out of the last 1 commits on your project's default branch `"+branch+"`")
+		require.EqualValues(t, 100*2/3.0, report.Scores[versioncontrol.RuleCommitMessages])
+
+		// without the remote's branches, e.g. in a shallow clone of a single branch, the merge workflow is not checked at all.
+		single := t.TempDir()
+		runGit(t, single, "clone", "--single-branch", "-b", "feature", "file://"+remote, ".")
+		runGit(t, single, "symbolic-ref", "refs/remotes/origin/HEAD", "refs/remotes/origin/"+branch)
+		report, err = linter.LintProject(context.Background(), api.Project{Dir: single, Git: api.GitInfo{Branch: "feature"}})
+		require.NoError(t, err)
+		require.NotContains(t, report.Scores, versioncontrol.RuleMergeWorkflow)
+		require.Contains(t, report.Scores, versioncontrol.RuleCommitMessages)
+	})
+
+	t.Run("LargeCommitsAndWindow", func(t *testing.T) {
+		dir, _ := createGitRepo(t, map[string]string{"train.py": "print('training')\n"})
+		defer os.RemoveAll(dir)
+		commitFiles(t, dir, "feat: add model", map[string]string{"model.py": "import torch\nimport numpy\nimport pandas\n"})
+		commitFiles(t, dir, "feat: add evaluation", map[string]string{"evaluate.py": "print('evaluating')\n"})
+
+		conf := config.Default()
+		conf.Git.History.Window = 2
+		conf.Git.History.MaxCommitSize = 2
+		linter := &versioncontrol.HistoryLinter{}
+		require.NoError(t, linter.Configure(conf))
+
+		report, err := linter.LintProject(context.Background(), api.Project{Dir: dir})
+		require.NoError(t, err)
+		require.EqualValues(t, 100, report.Scores[versioncontrol.RuleCommitMessages])
+		require.EqualValues(t, 50, report.Scores[versioncontrol.RuleCommitSize])
+		require.Contains(t, report.Details[versioncontrol.RuleCommitSize], "feat: add model - changes 3 lines")
+	})
+}
+
+func runGit(t *testing.T, dir string, args ...string) {
+	output, err := exec.CommandOutput(context.Background(), dir, "git", append([]string{"-c", "user.name=mllint", "-c", "user.email=mllint@example.com", "-c", "commit.gpgsign=false"}, args...)...)
+	require.NoError(t, err, string(output))
+}
+
+func commitFiles(t *testing.T, dir string, message string, files map[string]string) {
+	writeUntrackedFiles(t, dir, files)
+	runGit(t, dir, "add", "-A")
+	runGit(t, dir, "commit", "-m", message)
+}
@@ -0,0 +1,94 @@
+package versioncontrol
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/bvobart/mllint/api"
+	"github.com/bvobart/mllint/setools/git"
+	"github.com/bvobart/mllint/utils/markdowngen"
+)
+
+// Extensions of large binary files that should be stored using Git LFS or DVC, rather than directly in Git.
+var binaryExtensions = append(append([]string{
+	".safetensors", ".bin", ".pb", ".tflite", ".hdf5", ".npz", ".feather", ".zip", ".tar", ".gz", ".tgz",
+}, modelExtensions...), dataExtensions...)
+
+// LFSLinter is a linter that checks whether large binary files are stored using Git LFS (or DVC), rather than directly in Git.
+type LFSLinter struct{}
+
+func (l *LFSLinter) Name() string {
+	return "Git LFS"
+}
+
+func (l *LFSLinter) Rules() []*api.Rule {
+	return []*api.Rule{&RuleBinaryFilesTracked}
+}
+
+func (l *LFSLinter) LintProject(ctx context.Context, project api.Project) (api.Report, error) {
+	report := api.NewReport()
+	backend := git.ForProject(project)
+	if !backend.Detect(ctx, project.Dir) {
+		return report, nil
+	}
+
+	trackedFiles, err := backend.ListTrackedFiles(ctx, project.Dir)
+	if err != nil {
+		return report, err
+	}
+
+	lfsPatterns, err := git.ReadLFSPatterns(backend.GetGitRoot(ctx, project.Dir))
+	if err != nil {
+		return report, err
+	}
+
+	// files versioned by other data version control tools, e.g. git-annex, are also fine.
+	versionedFiles := project.DataVCs.Files()
+
+	binaryFiles := 0
+	notInLFS := []interface{}{}
+	for _, file := range trackedFiles {
+		if !hasExtension(file, binaryExtensions) {
+			continue
+		}
+
+		binaryFiles++
+		if !lfsPatterns.Matches(file) && !isVersioned(file, versionedFiles) {
+			notInLFS = append(notInLFS, "`"+file+"`")
+		}
+	}
+
+	if binaryFiles == 0 {
+		report.Scores[RuleBinaryFilesTracked] = 100
+		return report, nil
+	}
+
+	report.Scores[RuleBinaryFilesTracked] = 100 * float64(binaryFiles-len(notInLFS)) / float64(binaryFiles)
+	if len(notInLFS) > 0 {
+		report.Details[RuleBinaryFilesTracked] = fmt.Sprintf("Your project is tracking **%d** large binary files, of which **%d** are committed directly into Git instead of being stored using Git LFS or DVC:\n\n%s",
+			binaryFiles, len(notInLFS), markdowngen.List(notInLFS))
+	}
+	return report, nil
+}
+
+func hasExtension(filename string, extensions []string) bool {
+	ext := strings.ToLower(path.Ext(filename))
+	for _, extension := range extensions {
+		if ext == extension {
+			return true
+		}
+	}
+	return false
+}
+
+// isVersioned returns whether the given file is one of the given versioned files, or inside one of the given versioned folders.
+func isVersioned(filename string, versioned []string) bool {
+	for _, file := range versioned {
+		if filename == file || strings.HasPrefix(filename, file+"/") {
+			return true
+		}
+	}
+	return false
+}
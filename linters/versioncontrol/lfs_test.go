@@ -0,0 +1,72 @@
+package versioncontrol_test
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/bvobart/mllint/api"
+	"github.com/bvobart/mllint/linters/versioncontrol"
+	"github.com/bvobart/mllint/setools/datavc"
+)
+
+const lfsPointer = `version https://git-lfs.github.com/spec/v1
+oid sha256:4d7a214614ab2935c943f9e0ff69d22eadbb8f32b1258daaa5e2ca24d17e2393
+size 123456789
+`
+
+func TestLFSName(t *testing.T) {
+	linter := &versioncontrol.LFSLinter{}
+	require.Equal(t, "Git LFS", linter.Name())
+}
+
+func TestLFSRules(t *testing.T) {
+	linter := &versioncontrol.LFSLinter{}
+	require.Equal(t, []*api.Rule{&versioncontrol.RuleBinaryFilesTracked}, linter.Rules())
+}
+
+func TestBinaryFilesTracked(t *testing.T) {
+	linter := &versioncontrol.LFSLinter{}
+
+	report, err := linter.LintProject(context.Background(), api.Project{Dir: t.TempDir()})
+	require.NoError(t, err)
+	require.NotContains(t, report.Scores, versioncontrol.RuleBinaryFilesTracked)
+
+	dir, _ := createGitRepo(t, map[string]string{
+		"train.py": "print('training')",
+	})
+	defer os.RemoveAll(dir)
+	report, err = linter.LintProject(context.Background(), api.Project{Dir: dir})
+	require.NoError(t, err)
+	require.EqualValues(t, 100, report.Scores[versioncontrol.RuleBinaryFilesTracked])
+
+	dir, _ = createGitRepo(t, map[string]string{
+		".gitattributes":        "*.pt filter=lfs diff=lfs merge=lfs -text\n",
+		"train.py":              "print('training')",
+		"models/model.pt":       lfsPointer,
+		"models/model.onnx":     "onnx model",
+		"data/train.csv":        "a,b\n1,2\n",
+		"data/train.csv.dvc":    "outs:\n- path: train.csv\n",
+		"data/features.parquet": "parquet",
+	})
+	defer os.RemoveAll(dir)
+	report, err = linter.LintProject(context.Background(), api.Project{Dir: dir})
+	require.NoError(t, err)
+	require.EqualValues(t, 25, report.Scores[versioncontrol.RuleBinaryFilesTracked])
+	details := report.Details[versioncontrol.RuleBinaryFilesTracked]
+	require.Contains(t, details, "tracking **4** large binary files, of which **3** are committed directly into Git")
+	require.Contains(t, details, "- `models/model.onnx`")
+	require.Contains(t, details, "- `data/train.csv`")
+	require.Contains(t, details, "- `data/features.parquet`")
+	require.NotContains(t, details, "model.pt")
+
+	// files versioned by another data version control tool, e.g. git-annex or lakeFS, also pass.
+	project := api.Project{Dir: dir}
+	project.DataVCs = api.DataVersionControlList{datavc.LakeFS{Project: project, Folders: []string{"data"}}}
+	report, err = linter.LintProject(context.Background(), project)
+	require.NoError(t, err)
+	require.EqualValues(t, 75, report.Scores[versioncontrol.RuleBinaryFilesTracked])
+	require.Contains(t, report.Details[versioncontrol.RuleBinaryFilesTracked], "- `models/model.onnx`")
+}
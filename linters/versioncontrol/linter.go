@@ -7,5 +7,5 @@ import (
 )
 
 func NewLinter() api.Linter {
-	return common.NewCompositeLinter(categories.VersionControl.Name, &GitLinter{}, &DVCLinter{})
+	return common.NewCompositeLinter(categories.VersionControl.Name, &GitLinter{}, &LFSLinter{}, &GitignoreLinter{}, &HistoryLinter{}, &DataLinter{}, &DVCLinter{}, &SecretsLinter{})
 }
@@ -35,6 +35,179 @@ Instead, see [this StackOverflow answer](https://stackoverflow.com/a/46615578/80
 	Weight: 1,
 }
 
+// RuleGitNoModels is a linting rule to check that no model artifacts have ever been committed to the Git repository.
+var RuleGitNoModels = api.Rule{
+	Slug: "version-control/code/git-no-models",
+	Name: "Project should not have any model artifacts in its Git history",
+	Details: `Trained models, such as ` + "`.pt`, `.pth`, `.h5`, `.onnx`, `.pkl`, `.joblib` and `.ckpt`" + ` files, are binary files that change every time you retrain your model.
+Since Git stores every version of every file that was ever committed, committing such artifacts quickly bloats your repository's Git history,
+which needs to be downloaded every time your project is cloned. Git also cannot show meaningful differences between versions of these files.
+
+Models should instead be version controlled as Data, e.g. using DVC or Git LFS, regardless of how small they are right now.
+See the ` + "`version-control/data/` rules of `mllint`" + ` for more info about version controlling data.
+
+To fix this rule, it is not enough to just remove these files from your project, as they will still exist inside your Git history.
+Instead, see [this StackOverflow answer](https://stackoverflow.com/a/46615578/8059181) to learn how to also remove these files from your project's Git history.`,
+	Weight: 1,
+}
+
+// RuleGitNoData is a linting rule to check that no datasets have ever been committed to the Git repository.
+var RuleGitNoData = api.Rule{
+	Slug: "version-control/code/git-no-data",
+	Name: "Project should not have any datasets in its Git history",
+	Details: `Datasets, such as ` + "`.csv`, `.parquet` and `.npy`" + ` files, tend to grow and change over time as you collect and process more data.
+Since Git stores every version of every file that was ever committed, committing datasets quickly bloats your repository's Git history,
+which needs to be downloaded every time your project is cloned.
+
+Datasets should instead be version controlled as Data, e.g. using DVC or Git LFS, regardless of how small they are right now.
+See the ` + "`version-control/data/` rules of `mllint`" + ` for more info about version controlling data.
+
+To fix this rule, it is not enough to just remove these files from your project, as they will still exist inside your Git history.
+Instead, see [this StackOverflow answer](https://stackoverflow.com/a/46615578/8059181) to learn how to also remove these files from your project's Git history.`,
+	Weight: 1,
+}
+
+// RuleBinaryFilesTracked is a linting rule to check that large binary file types that are tracked in the project,
+// such as models and datasets, are stored using Git LFS or DVC rather than directly in Git.
+var RuleBinaryFilesTracked = api.Rule{
+	Slug: "version-control/data/binary-files-tracked",
+	Name: "Large binary files should be tracked using Git LFS or DVC",
+	Details: `Binary files such as models (e.g. ` + "`.pt`, `.h5`, `.onnx`, `.pkl`" + `), datasets (e.g. ` + "`.csv`, `.parquet`, `.npy`" + `) and archives (e.g. ` + "`.zip`, `.tar.gz`" + `)
+tend to be large and change often. When they are committed directly into Git, every version of these files is stored in your repository's Git history forever,
+which needs to be downloaded every time your project is cloned.
+
+This rule checks whether all such files that are tracked by Git are stored using [Git LFS](https://git-lfs.github.com/),
+i.e. whether they match a pattern with ` + "`filter=lfs`" + ` in your project's ` + "`.gitattributes`" + ` file.
+Files that are version controlled using [DVC](https://dvc.org) are not tracked by Git itself, so they also pass this rule.
+
+To store a file type using Git LFS, [install Git LFS](https://git-lfs.github.com/) and run e.g. ` + "`git lfs track \"*.pt\"`" + `, then commit your ` + "`.gitattributes`" + `.
+Files that were already committed can be moved into Git LFS using ` + "`git lfs migrate import --include=\"*.pt\"`" + `.
+To version control a file using DVC instead, run ` + "`git rm --cached <file>`" + ` followed by ` + "`dvc add <file>`" + `.`,
+	Weight: 1,
+}
+
+// RuleNoSecrets is a linting rule to check that the project does not contain any secrets or credentials.
+var RuleNoSecrets = api.Rule{
+	Slug: "version-control/code/no-secrets",
+	Name: "Project should not contain secrets or credentials",
+	Details: `Secrets such as API tokens, cloud credentials, passwords and private keys should never be committed to your project's repository.
+Anyone who has access to your repository, now or in the future, can use them to access your cloud resources, data or experiment tracking, possibly running up a hefty bill.
+Since Git keeps every version of every file, removing a secret from your project does not remove it from your repository's history:
+a secret that has been committed should always be considered leaked and should be revoked.
+
+In ML projects, secrets most often end up in Jupyter Notebooks, e.g. an API token pasted into a cell to quickly log in to Weights & Biases or the Hugging Face Hub,
+or credentials that are printed in a cell's output. This rule therefore scans your project's Python files, notebooks (both the cells' sources and outputs)
+and configuration files (e.g. ` + "`.yml`, `.json`, `.toml`, `.ini` and `.env`" + ` files) for:
+- known credential formats, such as AWS access keys, private keys, Hugging Face, Weights & Biases, GitHub, Google, Slack and OpenAI tokens.
+- values with a high entropy (i.e. random-looking strings) that are assigned to secret-sounding names, such as ` + "`api_key`, `token` or `password`" + `.
+
+Files that are ignored by Git are not scanned, so a Git-ignored ` + "`.env`" + ` file is a perfectly fine place to keep your secrets locally.
+Instead of hard-coding secrets, load them from environment variables, e.g. using ` + "`os.environ[\"WANDB_API_KEY\"]`" + ` or [python-dotenv](https://pypi.org/project/python-dotenv/).
+
+False positives can be excluded by adding a ` + "`# pragma: allowlist secret`" + ` comment to the offending line, or using the following configuration:
+` + "```yaml" + `
+secrets:
+  allowlist: # regular expressions matching strings that are not secrets
+    - ^test-token-
+  ignoreFiles: # glob patterns of files that should not be scanned
+    - tests/fixtures/*
+  minEntropy: 3.5 # minimum entropy (bits per character) of a value assigned to a secret-sounding name
+` + "```" + `
+
+or equivalent TOML:
+` + "```toml" + `
+[tool.mllint.secrets]
+allowlist = ["^test-token-"]
+ignoreFiles = ["tests/fixtures/*"]
+minEntropy = 3.5
+` + "```",
+	Weight:  1,
+	PerFile: true,
+}
+
+// RuleNoSecretsInHistory is a linting rule to check that no secrets or credentials have ever been committed to the Git repository.
+var RuleNoSecretsInHistory = api.Rule{
+	Slug: "version-control/code/no-secrets-in-history",
+	Name: "Project should not have any secrets or credentials in its Git history",
+	Details: `Since Git keeps every version of every file, a secret that was once committed can still be retrieved from your repository's history,
+even if it has since been removed from your project. This rule scans every version of your project's Python files, notebooks and configuration files
+that has ever been committed, for the same kinds of secrets as the ` + "`version-control/code/no-secrets`" + ` rule.
+The ` + "`secrets`" + ` configuration of that rule (i.e. the allowlist and ignored files) also applies to this rule.
+
+Any secret found in your Git history should be considered leaked and should be **revoked**, especially if your repository is (or will be) public.
+Afterwards, you can remove the secret from your Git history, e.g. using [git-filter-repo](https://github.com/newren/git-filter-repo)
+or [BFG Repo-Cleaner](https://rtyley.github.io/bfg-repo-cleaner/).`,
+	Weight: 1,
+}
+
+// RuleGitignore is a linting rule to check that the project's `.gitignore` covers the files that are commonly generated in ML projects.
+var RuleGitignore = api.Rule{
+	Slug: "version-control/code/gitignore",
+	Name: "Project's `.gitignore` should ignore files commonly generated in ML projects",
+	Details: `ML projects tend to generate many files that should not be committed to Git, such as virtual environments, Python's ` + "`__pycache__`" + ` folders,
+Jupyter's ` + "`.ipynb_checkpoints`" + `, experiment tracking runs (e.g. ` + "`mlruns/`, `wandb/` and `lightning_logs/`" + `), model outputs and checkpoints,
+data that is version controlled using DVC and ` + "`.env`" + ` files containing secrets. Committing these files bloats your repository's Git history,
+clutters your diffs and may leak credentials.
+
+This rule checks whether your project's ` + "`.gitignore`" + ` files (including those in subfolders) ignore all of these files,
+and whether none of these files are currently being tracked by Git. If your project is missing any lines in its ` + "`.gitignore`" + `,
+the details of this rule will show exactly which lines to add. Files that are already tracked should also be removed from Git using ` + "`git rm -r --cached <file>`" + `.
+
+Model outputs and checkpoints are only expected to be ignored when your project has an ` + "`outputs` or `checkpoints`" + ` folder.
+If your project stores them elsewhere, configure these folders in your ` + "`mllint`" + ` configuration:
+
+` + "```yaml" + `
+git:
+  outputDirs:
+    - models/checkpoints
+` + "```" + `
+
+See also GitHub's [template ` + "`.gitignore`" + ` for Python projects](https://github.com/github/gitignore/blob/main/Python.gitignore).`,
+	Weight: 1,
+}
+
+//------------------------------------------------------------------------------------------
+
+// RuleDataVersioned is a linting rule to check whether the project is using any tool to version control its data.
+// Relates to best practice 'Use Versioning for Data, Model, Configurations and Training Script'
+// See https://se-ml.github.io/best_practices/02-data_version/
+var RuleDataVersioned = api.Rule{
+	Slug: "version-control/data/versioned",
+	Name: "Project uses a tool to version control its data",
+	Details: `Similar to code, the data and models of an ML project should also be version controlled, such that every version of your model
+can be traced back to the exact data it was trained on. Since Git is not designed to deal with large and / or binary files,
+data should be versioned using a dedicated data version control tool.
+
+mllint recognises the following data version control tools:
+- [DVC](https://dvc.org), detected by its ` + "`.dvc/config`" + ` file. This is the tool that mllint recommends, see the ` + "`version-control/data/dvc`" + ` rule.
+- [Git LFS](https://git-lfs.github.com), detected by patterns with ` + "`filter=lfs`" + ` in your project's ` + "`.gitattributes`" + `.
+- [git-annex](https://git-annex.branchable.com), detected by the ` + "`.git/annex`" + ` folder in your project's Git repository.
+- [lakeFS](https://lakefs.io), detected by a ` + "`.lakectl.yaml`" + ` file, or folders synced using ` + "`lakectl local`" + `.
+- [Pachyderm](https://www.pachyderm.com), detected by pipeline specifications (` + "`.json` or `.yaml`" + ` files defining a ` + "`pipeline` and `transform`" + `).`,
+	Weight: 1,
+}
+
+// RuleDataHasRemote is a linting rule to check whether the project's data version control tool stores the data in a remote storage.
+var RuleDataHasRemote = api.Rule{
+	Slug: "version-control/data/has-remote",
+	Name: "Versioned data should be stored in a remote storage",
+	Details: `To share your versioned data with your colleagues, the data version control tool that your project uses should store your data in a remote storage,
+rather than only on your own machine. For DVC, this means that at least one DVC remote should be configured.
+For Git LFS, this means that your Git repository should have a remote (or a custom LFS server configured in ` + "`.lfsconfig`" + `),
+and for git-annex, at least one of your Git remotes should be known to git-annex. lakeFS and Pachyderm always store data on their server or cluster.`,
+	Weight: 1,
+}
+
+// RuleDataHasFiles is a linting rule to check whether the project's data version control tool is actually versioning any data.
+var RuleDataHasFiles = api.Rule{
+	Slug: "version-control/data/has-files",
+	Name: "Data version control tool should be versioning at least one data file",
+	Details: `Using a data version control tool entails actually versioning your data and models with it.
+If you're seeing this in a report, your project is using a data version control tool, but it is currently not versioning any files with it.
+For example, add files to DVC using ` + "`dvc add <files>`" + `, to Git LFS using ` + "`git lfs track \"*.csv\"`" + ` or to git-annex using ` + "`git annex add <files>`" + `.`,
+	Weight: 1,
+}
+
 //------------------------------------------------------------------------------------------
 
 var RuleDVC = api.Rule{
@@ -121,6 +294,21 @@ to DVC using
 	Weight: 1,
 }
 
+var RuleDVCRemoteNotLocal = api.Rule{
+	Slug: "version-control/data/dvc-remote-not-local",
+	Name: "DVC: Should have a remote data storage that is not local",
+	Details: `DVC supports many types of remote storage, such as Amazon S3 (` + "`s3://`" + `), Google Cloud Storage (` + "`gs://`" + `),
+Azure Blob Storage (` + "`azure://`" + `) and SSH servers (` + "`ssh://`" + `), but also folders on your local filesystem.
+A local remote is fine for trying out DVC, but your colleagues (and your CI pipeline) cannot access the data that you push to it,
+and it does not protect your data against the failure of your machine.
+
+If you're seeing this in a report, then all of your project's DVC remotes are local folders. mllint reads the remotes from your project's
+` + "`.dvc/config` and `.dvc/config.local`" + ` files, so DVC does not need to be installed. Add a remote on a shared storage solution using e.g.
+
+` + "```console\ndvc remote add -d storage s3://mybucket/dvcstore\n```",
+	Weight: 1,
+}
+
 var RuleDVCHasFiles = api.Rule{
 	Slug: "version-control/data/dvc-has-files",
 	Name: "DVC: Should be tracking at least one data file",
@@ -131,6 +319,154 @@ or the [%s](https://dvc.org/doc/command-reference/add) command.
 
 Then, add your datasets and models to DVC by running the command %s
 
-_Tip: Under the hood, mllint uses the command %s in order to see which files DVC is tracking._`, "`dvc add`", "`dvc add <files>`", "`dvc list . -R --dvc-only`"),
+_Tip: mllint considers the outputs of all %s files in your project, as well as the outputs of your pipeline that are recorded in %s, to be tracked by DVC._`,
+		"`dvc add`", "`dvc add <files>`", "`.dvc`", "`dvc.lock`"),
+	Weight: 1,
+}
+
+var RuleDVCPipeline = api.Rule{
+	Slug: "version-control/data/dvc-pipeline",
+	Name: "DVC: Project should define a pipeline in `dvc.yaml`",
+	Details: fmt.Sprintf(`Besides versioning your data, DVC can also capture how your data is processed and how your models are trained in a pipeline,
+defined as a series of stages in a `+"`dvc.yaml`"+` file. Each stage specifies the command that it runs, along with the files it depends on and the files it produces.
+DVC uses this information to only rerun the stages whose dependencies have changed, which makes your experiments reproducible and your results easy to share.
+
+If you're seeing this in a report, your project is using DVC, but it does not define a pipeline with any stages in a `+"`dvc.yaml`"+`file yet.
+Learn more about [data pipelines](https://dvc.org/doc/start/data-management/data-pipelines) in DVC, then add your first stage using e.g.
+
+%s`, "```console\ndvc stage add -n train -d train.py -d data/train.csv -o model.pkl python train.py\n```"),
+	Weight: 1,
+}
+
+var RuleDVCStagesDepsOuts = api.Rule{
+	Slug: "version-control/data/dvc-stages-deps-outs",
+	Name: "DVC: Pipeline stages should declare their dependencies and outputs",
+	Details: `DVC decides whether a stage of your pipeline needs to be rerun based on the dependencies that the stage declares (` + "`deps` and `params`" + `),
+and only tracks and caches the outputs that the stage declares (` + "`outs`, `metrics` and `plots`" + `).
+A stage without dependencies is always considered to be up to date, even when the code or data it uses has changed,
+while the results of a stage without outputs are not versioned and cannot be reused by other stages.
+
+This rule checks that every stage in your ` + "`dvc.yaml`" + ` declares at least one dependency and at least one output.
+Remember that the script that a stage runs is also one of its dependencies, e.g. ` + "`deps: [train.py, data/train.csv]`" + `.`,
+	Weight: 1,
+}
+
+var RuleDVCParams = api.Rule{
+	Slug: "version-control/data/dvc-params",
+	Name: "DVC: Pipeline should use `params.yaml` for hyperparameters",
+	Details: `Hardcoding the hyperparameters of your data processing and training scripts makes it hard to see which parameters produced which results.
+DVC allows you to put your hyperparameters in a ` + "`params.yaml`" + ` file and declare which parameters each stage depends on,
+such that the stage is rerun when those parameters change and the values of the parameters are recorded in ` + "`dvc.lock`" + `.
+This also enables you to compare experiments with different parameters using ` + "`dvc params diff`" + ` and ` + "`dvc exp`" + `.
+
+This rule checks that your project has a ` + "`params.yaml`" + ` and that at least one stage in your ` + "`dvc.yaml`" + ` declares parameters from it, e.g.:
+` + "```yaml" + `
+stages:
+  train:
+    cmd: python train.py
+    deps: [train.py, data/train.csv]
+    params: [train.lr, train.epochs]
+    outs: [model.pkl]
+` + "```" + `
+
+Learn more about [parameter dependencies](https://dvc.org/doc/user-guide/project-structure/dvcyaml-files#parameters) in DVC.`,
+	Weight: 1,
+}
+
+var RuleDVCMetrics = api.Rule{
+	Slug: "version-control/data/dvc-metrics",
+	Name: "DVC: Pipeline should declare metrics or plots",
+	Details: `When your pipeline declares the metrics (e.g. a ` + "`metrics.json`" + ` with your model's accuracy) and plots (e.g. a ROC curve)
+that it produces, DVC versions them along with your code and data. This allows you to compare the performance of your models
+between commits and experiments using ` + "`dvc metrics diff`" + ` and ` + "`dvc plots diff`" + `.
+
+This rule checks that your ` + "`dvc.yaml`" + ` declares ` + "`metrics` or `plots`" + `, either in one of its stages or at its top-level, e.g.:
+` + "```yaml" + `
+stages:
+  evaluate:
+    cmd: python evaluate.py
+    deps: [evaluate.py, model.pkl]
+    metrics:
+      - metrics.json:
+          cache: false
+` + "```" + `
+
+Learn more about [metrics and plots](https://dvc.org/doc/start/experiments/metrics-parameters-plots) in DVC.`,
+	Weight: 1,
+}
+
+var RuleDVCLockInSync = api.Rule{
+	Slug: "version-control/data/dvc-lock-in-sync",
+	Name: "DVC: File `dvc.lock` should be in sync with `dvc.yaml`",
+	Details: `DVC records the commands, dependencies and outputs of every stage of your pipeline in ` + "`dvc.lock`" + `, each time the stage is run.
+When ` + "`dvc.lock`" + ` is not in sync with your ` + "`dvc.yaml`" + ` and your project's files, then the results that you committed
+were not produced by the code, data and parameters that you committed, so your results are not reproducible.
+
+This rule checks, without running DVC, that every stage in your ` + "`dvc.yaml`" + ` is recorded in ` + "`dvc.lock`" + `,
+that ` + "`dvc.lock`" + ` does not contain stages that no longer exist, that the commands and dependencies of the stages have not changed,
+and that the MD5 hashes of the dependencies and outputs in your project match the hashes recorded in ` + "`dvc.lock`" + `.
+Folders and files that are not present in your project (e.g. because they have not been pulled from your DVC remote) are not checked.
+
+To bring ` + "`dvc.lock`" + ` up to date, run ` + "`dvc repro`" + ` and commit the updated ` + "`dvc.lock`" + `.`,
+	Weight: 1,
+}
+
+// RuleCommitMessages is a linting rule to check that the project's recent commit messages follow a convention.
+var RuleCommitMessages = api.Rule{
+	Slug: "version-control/code/commit-messages",
+	Name: "Project's commit messages should follow a convention",
+	Details: `Commit messages are the documentation of your project's history. When they follow a convention, it is easy to see at a glance
+what each commit changed and why, which helps when looking for the change that broke your model's performance or when writing a changelog.
+
+By default, this rule checks that the subjects (first lines) of your project's recent commit messages follow the [Conventional Commits](https://www.conventionalcommits.org/) specification,
+e.g. ` + "`feat(data): add preprocessing step` or `fix: use correct learning rate`" + `. Merge commits are not checked.
+If your project follows a different convention, you can configure a regular expression that commit subjects should match, e.g.:
+` + "```yaml" + `
+git:
+  history:
+    window: 100 # number of recent commits to check
+    messagePattern: '^\[[A-Z]+-[0-9]+\] ' # e.g. '[ML-42] Add preprocessing step'
+` + "```",
+	Weight: 1,
+}
+
+// RuleCommitSize is a linting rule to check that the project's recent commits are small and do not mix code with data.
+var RuleCommitSize = api.Rule{
+	Slug: "version-control/code/commit-size",
+	Name: "Project's commits should be small and should not mix code with data",
+	Details: `Small, focused commits are easier to review, easier to understand when looking back at your project's history, and easier to revert
+when they turn out to break something. Commits that change both code and data (e.g. ` + "`train.py` and `data/train.csv`" + `) make it
+hard to tell whether a change in your model's behaviour was caused by the change in code or the change in data.
+Moreover, data should be version controlled separately from your code, e.g. using DVC or Git LFS.
+
+This rule checks that none of your project's recent non-merge commits change more lines than configured with ` + "`git.history.maxCommitSize`" + ` (default 1000),
+and that none of them change both Python files or notebooks and binary or data files.`,
+	Weight: 1,
+}
+
+// RuleMergeWorkflow is a linting rule to check that changes are merged into the project's default branch, rather than committed to it directly.
+var RuleMergeWorkflow = api.Rule{
+	Slug: "version-control/code/merge-workflow",
+	Name: "Changes should be merged into the default branch rather than committed to it directly",
+	Details: `Developing changes on a separate branch and merging them into your project's default branch (e.g. ` + "`main`" + `) using a pull request or merge request,
+allows your changes to be reviewed and tested by your CI pipeline before they end up on the branch that everyone else builds upon.
+Committing directly to the default branch bypasses these checks.
+
+This rule checks which of the recent commits on your project's default branch were merged from another branch, i.e. are merge commits,
+or were squash-merged from a pull request, i.e. have a subject ending in e.g. ` + "`(#42)`" + `. The first commit of your project is not checked.
+Consider protecting your default branch, see e.g. [GitHub's documentation](https://docs.github.com/en/repositories/configuring-branches-and-merges-in-your-repository/managing-protected-branches/about-protected-branches).`,
+	Weight: 1,
+}
+
+// RuleSignedCommits is a linting rule to check that the project's recent commits are signed.
+var RuleSignedCommits = api.Rule{
+	Slug: "version-control/code/signed-commits",
+	Name: "Project's commits should be signed",
+	Details: `Anyone can create a Git commit using any name and email address. Signing your commits with a GPG or SSH key proves that a commit was
+actually made by you, which allows others to verify the origin of the code, data and models in your project.
+
+This rule checks which of your project's recent commits are signed. Note that the signatures themselves are not verified.
+See [GitHub's documentation](https://docs.github.com/en/authentication/managing-commit-signature-verification/signing-commits) to learn how to sign your commits,
+or enable signing for all your commits with ` + "`git config --global commit.gpgsign true`" + `.`,
 	Weight: 1,
 }
@@ -0,0 +1,284 @@
+package versioncontrol
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/hashicorp/go-multierror"
+
+	"github.com/bvobart/mllint/api"
+	"github.com/bvobart/mllint/config"
+	"github.com/bvobart/mllint/setools/git"
+	"github.com/bvobart/mllint/setools/jupyter"
+	"github.com/bvobart/mllint/utils"
+	"github.com/bvobart/mllint/utils/markdowngen"
+	"github.com/bvobart/mllint/utils/secrets"
+)
+
+// Files larger than this are not scanned for secrets, as these are unlikely to be hand-written configuration or code.
+const maxSecretsFileSize = 1_000_000 // 1 MB
+
+// Extensions of configuration files that are scanned for secrets, in addition to Python files and notebooks.
+var configFileExtensions = map[string]bool{
+	".yml": true, ".yaml": true, ".json": true, ".toml": true, ".ini": true,
+	".cfg": true, ".conf": true, ".env": true, ".properties": true,
+}
+
+type SecretsLinter struct {
+	Config  config.SecretsConfig
+	scanner *secrets.Scanner
+}
+
+// SecretFinding is a (possible) secret found in one of the project's files.
+type SecretFinding struct {
+	// Filename relative to the project's root.
+	Filename string
+	// Where in the file the secret was found, e.g. `line 3` or `cell 2, output 1, line 4`
+	Location string
+	secrets.Match
+}
+
+func (f SecretFinding) String() string {
+	return fmt.Sprintf("`%s` (%s) - **%s**: `%s`", f.Filename, f.Location, f.Kind, f.Redacted())
+}
+
+// HistorySecretFinding is a (possible) secret found in a file in the project's Git history.
+type HistorySecretFinding struct {
+	// Hash of the commit that introduced the version of the file containing the secret.
+	CommitHash string
+	SecretFinding
+}
+
+func (f HistorySecretFinding) String() string {
+	return fmt.Sprintf("**%s** - commit `%s` - `%s` (%s): `%s`", f.Kind, f.CommitHash, f.Filename, f.Location, f.Redacted())
+}
+
+func (l *SecretsLinter) Name() string {
+	return "Secrets"
+}
+
+func (l *SecretsLinter) Rules() []*api.Rule {
+	return []*api.Rule{&RuleNoSecrets, &RuleNoSecretsInHistory}
+}
+
+func (l *SecretsLinter) Configure(conf *config.Config) (err error) {
+	l.Config = conf.Secrets
+	for _, pattern := range l.Config.IgnoreFiles {
+		if _, err := filepath.Match(pattern, ""); err != nil {
+			return fmt.Errorf("invalid pattern `%s` in secrets.ignoreFiles: %w", pattern, err)
+		}
+	}
+
+	l.scanner, err = secrets.NewScanner(l.Config.Allowlist, l.Config.MinEntropy)
+	return err
+}
+
+func (l *SecretsLinter) LintProject(ctx context.Context, project api.Project) (api.Report, error) {
+	report := api.NewReport()
+	backend := git.ForProject(project)
+	if l.scanner == nil { // not configured, so use the default configuration
+		if err := l.Configure(config.Default()); err != nil {
+			return report, err
+		}
+	}
+
+	configFiles, err := project.FileFilter().FindFiles(project.Dir, isConfigFile)
+	if err != nil {
+		return report, fmt.Errorf("failed to find configuration files: %w", err)
+	}
+
+	files := utils.Filenames{}
+	files = append(files, project.PythonFiles...)
+	files = append(files, project.Notebooks...)
+	files = append(files, configFiles.Prefix(project.Dir)...)
+	if files, err = backend.FilterIgnored(ctx, project.Dir, files); err != nil {
+		return report, err
+	}
+
+	var multiErr *multierror.Error
+	findings := []interface{}{}
+	for _, filename := range files {
+		relpath := utils.RelativePath(project.Dir, filename)
+		if l.isIgnored(relpath) {
+			continue
+		}
+
+		fileFindings, err := l.scanFile(filename, relpath)
+		if err != nil {
+			multiErr = multierror.Append(multiErr, err)
+			continue
+		}
+		for _, finding := range fileFindings {
+			findings = append(findings, finding)
+		}
+	}
+
+	if len(findings) == 0 {
+		report.Scores[RuleNoSecrets] = 100
+	} else {
+		report.Scores[RuleNoSecrets] = 0
+		report.Details[RuleNoSecrets] = fmt.Sprintf("We found **%d** possible secrets or credentials in your project:\n\n%s\n", len(findings), markdowngen.List(findings)) +
+			"Remove these secrets from your project and **revoke them**, as anyone with access to your project's files may have already copied them. " +
+			"Load secrets from environment variables or a Git-ignored `.env` file instead.\n\n" +
+			"If any of these are not actually secrets, add them to the `secrets.allowlist` in your `mllint` configuration, " +
+			"or add a `# " + secrets.AllowlistComment + "` comment to the line that contains them."
+	}
+
+	if err := l.lintHistory(ctx, backend, project, &report); err != nil {
+		multiErr = multierror.Append(multiErr, fmt.Errorf("failed to scan Git history for secrets: %w", err))
+	}
+	return report, multiErr.ErrorOrNil()
+}
+
+func (l *SecretsLinter) lintHistory(ctx context.Context, backend git.Backend, project api.Project, report *api.Report) error {
+	if !backend.Detect(ctx, project.Dir) {
+		return nil
+	}
+
+	blobs, err := backend.ListBlobsInHistory(ctx, project.Dir)
+	if err != nil {
+		return err
+	}
+
+	// the history is listed starting from the newest commits, so iterate backwards to report secrets in the order they were introduced.
+	// Each blob only needs to be read and scanned once, even when it is listed for several commits or paths.
+	scanned := map[string]git.Blob{}
+	hashes := []string{}
+	for i := len(blobs) - 1; i >= 0; i-- {
+		blob := blobs[i]
+		if _, ok := scanned[blob.Hash]; ok {
+			continue
+		}
+		if blob.Size <= maxSecretsFileSize && isScannedFile(blob.Path) && !l.isIgnored(blob.Path) {
+			scanned[blob.Hash] = blob
+			hashes = append(hashes, blob.Hash)
+		}
+	}
+
+	commits := map[string]string{}
+	findings := []interface{}{}
+	seen := map[string]bool{}
+	err = backend.ReadBlobs(ctx, project.Dir, hashes, func(hash string, contents []byte) error {
+		blob := scanned[hash]
+		blobFindings, err := l.scanContents(contents, blob.Path)
+		if err != nil {
+			return nil // e.g. an old version of a notebook that was invalid at the time. The current version is checked by RuleNoSecrets.
+		}
+
+		for _, finding := range blobFindings {
+			key := finding.Filename + "\x00" + finding.Secret
+			if seen[key] {
+				continue
+			}
+			seen[key] = true
+
+			if len(findings) == 0 {
+				if commits, err = backend.FindBlobCommits(ctx, project.Dir); err != nil {
+					return err
+				}
+			}
+			findings = append(findings, HistorySecretFinding{commitOrBlob(blob, commits), finding})
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	if len(findings) == 0 {
+		report.Scores[RuleNoSecretsInHistory] = 100
+		return nil
+	}
+
+	report.Scores[RuleNoSecretsInHistory] = 0
+	report.Details[RuleNoSecretsInHistory] = fmt.Sprintf("We found **%d** possible secrets or credentials in your project's Git history:\n\n%s\n", len(findings), markdowngen.List(findings)) +
+		"These secrets may not necessarily be in your project right now, but they are still stored inside your project's Git history, " +
+		"so anyone with access to your repository can retrieve them. **Revoke them**, then consider removing them from your Git history.\n\n" +
+		"If any of these are not actually secrets, add them to the `secrets.allowlist` in your `mllint` configuration."
+	return nil
+}
+
+// isScannedFile returns whether the given file is scanned for secrets, i.e. whether it is a Python file, notebook or configuration file.
+func isScannedFile(filename string) bool {
+	ext := filepath.Ext(filename)
+	return ext == ".py" || ext == ".ipynb" || isConfigFile(filename)
+}
+
+func isConfigFile(filename string) bool {
+	base := filepath.Base(filename)
+	return configFileExtensions[filepath.Ext(base)] || strings.HasPrefix(base, ".env")
+}
+
+func (l *SecretsLinter) isIgnored(relpath string) bool {
+	for _, pattern := range l.Config.IgnoreFiles {
+		if matched, _ := filepath.Match(pattern, relpath); matched {
+			return true
+		}
+		if matched, _ := filepath.Match(pattern, filepath.Base(relpath)); matched {
+			return true
+		}
+	}
+	return false
+}
+
+// scans a single file for secrets. Notebooks are scanned cell by cell, including the cells' outputs.
+func (l *SecretsLinter) scanFile(filename string, relpath string) ([]SecretFinding, error) {
+	info, err := os.Stat(filename)
+	if err != nil {
+		return nil, err
+	}
+	if info.Size() > maxSecretsFileSize {
+		return nil, nil
+	}
+
+	contents, err := ioutil.ReadFile(filename)
+	if err != nil {
+		return nil, err
+	}
+
+	findings, err := l.scanContents(contents, relpath)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", filename, err)
+	}
+	return findings, nil
+}
+
+// scans the contents of a file for secrets, where relpath is the file's path relative to the project's root.
+func (l *SecretsLinter) scanContents(contents []byte, relpath string) ([]SecretFinding, error) {
+	if filepath.Ext(relpath) == ".ipynb" {
+		notebook, err := jupyter.Parse(bytes.NewReader(contents))
+		if err != nil {
+			return nil, err
+		}
+		return l.scanNotebook(notebook, relpath), nil
+	}
+
+	findings := []SecretFinding{}
+	unquoted := filepath.Ext(relpath) != ".py"
+	for _, match := range l.scanner.ScanText(string(contents), unquoted) {
+		findings = append(findings, SecretFinding{relpath, fmt.Sprintf("line %d", match.Line), match})
+	}
+	return findings, nil
+}
+
+func (l *SecretsLinter) scanNotebook(notebook *jupyter.Notebook, relpath string) []SecretFinding {
+	findings := []SecretFinding{}
+	for i, cell := range notebook.Cells {
+		unquoted := cell.CellType != jupyter.CellTypeCode
+		for _, match := range l.scanner.ScanText(string(cell.Source), unquoted) {
+			findings = append(findings, SecretFinding{relpath, fmt.Sprintf("cell %d, line %d", i+1, match.Line), match})
+		}
+
+		for j, output := range cell.Outputs {
+			for _, match := range l.scanner.ScanText(string(output.PlainText()), true) {
+				findings = append(findings, SecretFinding{relpath, fmt.Sprintf("cell %d, output %d, line %d", i+1, j+1, match.Line), match})
+			}
+		}
+	}
+	return findings
+}
@@ -0,0 +1,148 @@
+package versioncontrol_test
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/bvobart/mllint/api"
+	"github.com/bvobart/mllint/config"
+	"github.com/bvobart/mllint/linters/versioncontrol"
+)
+
+func TestSecretsName(t *testing.T) {
+	linter := &versioncontrol.SecretsLinter{}
+	require.Equal(t, "Secrets", linter.Name())
+}
+
+func TestSecretsRules(t *testing.T) {
+	linter := &versioncontrol.SecretsLinter{}
+	require.Equal(t, []*api.Rule{&versioncontrol.RuleNoSecrets, &versioncontrol.RuleNoSecretsInHistory}, linter.Rules())
+}
+
+func TestSecretsConfigure(t *testing.T) {
+	linter := &versioncontrol.SecretsLinter{}
+	conf := config.Default()
+	require.NoError(t, linter.Configure(conf))
+
+	conf.Secrets.Allowlist = []string{"(unclosed"}
+	require.Error(t, linter.Configure(conf))
+
+	conf = config.Default()
+	conf.Secrets.IgnoreFiles = []string{"[invalid"}
+	require.Error(t, linter.Configure(conf))
+}
+
+func TestNoSecrets(t *testing.T) {
+	dir := "test-resources/secrets"
+	project := api.Project{
+		Dir:         dir,
+		PythonFiles: []string{dir + "/train.py"},
+		Notebooks:   []string{dir + "/explore.ipynb"},
+	}
+
+	linter := &versioncontrol.SecretsLinter{}
+	report, err := linter.LintProject(context.Background(), project)
+	require.NoError(t, err)
+	require.EqualValues(t, 0, report.Scores[versioncontrol.RuleNoSecrets])
+
+	details := report.Details[versioncontrol.RuleNoSecrets]
+	require.Contains(t, details, "We found **6** possible secrets")
+	require.Contains(t, details, "`train.py` (line 5) - **Weights & Biases API Key**: `0123****************`")
+	require.Contains(t, details, "`train.py` (line 6) - **High-entropy string**: `Zx8v****************`")
+	require.Contains(t, details, "`config.yml` (line 4) - **High-entropy string**: `S3cr***************`")
+	require.Contains(t, details, "`fixtures/credentials.json` (line 1) - **AWS Access Key ID**: `AKIA****************`")
+	require.Contains(t, details, "`explore.ipynb` (cell 1, line 2) - **Hugging Face Token**: `hf_a****************`")
+	require.Contains(t, details, "`explore.ipynb` (cell 1, output 1, line 2) - **AWS Secret Access Key**: `wJal****************`")
+	require.NotContains(t, details, "(line 7)")
+	require.NotContains(t, details, "(line 9)")
+
+	t.Run("AllowlistAndIgnoreFiles", func(t *testing.T) {
+		conf := config.Default()
+		conf.Secrets.Allowlist = []string{"^Zx8v", "^S3cr3t"}
+		conf.Secrets.IgnoreFiles = []string{"fixtures/*", "*.ipynb"}
+		linter := &versioncontrol.SecretsLinter{}
+		require.NoError(t, linter.Configure(conf))
+
+		report, err := linter.LintProject(context.Background(), project)
+		require.NoError(t, err)
+		require.EqualValues(t, 0, report.Scores[versioncontrol.RuleNoSecrets])
+		require.Contains(t, report.Details[versioncontrol.RuleNoSecrets], "We found **1** possible secrets")
+		require.Contains(t, report.Details[versioncontrol.RuleNoSecrets], "`train.py` (line 5)")
+	})
+
+	t.Run("Clean", func(t *testing.T) {
+		linter := &versioncontrol.SecretsLinter{}
+		report, err := linter.LintProject(context.Background(), api.Project{Dir: t.TempDir()})
+		require.NoError(t, err)
+		require.EqualValues(t, 100, report.Scores[versioncontrol.RuleNoSecrets])
+	})
+}
+
+func TestNoSecretsInHistory(t *testing.T) {
+	dir, commits := createGitRepo(t, map[string]string{
+		"train.py":   "import wandb\nwandb.login(key=\"0123456789abcdef0123456789abcdef01234567\")\n",
+		"params.yml": "lr: 0.01\n",
+		"notes.txt":  "api_key = \"Zx8vQ2mK9pLr4TnW7yB3\"",
+	}, map[string]string{
+		"train.py":   "import os\nimport wandb\nwandb.login(key=\"0123456789abcdef0123456789abcdef01234567\")\n",
+		"params.yml": "lr: 0.01\napi_token: Zx8vQ2mK9pLr4TnW7yB3\n",
+	}, map[string]string{
+		"train.py":   "import os\nimport wandb\nwandb.login(key=os.environ[\"WANDB_API_KEY\"])\n",
+		"params.yml": "",
+	})
+	defer os.RemoveAll(dir)
+
+	linter := &versioncontrol.SecretsLinter{}
+	report, err := linter.LintProject(context.Background(), api.Project{Dir: dir, PythonFiles: []string{dir + "/train.py"}})
+	require.NoError(t, err)
+	require.EqualValues(t, 100, report.Scores[versioncontrol.RuleNoSecrets])
+	require.EqualValues(t, 0, report.Scores[versioncontrol.RuleNoSecretsInHistory])
+
+	details := report.Details[versioncontrol.RuleNoSecretsInHistory]
+	require.Contains(t, details, "We found **2** possible secrets or credentials in your project's Git history")
+	require.Contains(t, details, "**Weights & Biases API Key** - commit `"+commits[0]+"` - `train.py` (line 2): `0123****************`")
+	require.Contains(t, details, "**High-entropy string** - commit `"+commits[1]+"` - `params.yml` (line 2): `Zx8v****************`")
+
+	t.Run("IgnoreFiles", func(t *testing.T) {
+		conf := config.Default()
+		conf.Secrets.IgnoreFiles = []string{"*.yml"}
+		conf.Secrets.Allowlist = []string{"^0123"}
+		linter := &versioncontrol.SecretsLinter{}
+		require.NoError(t, linter.Configure(conf))
+
+		report, err := linter.LintProject(context.Background(), api.Project{Dir: dir})
+		require.NoError(t, err)
+		require.EqualValues(t, 100, report.Scores[versioncontrol.RuleNoSecretsInHistory])
+	})
+
+	t.Run("Renamed", func(t *testing.T) {
+		dir, commits := createGitRepo(t, map[string]string{
+			"config.py": "API_KEY = \"Zx8vQ2mK9pLr4TnW7yB3\"\n",
+		}, map[string]string{
+			"config.py":   "",
+			"settings.py": "API_KEY = \"Zx8vQ2mK9pLr4TnW7yB3\"\n",
+		}, map[string]string{
+			"train.py": "print('training')\n",
+		})
+		defer os.RemoveAll(dir)
+
+		linter := &versioncontrol.SecretsLinter{}
+		report, err := linter.LintProject(context.Background(), api.Project{Dir: dir})
+		require.NoError(t, err)
+		require.EqualValues(t, 0, report.Scores[versioncontrol.RuleNoSecretsInHistory])
+
+		details := report.Details[versioncontrol.RuleNoSecretsInHistory]
+		require.Contains(t, details, "We found **1** possible secrets or credentials in your project's Git history")
+		require.Contains(t, details, "**High-entropy string** - commit `"+commits[0]+"` - ")
+	})
+
+	t.Run("NotGit", func(t *testing.T) {
+		linter := &versioncontrol.SecretsLinter{}
+		report, err := linter.LintProject(context.Background(), api.Project{Dir: t.TempDir()})
+		require.NoError(t, err)
+		require.NotContains(t, report.Scores, versioncontrol.RuleNoSecretsInHistory)
+	})
+}
@@ -2,8 +2,10 @@ package ciproviders
 
 import (
 	"path"
+	"strings"
+
+	"gopkg.in/yaml.v3"
 
-	"github.com/bvobart/mllint/setools/git"
 	"github.com/bvobart/mllint/utils"
 )
 
@@ -11,14 +13,120 @@ const azureFile = "azure-pipelines.yml"
 
 type Azure struct{}
 
-func (_ Azure) ConfigFile(projectdir string) string {
-	return path.Join(git.GetGitRoot(projectdir), azureFile)
+func (_ Azure) ConfigFile(rootdir string) string {
+	return path.Join(rootdir, azureFile)
 }
 
-func (_ Azure) Detect(projectdir string) bool {
-	return utils.FileExists(path.Join(git.GetGitRoot(projectdir), azureFile))
+func (_ Azure) Detect(rootdir string) bool {
+	return utils.FileExists(path.Join(rootdir, azureFile))
 }
 
 func (_ Azure) Type() ProviderType {
 	return TypeAzure
 }
+
+type azurePipeline struct {
+	Name      string       `yaml:"name"`
+	Trigger   yaml.Node    `yaml:"trigger"`
+	PR        yaml.Node    `yaml:"pr"`
+	Schedules yaml.Node    `yaml:"schedules"`
+	Stages    []azureStage `yaml:"stages"`
+	Jobs      []azureJob   `yaml:"jobs"`
+	Steps     []azureStep  `yaml:"steps"`
+}
+
+type azureStage struct {
+	Stage string     `yaml:"stage"`
+	Jobs  []azureJob `yaml:"jobs"`
+}
+
+type azureJob struct {
+	Job         string      `yaml:"job"`
+	Deployment  string      `yaml:"deployment"`
+	DisplayName string      `yaml:"displayName"`
+	Steps       []azureStep `yaml:"steps"`
+	// Deployment jobs define their steps in a deployment strategy, e.g. `strategy: { runOnce: { deploy: { steps: [...] } } }`
+	Strategy map[string]map[string]struct {
+		Steps []azureStep `yaml:"steps"`
+	} `yaml:"strategy"`
+}
+
+type azureStep struct {
+	DisplayName string            `yaml:"displayName"`
+	Script      string            `yaml:"script"`
+	Bash        string            `yaml:"bash"`
+	Pwsh        string            `yaml:"pwsh"`
+	PowerShell  string            `yaml:"powershell"`
+	Task        string            `yaml:"task"`
+	Template    string            `yaml:"template"`
+	Inputs      map[string]string `yaml:"inputs"`
+}
+
+// Pipelines parses the project's `azure-pipelines.yml`. Templates are not parsed.
+func (_ Azure) Pipelines(rootdir string) ([]Pipeline, error) {
+	config := azurePipeline{}
+	if err := readYAML(path.Join(rootdir, azureFile), &config); err != nil {
+		return nil, err
+	}
+
+	pipeline := Pipeline{File: azureFile, Name: config.Name, Triggers: []Trigger{}, Jobs: []Job{}}
+	// CI and PR triggers are enabled for all branches unless they are disabled with `none`
+	if !isAzureNone(config.Trigger) {
+		pipeline.Triggers = append(pipeline.Triggers, TriggerPush)
+	}
+	if !isAzureNone(config.PR) {
+		pipeline.Triggers = append(pipeline.Triggers, TriggerPullRequest)
+	}
+	if config.Schedules.Kind != 0 {
+		pipeline.Triggers = append(pipeline.Triggers, TriggerSchedule)
+	}
+
+	if len(config.Steps) > 0 {
+		pipeline.Jobs = append(pipeline.Jobs, azureJob{Job: "job", Steps: config.Steps}.toJob())
+	}
+	for _, job := range config.Jobs {
+		pipeline.Jobs = append(pipeline.Jobs, job.toJob())
+	}
+	for _, stage := range config.Stages {
+		for _, job := range stage.Jobs {
+			pipeline.Jobs = append(pipeline.Jobs, job.toJob())
+		}
+	}
+	return []Pipeline{pipeline}, nil
+}
+
+func (j azureJob) toJob() Job {
+	name := j.Job
+	if name == "" {
+		name = j.Deployment
+	}
+	if name == "" {
+		name = j.DisplayName
+	}
+
+	steps := append([]azureStep{}, j.Steps...)
+	for _, strategy := range j.Strategy {
+		for _, hook := range strategy {
+			steps = append(steps, hook.Steps...)
+		}
+	}
+
+	job := Job{Name: name, Steps: []Step{}, Caches: []string{}}
+	for _, step := range steps {
+		run := step.Script + step.Bash + step.Pwsh + step.PowerShell
+		uses := step.Task
+		if uses == "" {
+			uses = step.Template
+		}
+		job.Steps = append(job.Steps, Step{Name: step.DisplayName, Run: run, Uses: uses})
+
+		if strings.HasPrefix(step.Task, "Cache@") && step.Inputs["path"] != "" {
+			job.Caches = append(job.Caches, step.Inputs["path"])
+		}
+	}
+	return job
+}
+
+func isAzureNone(node yaml.Node) bool {
+	return node.Kind == yaml.ScalarNode && (node.Value == "none" || node.Value == "false")
+}
@@ -0,0 +1,124 @@
+package ciproviders
+
+import (
+	"fmt"
+	"path"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/bvobart/mllint/utils"
+)
+
+const bitbucketFile = "bitbucket-pipelines.yml"
+
+type Bitbucket struct{}
+
+func (_ Bitbucket) ConfigFile(rootdir string) string {
+	return path.Join(rootdir, bitbucketFile)
+}
+
+func (_ Bitbucket) Detect(rootdir string) bool {
+	return utils.FileExists(path.Join(rootdir, bitbucketFile))
+}
+
+func (_ Bitbucket) Type() ProviderType {
+	return TypeBitbucket
+}
+
+type bitbucketConfig struct {
+	Pipelines yaml.Node `yaml:"pipelines"`
+}
+
+type bitbucketStep struct {
+	Name        string    `yaml:"name"`
+	Caches      []string  `yaml:"caches"`
+	Script      yaml.Node `yaml:"script"`
+	AfterScript yaml.Node `yaml:"after-script"`
+}
+
+// Pipelines parses the project's `bitbucket-pipelines.yml`, where each of the steps in the default, branch, tag, pull request
+// and custom pipelines becomes a job.
+func (_ Bitbucket) Pipelines(rootdir string) ([]Pipeline, error) {
+	config := bitbucketConfig{}
+	if err := readYAML(path.Join(rootdir, bitbucketFile), &config); err != nil {
+		return nil, err
+	}
+
+	pipeline := Pipeline{File: bitbucketFile, Triggers: []Trigger{}, Jobs: []Job{}}
+	for _, section := range mappingKeys(&config.Pipelines) {
+		switch section {
+		case "default", "branches", "tags":
+			pipeline.Triggers = appendTrigger(pipeline.Triggers, TriggerPush)
+		case "pull-requests":
+			pipeline.Triggers = appendTrigger(pipeline.Triggers, TriggerPullRequest)
+		case "custom":
+			pipeline.Triggers = appendTrigger(pipeline.Triggers, TriggerManual)
+		}
+
+		jobs, err := bitbucketJobs(mappingValue(&config.Pipelines, section))
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse '%s' pipelines in %s: %w", section, bitbucketFile, err)
+		}
+		pipeline.Jobs = append(pipeline.Jobs, jobs...)
+	}
+	return []Pipeline{pipeline}, nil
+}
+
+// bitbucketJobs finds all steps in the given node, which may be a list of steps, or may contain them, e.g. in `parallel` or `stage` items,
+// or in mappings from branch names to lists of steps.
+func bitbucketJobs(node *yaml.Node) ([]Job, error) {
+	jobs := []Job{}
+	if node == nil {
+		return jobs, nil
+	}
+
+	if stepNode := mappingValue(node, "step"); stepNode != nil {
+		step := bitbucketStep{}
+		if err := stepNode.Decode(&step); err != nil {
+			return nil, err
+		}
+		return append(jobs, step.toJob()), nil
+	}
+
+	if node.Kind == yaml.MappingNode || node.Kind == yaml.SequenceNode {
+		for _, child := range node.Content {
+			childJobs, err := bitbucketJobs(child)
+			if err != nil {
+				return nil, err
+			}
+			jobs = append(jobs, childJobs...)
+		}
+	}
+	return jobs, nil
+}
+
+func (s bitbucketStep) toJob() Job {
+	name := s.Name
+	if name == "" {
+		name = "step"
+	}
+
+	job := Job{Name: name, Steps: []Step{}, Caches: append([]string{}, s.Caches...)}
+	job.Steps = append(job.Steps, bitbucketScript("script", s.Script)...)
+	job.Steps = append(job.Steps, bitbucketScript("after-script", s.AfterScript)...)
+	return job
+}
+
+// bitbucketScript converts the given script into steps. Scripts are lists of commands, which may also contain pipes,
+// e.g. `- pipe: atlassian/aws-s3-deploy:1.1.0`
+func bitbucketScript(name string, node yaml.Node) []Step {
+	steps := []Step{}
+	commands := script{}
+	for _, item := range node.Content {
+		if pipe := mappingValue(item, "pipe"); pipe != nil {
+			steps = append(steps, Step{Name: name, Uses: pipe.Value})
+		} else if item.Kind == yaml.ScalarNode {
+			commands = append(commands, item.Value)
+		}
+	}
+
+	if len(commands) > 0 {
+		steps = append([]Step{{Name: name, Run: commands.String()}}, steps...)
+	}
+	return steps
+}
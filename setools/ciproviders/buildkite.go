@@ -0,0 +1,147 @@
+package ciproviders
+
+import (
+	"fmt"
+	"path"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/bvobart/mllint/utils"
+)
+
+const buildkiteFile = ".buildkite/pipeline.yml"
+
+type Buildkite struct{}
+
+func (_ Buildkite) ConfigFile(rootdir string) string {
+	return path.Join(rootdir, buildkiteFile)
+}
+
+func (_ Buildkite) Detect(rootdir string) bool {
+	return utils.FileExists(path.Join(rootdir, buildkiteFile))
+}
+
+func (_ Buildkite) Type() ProviderType {
+	return TypeBuildkite
+}
+
+type buildkiteStep struct {
+	Label    string    `yaml:"label"`
+	Name     string    `yaml:"name"`
+	Key      string    `yaml:"key"`
+	Command  script    `yaml:"command"`
+	Commands script    `yaml:"commands"`
+	Plugins  yaml.Node `yaml:"plugins"`
+	Trigger  string    `yaml:"trigger"`
+	Group    string    `yaml:"group"`
+	Steps    yaml.Node `yaml:"steps"`
+}
+
+// Pipelines parses the project's `.buildkite/pipeline.yml`, where each command step becomes a job. Dynamically uploaded pipelines are not parsed.
+func (_ Buildkite) Pipelines(rootdir string) ([]Pipeline, error) {
+	root := yaml.Node{}
+	if err := readYAML(path.Join(rootdir, buildkiteFile), &root); err != nil {
+		return nil, err
+	}
+
+	// Buildkite builds every push to a branch, as well as pull requests by default.
+	pipeline := Pipeline{File: buildkiteFile, Triggers: []Trigger{TriggerPush, TriggerPullRequest}, Jobs: []Job{}}
+	if len(root.Content) == 0 {
+		return []Pipeline{pipeline}, nil
+	}
+
+	// the pipeline can be either a mapping with `steps`, or just a list of steps.
+	steps := root.Content[0]
+	if steps.Kind == yaml.MappingNode {
+		steps = mappingValue(steps, "steps")
+	}
+
+	jobs, err := buildkiteJobs(steps)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", buildkiteFile, err)
+	}
+	pipeline.Jobs = jobs
+	return []Pipeline{pipeline}, nil
+}
+
+func buildkiteJobs(steps *yaml.Node) ([]Job, error) {
+	jobs := []Job{}
+	if steps == nil {
+		return jobs, nil
+	}
+
+	for _, node := range steps.Content {
+		// e.g. `- wait`
+		if node.Kind != yaml.MappingNode {
+			continue
+		}
+
+		step := buildkiteStep{}
+		if err := node.Decode(&step); err != nil {
+			return nil, err
+		}
+
+		// group steps contain other steps.
+		if step.Group != "" {
+			groupJobs, err := buildkiteJobs(&step.Steps)
+			if err != nil {
+				return nil, err
+			}
+			jobs = append(jobs, groupJobs...)
+			continue
+		}
+
+		if job, ok := step.toJob(); ok {
+			jobs = append(jobs, job)
+		}
+	}
+	return jobs, nil
+}
+
+func (s buildkiteStep) toJob() (Job, bool) {
+	name := s.Label
+	for _, alternative := range []string{s.Name, s.Key, s.Trigger} {
+		if name == "" {
+			name = alternative
+		}
+	}
+
+	job := Job{Name: name, Steps: []Step{}, Caches: []string{}}
+	if s.Trigger != "" {
+		job.Steps = append(job.Steps, Step{Uses: s.Trigger})
+	}
+	if commands := append(append(script{}, s.Command...), s.Commands...); len(commands) > 0 {
+		job.Steps = append(job.Steps, Step{Run: commands.String()})
+	}
+
+	// plugins can be a list of either plugin names or mappings from plugin names to their configuration, or a mapping from names to configuration.
+	plugins := []*yaml.Node{&s.Plugins}
+	if s.Plugins.Kind == yaml.SequenceNode {
+		plugins = s.Plugins.Content
+	}
+	for _, plugin := range plugins {
+		names := []string{plugin.Value}
+		if plugin.Kind == yaml.MappingNode {
+			names = mappingKeys(plugin)
+		}
+
+		for _, pluginName := range names {
+			if pluginName == "" {
+				continue
+			}
+			job.Steps = append(job.Steps, Step{Uses: pluginName})
+
+			// e.g. `cache#v0.6.0: { path: .venv }` or `gencer/cache#v2.4.10: { paths: [.venv] }`
+			if strings.Contains(pluginName, "cache") {
+				config := mappingValue(plugin, pluginName)
+				cachePaths := append(scalarValues(mappingValue(config, "path")), scalarValues(mappingValue(config, "paths"))...)
+				if len(cachePaths) == 0 {
+					cachePaths = []string{"cache"}
+				}
+				job.Caches = appendUnique(job.Caches, cachePaths...)
+			}
+		}
+	}
+	return job, len(job.Steps) > 0
+}
@@ -0,0 +1,113 @@
+package ciproviders
+
+import (
+	"fmt"
+	"path"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/bvobart/mllint/utils"
+)
+
+const circleciFile = ".circleci/config.yml"
+
+type CircleCI struct{}
+
+func (_ CircleCI) ConfigFile(rootdir string) string {
+	return path.Join(rootdir, circleciFile)
+}
+
+func (_ CircleCI) Detect(rootdir string) bool {
+	return utils.FileExists(path.Join(rootdir, circleciFile))
+}
+
+func (_ CircleCI) Type() ProviderType {
+	return TypeCircleCI
+}
+
+type circleciConfig struct {
+	Jobs      yaml.Node `yaml:"jobs"`
+	Workflows yaml.Node `yaml:"workflows"`
+}
+
+// Pipelines parses the project's `.circleci/config.yml`. Commands and jobs from orbs are not expanded,
+// but are included as steps that use the orb, e.g. `python/test`
+func (_ CircleCI) Pipelines(rootdir string) ([]Pipeline, error) {
+	config := circleciConfig{}
+	if err := readYAML(path.Join(rootdir, circleciFile), &config); err != nil {
+		return nil, err
+	}
+
+	// CircleCI builds every push to a branch, which includes the branches of pull requests.
+	pipeline := Pipeline{File: circleciFile, Triggers: []Trigger{TriggerPush, TriggerPullRequest}, Jobs: []Job{}}
+	if strings.Contains(strings.Join(scalarValues(&config.Workflows), " "), "schedule") {
+		pipeline.Triggers = append(pipeline.Triggers, TriggerSchedule)
+	}
+
+	for _, name := range mappingKeys(&config.Jobs) {
+		job, err := circleciJob(name, mappingValue(&config.Jobs, name))
+		if err != nil {
+			return nil, err
+		}
+		pipeline.Jobs = append(pipeline.Jobs, job)
+	}
+
+	// jobs from orbs that are used directly in a workflow, e.g. `- python/test: { pkg-manager: poetry }`
+	for _, workflow := range mappingKeys(&config.Workflows) {
+		jobs := mappingValue(mappingValue(&config.Workflows, workflow), "jobs")
+		if jobs == nil {
+			continue
+		}
+		for _, node := range jobs.Content {
+			name := node.Value
+			if node.Kind == yaml.MappingNode && len(node.Content) > 0 {
+				name = node.Content[0].Value
+			}
+			if strings.Contains(name, "/") {
+				pipeline.Jobs = append(pipeline.Jobs, Job{Name: name, Steps: []Step{{Uses: name}}, Caches: []string{}})
+			}
+		}
+	}
+	return []Pipeline{pipeline}, nil
+}
+
+func circleciJob(name string, node *yaml.Node) (Job, error) {
+	job := Job{Name: name, Steps: []Step{}, Caches: []string{}}
+	steps := mappingValue(node, "steps")
+	if steps == nil {
+		return job, nil
+	}
+
+	for _, step := range steps.Content {
+		// e.g. `- checkout`
+		if step.Kind == yaml.ScalarNode {
+			job.Steps = append(job.Steps, Step{Uses: step.Value})
+			continue
+		}
+		if step.Kind != yaml.MappingNode || len(step.Content) < 2 {
+			return job, fmt.Errorf("failed to parse job '%s' in %s: line %d: expected a step", name, circleciFile, step.Line)
+		}
+
+		kind, args := step.Content[0].Value, step.Content[1]
+		switch kind {
+		case "run":
+			// e.g. `- run: pytest` or `- run: { name: Run tests, command: pytest }`
+			if args.Kind == yaml.ScalarNode {
+				job.Steps = append(job.Steps, Step{Run: args.Value})
+			} else {
+				job.Steps = append(job.Steps, Step{Name: scalarOrEmpty(mappingValue(args, "name")), Run: scalarOrEmpty(mappingValue(args, "command"))})
+			}
+		case "save_cache":
+			job.Steps = append(job.Steps, Step{Uses: kind})
+			job.Caches = append(job.Caches, scalarValues(mappingValue(args, "paths"))...)
+		default:
+			job.Steps = append(job.Steps, Step{Name: scalarOrEmpty(mappingValue(args, "name")), Uses: kind})
+			// the `install-packages` command of the Python orb caches the installed dependencies.
+			if strings.HasSuffix(kind, "/install-packages") {
+				job.Caches = append(job.Caches, scalarOrDefault(mappingValue(args, "pkg-manager"), "pip"))
+			}
+		}
+	}
+	return job, nil
+}
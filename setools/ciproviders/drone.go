@@ -0,0 +1,191 @@
+package ciproviders
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/bvobart/mllint/utils"
+)
+
+const droneFile = ".drone.yml"
+
+type Drone struct{}
+
+func (_ Drone) ConfigFile(rootdir string) string {
+	return path.Join(rootdir, droneFile)
+}
+
+func (_ Drone) Detect(rootdir string) bool {
+	return utils.FileExists(path.Join(rootdir, droneFile))
+}
+
+func (_ Drone) Type() ProviderType {
+	return TypeDrone
+}
+
+// droneConfig is a pipeline in a Drone or Woodpecker CI configuration file, which share most of their syntax.
+type droneConfig struct {
+	Kind    string    `yaml:"kind"`
+	Name    string    `yaml:"name"`
+	Trigger yaml.Node `yaml:"trigger"`
+	When    yaml.Node `yaml:"when"`
+	Steps   yaml.Node `yaml:"steps"`
+	// Older versions of Woodpecker CI define the steps under `pipeline`
+	Pipeline yaml.Node `yaml:"pipeline"`
+}
+
+type droneStep struct {
+	Name     string `yaml:"name"`
+	Image    string `yaml:"image"`
+	Commands script `yaml:"commands"`
+}
+
+// Pipelines parses the pipelines in the project's `.drone.yml`, which may contain multiple YAML documents.
+// Each of these pipelines is a single job.
+func (_ Drone) Pipelines(rootdir string) ([]Pipeline, error) {
+	file, err := os.Open(path.Join(rootdir, droneFile))
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	pipelines := []Pipeline{}
+	decoder := yaml.NewDecoder(file)
+	for {
+		config := droneConfig{}
+		err := decoder.Decode(&config)
+		if errors.Is(err, io.EOF) {
+			return pipelines, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse %s: %w", droneFile, err)
+		}
+
+		// besides pipelines, `.drone.yml` can also contain e.g. secrets and signatures.
+		if config.Kind != "" && config.Kind != "pipeline" {
+			continue
+		}
+
+		pipeline, err := config.toPipeline(droneFile, &config.Trigger)
+		if err != nil {
+			return nil, err
+		}
+		pipelines = append(pipelines, pipeline)
+	}
+}
+
+// toPipeline converts the Drone or Woodpecker pipeline into a Pipeline with a single job, using the events in the given `trigger` or `when` node.
+func (c droneConfig) toPipeline(filename string, trigger *yaml.Node) (Pipeline, error) {
+	name := c.Name
+	if name == "" {
+		name = "default"
+	}
+
+	pipeline := Pipeline{File: filename, Name: c.Name, Triggers: droneTriggers(trigger), Jobs: []Job{}}
+	job := Job{Name: name, Steps: []Step{}, Caches: []string{}}
+
+	steps := &c.Steps
+	if steps.Kind == 0 {
+		steps = &c.Pipeline
+	}
+
+	// steps are a list of steps in Drone, but can also be a mapping from names to steps in Woodpecker.
+	nodes := steps.Content
+	names := make([]string, len(nodes))
+	if steps.Kind == yaml.MappingNode {
+		nodes, names = []*yaml.Node{}, []string{}
+		for _, key := range mappingKeys(steps) {
+			nodes = append(nodes, mappingValue(steps, key))
+			names = append(names, key)
+		}
+	}
+
+	for i, node := range nodes {
+		step := droneStep{}
+		if err := node.Decode(&step); err != nil {
+			return pipeline, fmt.Errorf("failed to parse step %d in %s: %w", i+1, filename, err)
+		}
+		if step.Name == "" {
+			step.Name = names[i]
+		}
+
+		if len(step.Commands) > 0 {
+			job.Steps = append(job.Steps, Step{Name: step.Name, Run: step.Commands.String()})
+		} else {
+			job.Steps = append(job.Steps, Step{Name: step.Name, Uses: step.Image})
+		}
+
+		// e.g. `meltwater/drone-cache` with `settings: { mount: [.venv] }`
+		if strings.Contains(step.Image, "cache") {
+			job.Caches = appendUnique(job.Caches, droneCacheMounts(node)...)
+		}
+	}
+
+	pipeline.Jobs = append(pipeline.Jobs, job)
+	return pipeline, nil
+}
+
+func droneCacheMounts(step *yaml.Node) []string {
+	settings := mappingValue(step, "settings")
+	for _, key := range []string{"mount", "path", "paths"} {
+		if mounts := scalarValues(mappingValue(settings, key)); len(mounts) > 0 {
+			return mounts
+		}
+	}
+	return []string{"cache"}
+}
+
+// droneTriggers determines the events that trigger a pipeline based on its `trigger` (Drone) or `when` (Woodpecker) section.
+// Without any events specified, pipelines run on all events, including pushes and pull requests.
+func droneTriggers(trigger *yaml.Node) []Trigger {
+	events := droneEvents(trigger)
+	if len(events) == 0 {
+		return []Trigger{TriggerPush, TriggerPullRequest}
+	}
+
+	triggers := []Trigger{}
+	for _, event := range events {
+		triggers = appendTrigger(triggers, droneTrigger(event))
+	}
+	return triggers
+}
+
+// droneEvents returns the events included in the `event` of the given `trigger` or `when` section, which may be a list of conditions.
+func droneEvents(node *yaml.Node) []string {
+	conditions := []*yaml.Node{node}
+	if node.Kind == yaml.SequenceNode {
+		conditions = node.Content
+	}
+
+	events := []string{}
+	for _, condition := range conditions {
+		event := mappingValue(condition, "event")
+		if include := mappingValue(event, "include"); include != nil {
+			event = include
+		}
+		if event != nil && event.Kind != yaml.MappingNode {
+			events = append(events, scalarValues(event)...)
+		}
+	}
+	return events
+}
+
+func droneTrigger(event string) Trigger {
+	switch event {
+	case "push", "tag":
+		return TriggerPush
+	case "pull_request":
+		return TriggerPullRequest
+	case "cron":
+		return TriggerSchedule
+	case "custom", "manual", "promote":
+		return TriggerManual
+	}
+	return Trigger(event)
+}
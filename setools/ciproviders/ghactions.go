@@ -1,9 +1,14 @@
 package ciproviders
 
 import (
+	"fmt"
+	"io/ioutil"
 	"path"
+	"strings"
+
+	"github.com/hashicorp/go-multierror"
+	"gopkg.in/yaml.v3"
 
-	"github.com/bvobart/mllint/setools/git"
 	"github.com/bvobart/mllint/utils"
 )
 
@@ -11,12 +16,12 @@ const ghactionsFolder = ".github/workflows"
 
 type GHActions struct{}
 
-func (_ GHActions) ConfigFile(projectdir string) string {
-	return path.Join(git.GetGitRoot(projectdir), ghactionsFolder)
+func (_ GHActions) ConfigFile(rootdir string) string {
+	return path.Join(rootdir, ghactionsFolder)
 }
 
-func (_ GHActions) Detect(projectdir string) bool {
-	workflowsdir := path.Join(git.GetGitRoot(projectdir), ghactionsFolder)
+func (_ GHActions) Detect(rootdir string) bool {
+	workflowsdir := path.Join(rootdir, ghactionsFolder)
 	if !utils.FolderExists(workflowsdir) {
 		return false
 	}
@@ -28,3 +33,117 @@ func (_ GHActions) Detect(projectdir string) bool {
 func (_ GHActions) Type() ProviderType {
 	return TypeGHActions
 }
+
+type ghWorkflow struct {
+	Name string    `yaml:"name"`
+	On   yaml.Node `yaml:"on"`
+	Jobs yaml.Node `yaml:"jobs"`
+}
+
+type ghJob struct {
+	Name  string   `yaml:"name"`
+	Steps []ghStep `yaml:"steps"`
+	// Reusable workflow that the job calls, instead of running steps.
+	Uses string `yaml:"uses"`
+}
+
+type ghStep struct {
+	Name string            `yaml:"name"`
+	Run  string            `yaml:"run"`
+	Uses string            `yaml:"uses"`
+	With map[string]string `yaml:"with"`
+}
+
+// Pipelines parses each of the workflows in the project's `.github/workflows` folder.
+// Returns the workflows that could be parsed, along with the errors of those that could not.
+func (_ GHActions) Pipelines(rootdir string) ([]Pipeline, error) {
+	workflowsdir := path.Join(rootdir, ghactionsFolder)
+	entries, err := ioutil.ReadDir(workflowsdir)
+	if err != nil {
+		return nil, err
+	}
+
+	var multiErr *multierror.Error
+	pipelines := []Pipeline{}
+	for _, entry := range entries {
+		ext := path.Ext(entry.Name())
+		if entry.IsDir() || (ext != ".yml" && ext != ".yaml") {
+			continue
+		}
+
+		workflow := ghWorkflow{}
+		if err := readYAML(path.Join(workflowsdir, entry.Name()), &workflow); err != nil {
+			multiErr = multierror.Append(multiErr, err)
+			continue
+		}
+
+		pipeline, err := workflow.toPipeline(path.Join(ghactionsFolder, entry.Name()))
+		if err != nil {
+			multiErr = multierror.Append(multiErr, err)
+			continue
+		}
+		pipelines = append(pipelines, pipeline)
+	}
+	return pipelines, multiErr.ErrorOrNil()
+}
+
+func (w ghWorkflow) toPipeline(filename string) (Pipeline, error) {
+	pipeline := Pipeline{File: filename, Name: w.Name, Triggers: []Trigger{}, Jobs: []Job{}}
+
+	// `on` can be a single event, a list of events, or a mapping from events to their configuration.
+	events := scalarValues(&w.On)
+	if w.On.Kind == yaml.MappingNode {
+		events = mappingKeys(&w.On)
+	}
+	for _, event := range events {
+		pipeline.Triggers = appendTrigger(pipeline.Triggers, ghTrigger(event))
+	}
+
+	for _, id := range mappingKeys(&w.Jobs) {
+		ghjob := ghJob{}
+		if err := mappingValue(&w.Jobs, id).Decode(&ghjob); err != nil {
+			return pipeline, fmt.Errorf("failed to parse job '%s' in %s: %w", id, path.Base(filename), err)
+		}
+		pipeline.Jobs = append(pipeline.Jobs, ghjob.toJob(id))
+	}
+	return pipeline, nil
+}
+
+func (j ghJob) toJob(id string) Job {
+	job := Job{Name: id, Steps: []Step{}, Caches: []string{}}
+	if j.Uses != "" {
+		job.Steps = append(job.Steps, Step{Uses: j.Uses})
+	}
+
+	for _, step := range j.Steps {
+		job.Steps = append(job.Steps, Step{Name: step.Name, Run: step.Run, Uses: step.Uses})
+
+		// e.g. `actions/setup-python@v4` with `cache: pip`
+		if strings.HasPrefix(step.Uses, "actions/setup-") && step.With["cache"] != "" {
+			job.Caches = append(job.Caches, step.With["cache"])
+		}
+		// `actions/cache@v3` and `actions/cache/restore@v3` with one or more paths to cache.
+		if strings.HasPrefix(step.Uses, "actions/cache@") || strings.HasPrefix(step.Uses, "actions/cache/restore@") {
+			for _, cachePath := range strings.Split(step.With["path"], "\n") {
+				if cachePath = strings.TrimSpace(cachePath); cachePath != "" {
+					job.Caches = append(job.Caches, cachePath)
+				}
+			}
+		}
+	}
+	return job
+}
+
+func ghTrigger(event string) Trigger {
+	switch event {
+	case "push":
+		return TriggerPush
+	case "pull_request", "pull_request_target":
+		return TriggerPullRequest
+	case "schedule":
+		return TriggerSchedule
+	case "workflow_dispatch":
+		return TriggerManual
+	}
+	return Trigger(event)
+}
@@ -1,9 +1,12 @@
 package ciproviders
 
 import (
+	"fmt"
 	"path"
+	"strings"
+
+	"gopkg.in/yaml.v3"
 
-	"github.com/bvobart/mllint/setools/git"
 	"github.com/bvobart/mllint/utils"
 )
 
@@ -11,14 +14,189 @@ const gitlabFile = ".gitlab-ci.yml"
 
 type Gitlab struct{}
 
-func (_ Gitlab) ConfigFile(projectdir string) string {
-	return path.Join(git.GetGitRoot(projectdir), gitlabFile)
+func (_ Gitlab) ConfigFile(rootdir string) string {
+	return path.Join(rootdir, gitlabFile)
 }
 
-func (_ Gitlab) Detect(projectdir string) bool {
-	return utils.FileExists(path.Join(git.GetGitRoot(projectdir), gitlabFile))
+func (_ Gitlab) Detect(rootdir string) bool {
+	return utils.FileExists(path.Join(rootdir, gitlabFile))
 }
 
 func (_ Gitlab) Type() ProviderType {
 	return TypeGitlab
 }
+
+// Top-level keywords in a `.gitlab-ci.yml` that are not jobs.
+// See https://docs.gitlab.com/ee/ci/yaml/#global-keywords
+var gitlabGlobalKeywords = map[string]bool{
+	"default": true, "include": true, "stages": true, "variables": true, "workflow": true,
+	"image": true, "services": true, "cache": true, "before_script": true, "after_script": true, "types": true,
+}
+
+type gitlabJob struct {
+	Script       script    `yaml:"script"`
+	BeforeScript *script   `yaml:"before_script"`
+	AfterScript  *script   `yaml:"after_script"`
+	Cache        yaml.Node `yaml:"cache"`
+	Rules        yaml.Node `yaml:"rules"`
+	Only         yaml.Node `yaml:"only"`
+	Extends      script    `yaml:"extends"`
+	// Downstream pipeline that the job triggers, instead of running a script.
+	Trigger yaml.Node `yaml:"trigger"`
+}
+
+// Pipelines parses the project's `.gitlab-ci.yml`. Jobs inherit the scripts and caches of the jobs they extend
+// and of the `default` section, but files included using `include` are not parsed.
+func (_ Gitlab) Pipelines(rootdir string) ([]Pipeline, error) {
+	root := yaml.Node{}
+	if err := readYAML(path.Join(rootdir, gitlabFile), &root); err != nil {
+		return nil, err
+	}
+	if len(root.Content) == 0 {
+		return []Pipeline{}, nil
+	}
+	config := root.Content[0]
+
+	// the global `before_script`, `after_script` and `cache` keywords are deprecated in favour of their equivalents in `default`.
+	defaults := gitlabJob{}
+	if err := config.Decode(&defaults); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", gitlabFile, err)
+	}
+	if defaultNode := mappingValue(config, "default"); defaultNode != nil {
+		if err := defaultNode.Decode(&defaults); err != nil {
+			return nil, fmt.Errorf("failed to parse 'default' in %s: %w", gitlabFile, err)
+		}
+	}
+
+	jobs := map[string]gitlabJob{}
+	for _, name := range mappingKeys(config) {
+		if gitlabGlobalKeywords[name] || mappingValue(config, name).Kind != yaml.MappingNode {
+			continue
+		}
+
+		job := gitlabJob{}
+		if err := mappingValue(config, name).Decode(&job); err != nil {
+			return nil, fmt.Errorf("failed to parse job '%s' in %s: %w", name, gitlabFile, err)
+		}
+		jobs[name] = job
+	}
+
+	pipeline := Pipeline{File: gitlabFile, Triggers: []Trigger{}, Jobs: []Job{}}
+	ruleExpressions := scalarValues(mappingValue(config, "workflow"))
+	for _, name := range mappingKeys(config) {
+		job, ok := jobs[name]
+		// hidden jobs, i.e. those starting with a dot, are only templates for other jobs.
+		if !ok || strings.HasPrefix(name, ".") {
+			continue
+		}
+
+		job = job.resolve(jobs, defaults, 0)
+		if len(job.Script) == 0 && job.Trigger.Kind == 0 {
+			continue
+		}
+
+		ruleExpressions = append(ruleExpressions, scalarValues(&job.Rules)...)
+		ruleExpressions = append(ruleExpressions, scalarValues(&job.Only)...)
+		pipeline.Jobs = append(pipeline.Jobs, job.toJob(name))
+	}
+
+	pipeline.Triggers = gitlabTriggers(ruleExpressions)
+	return []Pipeline{pipeline}, nil
+}
+
+// resolve returns the job with the keywords it inherits from the jobs it extends and from the defaults filled in.
+func (j gitlabJob) resolve(jobs map[string]gitlabJob, defaults gitlabJob, depth int) gitlabJob {
+	// GitLab supports up to 11 levels of inheritance, which also protects against circular `extends`
+	if depth > 11 {
+		return j
+	}
+
+	parents := append([]string{}, j.Extends...)
+	for i := len(parents) - 1; i >= 0; i-- {
+		parent, ok := jobs[parents[i]]
+		if !ok {
+			continue
+		}
+		parent = parent.resolve(jobs, gitlabJob{}, depth+1)
+		if len(j.Script) == 0 {
+			j.Script = parent.Script
+		}
+		if j.BeforeScript == nil {
+			j.BeforeScript = parent.BeforeScript
+		}
+		if j.AfterScript == nil {
+			j.AfterScript = parent.AfterScript
+		}
+		if j.Cache.Kind == 0 {
+			j.Cache = parent.Cache
+		}
+		if j.Rules.Kind == 0 {
+			j.Rules = parent.Rules
+		}
+		if j.Only.Kind == 0 {
+			j.Only = parent.Only
+		}
+	}
+
+	if j.BeforeScript == nil {
+		j.BeforeScript = defaults.BeforeScript
+	}
+	if j.AfterScript == nil {
+		j.AfterScript = defaults.AfterScript
+	}
+	if j.Cache.Kind == 0 {
+		j.Cache = defaults.Cache
+	}
+	return j
+}
+
+func (j gitlabJob) toJob(name string) Job {
+	job := Job{Name: name, Steps: []Step{}, Caches: []string{}}
+	if j.BeforeScript != nil && len(*j.BeforeScript) > 0 {
+		job.Steps = append(job.Steps, Step{Name: "before_script", Run: j.BeforeScript.String()})
+	}
+	if len(j.Script) > 0 {
+		job.Steps = append(job.Steps, Step{Name: "script", Run: j.Script.String()})
+	}
+	if j.AfterScript != nil && len(*j.AfterScript) > 0 {
+		job.Steps = append(job.Steps, Step{Name: "after_script", Run: j.AfterScript.String()})
+	}
+	if j.Trigger.Kind != 0 {
+		job.Steps = append(job.Steps, Step{Name: "trigger", Uses: strings.Join(scalarValues(&j.Trigger), " ")})
+	}
+
+	// `cache` can be a single cache or a list of caches, each with a list of paths.
+	caches := []*yaml.Node{&j.Cache}
+	if j.Cache.Kind == yaml.SequenceNode {
+		caches = j.Cache.Content
+	}
+	for _, cache := range caches {
+		if paths := mappingValue(cache, "paths"); paths != nil {
+			job.Caches = append(job.Caches, scalarValues(paths)...)
+		}
+	}
+	return job
+}
+
+// gitlabTriggers determines which events trigger the pipeline, based on the expressions in the `rules` and `only` keywords of the workflow and its jobs.
+// Without any such expressions that refer to specific events, GitLab runs a pipeline for every push to a branch.
+func gitlabTriggers(expressions []string) []Trigger {
+	triggers := []Trigger{}
+	for _, expression := range expressions {
+		switch {
+		case strings.Contains(expression, "merge_request"):
+			triggers = appendTrigger(triggers, TriggerPullRequest)
+		case strings.Contains(expression, "schedule"):
+			triggers = appendTrigger(triggers, TriggerSchedule)
+		case strings.Contains(expression, `"web"`), strings.Contains(expression, "'web'"), expression == "web":
+			triggers = appendTrigger(triggers, TriggerManual)
+		case strings.Contains(expression, "push"), strings.Contains(expression, "CI_COMMIT_BRANCH"), expression == "branches":
+			triggers = appendTrigger(triggers, TriggerPush)
+		}
+	}
+
+	if len(triggers) == 0 {
+		return []Trigger{TriggerPush}
+	}
+	return triggers
+}
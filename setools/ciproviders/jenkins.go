@@ -0,0 +1,137 @@
+package ciproviders
+
+import (
+	"io/ioutil"
+	"path"
+	"regexp"
+	"strings"
+
+	"github.com/bvobart/mllint/utils"
+)
+
+const jenkinsFile = "Jenkinsfile"
+
+var (
+	jenkinsStagePattern = regexp.MustCompile(`\bstage\s*\(\s*(?:name\s*:\s*)?(?:'([^']*)'|"([^"]*)")`)
+	// Matches shell steps, e.g. `sh 'pytest'`, `sh(script: "pytest", returnStatus: true)` or `bat '''...'''`
+	jenkinsShellPattern = regexp.MustCompile(`\b(?:sh|bat|powershell|pwsh)\s*\(?\s*(?:script\s*:\s*)?(?:'''([\s\S]*?)'''|"""([\s\S]*?)"""|'((?:[^'\\\n]|\\.)*)'|"((?:[^"\\\n]|\\.)*)")`)
+	// Matches the paths cached using the Job Cacher plugin, e.g. `cache(caches: [arbitraryFileCache(path: '.venv')])`
+	jenkinsCachePathPattern = regexp.MustCompile(`\bpath\s*:\s*(?:'([^']*)'|"([^"]*)")`)
+)
+
+type Jenkins struct{}
+
+func (_ Jenkins) ConfigFile(rootdir string) string {
+	return path.Join(rootdir, jenkinsFile)
+}
+
+func (_ Jenkins) Detect(rootdir string) bool {
+	return utils.FileExists(path.Join(rootdir, jenkinsFile))
+}
+
+func (_ Jenkins) Type() ProviderType {
+	return TypeJenkins
+}
+
+// Pipelines parses the project's `Jenkinsfile`, either declarative or scripted, without evaluating any Groovy code.
+// Each stage that does not contain any other stages becomes a job, with a step for every shell command that it runs.
+func (_ Jenkins) Pipelines(rootdir string) ([]Pipeline, error) {
+	contents, err := ioutil.ReadFile(path.Join(rootdir, jenkinsFile))
+	if err != nil {
+		return nil, err
+	}
+
+	source := string(contents)
+	pipeline := Pipeline{File: jenkinsFile, Triggers: jenkinsTriggers(source), Jobs: []Job{}}
+	for _, match := range jenkinsStagePattern.FindAllStringSubmatchIndex(source, -1) {
+		open := strings.Index(source[match[1]:], "{")
+		if open == -1 {
+			continue
+		}
+		body := groovyBlock(source, match[1]+open)
+		if jenkinsStagePattern.MatchString(body) {
+			continue
+		}
+
+		name := source[match[2]:match[3]]
+		if match[2] == -1 {
+			name = source[match[4]:match[5]]
+		}
+		pipeline.Jobs = append(pipeline.Jobs, jenkinsJob(name, body))
+	}
+
+	if len(pipeline.Jobs) == 0 {
+		pipeline.Jobs = append(pipeline.Jobs, jenkinsJob("pipeline", source))
+	}
+	return []Pipeline{pipeline}, nil
+}
+
+func jenkinsJob(name string, body string) Job {
+	job := Job{Name: name, Steps: []Step{}, Caches: []string{}}
+	for _, match := range jenkinsShellPattern.FindAllStringSubmatch(body, -1) {
+		job.Steps = append(job.Steps, Step{Run: strings.Join(match[1:], "")})
+	}
+
+	if strings.Contains(body, "cache(") {
+		for _, match := range jenkinsCachePathPattern.FindAllStringSubmatch(body, -1) {
+			job.Caches = append(job.Caches, match[1]+match[2])
+		}
+		if len(job.Caches) == 0 {
+			job.Caches = append(job.Caches, "cache")
+		}
+	}
+	return job
+}
+
+// jenkinsTriggers determines which events trigger the pipeline. Jenkins builds the branches of a multibranch pipeline when they are pushed,
+// but only builds pull requests when the pipeline refers to them, e.g. using `when { changeRequest() }` or `env.CHANGE_ID`
+func jenkinsTriggers(source string) []Trigger {
+	triggers := []Trigger{TriggerPush}
+	if strings.Contains(source, "changeRequest") || strings.Contains(source, "CHANGE_ID") {
+		triggers = append(triggers, TriggerPullRequest)
+	}
+	if strings.Contains(source, "cron(") || strings.Contains(source, "parameterizedCron(") {
+		triggers = append(triggers, TriggerSchedule)
+	}
+	return triggers
+}
+
+// groovyBlock returns the contents of the block that starts with the opening brace at the given index in the given Groovy source,
+// skipping braces in strings and comments. Returns the rest of the source if the block is not closed.
+func groovyBlock(source string, open int) string {
+	depth := 0
+	for i := open; i < len(source); i++ {
+		switch {
+		case strings.HasPrefix(source[i:], "'''"), strings.HasPrefix(source[i:], `"""`):
+			end := strings.Index(source[i+3:], source[i:i+3])
+			if end == -1 {
+				return source[open+1:]
+			}
+			i += end + 5
+		case source[i] == '\'' || source[i] == '"':
+			quote := source[i]
+			for i++; i < len(source) && source[i] != quote && source[i] != '\n'; i++ {
+				if source[i] == '\\' {
+					i++
+				}
+			}
+		case strings.HasPrefix(source[i:], "//"):
+			for ; i < len(source) && source[i] != '\n'; i++ {
+			}
+		case strings.HasPrefix(source[i:], "/*"):
+			end := strings.Index(source[i:], "*/")
+			if end == -1 {
+				return source[open+1:]
+			}
+			i += end + 1
+		case source[i] == '{':
+			depth++
+		case source[i] == '}':
+			depth--
+			if depth == 0 {
+				return source[open+1 : i]
+			}
+		}
+	}
+	return source[open+1:]
+}
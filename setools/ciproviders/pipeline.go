@@ -0,0 +1,201 @@
+package ciproviders
+
+import (
+	"fmt"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Trigger is a kind of event that makes a CI provider run a pipeline.
+type Trigger string
+
+const (
+	TriggerPush        Trigger = "push"
+	TriggerPullRequest Trigger = "pull request"
+	TriggerSchedule    Trigger = "schedule"
+	TriggerManual      Trigger = "manual"
+)
+
+// Pipeline is a CI pipeline (also known as a workflow) as defined in a CI provider's configuration file.
+type Pipeline struct {
+	// Filename of the configuration file that defines this pipeline, relative to the root of the Git repository.
+	File string
+	Name string
+	// The kinds of events that this pipeline runs on.
+	Triggers []Trigger
+	Jobs     []Job
+}
+
+// HasTrigger returns whether the pipeline runs on the given trigger.
+func (p Pipeline) HasTrigger(trigger Trigger) bool {
+	for _, t := range p.Triggers {
+		if t == trigger {
+			return true
+		}
+	}
+	return false
+}
+
+// Job is a single job in a CI pipeline, which runs a sequence of steps.
+type Job struct {
+	Name  string
+	Steps []Step
+	// The paths or package managers (e.g. `pip` or `~/.cache/pip`) of which the job caches files between runs.
+	Caches []string
+}
+
+// Step is a single step in a CI job.
+type Step struct {
+	Name string
+	// The shell script that the step runs, if any.
+	Run string
+	// The reusable action, task or template that the step uses, if any, e.g. `actions/setup-python@v4` or `Cache@2`
+	Uses string
+}
+
+// Commands returns the individual shell commands in the step's script, i.e. split on newlines, `&&` and `;`,
+// without empty lines and comments.
+func (s Step) Commands() []string {
+	commands := []string{}
+	for _, line := range strings.Split(s.Run, "\n") {
+		for _, andPart := range strings.Split(line, "&&") {
+			for _, command := range strings.Split(andPart, ";") {
+				command = strings.TrimSpace(command)
+				if command != "" && !strings.HasPrefix(command, "#") {
+					commands = append(commands, command)
+				}
+			}
+		}
+	}
+	return commands
+}
+
+// Location describes where a step is defined, e.g. "step `Run tests` of job `test` in `.github/workflows/ci.yml`"
+type Location struct {
+	Pipeline Pipeline
+	Job      Job
+	Step     Step
+}
+
+func (l Location) String() string {
+	if l.Step.Name != "" {
+		return fmt.Sprintf("step `%s` of job `%s` in `%s`", l.Step.Name, l.Job.Name, l.Pipeline.File)
+	}
+	return fmt.Sprintf("job `%s` in `%s`", l.Job.Name, l.Pipeline.File)
+}
+
+//---------------------------------------------------------------------------------------
+
+// script is a shell script in a CI configuration file, which may be specified either as a single string,
+// or as a (nested) list of lines, e.g. when using YAML anchors in GitLab CI.
+type script []string
+
+func (s *script) UnmarshalYAML(node *yaml.Node) error {
+	switch node.Kind {
+	case yaml.ScalarNode:
+		if node.Tag != "!!null" {
+			*s = append(*s, node.Value)
+		}
+		return nil
+	case yaml.SequenceNode:
+		for _, item := range node.Content {
+			if err := s.UnmarshalYAML(item); err != nil {
+				return err
+			}
+		}
+		return nil
+	case yaml.AliasNode:
+		return s.UnmarshalYAML(node.Alias)
+	}
+	return fmt.Errorf("line %d: expected a string or a list of strings", node.Line)
+}
+
+func (s script) String() string {
+	return strings.Join(s, "\n")
+}
+
+// mappingKeys returns the keys of the given YAML mapping node, in the order in which they are defined.
+func mappingKeys(node *yaml.Node) []string {
+	keys := []string{}
+	if node == nil || node.Kind != yaml.MappingNode {
+		return keys
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		keys = append(keys, node.Content[i].Value)
+	}
+	return keys
+}
+
+// mappingValue returns the value of the given key in the given YAML mapping node, or nil if the key does not exist.
+func mappingValue(node *yaml.Node, key string) *yaml.Node {
+	if node == nil || node.Kind != yaml.MappingNode {
+		return nil
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			return node.Content[i+1]
+		}
+	}
+	return nil
+}
+
+// scalarValues returns the values of the scalars in the given YAML node, or in its (nested) sequences and mappings.
+func scalarValues(node *yaml.Node) []string {
+	if node == nil {
+		return []string{}
+	}
+
+	switch node.Kind {
+	case yaml.ScalarNode:
+		return []string{node.Value}
+	case yaml.AliasNode:
+		return scalarValues(node.Alias)
+	}
+
+	values := []string{}
+	for _, child := range node.Content {
+		values = append(values, scalarValues(child)...)
+	}
+	return values
+}
+
+// scalarOrEmpty returns the value of the given YAML scalar node, or an empty string if it is not a scalar.
+func scalarOrEmpty(node *yaml.Node) string {
+	if node == nil || node.Kind != yaml.ScalarNode {
+		return ""
+	}
+	return node.Value
+}
+
+// scalarOrDefault returns the value of the given YAML scalar node, or the given default if it is not a scalar or empty.
+func scalarOrDefault(node *yaml.Node, def string) string {
+	if value := scalarOrEmpty(node); value != "" {
+		return value
+	}
+	return def
+}
+
+func appendTrigger(triggers []Trigger, trigger Trigger) []Trigger {
+	for _, t := range triggers {
+		if t == trigger {
+			return triggers
+		}
+	}
+	return append(triggers, trigger)
+}
+
+// appendUnique appends those of the given values that are not yet in the given list.
+func appendUnique(list []string, values ...string) []string {
+	seen := map[string]bool{}
+	for _, value := range list {
+		seen[value] = true
+	}
+	for _, value := range values {
+		if !seen[value] {
+			list = append(list, value)
+			seen[value] = true
+		}
+	}
+	return list
+}
@@ -0,0 +1,648 @@
+package ciproviders_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/bvobart/mllint/setools/ciproviders"
+)
+
+const ghWorkflow = `
+name: CI
+on:
+  push:
+    branches: [main]
+  pull_request:
+  workflow_dispatch:
+
+jobs:
+  test:
+    name: Run tests
+    runs-on: ubuntu-latest
+    steps:
+      - uses: actions/checkout@v3
+      - uses: actions/setup-python@v4
+        with:
+          python-version: "3.10"
+          cache: pip
+      - name: Install dependencies
+        run: pip install -r requirements.txt
+      - name: Run tests
+        run: |
+          # run the tests
+          pytest --junitxml=report.xml && coverage xml; echo done
+  lint:
+    runs-on: ubuntu-latest
+    steps:
+      - uses: actions/cache@v3
+        with:
+          path: |
+            ~/.cache/pip
+            .venv
+          key: venv
+      - uses: psf/black@stable
+  release:
+    uses: ./.github/workflows/release.yml
+`
+
+const ghWorkflowSchedule = `
+on: [schedule, push]
+jobs:
+  nightly:
+    steps:
+      - run: mllint run
+`
+
+func TestGHActionsPipelines(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.MkdirAll(path.Join(dir, ".github", "workflows"), 0755))
+	require.NoError(t, ioutil.WriteFile(path.Join(dir, ".github", "workflows", "ci.yml"), []byte(ghWorkflow), 0644))
+	require.NoError(t, ioutil.WriteFile(path.Join(dir, ".github", "workflows", "nightly.yaml"), []byte(ghWorkflowSchedule), 0644))
+	require.NoError(t, ioutil.WriteFile(path.Join(dir, ".github", "workflows", "ReadMe.md"), []byte("# Workflows"), 0644))
+
+	pipelines, err := ciproviders.GHActions{}.Pipelines(dir)
+	require.NoError(t, err)
+	require.Equal(t, []ciproviders.Pipeline{
+		{
+			File:     ".github/workflows/ci.yml",
+			Name:     "CI",
+			Triggers: []ciproviders.Trigger{ciproviders.TriggerPush, ciproviders.TriggerPullRequest, ciproviders.TriggerManual},
+			Jobs: []ciproviders.Job{
+				{
+					Name: "test",
+					Steps: []ciproviders.Step{
+						{Uses: "actions/checkout@v3"},
+						{Uses: "actions/setup-python@v4"},
+						{Name: "Install dependencies", Run: "pip install -r requirements.txt"},
+						{Name: "Run tests", Run: "# run the tests\npytest --junitxml=report.xml && coverage xml; echo done\n"},
+					},
+					Caches: []string{"pip"},
+				},
+				{
+					Name:   "lint",
+					Steps:  []ciproviders.Step{{Uses: "actions/cache@v3"}, {Uses: "psf/black@stable"}},
+					Caches: []string{"~/.cache/pip", ".venv"},
+				},
+				{
+					Name:   "release",
+					Steps:  []ciproviders.Step{{Uses: "./.github/workflows/release.yml"}},
+					Caches: []string{},
+				},
+			},
+		},
+		{
+			File:     ".github/workflows/nightly.yaml",
+			Triggers: []ciproviders.Trigger{ciproviders.TriggerSchedule, ciproviders.TriggerPush},
+			Jobs: []ciproviders.Job{
+				{Name: "nightly", Steps: []ciproviders.Step{{Run: "mllint run"}}, Caches: []string{}},
+			},
+		},
+	}, pipelines)
+
+	require.Equal(t, []string{"pytest --junitxml=report.xml", "coverage xml", "echo done"}, pipelines[0].Jobs[0].Steps[3].Commands())
+	require.True(t, pipelines[0].HasTrigger(ciproviders.TriggerPullRequest))
+	require.False(t, pipelines[1].HasTrigger(ciproviders.TriggerPullRequest))
+
+	// workflows that cannot be parsed are reported, but do not prevent the other workflows from being parsed.
+	require.NoError(t, ioutil.WriteFile(path.Join(dir, ".github", "workflows", "broken.yml"), []byte("jobs: [\n"), 0644))
+	require.NoError(t, ioutil.WriteFile(path.Join(dir, ".github", "workflows", "another-broken.yml"), []byte("jobs: [\n"), 0644))
+	brokenPipelines, err := ciproviders.GHActions{}.Pipelines(dir)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "failed to parse broken.yml")
+	require.Contains(t, err.Error(), "failed to parse another-broken.yml")
+	require.Equal(t, pipelines, brokenPipelines)
+}
+
+const gitlabCI = `
+default:
+  image: python:3.10
+  before_script:
+    - pip install poetry
+  cache:
+    paths: [.cache/pip]
+
+stages: [lint, test]
+
+workflow:
+  rules:
+    - if: $CI_PIPELINE_SOURCE == "merge_request_event"
+    - if: $CI_COMMIT_BRANCH == $CI_DEFAULT_BRANCH
+
+.python:
+  script:
+    - poetry install
+    - poetry run pytest
+
+test:
+  stage: test
+  extends: .python
+
+lint:
+  stage: lint
+  before_script: []
+  script: &lint
+    - [mypy src, pylint src]
+  cache: []
+
+variables:
+  PIP_CACHE_DIR: .cache/pip
+`
+
+func TestGitlabPipelines(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, ioutil.WriteFile(path.Join(dir, ".gitlab-ci.yml"), []byte(gitlabCI), 0644))
+
+	pipelines, err := ciproviders.Gitlab{}.Pipelines(dir)
+	require.NoError(t, err)
+	require.Equal(t, []ciproviders.Pipeline{
+		{
+			File:     ".gitlab-ci.yml",
+			Triggers: []ciproviders.Trigger{ciproviders.TriggerPullRequest, ciproviders.TriggerPush},
+			Jobs: []ciproviders.Job{
+				{
+					Name: "test",
+					Steps: []ciproviders.Step{
+						{Name: "before_script", Run: "pip install poetry"},
+						{Name: "script", Run: "poetry install\npoetry run pytest"},
+					},
+					Caches: []string{".cache/pip"},
+				},
+				{
+					Name:   "lint",
+					Steps:  []ciproviders.Step{{Name: "script", Run: "mypy src\npylint src"}},
+					Caches: []string{},
+				},
+			},
+		},
+	}, pipelines)
+
+	require.NoError(t, ioutil.WriteFile(path.Join(dir, ".gitlab-ci.yml"), []byte("test:\n  script: pytest\n"), 0644))
+	pipelines, err = ciproviders.Gitlab{}.Pipelines(dir)
+	require.NoError(t, err)
+	require.Equal(t, []ciproviders.Trigger{ciproviders.TriggerPush}, pipelines[0].Triggers)
+}
+
+const azurePipelines = `
+trigger:
+  - main
+schedules:
+  - cron: "0 0 * * *"
+    branches: { include: [main] }
+stages:
+  - stage: Test
+    jobs:
+      - job: test
+        steps:
+          - task: UsePythonVersion@0
+            inputs:
+              versionSpec: "3.10"
+          - task: Cache@2
+            inputs:
+              key: 'python | requirements.txt'
+              path: $(PIP_CACHE_DIR)
+          - script: |
+              pip install -r requirements.txt
+              pytest
+            displayName: Run tests
+  - stage: Deploy
+    jobs:
+      - deployment: deploy
+        strategy:
+          runOnce:
+            deploy:
+              steps:
+                - bash: ./deploy.sh
+`
+
+func TestAzurePipelines(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, ioutil.WriteFile(path.Join(dir, "azure-pipelines.yml"), []byte(azurePipelines), 0644))
+
+	pipelines, err := ciproviders.Azure{}.Pipelines(dir)
+	require.NoError(t, err)
+	require.Equal(t, []ciproviders.Pipeline{
+		{
+			File:     "azure-pipelines.yml",
+			Triggers: []ciproviders.Trigger{ciproviders.TriggerPush, ciproviders.TriggerPullRequest, ciproviders.TriggerSchedule},
+			Jobs: []ciproviders.Job{
+				{
+					Name: "test",
+					Steps: []ciproviders.Step{
+						{Uses: "UsePythonVersion@0"},
+						{Uses: "Cache@2"},
+						{Name: "Run tests", Run: "pip install -r requirements.txt\npytest\n"},
+					},
+					Caches: []string{"$(PIP_CACHE_DIR)"},
+				},
+				{
+					Name:   "deploy",
+					Steps:  []ciproviders.Step{{Run: "./deploy.sh"}},
+					Caches: []string{},
+				},
+			},
+		},
+	}, pipelines)
+
+	require.NoError(t, ioutil.WriteFile(path.Join(dir, "azure-pipelines.yml"), []byte("pr: none\nsteps:\n  - script: pytest\n"), 0644))
+	pipelines, err = ciproviders.Azure{}.Pipelines(dir)
+	require.NoError(t, err)
+	require.Equal(t, []ciproviders.Trigger{ciproviders.TriggerPush}, pipelines[0].Triggers)
+	require.Equal(t, []ciproviders.Job{{Name: "job", Steps: []ciproviders.Step{{Run: "pytest"}}, Caches: []string{}}}, pipelines[0].Jobs)
+}
+
+const travisCI = `
+language: python
+cache:
+  pip: true
+  directories: [.mypy_cache]
+install: pip install -r requirements.txt
+script:
+  - pytest
+jobs:
+  include:
+    - name: Lint
+      script: [black --check ., isort --check .]
+      cache: false
+    - stage: deploy
+      deploy:
+        provider: pypi
+`
+
+func TestTravisPipelines(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, ioutil.WriteFile(path.Join(dir, ".travis.yml"), []byte(travisCI), 0644))
+
+	pipelines, err := ciproviders.Travis{}.Pipelines(dir)
+	require.NoError(t, err)
+	require.Equal(t, []ciproviders.Pipeline{
+		{
+			File:     ".travis.yml",
+			Triggers: []ciproviders.Trigger{ciproviders.TriggerPush, ciproviders.TriggerPullRequest},
+			Jobs: []ciproviders.Job{
+				{
+					Name: "Lint",
+					Steps: []ciproviders.Step{
+						{Name: "install", Run: "pip install -r requirements.txt"},
+						{Name: "script", Run: "black --check .\nisort --check ."},
+					},
+					Caches: []string{},
+				},
+				{
+					Name: "deploy",
+					Steps: []ciproviders.Step{
+						{Name: "install", Run: "pip install -r requirements.txt"},
+						{Name: "script", Run: "pytest"},
+					},
+					Caches: []string{"pip", ".mypy_cache"},
+				},
+			},
+		},
+	}, pipelines)
+
+	require.NoError(t, ioutil.WriteFile(path.Join(dir, ".travis.yml"), []byte("language: python\ncache: pip\nscript: pytest\n"), 0644))
+	pipelines, err = ciproviders.Travis{}.Pipelines(dir)
+	require.NoError(t, err)
+	require.Equal(t, []ciproviders.Job{{Name: "build", Steps: []ciproviders.Step{{Name: "script", Run: "pytest"}}, Caches: []string{"pip"}}}, pipelines[0].Jobs)
+}
+
+func TestPipelines(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, ioutil.WriteFile(path.Join(dir, ".travis.yml"), []byte("script: pytest\n"), 0644))
+	require.NoError(t, ioutil.WriteFile(path.Join(dir, ".gitlab-ci.yml"), []byte("test: [\n"), 0644))
+
+	providers := ciproviders.Detect(dir)
+	require.Len(t, providers, 2)
+	pipelines, err := ciproviders.Pipelines(dir, providers)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "GitLab CI")
+	require.Len(t, pipelines, 1)
+	require.Equal(t, ".travis.yml", pipelines[0].File)
+}
+
+const jenkinsfile = `
+pipeline {
+    agent { docker { image 'python:3.10' } }
+    triggers { cron('H 4 * * *') }
+    stages {
+        stage('Install') {
+            steps {
+                cache(caches: [arbitraryFileCache(path: '.venv', cacheValidityDecidingFile: 'requirements.txt')]) {
+                    sh 'python -m venv .venv && .venv/bin/pip install -r requirements.txt'
+                }
+            }
+        }
+        stage("Checks") {
+            parallel {
+                stage('Test') {
+                    steps {
+                        sh(script: "pytest --junitxml=report.xml", returnStatus: true)
+                    }
+                }
+                stage('Lint') {
+                    when { changeRequest() }
+                    steps {
+                        // a comment with a brace }
+                        sh '''
+                            pylint src
+                            echo "}"
+                        '''
+                    }
+                }
+            }
+        }
+    }
+}
+`
+
+func TestJenkinsPipelines(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, ioutil.WriteFile(path.Join(dir, "Jenkinsfile"), []byte(jenkinsfile), 0644))
+
+	pipelines, err := ciproviders.Jenkins{}.Pipelines(dir)
+	require.NoError(t, err)
+	require.Equal(t, []ciproviders.Pipeline{
+		{
+			File:     "Jenkinsfile",
+			Triggers: []ciproviders.Trigger{ciproviders.TriggerPush, ciproviders.TriggerPullRequest, ciproviders.TriggerSchedule},
+			Jobs: []ciproviders.Job{
+				{Name: "Install", Steps: []ciproviders.Step{{Run: "python -m venv .venv && .venv/bin/pip install -r requirements.txt"}}, Caches: []string{".venv"}},
+				{Name: "Test", Steps: []ciproviders.Step{{Run: "pytest --junitxml=report.xml"}}, Caches: []string{}},
+				{Name: "Lint", Steps: []ciproviders.Step{{Run: "\n                            pylint src\n                            echo \"}\"\n                        "}}, Caches: []string{}},
+			},
+		},
+	}, pipelines)
+
+	require.NoError(t, ioutil.WriteFile(path.Join(dir, "Jenkinsfile"), []byte("node {\n  sh 'make test'\n}\n"), 0644))
+	pipelines, err = ciproviders.Jenkins{}.Pipelines(dir)
+	require.NoError(t, err)
+	require.Equal(t, []ciproviders.Trigger{ciproviders.TriggerPush}, pipelines[0].Triggers)
+	require.Equal(t, []ciproviders.Job{{Name: "pipeline", Steps: []ciproviders.Step{{Run: "make test"}}, Caches: []string{}}}, pipelines[0].Jobs)
+}
+
+const circleciConfig = `
+version: 2.1
+orbs:
+  python: circleci/python@2.1.1
+jobs:
+  test:
+    docker:
+      - image: cimg/python:3.10
+    steps:
+      - checkout
+      - restore_cache:
+          keys:
+            - deps-{{ checksum "poetry.lock" }}
+      - run: poetry install
+      - save_cache:
+          key: deps-{{ checksum "poetry.lock" }}
+          paths: [~/.cache/pypoetry]
+      - run:
+          name: Run tests
+          command: poetry run pytest
+  lint:
+    executor: python/default
+    steps:
+      - checkout
+      - python/install-packages:
+          pkg-manager: poetry
+      - run: poetry run mypy src
+workflows:
+  main:
+    jobs:
+      - test
+      - lint
+      - python/test:
+          pkg-manager: poetry
+`
+
+func TestCircleCIPipelines(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.MkdirAll(path.Join(dir, ".circleci"), 0755))
+	require.NoError(t, ioutil.WriteFile(path.Join(dir, ".circleci", "config.yml"), []byte(circleciConfig), 0644))
+
+	pipelines, err := ciproviders.CircleCI{}.Pipelines(dir)
+	require.NoError(t, err)
+	require.Equal(t, []ciproviders.Pipeline{
+		{
+			File:     ".circleci/config.yml",
+			Triggers: []ciproviders.Trigger{ciproviders.TriggerPush, ciproviders.TriggerPullRequest},
+			Jobs: []ciproviders.Job{
+				{
+					Name: "test",
+					Steps: []ciproviders.Step{
+						{Uses: "checkout"},
+						{Uses: "restore_cache"},
+						{Run: "poetry install"},
+						{Uses: "save_cache"},
+						{Name: "Run tests", Run: "poetry run pytest"},
+					},
+					Caches: []string{"~/.cache/pypoetry"},
+				},
+				{
+					Name:   "lint",
+					Steps:  []ciproviders.Step{{Uses: "checkout"}, {Uses: "python/install-packages"}, {Run: "poetry run mypy src"}},
+					Caches: []string{"poetry"},
+				},
+				{Name: "python/test", Steps: []ciproviders.Step{{Uses: "python/test"}}, Caches: []string{}},
+			},
+		},
+	}, pipelines)
+}
+
+const bitbucketPipelines = `
+image: python:3.10
+pipelines:
+  default:
+    - step:
+        name: Test
+        caches: [pip]
+        script:
+          - pip install -r requirements.txt
+          - pytest
+  pull-requests:
+    '**':
+      - parallel:
+          - step:
+              name: Lint
+              script:
+                - black --check .
+          - step:
+              script:
+                - pipe: atlassian/git-secrets-scan:0.5.1
+        after-script:
+          - echo done
+`
+
+func TestBitbucketPipelines(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, ioutil.WriteFile(path.Join(dir, "bitbucket-pipelines.yml"), []byte(bitbucketPipelines), 0644))
+
+	pipelines, err := ciproviders.Bitbucket{}.Pipelines(dir)
+	require.NoError(t, err)
+	require.Equal(t, []ciproviders.Pipeline{
+		{
+			File:     "bitbucket-pipelines.yml",
+			Triggers: []ciproviders.Trigger{ciproviders.TriggerPush, ciproviders.TriggerPullRequest},
+			Jobs: []ciproviders.Job{
+				{Name: "Test", Steps: []ciproviders.Step{{Name: "script", Run: "pip install -r requirements.txt\npytest"}}, Caches: []string{"pip"}},
+				{Name: "Lint", Steps: []ciproviders.Step{{Name: "script", Run: "black --check ."}}, Caches: []string{}},
+				{Name: "step", Steps: []ciproviders.Step{{Name: "script", Uses: "atlassian/git-secrets-scan:0.5.1"}}, Caches: []string{}},
+			},
+		},
+	}, pipelines)
+}
+
+const droneConfig = `
+kind: pipeline
+type: docker
+name: test
+
+trigger:
+  event:
+    include: [push, pull_request]
+
+steps:
+  - name: restore-cache
+    image: meltwater/drone-cache
+    settings:
+      restore: true
+      mount: [.venv]
+  - name: test
+    image: python:3.10
+    commands:
+      - pip install -r requirements.txt
+      - pytest
+  - name: rebuild-cache
+    image: meltwater/drone-cache
+    settings:
+      rebuild: true
+      mount: [.venv]
+---
+kind: pipeline
+name: nightly
+trigger:
+  event: [cron]
+steps:
+  - name: mllint
+    image: python:3.10
+    commands: [mllint]
+---
+kind: secret
+name: token
+`
+
+func TestDronePipelines(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, ioutil.WriteFile(path.Join(dir, ".drone.yml"), []byte(droneConfig), 0644))
+
+	pipelines, err := ciproviders.Drone{}.Pipelines(dir)
+	require.NoError(t, err)
+	require.Equal(t, []ciproviders.Pipeline{
+		{
+			File:     ".drone.yml",
+			Name:     "test",
+			Triggers: []ciproviders.Trigger{ciproviders.TriggerPush, ciproviders.TriggerPullRequest},
+			Jobs: []ciproviders.Job{
+				{
+					Name: "test",
+					Steps: []ciproviders.Step{
+						{Name: "restore-cache", Uses: "meltwater/drone-cache"},
+						{Name: "test", Run: "pip install -r requirements.txt\npytest"},
+						{Name: "rebuild-cache", Uses: "meltwater/drone-cache"},
+					},
+					Caches: []string{".venv"},
+				},
+			},
+		},
+		{
+			File:     ".drone.yml",
+			Name:     "nightly",
+			Triggers: []ciproviders.Trigger{ciproviders.TriggerSchedule},
+			Jobs:     []ciproviders.Job{{Name: "nightly", Steps: []ciproviders.Step{{Name: "mllint", Run: "mllint"}}, Caches: []string{}}},
+		},
+	}, pipelines)
+}
+
+const buildkitePipeline = `
+steps:
+  - label: ":pytest: Test"
+    command: pytest
+    plugins:
+      - docker#v5.8.0:
+          image: python:3.10
+      - gencer/cache#v2.4.10:
+          paths: [.venv]
+  - wait
+  - group: Lint
+    steps:
+      - key: lint
+        commands:
+          - pylint src
+          - mypy src
+  - trigger: deploy-pipeline
+`
+
+func TestBuildkitePipelines(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.MkdirAll(path.Join(dir, ".buildkite"), 0755))
+	require.NoError(t, ioutil.WriteFile(path.Join(dir, ".buildkite", "pipeline.yml"), []byte(buildkitePipeline), 0644))
+
+	pipelines, err := ciproviders.Buildkite{}.Pipelines(dir)
+	require.NoError(t, err)
+	require.Equal(t, []ciproviders.Pipeline{
+		{
+			File:     ".buildkite/pipeline.yml",
+			Triggers: []ciproviders.Trigger{ciproviders.TriggerPush, ciproviders.TriggerPullRequest},
+			Jobs: []ciproviders.Job{
+				{
+					Name:   ":pytest: Test",
+					Steps:  []ciproviders.Step{{Run: "pytest"}, {Uses: "docker#v5.8.0"}, {Uses: "gencer/cache#v2.4.10"}},
+					Caches: []string{".venv"},
+				},
+				{Name: "lint", Steps: []ciproviders.Step{{Run: "pylint src\nmypy src"}}, Caches: []string{}},
+				{Name: "deploy-pipeline", Steps: []ciproviders.Step{{Uses: "deploy-pipeline"}}, Caches: []string{}},
+			},
+		},
+	}, pipelines)
+}
+
+const woodpeckerConfig = `
+when:
+  - event: [push, manual]
+steps:
+  test:
+    image: python:3.10
+    commands:
+      - pip install -r requirements.txt
+      - pytest
+  notify:
+    image: plugins/slack
+`
+
+func TestWoodpeckerPipelines(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, ioutil.WriteFile(path.Join(dir, ".woodpecker.yml"), []byte(woodpeckerConfig), 0644))
+
+	pipelines, err := ciproviders.Woodpecker{}.Pipelines(dir)
+	require.NoError(t, err)
+	require.Equal(t, []ciproviders.Pipeline{
+		{
+			File:     ".woodpecker.yml",
+			Triggers: []ciproviders.Trigger{ciproviders.TriggerPush, ciproviders.TriggerManual},
+			Jobs: []ciproviders.Job{
+				{
+					Name: "default",
+					Steps: []ciproviders.Step{
+						{Name: "test", Run: "pip install -r requirements.txt\npytest"},
+						{Name: "notify", Uses: "plugins/slack"},
+					},
+					Caches: []string{},
+				},
+			},
+		},
+	}, pipelines)
+}
@@ -1,42 +1,93 @@
 package ciproviders
 
+import (
+	"fmt"
+	"io/ioutil"
+	"path"
+
+	"github.com/hashicorp/go-multierror"
+	"gopkg.in/yaml.v3"
+)
+
 const (
-	TypeAzure     ProviderType = "Azure DevOps"
-	TypeGHActions ProviderType = "GitHub Actions"
-	TypeGitlab    ProviderType = "GitLab CI"
-	TypeTravis    ProviderType = "Travis CI"
+	TypeAzure      ProviderType = "Azure DevOps"
+	TypeBitbucket  ProviderType = "Bitbucket Pipelines"
+	TypeBuildkite  ProviderType = "Buildkite"
+	TypeCircleCI   ProviderType = "CircleCI"
+	TypeDrone      ProviderType = "Drone CI"
+	TypeGHActions  ProviderType = "GitHub Actions"
+	TypeGitlab     ProviderType = "GitLab CI"
+	TypeJenkins    ProviderType = "Jenkins"
+	TypeTravis     ProviderType = "Travis CI"
+	TypeWoodpecker ProviderType = "Woodpecker CI"
 )
 
 var (
-	azure     Provider = Azure{}
-	ghActions Provider = GHActions{}
-	gitlab    Provider = Gitlab{}
-	travis    Provider = Travis{}
+	azure      Provider = Azure{}
+	bitbucket  Provider = Bitbucket{}
+	buildkite  Provider = Buildkite{}
+	circleci   Provider = CircleCI{}
+	drone      Provider = Drone{}
+	ghActions  Provider = GHActions{}
+	gitlab     Provider = Gitlab{}
+	jenkins    Provider = Jenkins{}
+	travis     Provider = Travis{}
+	woodpecker Provider = Woodpecker{}
 )
 
-var all = []Provider{azure, ghActions, gitlab, travis}
+var all = []Provider{azure, bitbucket, buildkite, circleci, drone, ghActions, gitlab, jenkins, travis, woodpecker}
 
 type ProviderType string
 type Provider interface {
-	// ConfigFile returns the location of the CI provider's configuration file in a project.
-	ConfigFile(projectdir string) string
+	// ConfigFile returns the location of the CI provider's configuration file in the Git repository with the given root directory.
+	ConfigFile(rootdir string) string
 
-	// Detects whether the project at the given location uses this provider. Checking for config file existance should be enough
-	Detect(projectdir string) bool
+	// Detects whether the Git repository with the given root directory uses this provider. Checking for config file existance should be enough
+	Detect(rootdir string) bool
 
 	// Type of CI provider, i.e. one of ciproviders.Type*
 	Type() ProviderType
+
+	// Pipelines parses the CI provider's configuration file(s) in the Git repository with the given root directory into the pipelines that they define.
+	Pipelines(rootdir string) ([]Pipeline, error)
 }
 
-// Detect detects CI providers in the root of the Git repository that the given folder is in.
-// Often the Git root dir and the given folder will be the same, but not in the case of monorepo style repos.
-// When the dir is not in a Git repo, then it will simply just check the dir.
-func Detect(projectdir string) []Provider {
+// Detect detects CI providers in the given root directory of a Git repository, see git.Backend.GetGitRoot.
+// Often the Git root dir and the project's folder will be the same, but not in the case of monorepo style repos.
+// When the project is not in a Git repo, then its folder should simply be given.
+func Detect(rootdir string) []Provider {
 	providers := []Provider{}
 	for _, p := range all {
-		if p.Detect(projectdir) {
+		if p.Detect(rootdir) {
 			providers = append(providers, p)
 		}
 	}
 	return providers
 }
+
+// Pipelines parses the pipelines of all the given CI providers in the Git repository with the given root directory.
+// Returns the pipelines that could be parsed, along with the errors of the configuration files that could not.
+func Pipelines(rootdir string, providers []Provider) ([]Pipeline, error) {
+	var multiErr *multierror.Error
+	pipelines := []Pipeline{}
+	for _, provider := range providers {
+		providerPipelines, err := provider.Pipelines(rootdir)
+		if err != nil {
+			multiErr = multierror.Append(multiErr, fmt.Errorf("failed to parse %s configuration: %w", provider.Type(), err))
+		}
+		pipelines = append(pipelines, providerPipelines...)
+	}
+	return pipelines, multiErr.ErrorOrNil()
+}
+
+// readYAML reads and parses the YAML file with the given filename into the given value.
+func readYAML(filename string, value interface{}) error {
+	contents, err := ioutil.ReadFile(filename)
+	if err != nil {
+		return err
+	}
+	if err := yaml.Unmarshal(contents, value); err != nil {
+		return fmt.Errorf("failed to parse %s: %w", path.Base(filename), err)
+	}
+	return nil
+}
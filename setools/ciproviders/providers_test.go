@@ -11,7 +11,7 @@ import (
 )
 
 func TestDetect(t *testing.T) {
-	dir := "."
+	dir := git.GetGitRoot(".")
 	providers := ciproviders.Detect(dir)
 	require.Equal(t, []ciproviders.Provider{ciproviders.GHActions{}}, providers)
 
@@ -21,8 +21,8 @@ func TestDetect(t *testing.T) {
 }
 
 func TestConfigFile(t *testing.T) {
-	dir := "."
+	dir := git.GetGitRoot(".")
 	provider := ciproviders.GHActions{}
 	configDir := provider.ConfigFile(dir)
-	require.Equal(t, path.Join(git.GetGitRoot(dir), ".github", "workflows"), configDir)
+	require.Equal(t, path.Join(dir, ".github", "workflows"), configDir)
 }
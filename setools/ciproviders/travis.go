@@ -1,9 +1,11 @@
 package ciproviders
 
 import (
+	"fmt"
 	"path"
 
-	"github.com/bvobart/mllint/setools/git"
+	"gopkg.in/yaml.v3"
+
 	"github.com/bvobart/mllint/utils"
 )
 
@@ -11,14 +13,137 @@ const travisFile = ".travis.yml"
 
 type Travis struct{}
 
-func (_ Travis) ConfigFile(projectdir string) string {
-	return path.Join(git.GetGitRoot(projectdir), travisFile)
+func (_ Travis) ConfigFile(rootdir string) string {
+	return path.Join(rootdir, travisFile)
 }
 
-func (_ Travis) Detect(projectdir string) bool {
-	return utils.FileExists(path.Join(git.GetGitRoot(projectdir), travisFile))
+func (_ Travis) Detect(rootdir string) bool {
+	return utils.FileExists(path.Join(rootdir, travisFile))
 }
 
 func (_ Travis) Type() ProviderType {
 	return TypeTravis
 }
+
+// The phases of a Travis CI job that run shell commands, in the order in which they are run.
+// See https://docs.travis-ci.com/user/job-lifecycle/
+var travisPhases = []string{"before_install", "install", "before_script", "script", "before_cache", "after_success", "after_failure", "after_script"}
+
+type travisJob struct {
+	Name   string
+	Stage  string
+	Cache  yaml.Node
+	Phases map[string]script
+}
+
+// Pipelines parses the project's `.travis.yml`. Jobs in `jobs.include` inherit the phases and cache of the top-level configuration.
+func (_ Travis) Pipelines(rootdir string) ([]Pipeline, error) {
+	root := yaml.Node{}
+	if err := readYAML(path.Join(rootdir, travisFile), &root); err != nil {
+		return nil, err
+	}
+
+	// Travis CI builds both pushes and pull requests by default.
+	pipeline := Pipeline{File: travisFile, Triggers: []Trigger{TriggerPush, TriggerPullRequest}, Jobs: []Job{}}
+	if len(root.Content) == 0 {
+		return []Pipeline{pipeline}, nil
+	}
+
+	config := root.Content[0]
+	defaults, err := decodeTravisJob(config)
+	if err != nil {
+		return nil, err
+	}
+
+	includes := []*yaml.Node{}
+	for _, key := range []string{"jobs", "matrix"} {
+		if include := mappingValue(mappingValue(config, key), "include"); include != nil && include.Kind == yaml.SequenceNode {
+			includes = append(includes, include.Content...)
+		}
+	}
+
+	if len(includes) == 0 {
+		pipeline.Jobs = append(pipeline.Jobs, defaults.toJob("build"))
+	}
+	for i, node := range includes {
+		job, err := decodeTravisJob(node)
+		if err != nil {
+			return nil, err
+		}
+		job = job.inherit(defaults)
+
+		name := job.Name
+		if name == "" {
+			name = job.Stage
+		}
+		if name == "" {
+			name = fmt.Sprintf("job %d", i+1)
+		}
+		pipeline.Jobs = append(pipeline.Jobs, job.toJob(name))
+	}
+	return []Pipeline{pipeline}, nil
+}
+
+func decodeTravisJob(node *yaml.Node) (travisJob, error) {
+	job := travisJob{Phases: map[string]script{}}
+	if node.Kind != yaml.MappingNode {
+		return job, nil
+	}
+
+	job.Name = scalarOrEmpty(mappingValue(node, "name"))
+	job.Stage = scalarOrEmpty(mappingValue(node, "stage"))
+	if cache := mappingValue(node, "cache"); cache != nil {
+		job.Cache = *cache
+	}
+	for _, phase := range travisPhases {
+		if value := mappingValue(node, phase); value != nil {
+			commands := script{}
+			if err := value.Decode(&commands); err != nil {
+				return job, fmt.Errorf("failed to parse '%s' in %s: %w", phase, travisFile, err)
+			}
+			job.Phases[phase] = commands
+		}
+	}
+	return job, nil
+}
+
+func (j travisJob) inherit(defaults travisJob) travisJob {
+	for phase, commands := range defaults.Phases {
+		if _, ok := j.Phases[phase]; !ok {
+			j.Phases[phase] = commands
+		}
+	}
+	if j.Cache.Kind == 0 {
+		j.Cache = defaults.Cache
+	}
+	return j
+}
+
+func (j travisJob) toJob(name string) Job {
+	job := Job{Name: name, Steps: []Step{}, Caches: []string{}}
+	for _, phase := range travisPhases {
+		if commands, ok := j.Phases[phase]; ok && len(commands) > 0 {
+			job.Steps = append(job.Steps, Step{Name: phase, Run: commands.String()})
+		}
+	}
+
+	// `cache` can be e.g. `pip`, `[pip, directories]` or `{ pip: true, directories: [~/.cache/custom] }`
+	switch j.Cache.Kind {
+	case yaml.ScalarNode:
+		if j.Cache.Value != "false" {
+			job.Caches = append(job.Caches, j.Cache.Value)
+		}
+	case yaml.SequenceNode:
+		job.Caches = append(job.Caches, scalarValues(&j.Cache)...)
+	case yaml.MappingNode:
+		for _, key := range mappingKeys(&j.Cache) {
+			value := mappingValue(&j.Cache, key)
+			if key == "directories" {
+				job.Caches = append(job.Caches, scalarValues(value)...)
+			} else if value.Value != "false" {
+				job.Caches = append(job.Caches, key)
+			}
+		}
+	}
+	return job
+}
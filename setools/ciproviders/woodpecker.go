@@ -0,0 +1,38 @@
+package ciproviders
+
+import (
+	"path"
+
+	"github.com/bvobart/mllint/utils"
+)
+
+const woodpeckerFile = ".woodpecker.yml"
+
+type Woodpecker struct{}
+
+func (_ Woodpecker) ConfigFile(rootdir string) string {
+	return path.Join(rootdir, woodpeckerFile)
+}
+
+func (_ Woodpecker) Detect(rootdir string) bool {
+	return utils.FileExists(path.Join(rootdir, woodpeckerFile))
+}
+
+func (_ Woodpecker) Type() ProviderType {
+	return TypeWoodpecker
+}
+
+// Pipelines parses the project's `.woodpecker.yml`, which defines a single pipeline with the same syntax as Drone CI pipelines,
+// except that the events that trigger the pipeline are defined in `when`
+func (_ Woodpecker) Pipelines(rootdir string) ([]Pipeline, error) {
+	config := droneConfig{}
+	if err := readYAML(path.Join(rootdir, woodpeckerFile), &config); err != nil {
+		return nil, err
+	}
+
+	pipeline, err := config.toPipeline(woodpeckerFile, &config.When)
+	if err != nil {
+		return nil, err
+	}
+	return []Pipeline{pipeline}, nil
+}
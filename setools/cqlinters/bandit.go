@@ -1,6 +1,7 @@
 package cqlinters
 
 import (
+	"context"
 	"fmt"
 	"path"
 	"strings"
@@ -38,7 +39,7 @@ func (p Bandit) IsProperlyConfigured(project api.Project) bool {
 	return true // Bandit doesn't necessarily need to be configured.
 }
 
-func (p Bandit) Run(project api.Project) ([]api.CQLinterResult, error) {
+func (p Bandit) Run(ctx context.Context, project api.Project) ([]api.CQLinterResult, error) {
 	if len(project.PythonFiles) == 0 {
 		return []api.CQLinterResult{}, nil
 	}
@@ -52,10 +53,14 @@ func (p Bandit) Run(project api.Project) ([]api.CQLinterResult, error) {
 	}
 	excludeArgs := strings.Join(excludeDirs, ",")
 
-	output, err := exec.CommandOutput(project.Dir, "bandit", "-f", "yaml", "-x", excludeArgs, "-r", project.Dir)
+	args := append([]string{"-f", "yaml", "-x", excludeArgs, "-r"}, lintTargets(project)...)
+	output, err := exec.CommandOutput(ctx, project.Dir, "bandit", args...)
 	if err == nil {
 		return []api.CQLinterResult{}, nil
 	}
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
 	return decodeBanditOutput(output, project.Dir)
 }
 
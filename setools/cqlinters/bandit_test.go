@@ -1,6 +1,7 @@
 package cqlinters_test
 
 import (
+	"context"
 	"errors"
 	"testing"
 
@@ -33,7 +34,7 @@ func TestBandit(t *testing.T) {
 func TestBanditRun(t *testing.T) {
 	l := cqlinters.Bandit{}
 	t.Run("EmptyProject", func(t *testing.T) {
-		results, err := l.Run(api.Project{})
+		results, err := l.Run(context.Background(), api.Project{})
 		require.NoError(t, err)
 		require.Equal(t, results, []api.CQLinterResult{})
 	})
@@ -45,10 +46,10 @@ func TestBanditRun(t *testing.T) {
 		}
 
 		exec.CommandOutput = mockexec.ExpectCommand(t).Dir(project.Dir).
-			CommandName("bandit").CommandArgs("-f", "yaml", "-x", ".env,.venv,env,venv,ENV,env.bak,venv.bak", "-r", project.Dir).
+			CommandName("bandit").CommandArgs("-f", "yaml", "-x", ".env,.venv,env,venv,ENV,env.bak,venv.bak", "-r", "file1", "file2", "file3").
 			ToOutput([]byte(testBanditOutput), errors.New("bandit always exits with an error when there are messages"))
 
-		results, err := l.Run(project)
+		results, err := l.Run(context.Background(), project)
 		require.NoError(t, err)
 		require.Len(t, results, 4)
 		for i, result := range results {
@@ -64,10 +65,10 @@ func TestBanditRun(t *testing.T) {
 		}
 
 		exec.CommandOutput = mockexec.ExpectCommand(t).Dir(project.Dir).
-			CommandName("bandit").CommandArgs("-f", "yaml", "-x", ".env,.venv,env,venv,ENV,env.bak,venv.bak", "-r", project.Dir).
+			CommandName("bandit").CommandArgs("-f", "yaml", "-x", ".env,.venv,env,venv,ENV,env.bak,venv.bak", "-r", "file1", "file2", "file3").
 			ToOutput([]byte(testBanditErrorOutput), errors.New("bandit always exits with an error when there are messages"))
 
-		results, err := l.Run(project)
+		results, err := l.Run(context.Background(), project)
 		require.Nil(t, results)
 		require.EqualError(t, err, "Bandit had errors: [Tbh I have no idea what this could be or this]")
 	})
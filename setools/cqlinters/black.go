@@ -1,6 +1,7 @@
 package cqlinters
 
 import (
+	"context"
 	"strings"
 
 	"github.com/bvobart/mllint/api"
@@ -40,7 +41,7 @@ func (p Black) IsProperlyConfigured(project api.Project) bool {
 	return true // Black doesn't really need configuration
 }
 
-func (p Black) Run(project api.Project) ([]api.CQLinterResult, error) {
+func (p Black) Run(ctx context.Context, project api.Project) ([]api.CQLinterResult, error) {
 	if len(project.PythonFiles) == 0 {
 		return []api.CQLinterResult{}, nil
 	}
@@ -48,10 +49,14 @@ func (p Black) Run(project api.Project) ([]api.CQLinterResult, error) {
 	// Enforce explicit ignoring of virtualenv folders.
 	// Folders to be ignored taken from official Python Gitignore: https://github.com/github/gitignore/blob/991e760c1c6d50fdda246e0178b9c58b06770b90/Python.gitignore#L107
 	excludeArg := `/(\.env|\.venv|env|venv|ENV|env\.bak|venv\.bak)/`
-	output, err := exec.CommandCombinedOutput(project.Dir, "black", "--check", "--extend-exclude", excludeArg, project.Dir)
+	args := append([]string{"--check", "--extend-exclude", excludeArg}, lintTargets(project)...)
+	output, err := exec.CommandCombinedOutput(ctx, project.Dir, "black", args...)
 	if err == nil {
 		return []api.CQLinterResult{}, nil
 	}
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
 	return decodeBlackOutput(string(output), project.Dir), nil
 }
 
@@ -1,6 +1,7 @@
 package cqlinters_test
 
 import (
+	"context"
 	"errors"
 	"testing"
 
@@ -51,7 +52,7 @@ const testBlackSuccessOutput = `All done! ✨ 🍰 ✨
 func TestBlackRun(t *testing.T) {
 	l := cqlinters.Black{}
 	t.Run("EmptyProject", func(t *testing.T) {
-		results, err := l.Run(api.Project{})
+		results, err := l.Run(context.Background(), api.Project{})
 		require.NoError(t, err)
 		require.Equal(t, results, []api.CQLinterResult{})
 	})
@@ -63,10 +64,10 @@ func TestBlackRun(t *testing.T) {
 		}
 
 		exec.CommandCombinedOutput = mockexec.ExpectCommand(t).Dir(project.Dir).
-			CommandName("black").CommandArgs("--check", "--extend-exclude", "/(\\.env|\\.venv|env|venv|ENV|env\\.bak|venv\\.bak)/", project.Dir).
+			CommandName("black").CommandArgs("--check", "--extend-exclude", "/(\\.env|\\.venv|env|venv|ENV|env\\.bak|venv\\.bak)/", "file1", "file2", "file3").
 			ToOutput([]byte(testBlackOutput), errors.New("black always exits with an error when there are messages"))
 
-		results, err := l.Run(project)
+		results, err := l.Run(context.Background(), project)
 		require.NoError(t, err)
 		require.Len(t, results, 3)
 		for i, result := range results {
@@ -81,10 +82,26 @@ func TestBlackRun(t *testing.T) {
 		}
 
 		exec.CommandCombinedOutput = mockexec.ExpectCommand(t).Dir(project.Dir).
-			CommandName("black").CommandArgs("--check", "--extend-exclude", "/(\\.env|\\.venv|env|venv|ENV|env\\.bak|venv\\.bak)/", project.Dir).
+			CommandName("black").CommandArgs("--check", "--extend-exclude", "/(\\.env|\\.venv|env|venv|ENV|env\\.bak|venv\\.bak)/", "file1", "file2", "file3").
 			ToOutput([]byte(testBlackSuccessOutput), nil)
 
-		results, err := l.Run(project)
+		results, err := l.Run(context.Background(), project)
+		require.NoError(t, err)
+		require.Len(t, results, 0)
+	})
+
+	t.Run("PartialProject", func(t *testing.T) {
+		project := api.Project{
+			Dir:         ".",
+			PythonFiles: utils.Filenames{"file1", "file2"},
+			Partial:     &api.PartialScope{Description: "staged files", AllPythonFiles: utils.Filenames{"file1", "file2", "file3"}},
+		}
+
+		exec.CommandCombinedOutput = mockexec.ExpectCommand(t).Dir(project.Dir).
+			CommandName("black").CommandArgs("--check", "--extend-exclude", "/(\\.env|\\.venv|env|venv|ENV|env\\.bak|venv\\.bak)/", "file1", "file2").
+			ToOutput([]byte(testBlackSuccessOutput), nil)
+
+		results, err := l.Run(context.Background(), project)
 		require.NoError(t, err)
 		require.Len(t, results, 0)
 	})
@@ -0,0 +1,110 @@
+package cqlinters
+
+import (
+	"context"
+	"encoding/gob"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/bvobart/mllint/api"
+	"github.com/bvobart/mllint/utils/cache"
+	"github.com/bvobart/mllint/utils/exec"
+)
+
+func init() {
+	// register all types of results that linters can produce, such that they can be stored in the cache.
+	gob.Register(PylintMessage{})
+	gob.Register(MypyMessage{})
+	gob.Register(BanditMessage{})
+	gob.Register(ISortProblem{})
+	gob.Register(stringer(""))
+}
+
+// Files in a project that may contain configuration for any of the linters.
+var configFiles = []string{
+	"pyproject.toml",
+	"setup.cfg",
+	"tox.ini",
+	"pylintrc",
+	".pylintrc",
+	"mypy.ini",
+	".mypy.ini",
+	".isort.cfg",
+	".bandit",
+}
+
+// RunCached runs the linter on the project like linter.Run, unless cache.Default already holds results of running
+// the same version of this linter, with the same configuration, on the same Python files, in which case those results are returned.
+func RunCached(ctx context.Context, linter api.CQLinter, project api.Project) ([]api.CQLinterResult, error) {
+	if cache.Default == nil {
+		return linter.Run(ctx, project)
+	}
+
+	key, err := cacheKey(ctx, linter, project)
+	if err != nil {
+		return linter.Run(ctx, project)
+	}
+
+	results := []api.CQLinterResult{}
+	if err := cache.Default.Get(key, &results); err == nil {
+		return results, nil
+	}
+
+	results, err = linter.Run(ctx, project)
+	if err != nil {
+		return nil, err
+	}
+
+	// failing to store the results only means that the linter will be run again next time.
+	_ = cache.Default.Put(key, results)
+	return results, nil
+}
+
+func cacheKey(ctx context.Context, linter api.CQLinter, project api.Project) (*cache.Key, error) {
+	version, err := toolVersion(ctx, project.Dir, linter.DependencyName())
+	if err != nil {
+		return nil, err
+	}
+
+	key := cache.NewKey("cqlinter", string(linter.Type()), version, project.Dir)
+	if err := key.AddFiles(projectConfigFiles(project)...); err != nil {
+		return nil, err
+	}
+	if err := key.AddFiles(project.PythonFiles...); err != nil {
+		return nil, err
+	}
+	return key, nil
+}
+
+func projectConfigFiles(project api.Project) []string {
+	files := make([]string, len(configFiles))
+	for i, filename := range configFiles {
+		files[i] = filepath.Join(project.Dir, filename)
+	}
+	return files
+}
+
+type toolInDir struct {
+	dir  string
+	tool string
+}
+
+var versions sync.Map
+
+// toolVersion returns the output of running the given tool with `--version` in the given directory, which is only done once per tool and directory,
+// as e.g. the projects in a monorepo may each have their own virtualenv with a different version of the tool.
+func toolVersion(ctx context.Context, dir string, tool string) (string, error) {
+	if version, ok := versions.Load(toolInDir{dir, tool}); ok {
+		return version.(string), nil
+	}
+
+	output, err := exec.CommandOutput(ctx, dir, tool, "--version")
+	if err != nil {
+		return "", err
+	}
+
+	version := strings.TrimSpace(string(output))
+	versions.Store(toolInDir{dir, tool}, version)
+	return version, nil
+}
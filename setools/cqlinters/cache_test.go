@@ -0,0 +1,101 @@
+package cqlinters_test
+
+import (
+	"context"
+	"os"
+	"path"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bvobart/mllint/api"
+	"github.com/bvobart/mllint/api/mock_api"
+	"github.com/bvobart/mllint/setools/cqlinters"
+	"github.com/bvobart/mllint/utils/cache"
+	"github.com/bvobart/mllint/utils/exec"
+	"github.com/bvobart/mllint/utils/exec/mockexec"
+)
+
+func TestRunCached(t *testing.T) {
+	dir := t.TempDir()
+	pyfile := path.Join(dir, "main.py")
+	require.NoError(t, os.WriteFile(pyfile, []byte("print('hello')\n"), 0644))
+	project := api.Project{Dir: dir, PythonFiles: []string{pyfile}}
+
+	results := []api.CQLinterResult{
+		cqlinters.ISortProblem{Path: "main.py", Message: "Imports are incorrectly sorted and/or formatted."},
+		cqlinters.PylintMessage{Type: cqlinters.TypeConvention, Symbol: "missing-module-docstring", Path: "main.py", Line: 1},
+	}
+
+	ctrl := gomock.NewController(t)
+	linter := mock_api.NewMockCQLinter(ctrl)
+	linter.EXPECT().Type().AnyTimes().Return(api.CQLinterType("test"))
+	linter.EXPECT().DependencyName().AnyTimes().Return("test-linter")
+	exec.CommandOutput = mockexec.ExpectCommand(t).Dir(dir).CommandName("test-linter").CommandArgs("--version").ToOutput([]byte("test-linter 1.0.0\n"), nil)
+	defer func() { exec.CommandOutput = exec.DefaultCommandOutput }()
+
+	defer func(original *cache.Cache) { cache.Default = original }(cache.Default)
+
+	t.Run("NoCache", func(t *testing.T) {
+		cache.Default = nil
+		linter.EXPECT().Run(gomock.Any(), project).Times(2).Return(results, nil)
+		for i := 0; i < 2; i++ {
+			actual, err := cqlinters.RunCached(context.Background(), linter, project)
+			require.NoError(t, err)
+			require.Equal(t, results, actual)
+		}
+	})
+
+	cache.Default = cache.New(t.TempDir())
+
+	t.Run("Cached", func(t *testing.T) {
+		linter.EXPECT().Run(gomock.Any(), project).Times(1).Return(results, nil)
+		for i := 0; i < 3; i++ {
+			actual, err := cqlinters.RunCached(context.Background(), linter, project)
+			require.NoError(t, err)
+			require.Equal(t, results, actual)
+		}
+	})
+
+	t.Run("ChangedPythonFile", func(t *testing.T) {
+		require.NoError(t, os.WriteFile(pyfile, []byte("print('world')\n"), 0644))
+		linter.EXPECT().Run(gomock.Any(), project).Times(1).Return(results[:1], nil)
+		for i := 0; i < 2; i++ {
+			actual, err := cqlinters.RunCached(context.Background(), linter, project)
+			require.NoError(t, err)
+			require.Equal(t, results[:1], actual)
+		}
+	})
+
+	t.Run("ChangedConfig", func(t *testing.T) {
+		require.NoError(t, os.WriteFile(path.Join(dir, "pyproject.toml"), []byte("[tool.isort]\nprofile = \"black\"\n"), 0644))
+		linter.EXPECT().Run(gomock.Any(), project).Times(1).Return(results, nil)
+		actual, err := cqlinters.RunCached(context.Background(), linter, project)
+		require.NoError(t, err)
+		require.Equal(t, results, actual)
+	})
+
+	t.Run("ErrorsAreNotCached", func(t *testing.T) {
+		require.NoError(t, os.WriteFile(pyfile, []byte("print('error')\n"), 0644))
+		linter.EXPECT().Run(gomock.Any(), project).Times(2).Return(nil, context.DeadlineExceeded)
+		for i := 0; i < 2; i++ {
+			_, err := cqlinters.RunCached(context.Background(), linter, project)
+			require.ErrorIs(t, err, context.DeadlineExceeded)
+		}
+	})
+
+	t.Run("OtherProject", func(t *testing.T) {
+		// the version of the linter is checked in each project's own directory, since e.g. each project may have its own virtualenv.
+		other := t.TempDir()
+		otherProject := api.Project{Dir: other, PythonFiles: []string{}}
+		exec.CommandOutput = mockexec.ExpectCommand(t).Dir(other).CommandName("test-linter").CommandArgs("--version").ToOutput([]byte("test-linter 2.0.0\n"), nil)
+
+		linter.EXPECT().Run(gomock.Any(), otherProject).Times(1).Return(results[:1], nil)
+		for i := 0; i < 2; i++ {
+			actual, err := cqlinters.RunCached(context.Background(), linter, otherProject)
+			require.NoError(t, err)
+			require.Equal(t, results[:1], actual)
+		}
+	})
+}
@@ -6,13 +6,16 @@ import (
 
 	"github.com/bvobart/mllint/api"
 	"github.com/bvobart/mllint/config"
+	"github.com/bvobart/mllint/setools/precommit"
 )
 
 // Detect finds all CQLinters that are used within the project.
 func Detect(project api.Project) []api.CQLinter {
+	// the project's pre-commit configuration is only read once, rather than once for each linter.
+	preCommit := readPreCommitConfig(project)
 	res := []api.CQLinter{}
 	for _, linter := range ByType {
-		if DetectLinter(linter, project) {
+		if detectLinter(linter, project, preCommit) {
 			res = append(res, linter)
 		}
 	}
@@ -26,10 +29,25 @@ func DetectType(typ api.CQLinterType, project api.Project) bool {
 }
 
 // DetectLinter returns true if this is CQLinter is being used in the project,
-// i.e. when either linter.IsConfigured() is true, or when the project's main dependency manager has the linter in its dependencies.
+// i.e. when either linter.IsConfigured() is true, when the project's main dependency manager has the linter in its dependencies,
+// or when the project runs the linter as a pre-commit hook.
 func DetectLinter(linter api.CQLinter, project api.Project) bool {
+	return detectLinter(linter, project, readPreCommitConfig(project))
+}
+
+func detectLinter(linter api.CQLinter, project api.Project, preCommit *precommit.Config) bool {
 	return linter.IsConfigured(project) ||
-		(len(project.DepManagers) > 0 && project.DepManagers.Main().HasDependency(linter.DependencyName()))
+		(len(project.DepManagers) > 0 && project.DepManagers.Main().HasDependency(linter.DependencyName())) ||
+		(preCommit != nil && preCommit.HasHook(linter.DependencyName()))
+}
+
+// readPreCommitConfig reads the project's pre-commit configuration, returning nil if it does not have a (valid) one.
+func readPreCommitConfig(project api.Project) *precommit.Config {
+	conf, err := precommit.ReadConfig(project.Dir)
+	if err != nil {
+		return nil
+	}
+	return conf
 }
 
 //---------------------------------------------------------------------------------------
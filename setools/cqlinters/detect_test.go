@@ -1,6 +1,8 @@
 package cqlinters_test
 
 import (
+	"os"
+	"path"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -39,3 +41,23 @@ func TestDetect(t *testing.T) {
 	require.Len(t, linters, 5)
 	require.Subset(t, linters, []api.CQLinter{cqlinters.Pylint{}, cqlinters.Mypy{}, cqlinters.Black{}, cqlinters.ISort{}, cqlinters.Bandit{}})
 }
+
+func TestDetectPreCommit(t *testing.T) {
+	dir := t.TempDir()
+	preCommitConfig := `repos:
+  - repo: https://github.com/psf/black
+    rev: 23.1.0
+    hooks:
+      - id: black-jupyter
+  - repo: local
+    hooks:
+      - id: lint
+        entry: poetry run pylint
+        language: system
+`
+	require.NoError(t, os.WriteFile(path.Join(dir, ".pre-commit-config.yaml"), []byte(preCommitConfig), 0644))
+
+	linters := cqlinters.Detect(api.Project{Dir: dir})
+	require.Len(t, linters, 2)
+	require.Subset(t, linters, []api.CQLinter{cqlinters.Pylint{}, cqlinters.Black{}})
+}
@@ -1,6 +1,7 @@
 package cqlinters
 
 import (
+	"context"
 	"path"
 	"strings"
 
@@ -49,7 +50,7 @@ func (p ISort) IsProperlyConfigured(project api.Project) bool {
 	return false
 }
 
-func (p ISort) Run(project api.Project) ([]api.CQLinterResult, error) {
+func (p ISort) Run(ctx context.Context, project api.Project) ([]api.CQLinterResult, error) {
 	if len(project.PythonFiles) == 0 {
 		return []api.CQLinterResult{}, nil
 	}
@@ -62,11 +63,14 @@ func (p ISort) Run(project api.Project) ([]api.CQLinterResult, error) {
 		excludeArgs = append(excludeArgs, "--extend-skip", excludeDir)
 	}
 
-	args := append([]string{"-c", project.Dir}, excludeArgs...)
-	output, err := exec.CommandCombinedOutput(project.Dir, "isort", args...)
+	args := append(append([]string{"-c"}, lintTargets(project)...), excludeArgs...)
+	output, err := exec.CommandCombinedOutput(ctx, project.Dir, "isort", args...)
 	if err == nil {
 		return []api.CQLinterResult{}, nil
 	}
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
 	return decodeISortOutput(string(output), project.Dir), nil
 }
 
@@ -1,6 +1,7 @@
 package cqlinters_test
 
 import (
+	"context"
 	"errors"
 	"testing"
 
@@ -56,17 +57,17 @@ func TestISortRun(t *testing.T) {
 	l := cqlinters.ISort{}
 
 	// helper mocking function to assert that isort is called correctly
-	isortCommandCombinedOutput := func(project api.Project, output string, err error) func(dir, name string, args ...string) ([]byte, error) {
-		return func(dir, name string, args ...string) ([]byte, error) {
+	isortCommandCombinedOutput := func(project api.Project, output string, err error) func(ctx context.Context, dir, name string, args ...string) ([]byte, error) {
+		return func(_ context.Context, dir, name string, args ...string) ([]byte, error) {
 			require.Equal(t, project.Dir, dir)
 			require.Equal(t, "isort", name)
-			require.Equal(t, []string{"-c", project.Dir, "--extend-skip", ".env", "--extend-skip", ".venv", "--extend-skip", "env", "--extend-skip", "venv", "--extend-skip", "ENV", "--extend-skip", "env.bak", "--extend-skip", "venv.bak"}, args)
+			require.Equal(t, []string{"-c", "file1", "file2", "file3", "--extend-skip", ".env", "--extend-skip", ".venv", "--extend-skip", "env", "--extend-skip", "venv", "--extend-skip", "ENV", "--extend-skip", "env.bak", "--extend-skip", "venv.bak"}, args)
 			return []byte(output), err
 		}
 	}
 
 	t.Run("EmptyProject", func(t *testing.T) {
-		results, err := l.Run(api.Project{})
+		results, err := l.Run(context.Background(), api.Project{})
 		require.NoError(t, err)
 		require.Equal(t, results, []api.CQLinterResult{})
 	})
@@ -79,7 +80,7 @@ func TestISortRun(t *testing.T) {
 
 		exec.CommandCombinedOutput = isortCommandCombinedOutput(project, testISortOutput, exiterr)
 
-		results, err := l.Run(project)
+		results, err := l.Run(context.Background(), project)
 		require.NoError(t, err)
 		require.Len(t, results, 5)
 		for i, result := range results {
@@ -96,7 +97,7 @@ func TestISortRun(t *testing.T) {
 
 		exec.CommandCombinedOutput = isortCommandCombinedOutput(project, testISortSuccessSkippedOutput, nil)
 
-		results, err := l.Run(project)
+		results, err := l.Run(context.Background(), project)
 		require.NoError(t, err)
 		require.Len(t, results, 0)
 	})
@@ -109,7 +110,7 @@ func TestISortRun(t *testing.T) {
 
 		exec.CommandCombinedOutput = isortCommandCombinedOutput(project, testISortSuccessEmptyOutput, nil)
 
-		results, err := l.Run(project)
+		results, err := l.Run(context.Background(), project)
 		require.NoError(t, err)
 		require.Len(t, results, 0)
 	})
@@ -1,6 +1,7 @@
 package cqlinters
 
 import (
+	"context"
 	"fmt"
 	"path"
 	"strconv"
@@ -38,7 +39,7 @@ func (p Mypy) IsProperlyConfigured(project api.Project) bool {
 	return p.IsConfigured(project)
 }
 
-func (p Mypy) Run(project api.Project) ([]api.CQLinterResult, error) {
+func (p Mypy) Run(ctx context.Context, project api.Project) ([]api.CQLinterResult, error) {
 	if len(project.PythonFiles) == 0 {
 		return []api.CQLinterResult{}, nil
 	}
@@ -46,7 +47,12 @@ func (p Mypy) Run(project api.Project) ([]api.CQLinterResult, error) {
 	// Enforce explicit ignoring of virtualenv folders.
 	// Folders to be ignored taken from official Python Gitignore: https://github.com/github/gitignore/blob/991e760c1c6d50fdda246e0178b9c58b06770b90/Python.gitignore#L107
 	excludeArg := `/(\.env|\.venv|env|venv|ENV|env\.bak|venv\.bak)/`
-	output, _ := exec.CommandOutput(project.Dir, "mypy", project.Dir, "--exclude", excludeArg, "--strict", "--no-pretty", "--no-error-summary", "--no-color-output", "--hide-error-context", "--show-error-codes", "--show-column-numbers")
+	args := append(lintTargets(project), "--exclude", excludeArg, "--strict", "--no-pretty", "--no-error-summary", "--no-color-output", "--hide-error-context", "--show-error-codes", "--show-column-numbers")
+	output, _ := exec.CommandOutput(ctx, project.Dir, "mypy", args...)
+	// Mypy exits with an error when there are messages, so that error is ignored, unless mypy was killed because the context is done.
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
 	return decodeMypyOutput(output)
 }
 
@@ -1,6 +1,7 @@
 package cqlinters_test
 
 import (
+	"context"
 	"errors"
 	"testing"
 
@@ -45,7 +46,7 @@ var expectedMypyMessageStrings = [5]string{
 func TestMypyRun(t *testing.T) {
 	l := cqlinters.Mypy{}
 	t.Run("EmptyProject", func(t *testing.T) {
-		results, err := l.Run(api.Project{})
+		results, err := l.Run(context.Background(), api.Project{})
 		require.NoError(t, err)
 		require.Equal(t, results, []api.CQLinterResult{})
 	})
@@ -56,14 +57,14 @@ func TestMypyRun(t *testing.T) {
 			PythonFiles: utils.Filenames{"file1", "file2", "file3"},
 		}
 
-		exec.CommandOutput = func(dir, name string, args ...string) ([]byte, error) {
+		exec.CommandOutput = func(_ context.Context, dir, name string, args ...string) ([]byte, error) {
 			require.Equal(t, project.Dir, dir)
 			require.Equal(t, "mypy", name)
-			require.Equal(t, []string{project.Dir, "--exclude", "/(\\.env|\\.venv|env|venv|ENV|env\\.bak|venv\\.bak)/", "--strict", "--no-pretty", "--no-error-summary", "--no-color-output", "--hide-error-context", "--show-error-codes", "--show-column-numbers"}, args)
+			require.Equal(t, []string{"file1", "file2", "file3", "--exclude", "/(\\.env|\\.venv|env|venv|ENV|env\\.bak|venv\\.bak)/", "--strict", "--no-pretty", "--no-error-summary", "--no-color-output", "--hide-error-context", "--show-error-codes", "--show-column-numbers"}, args)
 			return []byte(testMypyOutput), errors.New("mypy always exits with an error when there are messages")
 		}
 
-		results, err := l.Run(project)
+		results, err := l.Run(context.Background(), project)
 		require.NoError(t, err)
 		require.Len(t, results, 5)
 		for i, result := range results {
@@ -77,14 +78,14 @@ func TestMypyRun(t *testing.T) {
 			PythonFiles: utils.Filenames{"file1", "file2", "file3"},
 		}
 
-		exec.CommandOutput = func(dir, name string, args ...string) ([]byte, error) {
+		exec.CommandOutput = func(_ context.Context, dir, name string, args ...string) ([]byte, error) {
 			require.Equal(t, project.Dir, dir)
 			require.Equal(t, "mypy", name)
-			require.Equal(t, []string{project.Dir, "--exclude", "/(\\.env|\\.venv|env|venv|ENV|env\\.bak|venv\\.bak)/", "--strict", "--no-pretty", "--no-error-summary", "--no-color-output", "--hide-error-context", "--show-error-codes", "--show-column-numbers"}, args)
+			require.Equal(t, []string{"file1", "file2", "file3", "--exclude", "/(\\.env|\\.venv|env|venv|ENV|env\\.bak|venv\\.bak)/", "--strict", "--no-pretty", "--no-error-summary", "--no-color-output", "--hide-error-context", "--show-error-codes", "--show-column-numbers"}, args)
 			return []byte(testMypySuccessOutput), nil
 		}
 
-		results, err := l.Run(project)
+		results, err := l.Run(context.Background(), project)
 		require.NoError(t, err)
 		require.Len(t, results, 0)
 	})
@@ -1,6 +1,7 @@
 package cqlinters
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"path"
@@ -37,15 +38,18 @@ func (p Pylint) IsProperlyConfigured(project api.Project) bool {
 	return p.IsConfigured(project)
 }
 
-func (p Pylint) Run(project api.Project) ([]api.CQLinterResult, error) {
+func (p Pylint) Run(ctx context.Context, project api.Project) ([]api.CQLinterResult, error) {
 	if len(project.PythonFiles) == 0 {
 		return []api.CQLinterResult{}, nil
 	}
 
 	pylintArgs := []string{"-f", "json"}
 	pylintArgs = append(pylintArgs, project.PythonFiles...)
-	output, _ := exec.CommandCombinedOutput(project.Dir, "pylint", pylintArgs...)
-	// Pylint always exits with an error when there are messages, so we ignore the error.
+	output, _ := exec.CommandCombinedOutput(ctx, project.Dir, "pylint", pylintArgs...)
+	// Pylint always exits with an error when there are messages, so we ignore the error, unless Pylint was killed because the context is done.
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
 
 	var messages pylintMessageList
 	if err := json.Unmarshal(output, &messages); err != nil {
@@ -1,6 +1,7 @@
 package cqlinters_test
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"testing"
@@ -131,7 +132,7 @@ var expectedPylintMessageStrings = [4]string{
 func TestPylintRun(t *testing.T) {
 	l := cqlinters.Pylint{}
 	t.Run("EmptyProject", func(t *testing.T) {
-		results, err := l.Run(api.Project{})
+		results, err := l.Run(context.Background(), api.Project{})
 		require.NoError(t, err)
 		require.Equal(t, []api.CQLinterResult{}, results)
 	})
@@ -142,14 +143,14 @@ func TestPylintRun(t *testing.T) {
 			PythonFiles: utils.Filenames{"file1", "file2", "file3"},
 		}
 
-		exec.CommandCombinedOutput = func(dir, name string, args ...string) ([]byte, error) {
+		exec.CommandCombinedOutput = func(_ context.Context, dir, name string, args ...string) ([]byte, error) {
 			require.Equal(t, project.Dir, dir)
 			require.Equal(t, "pylint", name)
 			require.Equal(t, []string{"-f", "json", "file1", "file2", "file3"}, args)
 			return []byte(testPylintOutput), errors.New("pylint always exits with an error when there are messages")
 		}
 
-		results, err := l.Run(project)
+		results, err := l.Run(context.Background(), project)
 		require.NoError(t, err)
 		require.Len(t, results, 4)
 		for i, result := range results {
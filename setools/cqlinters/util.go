@@ -1,6 +1,10 @@
 package cqlinters
 
-import "path/filepath"
+import (
+	"path/filepath"
+
+	"github.com/bvobart/mllint/api"
+)
 
 type stringer string
 
@@ -13,3 +17,10 @@ func trimProjectDir(path string, projectdir string) string {
 	}
 	return relpath
 }
+
+// lintTargets returns the paths to run linters on that would otherwise analyse the project's entire directory,
+// i.e. the project's Python files, such that linters analyse exactly the files that mllint discovered,
+// respecting the project's `.gitignore` files and the configured include and exclude patterns.
+func lintTargets(project api.Project) []string {
+	return append([]string{}, project.PythonFiles...)
+}
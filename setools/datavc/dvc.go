@@ -0,0 +1,44 @@
+package datavc
+
+import (
+	"errors"
+	"path"
+
+	"github.com/bvobart/mllint/api"
+	"github.com/bvobart/mllint/setools/dvc"
+	"github.com/bvobart/mllint/utils"
+)
+
+var ErrNotDetected = errors.New("data version control tool not detected")
+
+type typeDVC string
+
+func (t typeDVC) String() string {
+	return string(t)
+}
+
+func (t typeDVC) Detect(project api.Project) (api.DataVersionControl, error) {
+	if !utils.FileExists(path.Join(project.Dir, ".dvc", "config")) {
+		return nil, ErrNotDetected
+	}
+	return DVC{Project: project}, nil
+}
+
+//---------------------------------------------------------------------------------------
+
+// DVC is Data Version Control, see https://dvc.org
+type DVC struct {
+	Project api.Project
+}
+
+func (d DVC) Type() api.DataVersionControlType {
+	return TypeDVC
+}
+
+func (d DVC) Files() []string {
+	return dvc.Files(d.Project.Dir)
+}
+
+func (d DVC) HasRemote() bool {
+	return len(dvc.Remotes(d.Project.Dir)) > 0
+}
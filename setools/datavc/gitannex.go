@@ -0,0 +1,106 @@
+package datavc
+
+import (
+	"bufio"
+	"os"
+	"path"
+	"strings"
+
+	"github.com/bvobart/mllint/api"
+	"github.com/bvobart/mllint/setools/git"
+	"github.com/bvobart/mllint/utils"
+)
+
+// prefix of the targets of the symlinks (or the contents of the pointer files, for unlocked files) that git-annex commits instead of the actual files.
+const annexObjectsPath = "annex/objects/"
+
+type typeGitAnnex string
+
+func (t typeGitAnnex) String() string {
+	return string(t)
+}
+
+func (t typeGitAnnex) Detect(project api.Project) (api.DataVersionControl, error) {
+	if !git.Detect(project.Dir) || !utils.FolderExists(path.Join(git.GetGitRoot(project.Dir), ".git", "annex")) {
+		return nil, ErrNotDetected
+	}
+	return GitAnnex{Project: project, files: findAnnexedFiles(project)}, nil
+}
+
+// findAnnexedFiles returns the files tracked by Git that have been annexed.
+func findAnnexedFiles(project api.Project) []string {
+	tracked, err := listTrackedFiles(project)
+	if err != nil {
+		return nil
+	}
+
+	files := []string{}
+	for _, file := range tracked {
+		if isAnnexed(path.Join(project.Dir, file)) {
+			files = append(files, file)
+		}
+	}
+	return files
+}
+
+//---------------------------------------------------------------------------------------
+
+// GitAnnex is git-annex, see https://git-annex.branchable.com
+type GitAnnex struct {
+	Project api.Project
+	// annexed files, listed once when git-annex is detected.
+	files []string
+}
+
+func (a GitAnnex) Type() api.DataVersionControlType {
+	return TypeGitAnnex
+}
+
+// Files returns the files tracked by Git that have been annexed, i.e. that are symlinks into the annex,
+// or pointer files to the annex in the case of unlocked files.
+func (a GitAnnex) Files() []string {
+	return a.files
+}
+
+func isAnnexed(filename string) bool {
+	info, err := os.Lstat(filename)
+	if err != nil {
+		return false
+	}
+
+	if info.Mode()&os.ModeSymlink != 0 {
+		target, err := os.Readlink(filename)
+		return err == nil && strings.Contains(target, annexObjectsPath)
+	}
+
+	// unlocked files are pointer files that contain the path of the file in the annex, unless their contents are present.
+	if !info.Mode().IsRegular() || info.Size() > 1024 {
+		return false
+	}
+	contents, err := os.ReadFile(filename)
+	return err == nil && strings.HasPrefix(string(contents), "/"+annexObjectsPath)
+}
+
+// HasRemote returns true if any of the Git remotes in the repository's `.git/config` is known to git-annex,
+// i.e. has an `annex-uuid`, meaning that annexed files can be copied to it.
+func (a GitAnnex) HasRemote() bool {
+	file, err := os.Open(path.Join(git.GetGitRoot(a.Project.Dir), ".git", "config"))
+	if err != nil {
+		return false
+	}
+	defer file.Close()
+
+	inRemote := false
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if strings.HasPrefix(line, "[") {
+			inRemote = strings.HasPrefix(line, "[remote ")
+			continue
+		}
+		if inRemote && strings.HasPrefix(line, "annex-uuid") {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,78 @@
+package datavc
+
+import (
+	"context"
+
+	"path"
+
+	"github.com/bvobart/mllint/api"
+	"github.com/bvobart/mllint/setools/git"
+	"github.com/bvobart/mllint/utils"
+)
+
+type typeGitLFS string
+
+func (t typeGitLFS) String() string {
+	return string(t)
+}
+
+func (t typeGitLFS) Detect(project api.Project) (api.DataVersionControl, error) {
+	if !git.Detect(project.Dir) {
+		return nil, ErrNotDetected
+	}
+
+	patterns, err := git.ReadLFSPatterns(git.GetGitRoot(project.Dir))
+	if err != nil {
+		return nil, err
+	}
+	if !patterns.Any() {
+		return nil, ErrNotDetected
+	}
+	return GitLFS{Project: project, Patterns: patterns, files: findLFSFiles(project, patterns)}, nil
+}
+
+// findLFSFiles returns the files tracked by Git that match the given `filter=lfs` patterns.
+func findLFSFiles(project api.Project, patterns git.LFSPatterns) []string {
+	tracked, err := listTrackedFiles(project)
+	if err != nil {
+		return nil
+	}
+
+	root := git.GetGitRoot(project.Dir)
+	files := []string{}
+	for _, file := range tracked {
+		// LFS patterns are relative to the repository's root, which is not necessarily the project's directory.
+		if relpath, err := relativeTo(root, path.Join(project.Dir, file)); err == nil && patterns.Matches(relpath) {
+			files = append(files, file)
+		}
+	}
+	return files
+}
+
+//---------------------------------------------------------------------------------------
+
+// GitLFS is Git Large File Storage, see https://git-lfs.github.com
+type GitLFS struct {
+	Project  api.Project
+	Patterns git.LFSPatterns
+	// files tracked by Git that match the patterns, listed once when Git LFS is detected.
+	files []string
+}
+
+func (l GitLFS) Type() api.DataVersionControlType {
+	return TypeGitLFS
+}
+
+// Files returns the files tracked by Git that match the `filter=lfs` patterns in the project's `.gitattributes`.
+func (l GitLFS) Files() []string {
+	return l.files
+}
+
+// HasRemote returns true if the project's Git repository has a remote, which Git LFS stores its files on by default,
+// or if a custom LFS server is configured in the project's `.lfsconfig`.
+func (l GitLFS) HasRemote() bool {
+	if remote, err := git.ForProject(l.Project).GetRemoteURL(context.Background(), l.Project.Dir); err == nil && remote != "" {
+		return true
+	}
+	return utils.FileExists(path.Join(git.GetGitRoot(l.Project.Dir), ".lfsconfig"))
+}
@@ -0,0 +1,82 @@
+package datavc
+
+import (
+	"os"
+	"path"
+	"sort"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/bvobart/mllint/api"
+)
+
+const (
+	// LakectlConfigFile is the configuration file of lakeFS's CLI, lakectl.
+	LakectlConfigFile = ".lakectl.yaml"
+	// LakeFSRefFile is the file that `lakectl local` creates in every folder that is synced with a lakeFS repository.
+	LakeFSRefFile = ".lakefs_ref.yaml"
+)
+
+type typeLakeFS string
+
+func (t typeLakeFS) String() string {
+	return string(t)
+}
+
+func (t typeLakeFS) Detect(project api.Project) (api.DataVersionControl, error) {
+	lakefs := LakeFS{Project: project, Folders: []string{}}
+
+	contents, err := os.ReadFile(path.Join(project.Dir, LakectlConfigFile))
+	hasConfig := err == nil
+	if hasConfig {
+		if err := yaml.Unmarshal(contents, &lakefs.Config); err != nil {
+			return nil, err
+		}
+	}
+
+	err = walkProject(project.Dir, func(relpath string, info os.FileInfo) {
+		if !info.IsDir() && info.Name() == LakeFSRefFile {
+			lakefs.Folders = append(lakefs.Folders, path.Dir(relpath))
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if !hasConfig && len(lakefs.Folders) == 0 {
+		return nil, ErrNotDetected
+	}
+	sort.Strings(lakefs.Folders)
+	return lakefs, nil
+}
+
+//---------------------------------------------------------------------------------------
+
+// LakeFS is lakeFS, see https://lakefs.io
+type LakeFS struct {
+	Project api.Project
+	Config  LakectlConfig
+	// Folders in the project that are synced with a lakeFS repository using `lakectl local`, relative to the project's root.
+	Folders []string
+}
+
+// LakectlConfig is the contents of a `.lakectl.yaml` file.
+type LakectlConfig struct {
+	Server struct {
+		EndpointURL string `yaml:"endpoint_url"`
+	} `yaml:"server"`
+}
+
+func (l LakeFS) Type() api.DataVersionControlType {
+	return TypeLakeFS
+}
+
+func (l LakeFS) Files() []string {
+	return l.Folders
+}
+
+// HasRemote returns true if a lakeFS server is configured, or any folder is synced with a lakeFS repository,
+// since lakeFS always stores data on a lakeFS server.
+func (l LakeFS) HasRemote() bool {
+	return l.Config.Server.EndpointURL != "" || len(l.Folders) > 0
+}
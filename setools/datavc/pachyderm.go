@@ -0,0 +1,141 @@
+package datavc
+
+import (
+	"os"
+	"path"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/bvobart/mllint/api"
+)
+
+// maximum size of a file that is inspected to see whether it is a Pachyderm pipeline specification.
+const maxPipelineSpecSize = 1_000_000
+
+type typePachyderm string
+
+func (t typePachyderm) String() string {
+	return string(t)
+}
+
+func (t typePachyderm) Detect(project api.Project) (api.DataVersionControl, error) {
+	pachyderm := Pachyderm{Project: project, Specs: map[string]PipelineSpec{}}
+	err := walkProject(project.Dir, func(relpath string, info os.FileInfo) {
+		if info.IsDir() || info.Size() > maxPipelineSpecSize || !isLikelyPipelineSpec(relpath) {
+			return
+		}
+
+		if spec, ok := parsePipelineSpec(path.Join(project.Dir, relpath)); ok {
+			pachyderm.Specs[relpath] = spec
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if len(pachyderm.Specs) == 0 {
+		return nil, ErrNotDetected
+	}
+	return pachyderm, nil
+}
+
+// returns whether the file at the given path may be a Pachyderm pipeline specification, i.e. a JSON or YAML file whose name contains
+// `pipeline` or `pachyderm`, such as `pipelines/edges.json` or `edges-pipeline.yml`, since parsing every JSON and YAML file in a project is too slow.
+func isLikelyPipelineSpec(relpath string) bool {
+	ext := path.Ext(relpath)
+	if ext != ".json" && ext != ".yaml" && ext != ".yml" {
+		return false
+	}
+	lower := strings.ToLower(relpath)
+	return strings.Contains(lower, "pipeline") || strings.Contains(lower, "pachyderm")
+}
+
+// parses the given file as a Pachyderm pipeline specification, returning false if it is not one.
+func parsePipelineSpec(filename string) (PipelineSpec, bool) {
+	contents, err := os.ReadFile(filename)
+	if err != nil {
+		return PipelineSpec{}, false
+	}
+
+	// YAML is a superset of JSON, so this also parses JSON pipeline specifications.
+	spec := PipelineSpec{}
+	if err := yaml.Unmarshal(contents, &spec); err != nil {
+		return PipelineSpec{}, false
+	}
+	return spec, spec.Pipeline.Name != "" && spec.Transform != nil
+}
+
+//---------------------------------------------------------------------------------------
+
+// Pachyderm is Pachyderm, see https://www.pachyderm.com
+type Pachyderm struct {
+	Project api.Project
+	// Maps the paths of the project's Pachyderm pipeline specifications, relative to the project's root, to their contents.
+	Specs map[string]PipelineSpec
+}
+
+// PipelineSpec is a Pachyderm pipeline specification, see https://docs.pachyderm.com/latest/reference/pipeline-spec/
+type PipelineSpec struct {
+	Pipeline struct {
+		Name string `yaml:"name"`
+	} `yaml:"pipeline"`
+	Transform interface{} `yaml:"transform"`
+	Input     *Input      `yaml:"input"`
+}
+
+// Input is the input of a Pachyderm pipeline, which is either a PFS input, or a combination of other inputs.
+type Input struct {
+	PFS *struct {
+		Repo string `yaml:"repo"`
+	} `yaml:"pfs"`
+	Cross []Input `yaml:"cross"`
+	Union []Input `yaml:"union"`
+	Join  []Input `yaml:"join"`
+	Group []Input `yaml:"group"`
+}
+
+// Repos returns the names of the Pachyderm repositories that are used by this input.
+func (i *Input) Repos() []string {
+	if i == nil {
+		return nil
+	}
+
+	repos := []string{}
+	if i.PFS != nil && i.PFS.Repo != "" {
+		repos = append(repos, i.PFS.Repo)
+	}
+	for _, inputs := range [][]Input{i.Cross, i.Union, i.Join, i.Group} {
+		for _, input := range inputs {
+			repos = append(repos, input.Repos()...)
+		}
+	}
+	return repos
+}
+
+func (p Pachyderm) Type() api.DataVersionControlType {
+	return TypePachyderm
+}
+
+// Files returns the Pachyderm repositories that the project's pipelines use as input, in the form `pfs://<repo>`,
+// since Pachyderm versions data in repositories inside a Pachyderm cluster, rather than files in the project.
+func (p Pachyderm) Files() []string {
+	seen := map[string]bool{}
+	files := []string{}
+	for _, spec := range p.Specs {
+		for _, repo := range spec.Input.Repos() {
+			if !seen[repo] {
+				seen[repo] = true
+				files = append(files, "pfs://"+repo)
+			}
+		}
+	}
+	sort.Strings(files)
+	return files
+}
+
+// HasRemote always returns true, since Pachyderm always stores data in a Pachyderm cluster.
+func (p Pachyderm) HasRemote() bool {
+	return true
+}
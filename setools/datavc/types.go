@@ -0,0 +1,100 @@
+package datavc
+
+import (
+	"context"
+
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/bvobart/mllint/api"
+	"github.com/bvobart/mllint/setools/git"
+)
+
+var (
+	TypeDVC       api.DataVersionControlType = typeDVC("DVC")
+	TypeGitLFS    api.DataVersionControlType = typeGitLFS("Git LFS")
+	TypeGitAnnex  api.DataVersionControlType = typeGitAnnex("git-annex")
+	TypeLakeFS    api.DataVersionControlType = typeLakeFS("lakeFS")
+	TypePachyderm api.DataVersionControlType = typePachyderm("Pachyderm")
+)
+
+// all is ordered by how recommended each tool is, i.e. the first one in this list is the type of data version control tool we want to recommend the most.
+var all = []api.DataVersionControlType{
+	TypeDVC,
+	TypeGitLFS,
+	TypeGitAnnex,
+	TypeLakeFS,
+	TypePachyderm,
+}
+
+//---------------------------------------------------------------------------------------
+
+// Detect checks the files in the project directory to detect which data version control tool(s) is / are
+// being used in this project.
+func Detect(project api.Project) api.DataVersionControlList {
+	tools := api.DataVersionControlList{}
+
+	for _, toolType := range all {
+		if tool, err := toolType.Detect(project); err == nil {
+			tools = append(tools, tool)
+		}
+	}
+
+	return tools
+}
+
+//---------------------------------------------------------------------------------------
+
+// Folders that are skipped when searching the project for the files of a data version control tool.
+var skippedFolders = map[string]bool{
+	".git": true, ".dvc": true, ".venv": true, "venv": true, "node_modules": true, "__pycache__": true,
+}
+
+// walkProject calls the given function for every file in the given directory and its subdirectories,
+// with the path of that file relative to the given directory.
+func walkProject(dir string, fn func(relpath string, info os.FileInfo)) error {
+	return filepath.Walk(dir, func(filename string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() && skippedFolders[info.Name()] {
+			return filepath.SkipDir
+		}
+
+		relpath, err := filepath.Rel(dir, filename)
+		if err != nil {
+			return err
+		}
+		fn(filepath.ToSlash(relpath), info)
+		return nil
+	})
+}
+
+// listTrackedFiles lists the files in the project's directory that are tracked by Git, relative to that directory.
+func listTrackedFiles(project api.Project) ([]string, error) {
+	dir := project.Dir
+	files, err := git.ForProject(project).ListTrackedFiles(context.Background(), dir)
+	if err != nil {
+		return nil, err
+	}
+
+	root := git.GetGitRoot(dir)
+	result := []string{}
+	for _, file := range files {
+		relpath, err := relativeTo(dir, filepath.Join(root, file))
+		if err != nil {
+			return nil, err
+		}
+		if !strings.HasPrefix(relpath, "../") {
+			result = append(result, relpath)
+		}
+	}
+	return result, nil
+}
+
+// relativeTo returns the path of the given file relative to the given directory, using forward slashes.
+func relativeTo(dir string, filename string) (string, error) {
+	relpath, err := filepath.Rel(dir, filename)
+	return filepath.ToSlash(relpath), err
+}
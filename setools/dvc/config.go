@@ -0,0 +1,156 @@
+package dvc
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// Types of DVC remotes, as determined from their URL, see https://dvc.org/doc/command-reference/remote/add#supported-storage-types
+const (
+	RemoteTypeLocal  = "local"
+	RemoteTypeS3     = "s3"
+	RemoteTypeGS     = "gs"
+	RemoteTypeAzure  = "azure"
+	RemoteTypeSSH    = "ssh"
+	RemoteTypeHDFS   = "hdfs"
+	RemoteTypeHTTP   = "http"
+	RemoteTypeWebDAV = "webdav"
+	RemoteTypeOSS    = "oss"
+	RemoteTypeGDrive = "gdrive"
+	RemoteTypeOther  = "other"
+)
+
+// maps the scheme of a remote's URL to the type of the remote.
+var remoteSchemes = map[string]string{
+	"s3":      RemoteTypeS3,
+	"gs":      RemoteTypeGS,
+	"azure":   RemoteTypeAzure,
+	"ssh":     RemoteTypeSSH,
+	"hdfs":    RemoteTypeHDFS,
+	"webhdfs": RemoteTypeHDFS,
+	"http":    RemoteTypeHTTP,
+	"https":   RemoteTypeHTTP,
+	"webdav":  RemoteTypeWebDAV,
+	"webdavs": RemoteTypeWebDAV,
+	"oss":     RemoteTypeOSS,
+	"gdrive":  RemoteTypeGDrive,
+}
+
+// Config is the configuration of DVC in a project, as read from `.dvc/config` and `.dvc/config.local`.
+// See https://dvc.org/doc/command-reference/config
+type Config struct {
+	// Name of the default remote, i.e. `core.remote`
+	DefaultRemote string
+	// Maps the names of the configured remotes to those remotes.
+	Remotes map[string]Remote
+}
+
+// Remote is a remote storage configured in DVC.
+type Remote struct {
+	Name string
+	URL  string
+}
+
+// Type returns the type of the remote, based on the scheme of its URL. URLs without a scheme are local remotes.
+func (r Remote) Type() string {
+	parts := strings.SplitN(r.URL, "://", 2)
+	if len(parts) < 2 {
+		return RemoteTypeLocal
+	}
+	if remoteType, ok := remoteSchemes[strings.ToLower(parts[0])]; ok {
+		return remoteType
+	}
+	if strings.ToLower(parts[0]) == "file" {
+		return RemoteTypeLocal
+	}
+	return RemoteTypeOther
+}
+
+// RemoteNames returns the names of all configured remotes in alphabetical order.
+func (c *Config) RemoteNames() []string {
+	names := []string{}
+	for name := range c.Remotes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// OnlyLocalRemotes returns whether all of the configured remotes are local remotes, i.e. folders on the local filesystem.
+// Returns false if there are no remotes.
+func (c *Config) OnlyLocalRemotes() bool {
+	for _, remote := range c.Remotes {
+		if remote.Type() != RemoteTypeLocal {
+			return false
+		}
+	}
+	return len(c.Remotes) > 0
+}
+
+// ReadConfig reads the DVC configuration of the project in the given directory from `.dvc/config`,
+// with the settings in `.dvc/config.local` taking precedence. Global and system-wide DVC configuration is not considered.
+func ReadConfig(dir string) (*Config, error) {
+	sections := map[string]map[string]string{}
+	for _, filename := range []string{"config", "config.local"} {
+		file, err := os.Open(path.Join(dir, ".dvc", filename))
+		if os.IsNotExist(err) && filename == "config.local" {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		err = parseConfig(file, sections)
+		file.Close()
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse .dvc/%s: %w", filename, err)
+		}
+	}
+
+	config := Config{DefaultRemote: sections["core"]["remote"], Remotes: map[string]Remote{}}
+	for section, values := range sections {
+		if match := remoteSectionRegex.FindStringSubmatch(section); match != nil {
+			config.Remotes[match[1]] = Remote{Name: match[1], URL: values["url"]}
+		}
+	}
+	return &config, nil
+}
+
+var (
+	sectionRegex       = regexp.MustCompile(`^\[\s*'?(.*?)'?\s*\]$`)
+	remoteSectionRegex = regexp.MustCompile(`^remote\s+"(.*)"$`)
+)
+
+// parseConfig parses a DVC config file, which is in the INI format of Python's ConfigObj, into the given sections,
+// overriding the values of any keys that are already present.
+func parseConfig(reader io.Reader, sections map[string]map[string]string) error {
+	section := ""
+	scanner := bufio.NewScanner(reader)
+	for lineNum := 1; scanner.Scan(); lineNum++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") || strings.HasPrefix(line, ";") {
+			continue
+		}
+
+		if match := sectionRegex.FindStringSubmatch(line); match != nil {
+			section = match[1]
+			if sections[section] == nil {
+				sections[section] = map[string]string{}
+			}
+			continue
+		}
+
+		parts := strings.SplitN(line, "=", 2)
+		if len(parts) != 2 || section == "" {
+			return fmt.Errorf("line %d: expected 'key = value' in a section, but got: %s", lineNum, line)
+		}
+		sections[section][strings.TrimSpace(parts[0])] = strings.Trim(strings.TrimSpace(parts[1]), `"'`)
+	}
+	return scanner.Err()
+}
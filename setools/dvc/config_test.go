@@ -0,0 +1,88 @@
+package dvc_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/bvobart/mllint/setools/dvc"
+)
+
+const dvcConfig = `[core]
+    remote = storage
+    autostage = true
+['remote "storage"']
+    url = s3://mybucket/dvcstore
+# a local remote for testing
+['remote "tmpfolder"']
+    url = /tmp/dvcstore
+`
+
+const dvcConfigLocal = `[core]
+    remote = local
+[remote "local"]
+    url = ../dvcstore
+['remote "storage"']
+    url = gs://mybucket/dvcstore
+    credentialpath = ~/.gcp/credentials.json
+`
+
+func TestReadConfig(t *testing.T) {
+	dir := writePipeline(t, map[string]string{".dvc/config": dvcConfig})
+	config, err := dvc.ReadConfig(dir)
+	require.NoError(t, err)
+	require.Equal(t, "storage", config.DefaultRemote)
+	require.Equal(t, map[string]dvc.Remote{
+		"storage":   {Name: "storage", URL: "s3://mybucket/dvcstore"},
+		"tmpfolder": {Name: "tmpfolder", URL: "/tmp/dvcstore"},
+	}, config.Remotes)
+	require.False(t, config.OnlyLocalRemotes())
+
+	dir = writePipeline(t, map[string]string{".dvc/config": dvcConfig, ".dvc/config.local": dvcConfigLocal})
+	config, err = dvc.ReadConfig(dir)
+	require.NoError(t, err)
+	require.Equal(t, "local", config.DefaultRemote)
+	require.Equal(t, []string{"local", "storage", "tmpfolder"}, config.RemoteNames())
+	require.Equal(t, "gs://mybucket/dvcstore", config.Remotes["storage"].URL)
+	require.Equal(t, "../dvcstore", config.Remotes["local"].URL)
+
+	dir = writePipeline(t, map[string]string{".dvc/config": "[core]\n"})
+	config, err = dvc.ReadConfig(dir)
+	require.NoError(t, err)
+	require.Empty(t, config.Remotes)
+	require.False(t, config.OnlyLocalRemotes())
+
+	dir = writePipeline(t, map[string]string{".dvc/config": "['remote \"local\"']\n    url = /tmp/dvcstore\n"})
+	config, err = dvc.ReadConfig(dir)
+	require.NoError(t, err)
+	require.True(t, config.OnlyLocalRemotes())
+
+	_, err = dvc.ReadConfig(t.TempDir())
+	require.Error(t, err)
+
+	dir = writePipeline(t, map[string]string{".dvc/config": "url = /tmp/dvcstore\n"})
+	_, err = dvc.ReadConfig(dir)
+	require.EqualError(t, err, "failed to parse .dvc/config: line 1: expected 'key = value' in a section, but got: url = /tmp/dvcstore")
+}
+
+func TestRemoteType(t *testing.T) {
+	tests := map[string]string{
+		"/tmp/dvcstore":                         dvc.RemoteTypeLocal,
+		"../dvcstore":                           dvc.RemoteTypeLocal,
+		"C:\\dvcstore":                          dvc.RemoteTypeLocal,
+		"file:///tmp/dvcstore":                  dvc.RemoteTypeLocal,
+		"s3://mybucket/dvcstore":                dvc.RemoteTypeS3,
+		"gs://mybucket/dvcstore":                dvc.RemoteTypeGS,
+		"azure://mycontainer/dvcstore":          dvc.RemoteTypeAzure,
+		"ssh://user@example.com/dvcstore":       dvc.RemoteTypeSSH,
+		"hdfs://user@example.com/dvcstore":      dvc.RemoteTypeHDFS,
+		"https://example.com/dvcstore":          dvc.RemoteTypeHTTP,
+		"webdavs://example.com/dvcstore":        dvc.RemoteTypeWebDAV,
+		"oss://mybucket/dvcstore":               dvc.RemoteTypeOSS,
+		"gdrive://0AIac4JZqHhKmUk9PDA/dvcstore": dvc.RemoteTypeGDrive,
+		"ftp://example.com/dvcstore":            dvc.RemoteTypeOther,
+	}
+	for url, expected := range tests {
+		require.Equal(t, expected, dvc.Remote{URL: url}.Type(), url)
+	}
+}
@@ -1,6 +1,10 @@
 package dvc
 
 import (
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
 	"strings"
 
 	"github.com/bvobart/mllint/utils/exec"
@@ -12,42 +16,90 @@ func IsInstalled() bool {
 	return err == nil
 }
 
-// Remotes runs 'dvc remote list' to figure out what remotes are configured for DVC in the current project.
+// Remotes returns the names of the remotes that are configured for DVC in the project in the given directory,
+// as read from `.dvc/config` and `.dvc/config.local`. Returns nil if the configuration cannot be read.
 func Remotes(dir string) []string {
-	output, err := exec.CommandOutput(dir, "dvc", "remote", "list")
+	config, err := ReadConfig(dir)
 	if err != nil {
 		return nil
 	}
-
-	remotes := []string{}
-	for _, line := range strings.Split(strings.TrimSpace(string(output)), "\n") {
-		fields := strings.Fields(line)
-		if len(fields) == 0 {
-			continue
-		}
-
-		remotes = append(remotes, fields[0])
-	}
-
-	return remotes
+	return config.RemoteNames()
 }
 
-// Files returns all files tracked by DVC in the current project.
+// Files returns all files and folders tracked by DVC in the project in the given directory, i.e. the outputs of all `.dvc` files
+// and all outputs of the project's pipeline that are recorded in `dvc.lock`, relative to the given directory.
+// Returns nil if the project's files cannot be read.
 func Files(dir string) []string {
-	output, err := exec.CommandOutput(dir, "dvc", "list", ".", "-R", "--dvc-only")
+	dvcFiles, err := findDVCFiles(dir)
 	if err != nil {
 		return nil
 	}
 
+	seen := map[string]bool{}
 	files := []string{}
-	for _, line := range strings.Split(strings.TrimSpace(string(output)), "\n") {
-		filename := strings.TrimSpace(line)
-		if len(filename) > 0 {
+	addFile := func(filename string) {
+		if !seen[filename] {
+			seen[filename] = true
 			files = append(files, filename)
 		}
 	}
 
+	for _, filename := range dvcFiles {
+		file, err := ParseFile(dir, filename)
+		if err != nil {
+			continue
+		}
+		for _, out := range file.OutPaths() {
+			addFile(out)
+		}
+	}
+
+	if lock, err := ParseLock(dir); err == nil {
+		pipeline, _ := ParsePipeline(dir)
+		for name, stage := range lock.Stages {
+			wdir := stageWdir(pipeline, name)
+			for _, out := range stage.Outs {
+				addFile(path.Join(wdir, out.Path))
+			}
+		}
+	}
+
+	sort.Strings(files)
 	return files
 }
 
-// TODO: add function to check whether there are any DVC pipelines in a project.
+// returns the working directory of the stage with the given name in the given pipeline, which may be nil.
+func stageWdir(pipeline *Pipeline, name string) string {
+	if pipeline == nil {
+		return "."
+	}
+	// stages defined using `foreach` are recorded as `<name>@<key>`
+	if stage, ok := pipeline.Stages[strings.SplitN(name, "@", 2)[0]]; ok && stage.definition().Wdir != "" {
+		return stage.definition().Wdir
+	}
+	return "."
+}
+
+// finds all `.dvc` files in the given directory and its subdirectories, relative to the given directory.
+func findDVCFiles(dir string) ([]string, error) {
+	files := []string{}
+	err := filepath.Walk(dir, func(filename string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() && (info.Name() == ".git" || info.Name() == ".dvc") {
+			return filepath.SkipDir
+		}
+		if info.IsDir() || path.Ext(info.Name()) != FileExtension {
+			return nil
+		}
+
+		relpath, err := filepath.Rel(dir, filename)
+		if err != nil {
+			return err
+		}
+		files = append(files, filepath.ToSlash(relpath))
+		return nil
+	})
+	return files, err
+}
@@ -26,96 +26,50 @@ func TestIsInstalled(t *testing.T) {
 
 func TestRemotes(t *testing.T) {
 	t.Run("with remotes", func(t *testing.T) {
-		exec.CommandOutput = func(dir, name string, args ...string) ([]byte, error) {
-			require.Equal(t, ".", dir)
-			require.Equal(t, "dvc", name)
-			require.True(t, arrayEqual(args, []string{"remote", "list"}))
-			return []byte(
-				`
-tmpfolder1       /tmp/dvcstore
-
-tmpfolder2       /tmp/dvcstore
-`,
-			), nil
-		}
-
-		actualRemotes := dvc.Remotes(".")
-		expectedRemotes := []string{"tmpfolder1", "tmpfolder2"}
-		require.Equal(t, expectedRemotes, actualRemotes)
+		dir := writePipeline(t, map[string]string{
+			".dvc/config":       dvcConfig,
+			".dvc/config.local": dvcConfigLocal,
+		})
+		require.Equal(t, []string{"local", "storage", "tmpfolder"}, dvc.Remotes(dir))
 	})
 
 	t.Run("no remotes", func(t *testing.T) {
-		exec.CommandOutput = func(dir, name string, args ...string) ([]byte, error) {
-			require.Equal(t, ".", dir)
-			require.Equal(t, "dvc", name)
-			require.True(t, arrayEqual(args, []string{"remote", "list"}))
-			return []byte("\n"), nil
-		}
-		require.Equal(t, []string{}, dvc.Remotes("."))
+		dir := writePipeline(t, map[string]string{".dvc/config": "[core]\n    autostage = true\n"})
+		require.Equal(t, []string{}, dvc.Remotes(dir))
 	})
 
 	t.Run("error", func(t *testing.T) {
-		exec.CommandOutput = func(dir, name string, args ...string) ([]byte, error) {
-			require.Equal(t, ".", dir)
-			require.Equal(t, "dvc", name)
-			require.True(t, arrayEqual(args, []string{"remote", "list"}))
-			return []byte("\n"), fmt.Errorf("dvc not on path or something")
-		}
-		require.Nil(t, dvc.Remotes("."))
+		require.Nil(t, dvc.Remotes(t.TempDir()))
 	})
 }
 
 func TestFiles(t *testing.T) {
 	t.Run("with files", func(t *testing.T) {
-		exec.CommandOutput = func(dir, name string, args ...string) ([]byte, error) {
-			require.Equal(t, ".", dir)
-			require.Equal(t, "dvc", name)
-			require.True(t, arrayEqual(args, []string{"list", ".", "-R", "--dvc-only"}))
-			return []byte(
-				`
-data/data.xml                                                                                                                                                                                                                                 
-data/prepared/test.tsv
-data/prepared/train.tsv
+		dir := writePipeline(t, map[string]string{
+			"data/data.xml.dvc": "outs:\n- md5: a304afb96060aad90176268345e10355\n  path: data.xml\n",
+			"models.dvc":        "outs:\n- path: models\n",
+			".dvc/ignored.dvc":  "outs:\n- path: ignored\n",
+			"invalid.dvc":       "outs: [[[",
+			dvc.PipelineFile:    "stages:\n  prepare:\n    wdir: src\n    cmd: python prepare.py\n",
+			dvc.LockFile: `schema: '2.0'
+stages:
+  prepare:
+    cmd: python prepare.py
+    outs:
+    - path: ../data/prepared
+      md5: 3863d0e317dee0a55c4e59d2ec0eef33.dir
+    - path: ../models
 `,
-			), nil
-		}
+		})
 
-		actualFiles := dvc.Files(".")
-		expectedFiles := []string{"data/data.xml", "data/prepared/test.tsv", "data/prepared/train.tsv"}
-		require.Equal(t, expectedFiles, actualFiles)
+		require.Equal(t, []string{"data/data.xml", "data/prepared", "models"}, dvc.Files(dir))
 	})
 
 	t.Run("no files", func(t *testing.T) {
-		exec.CommandOutput = func(dir, name string, args ...string) ([]byte, error) {
-			require.Equal(t, ".", dir)
-			require.Equal(t, "dvc", name)
-			require.True(t, arrayEqual(args, []string{"list", ".", "-R", "--dvc-only"}))
-			return []byte("\n"), nil
-		}
-		require.Equal(t, []string{}, dvc.Files("."))
+		require.Equal(t, []string{}, dvc.Files(t.TempDir()))
 	})
 
 	t.Run("error", func(t *testing.T) {
-		exec.CommandOutput = func(dir, name string, args ...string) ([]byte, error) {
-			require.Equal(t, ".", dir)
-			require.Equal(t, "dvc", name)
-			require.True(t, arrayEqual(args, []string{"list", ".", "-R", "--dvc-only"}))
-			return []byte("\n"), fmt.Errorf("dvc not on path or something")
-		}
-		require.Nil(t, dvc.Files("."))
+		require.Nil(t, dvc.Files("non-existent-dir"))
 	})
 }
-
-func arrayEqual(one, other []string) bool {
-	if len(one) != len(other) {
-		return false
-	}
-
-	for i, valueOne := range one {
-		if valueOne != other[i] {
-			return false
-		}
-	}
-
-	return true
-}
@@ -0,0 +1,56 @@
+package dvc
+
+import (
+	"fmt"
+	"os"
+	"path"
+
+	"gopkg.in/yaml.v3"
+)
+
+// FileExtension is the extension of the `.dvc` files that DVC creates for each file or folder tracked with `dvc add`.
+const FileExtension = ".dvc"
+
+// File is the contents of a `.dvc` file.
+// See https://dvc.org/doc/user-guide/project-structure/dvc-files
+type File struct {
+	Outs []Output `yaml:"outs"`
+	Deps []Output `yaml:"deps"`
+	// Path of the `.dvc` file itself, relative to the directory it was parsed from.
+	Filename string `yaml:"-"`
+}
+
+// Output is an output (or dependency) of a `.dvc` file, i.e. a file or folder that is tracked with DVC.
+type Output struct {
+	// Path to the file or folder, relative to the directory containing the `.dvc` file.
+	Path   string `yaml:"path"`
+	MD5    string `yaml:"md5"`
+	Size   uint64 `yaml:"size"`
+	NFiles int    `yaml:"nfiles"`
+	// Whether the output is cached by DVC, defaults to true.
+	Cache *bool `yaml:"cache"`
+}
+
+// ParseFile parses the `.dvc` file with the given filename, relative to the given directory.
+func ParseFile(dir string, filename string) (*File, error) {
+	contents, err := os.ReadFile(path.Join(dir, filename))
+	if err != nil {
+		return nil, err
+	}
+
+	file := File{}
+	if err := yaml.Unmarshal(contents, &file); err != nil {
+		return nil, fmt.Errorf("failed to parse DVC file '%s': %w", filename, err)
+	}
+	file.Filename = filename
+	return &file, nil
+}
+
+// OutPaths returns the paths of all of the file's outputs, relative to the directory that the file was parsed from.
+func (f *File) OutPaths() []string {
+	paths := []string{}
+	for _, out := range f.Outs {
+		paths = append(paths, path.Join(path.Dir(f.Filename), out.Path))
+	}
+	return paths
+}
@@ -0,0 +1,44 @@
+package dvc_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/bvobart/mllint/setools/dvc"
+)
+
+const dvcFile = `outs:
+- md5: a304afb96060aad90176268345e10355
+  size: 1234
+  path: train.csv
+- md5: 3863d0e317dee0a55c4e59d2ec0eef33.dir
+  nfiles: 3
+  path: images
+  cache: false
+`
+
+func TestParseFile(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.MkdirAll(path.Join(dir, "data"), 0755))
+	require.NoError(t, ioutil.WriteFile(path.Join(dir, "data", "train.csv.dvc"), []byte(dvcFile), 0644))
+
+	file, err := dvc.ParseFile(dir, "data/train.csv.dvc")
+	require.NoError(t, err)
+	require.Equal(t, "data/train.csv.dvc", file.Filename)
+	require.Len(t, file.Outs, 2)
+	require.Equal(t, dvc.Output{Path: "train.csv", MD5: "a304afb96060aad90176268345e10355", Size: 1234}, file.Outs[0])
+	require.Equal(t, 3, file.Outs[1].NFiles)
+	require.False(t, *file.Outs[1].Cache)
+	require.Equal(t, []string{"data/train.csv", "data/images"}, file.OutPaths())
+
+	_, err = dvc.ParseFile(dir, "non-existent.dvc")
+	require.True(t, os.IsNotExist(err))
+
+	require.NoError(t, ioutil.WriteFile(path.Join(dir, "invalid.dvc"), []byte("outs: [[["), 0644))
+	_, err = dvc.ParseFile(dir, "invalid.dvc")
+	require.Error(t, err)
+}
@@ -0,0 +1,38 @@
+package dvc
+
+import (
+	"bytes"
+	"crypto/md5"
+	"encoding/hex"
+)
+
+// number of bytes that DVC inspects to determine whether a file is a text file.
+const textCheckSize = 512
+
+func fileMD5(contents []byte) string {
+	hash := md5.Sum(contents)
+	return hex.EncodeToString(hash[:])
+}
+
+// isText mimics DVC's heuristic for determining whether a file is a text file, i.e. its first 512 bytes do not contain a null byte
+// and mostly consist of printable characters. DVC 2 normalises the line endings of text files before computing their MD5.
+func isText(contents []byte) bool {
+	if len(contents) > textCheckSize {
+		contents = contents[:textCheckSize]
+	}
+	if bytes.IndexByte(contents, 0) >= 0 {
+		return false
+	}
+
+	nonText := 0
+	for _, b := range contents {
+		if b < 32 && b != '\n' && b != '\r' && b != '\t' && b != '\f' && b != '\b' && b != 27 {
+			nonText++
+		}
+	}
+	return len(contents) == 0 || float64(nonText)/float64(len(contents)) <= 0.3
+}
+
+func dos2unix(contents []byte) []byte {
+	return bytes.ReplaceAll(contents, []byte("\r\n"), []byte("\n"))
+}
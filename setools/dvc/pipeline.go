@@ -0,0 +1,371 @@
+package dvc
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Filenames of the files in which DVC pipelines are defined and their state is recorded,
+// see https://dvc.org/doc/user-guide/project-structure/dvcyaml-files and https://dvc.org/doc/user-guide/project-structure/dvclock-files
+const (
+	PipelineFile = "dvc.yaml"
+	LockFile     = "dvc.lock"
+	// ParamsFile is the file from which DVC reads a stage's parameters by default.
+	ParamsFile = "params.yaml"
+)
+
+// Pipeline is the contents of a `dvc.yaml` file.
+type Pipeline struct {
+	Stages map[string]Stage `yaml:"stages"`
+	// Files containing parameters that are tracked at the top-level of the pipeline.
+	Params []Params `yaml:"params"`
+	// Files containing metrics that are tracked at the top-level of the pipeline.
+	Metrics Paths `yaml:"metrics"`
+	// Files containing plots that are tracked at the top-level of the pipeline.
+	Plots Paths `yaml:"plots"`
+}
+
+// Stage is a stage of a DVC pipeline.
+type Stage struct {
+	Cmd Command `yaml:"cmd"`
+	// Working directory of the stage, relative to the directory containing the `dvc.yaml` file.
+	Wdir    string   `yaml:"wdir"`
+	Deps    Paths    `yaml:"deps"`
+	Outs    Paths    `yaml:"outs"`
+	Params  []Params `yaml:"params"`
+	Metrics Paths    `yaml:"metrics"`
+	Plots   Paths    `yaml:"plots"`
+
+	// Stages defined using `foreach` (or `matrix`) are templates for multiple stages, named `<name>@<key>` in `dvc.lock`.
+	Foreach interface{} `yaml:"foreach"`
+	Matrix  interface{} `yaml:"matrix"`
+	Do      *Stage      `yaml:"do"`
+}
+
+// IsTemplate returns whether this stage is defined using `foreach` or `matrix`, i.e. whether it expands to multiple stages.
+func (s Stage) IsTemplate() bool {
+	return s.Foreach != nil || s.Matrix != nil
+}
+
+// definition returns the stage that defines the deps and outs of this stage, i.e. the `do` stage of a `foreach` stage.
+func (s Stage) definition() Stage {
+	if s.Foreach != nil && s.Do != nil {
+		return *s.Do
+	}
+	return s
+}
+
+// HasDeps returns whether the stage declares any dependencies, including parameters.
+func (s Stage) HasDeps() bool {
+	def := s.definition()
+	return len(def.Deps) > 0 || len(def.Params) > 0
+}
+
+// HasOuts returns whether the stage declares any outputs, including metrics and plots.
+func (s Stage) HasOuts() bool {
+	def := s.definition()
+	return len(def.Outs) > 0 || len(def.Metrics) > 0 || len(def.Plots) > 0
+}
+
+// HasMetrics returns whether the stage declares any metrics or plots.
+func (s Stage) HasMetrics() bool {
+	def := s.definition()
+	return len(def.Metrics) > 0 || len(def.Plots) > 0
+}
+
+// UsesParamsFile returns whether the stage reads any of its parameters from the given file, relative to the stage's working directory.
+func (s Stage) UsesParamsFile(filename string) bool {
+	for _, params := range s.definition().Params {
+		if params.File == filename {
+			return true
+		}
+	}
+	return false
+}
+
+// Command is the command of a stage, which can be a single command or a list of commands.
+type Command []string
+
+func (c *Command) UnmarshalYAML(value *yaml.Node) error {
+	if value.Kind == yaml.ScalarNode {
+		*c = Command{value.Value}
+		return nil
+	}
+	return value.Decode((*[]string)(c))
+}
+
+// String returns the stage's commands, separated by newlines.
+func (c Command) String() string {
+	return strings.Join(c, "\n")
+}
+
+// Paths is a list of the paths of a stage's deps, outs, metrics or plots. Each entry in such a list is either
+// a path, or a map from a path to the options of that path, e.g. `- model.pkl: { cache: false }`
+type Paths []string
+
+func (p *Paths) UnmarshalYAML(value *yaml.Node) error {
+	if value.Kind != yaml.SequenceNode {
+		return fmt.Errorf("line %d: expected a list of paths", value.Line)
+	}
+
+	for _, entry := range value.Content {
+		switch entry.Kind {
+		case yaml.ScalarNode:
+			*p = append(*p, entry.Value)
+		case yaml.MappingNode:
+			for i := 0; i < len(entry.Content); i += 2 {
+				*p = append(*p, entry.Content[i].Value)
+			}
+		default:
+			return fmt.Errorf("line %d: expected a path", entry.Line)
+		}
+	}
+	return nil
+}
+
+// Params are the parameters that a stage reads from a parameters file.
+type Params struct {
+	File string
+	// Keys of the parameters that the stage depends on. Empty means that the stage depends on the whole file.
+	Keys []string
+}
+
+// UnmarshalYAML parses an entry of a stage's params, which is either the key of a parameter in `params.yaml`,
+// or a map from a parameters file to the keys in that file, e.g. `- config.yaml: [lr, epochs]`
+func (p *Params) UnmarshalYAML(value *yaml.Node) error {
+	switch value.Kind {
+	case yaml.ScalarNode:
+		*p = Params{File: ParamsFile, Keys: []string{value.Value}}
+		return nil
+	case yaml.MappingNode:
+		if len(value.Content) != 2 {
+			return fmt.Errorf("line %d: expected a single parameters file", value.Line)
+		}
+		*p = Params{File: value.Content[0].Value}
+		return value.Content[1].Decode(&p.Keys)
+	default:
+		return fmt.Errorf("line %d: expected a parameter or parameters file", value.Line)
+	}
+}
+
+// ParsePipeline parses the `dvc.yaml` file in the given directory.
+func ParsePipeline(dir string) (*Pipeline, error) {
+	contents, err := os.ReadFile(path.Join(dir, PipelineFile))
+	if err != nil {
+		return nil, err
+	}
+
+	pipeline := Pipeline{}
+	if err := yaml.Unmarshal(contents, &pipeline); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", PipelineFile, err)
+	}
+	return &pipeline, nil
+}
+
+// HasPipeline returns whether the project in the given directory defines a DVC pipeline with at least one stage.
+func HasPipeline(dir string) bool {
+	pipeline, err := ParsePipeline(dir)
+	return err == nil && len(pipeline.Stages) > 0
+}
+
+// StageNames returns the names of the pipeline's stages in alphabetical order.
+func (p *Pipeline) StageNames() []string {
+	names := []string{}
+	for name := range p.Stages {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// HasMetrics returns whether the pipeline declares any metrics or plots, either at the top-level or in any of its stages.
+func (p *Pipeline) HasMetrics() bool {
+	if len(p.Metrics) > 0 || len(p.Plots) > 0 {
+		return true
+	}
+	for _, stage := range p.Stages {
+		if stage.HasMetrics() {
+			return true
+		}
+	}
+	return false
+}
+
+// UsesParamsFile returns whether any stage of the pipeline reads parameters from `params.yaml`,
+// or whether the pipeline tracks `params.yaml` at the top-level.
+func (p *Pipeline) UsesParamsFile() bool {
+	for _, params := range p.Params {
+		if params.File == ParamsFile {
+			return true
+		}
+	}
+	for _, stage := range p.Stages {
+		if stage.UsesParamsFile(ParamsFile) {
+			return true
+		}
+	}
+	return false
+}
+
+//---------------------------------------------------------------------------------------
+
+// Lock is the contents of a `dvc.lock` file, which records the state of each stage of the pipeline the last time it was run.
+type Lock struct {
+	Schema string               `yaml:"schema"`
+	Stages map[string]LockStage `yaml:"stages"`
+}
+
+// LockStage is the recorded state of a stage in a `dvc.lock` file.
+type LockStage struct {
+	Cmd  Command     `yaml:"cmd"`
+	Deps []LockEntry `yaml:"deps"`
+	Outs []LockEntry `yaml:"outs"`
+}
+
+// LockEntry is the recorded state of a dependency or output of a stage in a `dvc.lock` file.
+type LockEntry struct {
+	Path string `yaml:"path"`
+	MD5  string `yaml:"md5"`
+	// The hash algorithm used to compute MD5. Since DVC 3.0, this is `md5`, which hashes the file as is.
+	// Before DVC 3.0, this is empty and the MD5 of text files was computed after converting Windows line endings to Unix line endings.
+	Hash  string `yaml:"hash"`
+	Size  uint64 `yaml:"size"`
+	Cache *bool  `yaml:"cache"`
+}
+
+// ParseLock parses the `dvc.lock` file in the given directory.
+func ParseLock(dir string) (*Lock, error) {
+	contents, err := os.ReadFile(path.Join(dir, LockFile))
+	if err != nil {
+		return nil, err
+	}
+
+	lock := Lock{}
+	if err := yaml.Unmarshal(contents, &lock); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", LockFile, err)
+	}
+	if lock.Stages == nil && lock.Schema == "" {
+		// dvc.lock files from before DVC 2.0 contain the stages at the top-level.
+		if err := yaml.Unmarshal(contents, &lock.Stages); err != nil {
+			return nil, fmt.Errorf("failed to parse %s: %w", LockFile, err)
+		}
+	}
+	return &lock, nil
+}
+
+// LockDiff describes the differences between a pipeline and the state of its stages recorded in a `dvc.lock` file.
+type LockDiff struct {
+	// Stages that are defined in `dvc.yaml`, but not recorded in `dvc.lock`.
+	Missing []string
+	// Stages that are recorded in `dvc.lock`, but no longer defined in `dvc.yaml`.
+	Extra []string
+	// Maps the names of stages that have changed since they were recorded in `dvc.lock`, to a description of each change.
+	Changed map[string][]string
+}
+
+// InSync returns whether `dvc.lock` is in sync with `dvc.yaml`.
+func (d LockDiff) InSync() bool {
+	return len(d.Missing) == 0 && len(d.Extra) == 0 && len(d.Changed) == 0
+}
+
+// CompareLock compares the given pipeline with the given lock, i.e. the `dvc.yaml` and `dvc.lock` files in the given directory.
+// A stage has changed when its command or its dependencies have changed, or when the hashes of any of its dependencies or outputs
+// that are present in the given directory, differ from the hashes recorded in `dvc.lock`.
+// Files that are not present, folders and stages that use templating (e.g. `${item}`) are not hashed.
+func CompareLock(dir string, pipeline *Pipeline, lock *Lock) LockDiff {
+	diff := LockDiff{Missing: []string{}, Extra: []string{}, Changed: map[string][]string{}}
+
+	for _, name := range pipeline.StageNames() {
+		stage := pipeline.Stages[name]
+		if stage.IsTemplate() {
+			if !lock.hasStagePrefix(name + "@") {
+				diff.Missing = append(diff.Missing, name)
+			}
+			continue
+		}
+
+		lockStage, ok := lock.Stages[name]
+		if !ok {
+			diff.Missing = append(diff.Missing, name)
+			continue
+		}
+
+		if changes := compareStage(path.Join(dir, stage.Wdir), stage, lockStage); len(changes) > 0 {
+			diff.Changed[name] = changes
+		}
+	}
+
+	for name := range lock.Stages {
+		stageName := strings.SplitN(name, "@", 2)[0]
+		if _, ok := pipeline.Stages[stageName]; !ok {
+			diff.Extra = append(diff.Extra, name)
+		}
+	}
+	sort.Strings(diff.Extra)
+	return diff
+}
+
+func (l *Lock) hasStagePrefix(prefix string) bool {
+	for name := range l.Stages {
+		if strings.HasPrefix(name, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+func compareStage(wdir string, stage Stage, lockStage LockStage) []string {
+	changes := []string{}
+	if !isTemplated(stage.Cmd.String()) && stage.Cmd.String() != lockStage.Cmd.String() {
+		changes = append(changes, "command changed")
+	}
+
+	lockDeps := map[string]bool{}
+	for _, dep := range lockStage.Deps {
+		lockDeps[path.Clean(dep.Path)] = true
+	}
+	for _, dep := range stage.Deps {
+		if !isTemplated(dep) && !lockDeps[path.Clean(dep)] {
+			changes = append(changes, fmt.Sprintf("dependency `%s` added", dep))
+		}
+	}
+
+	for _, dep := range lockStage.Deps {
+		if entryChanged(wdir, dep) {
+			changes = append(changes, fmt.Sprintf("dependency `%s` modified", dep.Path))
+		}
+	}
+	for _, out := range lockStage.Outs {
+		if entryChanged(wdir, out) {
+			changes = append(changes, fmt.Sprintf("output `%s` modified", out.Path))
+		}
+	}
+	return changes
+}
+
+// entryChanged returns whether the file of the given lock entry has changed since it was recorded.
+// Files that do not exist, folders and entries without an MD5 hash are considered unchanged.
+func entryChanged(wdir string, entry LockEntry) bool {
+	if entry.MD5 == "" || strings.HasSuffix(entry.MD5, ".dir") {
+		return false
+	}
+
+	contents, err := os.ReadFile(path.Join(wdir, entry.Path))
+	if err != nil {
+		return false
+	}
+
+	if fileMD5(contents) == entry.MD5 {
+		return false
+	}
+	return entry.Hash != "" || !isText(contents) || fileMD5(dos2unix(contents)) != entry.MD5
+}
+
+func isTemplated(s string) bool {
+	return strings.Contains(s, "${")
+}
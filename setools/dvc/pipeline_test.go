@@ -0,0 +1,157 @@
+package dvc_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/bvobart/mllint/setools/dvc"
+)
+
+const pipelineYaml = `stages:
+  featurize:
+    foreach: [train, test]
+    do:
+      cmd: python featurize.py ${item}
+      deps: [featurize.py]
+      outs:
+        - features/${item}.npy
+  train:
+    wdir: src
+    cmd:
+      - python train.py
+      - python export.py
+    deps: [train.py]
+    params:
+      - epochs
+      - config/train.yaml: [lr]
+    outs:
+      - model.pkl:
+          cache: false
+    plots:
+      - loss.csv:
+          x: epoch
+          y: loss
+metrics: [metrics.json]
+`
+
+const pipelineLock = `schema: '2.0'
+stages:
+  featurize@train:
+    cmd: python featurize.py train
+  featurize@test:
+    cmd: python featurize.py test
+  train:
+    cmd:
+      - python train.py
+      - python export.py
+    deps:
+    - path: train.py
+      hash: md5
+      md5: f64702d733ae8a4833ae909c0763f188
+      size: 18
+    outs:
+    - path: model.pkl
+      hash: md5
+      md5: 0123456789abcdef0123456789abcdef
+`
+
+const oldPipelineLock = `train:
+  cmd: python train.py
+  deps:
+  - path: train.py
+    md5: f64702d733ae8a4833ae909c0763f188
+`
+
+func writePipeline(t *testing.T, files map[string]string) string {
+	dir := t.TempDir()
+	for filename, contents := range files {
+		require.NoError(t, os.MkdirAll(path.Dir(path.Join(dir, filename)), 0755))
+		require.NoError(t, ioutil.WriteFile(path.Join(dir, filename), []byte(contents), 0644))
+	}
+	return dir
+}
+
+func TestParsePipeline(t *testing.T) {
+	dir := writePipeline(t, map[string]string{dvc.PipelineFile: pipelineYaml})
+	pipeline, err := dvc.ParsePipeline(dir)
+	require.NoError(t, err)
+	require.True(t, dvc.HasPipeline(dir))
+
+	require.Equal(t, []string{"featurize", "train"}, pipeline.StageNames())
+	require.Equal(t, dvc.Paths{"metrics.json"}, pipeline.Metrics)
+	require.True(t, pipeline.HasMetrics())
+	require.True(t, pipeline.UsesParamsFile())
+
+	featurize := pipeline.Stages["featurize"]
+	require.True(t, featurize.IsTemplate())
+	require.True(t, featurize.HasDeps())
+	require.True(t, featurize.HasOuts())
+	require.False(t, featurize.HasMetrics())
+
+	train := pipeline.Stages["train"]
+	require.False(t, train.IsTemplate())
+	require.Equal(t, "src", train.Wdir)
+	require.Equal(t, dvc.Command{"python train.py", "python export.py"}, train.Cmd)
+	require.Equal(t, dvc.Paths{"model.pkl"}, train.Outs)
+	require.Equal(t, dvc.Paths{"loss.csv"}, train.Plots)
+	require.Equal(t, []dvc.Params{{File: "params.yaml", Keys: []string{"epochs"}}, {File: "config/train.yaml", Keys: []string{"lr"}}}, train.Params)
+	require.True(t, train.UsesParamsFile("config/train.yaml"))
+	require.True(t, train.HasMetrics())
+
+	_, err = dvc.ParsePipeline(t.TempDir())
+	require.True(t, os.IsNotExist(err))
+	require.False(t, dvc.HasPipeline(t.TempDir()))
+
+	dir = writePipeline(t, map[string]string{dvc.PipelineFile: "stages:\n  train:\n    outs: train.py\n"})
+	_, err = dvc.ParsePipeline(dir)
+	require.Error(t, err)
+}
+
+func TestParseLock(t *testing.T) {
+	dir := writePipeline(t, map[string]string{dvc.LockFile: pipelineLock})
+	lock, err := dvc.ParseLock(dir)
+	require.NoError(t, err)
+	require.Equal(t, "2.0", lock.Schema)
+	require.Len(t, lock.Stages, 3)
+	require.Equal(t, dvc.LockEntry{Path: "train.py", MD5: "f64702d733ae8a4833ae909c0763f188", Hash: "md5", Size: 18}, lock.Stages["train"].Deps[0])
+
+	dir = writePipeline(t, map[string]string{dvc.LockFile: oldPipelineLock})
+	lock, err = dvc.ParseLock(dir)
+	require.NoError(t, err)
+	require.Equal(t, dvc.Command{"python train.py"}, lock.Stages["train"].Cmd)
+	require.Equal(t, "train.py", lock.Stages["train"].Deps[0].Path)
+
+	_, err = dvc.ParseLock(t.TempDir())
+	require.True(t, os.IsNotExist(err))
+}
+
+func TestCompareLock(t *testing.T) {
+	dir := writePipeline(t, map[string]string{
+		dvc.PipelineFile: pipelineYaml,
+		dvc.LockFile:     pipelineLock,
+		"src/train.py":   "print('training')\n",
+	})
+	pipeline, err := dvc.ParsePipeline(dir)
+	require.NoError(t, err)
+	lock, err := dvc.ParseLock(dir)
+	require.NoError(t, err)
+
+	diff := dvc.CompareLock(dir, pipeline, lock)
+	require.True(t, diff.InSync(), diff)
+
+	require.NoError(t, ioutil.WriteFile(path.Join(dir, "src", "train.py"), []byte("print('training!')\n"), 0644))
+	require.NoError(t, ioutil.WriteFile(path.Join(dir, "src", "model.pkl"), []byte("model"), 0644))
+	delete(lock.Stages, "featurize@train")
+	delete(lock.Stages, "featurize@test")
+	lock.Stages["evaluate"] = dvc.LockStage{}
+
+	diff = dvc.CompareLock(dir, pipeline, lock)
+	require.False(t, diff.InSync())
+	require.Equal(t, []string{"featurize"}, diff.Missing)
+	require.Equal(t, []string{"evaluate"}, diff.Extra)
+	require.Equal(t, map[string][]string{"train": {"dependency `train.py` modified", "output `model.pkl` modified"}}, diff.Changed)
+}
@@ -0,0 +1,215 @@
+package git
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	gogit "github.com/go-git/go-git/v5"
+
+	"github.com/bvobart/mllint/api"
+	"github.com/bvobart/mllint/utils/exec"
+)
+
+// Names of the Git backends that can be selected in mllint's configuration, see BackendByName.
+const (
+	BackendAuto   = "auto"
+	BackendNative = "native"
+	BackendExec   = "exec"
+)
+
+var ErrUnknownBackend = errors.New("unknown Git backend")
+
+// Backend implements the operations on Git repositories that mllint needs.
+// See the package-level functions of the same name for a description of each operation.
+// Those functions use the AutoBackend, while linters should use the Backend selected in the project's configuration, see ForProject.
+type Backend interface {
+	Detect(ctx context.Context, dir string) bool
+	GetGitRoot(ctx context.Context, dir string) string
+	GetRemoteURL(ctx context.Context, dir string) (string, error)
+	GetCurrentCommit(ctx context.Context, dir string) (string, error)
+	GetCurrentBranch(ctx context.Context, dir string) (string, error)
+	IsDirty(ctx context.Context, dir string) bool
+	IsTracking(ctx context.Context, dir string, pattern string) bool
+	ListTrackedFiles(ctx context.Context, dir string) ([]string, error)
+	FilterIgnored(ctx context.Context, dir string, files []string) ([]string, error)
+	FindLargeFiles(ctx context.Context, dir string, threshold uint64) ([]FileSize, error)
+	ListBlobsInHistory(ctx context.Context, dir string) ([]Blob, error)
+	FindBlobCommits(ctx context.Context, dir string) (map[string]string, error)
+	ReadBlob(ctx context.Context, dir string, hash string) ([]byte, error)
+	ReadBlobs(ctx context.Context, dir string, hashes []string, fn func(hash string, contents []byte) error) error
+	ListCommits(ctx context.Context, dir string, opts LogOptions) ([]Commit, error)
+	GetDefaultBranch(ctx context.Context, dir string) string
+	ListChangedFiles(ctx context.Context, dir string, ref string) ([]string, error)
+	ListStagedFiles(ctx context.Context, dir string) ([]string, error)
+}
+
+// ForProject returns the Backend selected by the `git.backend` setting in the given project's configuration,
+// or the AutoBackend if that setting is empty or invalid. Use BackendByName to validate the setting.
+func ForProject(project api.Project) Backend {
+	backend, err := BackendByName(project.Config.Git.Backend)
+	if err != nil {
+		return AutoBackend{}
+	}
+	return backend
+}
+
+// BackendByName returns the Backend with the given name, i.e. one of BackendAuto, BackendNative or BackendExec.
+func BackendByName(name string) (Backend, error) {
+	switch name {
+	case BackendAuto, "":
+		return AutoBackend{}, nil
+	case BackendNative:
+		return NativeBackend{}, nil
+	case BackendExec:
+		return ExecBackend{}, nil
+	default:
+		return nil, fmt.Errorf("%w: '%s', expected one of '%s', '%s' or '%s'", ErrUnknownBackend, name, BackendAuto, BackendNative, BackendExec)
+	}
+}
+
+//---------------------------------------------------------------------------------------
+
+// AutoBackend uses the NativeBackend, unless the `git` binary is installed and the NativeBackend is unable to
+// open the repository (e.g. because it uses a repository format that go-git does not support) or fails to perform an operation.
+// In those cases, it falls back to the ExecBackend.
+type AutoBackend struct{}
+
+func (b AutoBackend) pick(dir string) Backend {
+	if !hasGitBinary() {
+		return NativeBackend{}
+	}
+	_, unlock, err := openRepository(dir)
+	if err != nil && !errors.Is(err, gogit.ErrRepositoryNotExists) {
+		return ExecBackend{}
+	}
+	if err == nil {
+		unlock()
+	}
+	return NativeBackend{}
+}
+
+// runs the given operation using the picked Backend, retrying it with the ExecBackend if the NativeBackend fails,
+// unless it failed because the context is done.
+func (b AutoBackend) run(ctx context.Context, dir string, operation func(backend Backend) error) error {
+	backend := b.pick(dir)
+	err := operation(backend)
+	if _, isNative := backend.(NativeBackend); err != nil && isNative && ctx.Err() == nil && hasGitBinary() {
+		return operation(ExecBackend{})
+	}
+	return err
+}
+
+func hasGitBinary() bool {
+	_, err := exec.LookPath("git")
+	return err == nil
+}
+
+func (b AutoBackend) Detect(ctx context.Context, dir string) bool {
+	return b.pick(dir).Detect(ctx, dir)
+}
+
+func (b AutoBackend) GetGitRoot(ctx context.Context, dir string) string {
+	return b.pick(dir).GetGitRoot(ctx, dir)
+}
+
+func (b AutoBackend) GetRemoteURL(ctx context.Context, dir string) (string, error) {
+	return b.pick(dir).GetRemoteURL(ctx, dir)
+}
+
+func (b AutoBackend) GetCurrentCommit(ctx context.Context, dir string) (string, error) {
+	return b.pick(dir).GetCurrentCommit(ctx, dir)
+}
+
+func (b AutoBackend) GetCurrentBranch(ctx context.Context, dir string) (string, error) {
+	return b.pick(dir).GetCurrentBranch(ctx, dir)
+}
+
+func (b AutoBackend) IsDirty(ctx context.Context, dir string) bool {
+	return b.pick(dir).IsDirty(ctx, dir)
+}
+
+func (b AutoBackend) IsTracking(ctx context.Context, dir string, pattern string) bool {
+	return b.pick(dir).IsTracking(ctx, dir, pattern)
+}
+
+func (b AutoBackend) FilterIgnored(ctx context.Context, dir string, files []string) (result []string, err error) {
+	err = b.run(ctx, dir, func(backend Backend) error {
+		result, err = backend.FilterIgnored(ctx, dir, files)
+		return err
+	})
+	return result, err
+}
+
+func (b AutoBackend) ListTrackedFiles(ctx context.Context, dir string) (files []string, err error) {
+	err = b.run(ctx, dir, func(backend Backend) error {
+		files, err = backend.ListTrackedFiles(ctx, dir)
+		return err
+	})
+	return files, err
+}
+
+func (b AutoBackend) FindLargeFiles(ctx context.Context, dir string, threshold uint64) (files []FileSize, err error) {
+	err = b.run(ctx, dir, func(backend Backend) error {
+		files, err = backend.FindLargeFiles(ctx, dir, threshold)
+		return err
+	})
+	return files, err
+}
+
+func (b AutoBackend) ListBlobsInHistory(ctx context.Context, dir string) (blobs []Blob, err error) {
+	err = b.run(ctx, dir, func(backend Backend) error {
+		blobs, err = backend.ListBlobsInHistory(ctx, dir)
+		return err
+	})
+	return blobs, err
+}
+
+func (b AutoBackend) FindBlobCommits(ctx context.Context, dir string) (commits map[string]string, err error) {
+	err = b.run(ctx, dir, func(backend Backend) error {
+		commits, err = backend.FindBlobCommits(ctx, dir)
+		return err
+	})
+	return commits, err
+}
+
+func (b AutoBackend) ReadBlob(ctx context.Context, dir string, hash string) (contents []byte, err error) {
+	err = b.run(ctx, dir, func(backend Backend) error {
+		contents, err = backend.ReadBlob(ctx, dir, hash)
+		return err
+	})
+	return contents, err
+}
+
+// ReadBlobs is not retried with the ExecBackend when the NativeBackend fails, since fn may already have been called for some of the blobs.
+func (b AutoBackend) ReadBlobs(ctx context.Context, dir string, hashes []string, fn func(hash string, contents []byte) error) error {
+	return b.pick(dir).ReadBlobs(ctx, dir, hashes, fn)
+}
+
+func (b AutoBackend) ListCommits(ctx context.Context, dir string, opts LogOptions) (commits []Commit, err error) {
+	err = b.run(ctx, dir, func(backend Backend) error {
+		commits, err = backend.ListCommits(ctx, dir, opts)
+		return err
+	})
+	return commits, err
+}
+
+func (b AutoBackend) GetDefaultBranch(ctx context.Context, dir string) string {
+	return b.pick(dir).GetDefaultBranch(ctx, dir)
+}
+
+func (b AutoBackend) ListChangedFiles(ctx context.Context, dir string, ref string) (files []string, err error) {
+	err = b.run(ctx, dir, func(backend Backend) error {
+		files, err = backend.ListChangedFiles(ctx, dir, ref)
+		return err
+	})
+	return files, err
+}
+
+func (b AutoBackend) ListStagedFiles(ctx context.Context, dir string) (files []string, err error) {
+	err = b.run(ctx, dir, func(backend Backend) error {
+		files, err = backend.ListStagedFiles(ctx, dir)
+		return err
+	})
+	return files, err
+}
@@ -0,0 +1,185 @@
+package git_test
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/bvobart/mllint/api"
+	"github.com/bvobart/mllint/setools/git"
+	"github.com/bvobart/mllint/utils/exec"
+)
+
+func TestBackendByName(t *testing.T) {
+	backend, err := git.BackendByName("auto")
+	require.NoError(t, err)
+	require.Equal(t, git.AutoBackend{}, backend)
+
+	backend, err = git.BackendByName("")
+	require.NoError(t, err)
+	require.Equal(t, git.AutoBackend{}, backend)
+
+	backend, err = git.BackendByName("native")
+	require.NoError(t, err)
+	require.Equal(t, git.NativeBackend{}, backend)
+
+	backend, err = git.BackendByName("exec")
+	require.NoError(t, err)
+	require.Equal(t, git.ExecBackend{}, backend)
+
+	_, err = git.BackendByName("libgit2")
+	require.ErrorIs(t, err, git.ErrUnknownBackend)
+}
+
+func TestBackends(t *testing.T) {
+	ctx := context.Background()
+	dir, err := ioutil.TempDir(os.TempDir(), "mllint-tests-git-backends")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	runGit := func(args ...string) string {
+		output, err := exec.CommandOutput(context.Background(), dir, "git", append([]string{"-c", "user.name=mllint", "-c", "user.email=mllint@example.com"}, args...)...)
+		require.NoError(t, err)
+		return string(output)
+	}
+	writeFile := func(filename string, contents string) {
+		require.NoError(t, os.MkdirAll(path.Dir(path.Join(dir, filename)), 0755))
+		require.NoError(t, ioutil.WriteFile(path.Join(dir, filename), []byte(contents), 0644))
+	}
+
+	runGit("init")
+	runGit("remote", "add", "origin", "https://github.com/bvobart/mllint.git")
+	writeFile(".gitignore", "*.log\nbuild/\n!keep.log\n")
+	writeFile("train.py", "print('training')\n")
+	writeFile("data/train.csv", "a,b\n1,2\n")
+	writeFile("sub/dir/file.txt", "some text that is a bit longer than the others\n")
+	runGit("add", "-A")
+	runGit("commit", "-m", "initial commit")
+	writeFile("debug.log", "debugging")
+	writeFile("keep.log", "keep me")
+	writeFile("build/model.bin", "model")
+
+	commit := runGit("rev-parse", "HEAD")[:40]
+	branch := runGit("branch", "--show-current")
+	branch = branch[:len(branch)-1]
+	blobHash := runGit("rev-parse", "HEAD:train.py")[:40]
+
+	for name, backend := range backends {
+		t.Run(name, func(t *testing.T) {
+			require.True(t, backend.Detect(ctx, dir))
+			require.True(t, backend.Detect(ctx, path.Join(dir, "sub", "dir")))
+			require.False(t, backend.Detect(ctx, os.TempDir()))
+
+			require.Equal(t, dir, backend.GetGitRoot(ctx, path.Join(dir, "sub")))
+			require.Equal(t, os.TempDir(), backend.GetGitRoot(ctx, os.TempDir()))
+
+			remote, err := backend.GetRemoteURL(ctx, dir)
+			require.NoError(t, err)
+			require.Equal(t, "https://github.com/bvobart/mllint.git", remote)
+
+			current, err := backend.GetCurrentCommit(ctx, dir)
+			require.NoError(t, err)
+			require.Equal(t, commit, current)
+
+			currentBranch, err := backend.GetCurrentBranch(ctx, dir)
+			require.NoError(t, err)
+			require.Equal(t, branch, currentBranch)
+
+			require.False(t, backend.IsDirty(ctx, dir))
+			writeFile("train.py", "print('changed')\n")
+			require.True(t, backend.IsDirty(ctx, dir))
+			writeFile("train.py", "print('training')\n")
+			require.False(t, backend.IsDirty(ctx, dir))
+
+			require.True(t, backend.IsTracking(ctx, dir, "train.py"))
+			require.True(t, backend.IsTracking(ctx, dir, "*.py"))
+			require.True(t, backend.IsTracking(ctx, dir, "data"))
+			require.True(t, backend.IsTracking(ctx, dir, path.Join(dir, "data", "train.csv")))
+			require.True(t, backend.IsTracking(ctx, path.Join(dir, "sub"), "dir/file.txt"))
+			require.False(t, backend.IsTracking(ctx, dir, "debug.log"))
+			require.False(t, backend.IsTracking(ctx, dir, "non-existent"))
+
+			files, err := backend.ListTrackedFiles(ctx, dir)
+			require.NoError(t, err)
+			sort.Strings(files)
+			require.Equal(t, []string{".gitignore", "data/train.csv", "sub/dir/file.txt", "train.py"}, files)
+			files, err = backend.ListTrackedFiles(ctx, path.Join(dir, "sub"))
+			require.NoError(t, err)
+			require.Equal(t, []string{"sub/dir/file.txt"}, files)
+
+			notIgnored, err := backend.FilterIgnored(ctx, dir, []string{"debug.log", "build/model.bin", "keep.log", "train.py", path.Join(dir, "debug.log")})
+			require.NoError(t, err)
+			require.Equal(t, []string{"keep.log", "train.py"}, notIgnored)
+
+			largeFiles, err := backend.FindLargeFiles(ctx, dir, 10)
+			require.NoError(t, err)
+			require.Equal(t, []git.FileSize{{Path: "sub/dir/file.txt", Size: 47}, {Path: ".gitignore", Size: 23}, {Path: "train.py", Size: 18}}, largeFiles)
+			largeFiles, err = backend.FindLargeFiles(ctx, path.Join(dir, "sub"), 10)
+			require.NoError(t, err)
+			require.Equal(t, []git.FileSize{{Path: "sub/dir/file.txt", Size: 47}}, largeFiles)
+
+			contents, err := backend.ReadBlob(ctx, dir, blobHash)
+			require.NoError(t, err)
+			require.Equal(t, "print('training')\n", string(contents))
+		})
+	}
+}
+
+func TestBackendsFindLargeFiles(t *testing.T) {
+	dir := t.TempDir()
+	runGit := func(args ...string) {
+		output, err := exec.CommandOutput(context.Background(), dir, "git", append([]string{"-c", "user.name=mllint", "-c", "user.email=mllint@example.com", "-c", "commit.gpgsign=false"}, args...)...)
+		require.NoError(t, err, string(output))
+	}
+	writeFile := func(filename string, contents string) {
+		require.NoError(t, os.MkdirAll(path.Dir(path.Join(dir, filename)), 0755))
+		require.NoError(t, ioutil.WriteFile(path.Join(dir, filename), []byte(contents), 0644))
+	}
+
+	// the same contents in several files are stored as a single blob, but each of the files should be reported.
+	model := strings.Repeat("weights", 10)
+	runGit("init")
+	writeFile("models/model.pkl", model)
+	writeFile("models/copy of model.pkl", model)
+	writeFile("backup/model.pkl", model)
+	writeFile("train.py", "print('training')\n")
+	runGit("add", "-A")
+	runGit("commit", "-m", "add models")
+
+	expected := []git.FileSize{
+		{Path: "backup/model.pkl", Size: 70},
+		{Path: "models/copy of model.pkl", Size: 70},
+		{Path: "models/model.pkl", Size: 70},
+	}
+	for name, backend := range backends {
+		t.Run(name, func(t *testing.T) {
+			files, err := backend.FindLargeFiles(context.Background(), dir, 20)
+			require.NoError(t, err)
+			require.Equal(t, expected, files)
+
+			files, err = backend.FindLargeFiles(context.Background(), path.Join(dir, "models"), 20)
+			require.NoError(t, err)
+			require.Equal(t, expected[1:], files)
+		})
+	}
+}
+
+func TestForProject(t *testing.T) {
+	project := api.Project{}
+	require.Equal(t, git.AutoBackend{}, git.ForProject(project))
+
+	project.Config.Git.Backend = "exec"
+	require.Equal(t, git.ExecBackend{}, git.ForProject(project))
+
+	project.Config.Git.Backend = "native"
+	require.Equal(t, git.NativeBackend{}, git.ForProject(project))
+
+	project.Config.Git.Backend = "libgit2"
+	require.Equal(t, git.AutoBackend{}, git.ForProject(project))
+}
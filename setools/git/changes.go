@@ -0,0 +1,16 @@
+package git
+
+import "context"
+
+// ListChangedFiles lists the files in the worktree of the repository in the given directory that differ from the given ref,
+// e.g. `main`, `origin/main` or `HEAD~3`, including staged changes, uncommitted changes and untracked files that are not ignored.
+// Deleted files are not included. The paths are relative to the root of the Git repository.
+func ListChangedFiles(ctx context.Context, dir string, ref string) ([]string, error) {
+	return AutoBackend{}.ListChangedFiles(ctx, dir, ref)
+}
+
+// ListStagedFiles lists the files that have been staged for the next commit in the repository in the given directory,
+// i.e. the files that `git commit` would add or modify. The paths are relative to the root of the Git repository.
+func ListStagedFiles(ctx context.Context, dir string) ([]string, error) {
+	return AutoBackend{}.ListStagedFiles(ctx, dir)
+}
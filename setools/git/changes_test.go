@@ -0,0 +1,67 @@
+package git_test
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/bvobart/mllint/utils/exec"
+)
+
+func TestChangedFiles(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	runGit := func(args ...string) string {
+		output, err := exec.CommandOutput(context.Background(), dir, "git", append([]string{"-c", "user.name=mllint", "-c", "user.email=mllint@example.com", "-c", "commit.gpgsign=false"}, args...)...)
+		require.NoError(t, err, string(output))
+		return strings.TrimSpace(string(output))
+	}
+	writeFile := func(filename string, contents string) {
+		require.NoError(t, os.MkdirAll(path.Dir(path.Join(dir, filename)), 0755))
+		require.NoError(t, ioutil.WriteFile(path.Join(dir, filename), []byte(contents), 0644))
+	}
+
+	runGit("init")
+	writeFile(".gitignore", "*.log\n")
+	writeFile("train.py", "print('training')\n")
+	writeFile("evaluate.py", "print('evaluating')\n")
+	writeFile("old.py", "print('old')\n")
+	runGit("add", "-A")
+	runGit("commit", "-m", "initial commit")
+	base := runGit("rev-parse", "HEAD")
+
+	writeFile("src/model.py", "print('model')\n")
+	require.NoError(t, os.Remove(path.Join(dir, "old.py")))
+	runGit("add", "-A")
+	runGit("commit", "-m", "add model, remove old")
+
+	writeFile("train.py", "print('training better')\n") // staged
+	runGit("add", "train.py")
+	writeFile("evaluate.py", "print('evaluating better')\n") // modified, not staged
+	writeFile("src/new.py", "print('new')\n")                // untracked
+	writeFile("debug.log", "debugging")                      // ignored
+
+	for name, backend := range backends {
+		t.Run(name, func(t *testing.T) {
+			files, err := backend.ListChangedFiles(ctx, dir, base)
+			require.NoError(t, err)
+			require.Equal(t, []string{"evaluate.py", "src/model.py", "src/new.py", "train.py"}, files)
+
+			files, err = backend.ListChangedFiles(ctx, path.Join(dir, "src"), "HEAD")
+			require.NoError(t, err)
+			require.Equal(t, []string{"evaluate.py", "src/new.py", "train.py"}, files)
+
+			_, err = backend.ListChangedFiles(ctx, dir, "non-existent-ref")
+			require.Error(t, err)
+
+			files, err = backend.ListStagedFiles(ctx, dir)
+			require.NoError(t, err)
+			require.Equal(t, []string{"train.py"}, files)
+		})
+	}
+}
@@ -0,0 +1,384 @@
+package git
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	osexec "os/exec"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/bvobart/mllint/utils"
+	"github.com/bvobart/mllint/utils/exec"
+)
+
+// ExecBackend is a Backend that executes the `git` binary for every operation.
+type ExecBackend struct{}
+
+func (ExecBackend) Detect(ctx context.Context, dir string) bool {
+	_, err := exec.CommandOutput(ctx, dir, "git", "rev-parse", "--git-dir")
+	return err == nil
+}
+
+func (ExecBackend) GetGitRoot(ctx context.Context, dir string) string {
+	gitDir, err := exec.CommandOutput(ctx, dir, "git", "rev-parse", "--path-format=absolute", "--git-dir")
+	if err != nil {
+		return dir
+	}
+
+	rootDir := path.Dir(string(gitDir))
+	return rootDir
+}
+
+func (ExecBackend) GetRemoteURL(ctx context.Context, dir string) (string, error) {
+	output, err := exec.CommandOutput(ctx, dir, "git", "remote", "get-url", "origin")
+	return strings.TrimSpace(string(output)), err
+}
+
+func (ExecBackend) GetCurrentCommit(ctx context.Context, dir string) (string, error) {
+	output, err := exec.CommandOutput(ctx, dir, "git", "rev-parse", "HEAD")
+	return strings.TrimSpace(string(output)), err
+}
+
+func (ExecBackend) GetCurrentBranch(ctx context.Context, dir string) (string, error) {
+	output, err := exec.CommandOutput(ctx, dir, "git", "branch", "--show-current")
+	return strings.TrimSpace(string(output)), err
+}
+
+func (ExecBackend) IsDirty(ctx context.Context, dir string) bool {
+	_, err := exec.CommandOutput(ctx, dir, "git", "diff", "--no-ext-diff", "--quiet")
+	return err != nil
+}
+
+func (ExecBackend) IsTracking(ctx context.Context, dir string, pattern string) bool {
+	_, err := exec.CommandOutput(ctx, dir, "git", "ls-files", "--error-unmatch", pattern)
+	return err == nil
+}
+
+func (ExecBackend) ListTrackedFiles(ctx context.Context, dir string) ([]string, error) {
+	output, err := exec.CommandOutput(ctx, dir, "git", "ls-files", "-z", "--full-name")
+	if err != nil {
+		return nil, fmt.Errorf("failed to list Git files: %w", utils.WrapExitError(err))
+	}
+
+	files := []string{}
+	for _, file := range strings.Split(string(output), "\x00") {
+		if file != "" {
+			files = append(files, file)
+		}
+	}
+	return files, nil
+}
+
+func (b ExecBackend) FilterIgnored(ctx context.Context, dir string, files []string) ([]string, error) {
+	if len(files) == 0 {
+		return files, nil
+	}
+
+	// the files are passed on stdin, since there may be too many of them to pass as arguments.
+	var output []byte
+	stdin := strings.NewReader(strings.Join(files, "\x00") + "\x00")
+	err := exec.CommandStream(ctx, dir, stdin, func(stdout io.Reader) (err error) {
+		output, err = ioutil.ReadAll(stdout)
+		return err
+	}, "git", "check-ignore", "--stdin", "-z")
+
+	// git check-ignore prints the ignored files, but exits with status 1 when none of the files are ignored.
+	var exitErr *osexec.ExitError
+	if errors.As(err, &exitErr) && exitErr.ExitCode() == 1 {
+		return files, nil
+	}
+	if err != nil {
+		if ctx.Err() == nil && !b.Detect(ctx, dir) {
+			return files, nil
+		}
+		return nil, fmt.Errorf("failed to check which files are ignored by Git: %w", utils.WrapExitError(err))
+	}
+
+	ignored := map[string]bool{}
+	for _, file := range strings.Split(string(output), "\x00") {
+		ignored[file] = true
+	}
+
+	result := []string{}
+	for _, file := range files {
+		if !ignored[file] {
+			result = append(result, file)
+		}
+	}
+	return result, nil
+}
+
+func (ExecBackend) FindLargeFiles(ctx context.Context, dir string, threshold uint64) ([]FileSize, error) {
+	output, err := exec.CommandOutput(ctx, dir, "git", "ls-tree", "-r", "-l", "-z", "--full-name", "HEAD")
+	if err != nil {
+		return nil, fmt.Errorf("failed to read Git files: %w", utils.WrapExitError(err))
+	}
+
+	// e.g. '100644 blob <hash>     1234\tpath/to/file', for every file in the tree, even if another file has the same contents.
+	files := []FileSize{}
+	for _, entry := range strings.Split(string(output), "\x00") {
+		if entry == "" {
+			continue
+		}
+
+		parts := strings.SplitN(entry, "\t", 2)
+		fields := strings.Fields(parts[0])
+		if len(parts) < 2 || len(fields) < 4 {
+			return nil, fmt.Errorf("unexpected output from git ls-tree: %s", entry)
+		}
+
+		sizeStr := fields[3]
+		if sizeStr == "-" {
+			continue // submodules
+		}
+
+		size, err := strconv.ParseUint(sizeStr, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse filesize from '%s': %w", sizeStr, err)
+		}
+
+		if size > threshold {
+			files = append(files, FileSize{Path: parts[1], Size: size})
+		}
+	}
+
+	sortBySize(files)
+	return files, nil
+}
+
+func (ExecBackend) ListBlobsInHistory(ctx context.Context, dir string) ([]Blob, error) {
+	output, err := exec.PipelineOutput(ctx, dir, [][]string{
+		{"git", "rev-list", "--objects", "--all"},
+		{"git", "cat-file", "--batch-check=%(objecttype) %(objectname) %(objectsize) %(rest)"},
+	}...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read Git files: %w", utils.WrapExitError(err))
+	}
+
+	blobs := []Blob{}
+	for _, line := range strings.Split(string(output), "\n") {
+		if !strings.HasPrefix(line, "blob") {
+			continue
+		}
+
+		fields := strings.SplitN(line, " ", 4)
+		if len(fields) < 4 {
+			return nil, fmt.Errorf("expecting 4 fields in this message but it has %d: '%s'", len(fields), line)
+		}
+
+		sizeStr := fields[2]
+		size, err := strconv.ParseUint(sizeStr, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse filesize from '%s': %w", sizeStr, err)
+		}
+
+		blobs = append(blobs, Blob{Hash: fields[1], Path: fields[3], Size: size})
+	}
+
+	return blobs, nil
+}
+
+func (ExecBackend) FindBlobCommits(ctx context.Context, dir string) (map[string]string, error) {
+	output, err := exec.CommandOutput(ctx, dir, "git", "log", "--all", "--raw", "--no-abbrev", "--no-renames", "--format=commit %H")
+	if err != nil {
+		return nil, fmt.Errorf("failed to read Git history: %w", utils.WrapExitError(err))
+	}
+
+	// git log lists the newest commits first, so older commits overwrite the blobs' entries.
+	commits := map[string]string{}
+	commit := ""
+	for _, line := range strings.Split(string(output), "\n") {
+		if strings.HasPrefix(line, "commit ") {
+			commit = strings.TrimPrefix(line, "commit ")
+			continue
+		}
+
+		// e.g. ':100644 100644 <old blob> <new blob> M\tpath/to/file'
+		fields := strings.Fields(line)
+		if !strings.HasPrefix(line, ":") || len(fields) < 5 || fields[4] == "D" {
+			continue
+		}
+		commits[fields[3]] = commit
+	}
+
+	return commits, nil
+}
+
+func (ExecBackend) ReadBlob(ctx context.Context, dir string, hash string) ([]byte, error) {
+	output, err := exec.CommandOutput(ctx, dir, "git", "cat-file", "blob", hash)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read Git blob %s: %w", hash, utils.WrapExitError(err))
+	}
+	return output, nil
+}
+
+func (ExecBackend) ReadBlobs(ctx context.Context, dir string, hashes []string, fn func(hash string, contents []byte) error) error {
+	if len(hashes) == 0 {
+		return nil
+	}
+
+	// git cat-file --batch outputs '<hash> blob <size>\n<contents>\n' for each of the hashes on its input, or '<hash> missing\n'.
+	stdin := strings.NewReader(strings.Join(hashes, "\n") + "\n")
+	err := exec.CommandStream(ctx, dir, stdin, func(stdout io.Reader) error {
+		output := bufio.NewReader(stdout)
+		for _, hash := range hashes {
+			header, err := output.ReadString('\n')
+			if err != nil {
+				return fmt.Errorf("failed to read Git blob %s: %w", hash, err)
+			}
+
+			fields := strings.Fields(header)
+			if len(fields) != 3 || fields[1] != "blob" {
+				return fmt.Errorf("failed to read Git blob %s: %s", hash, strings.TrimSpace(header))
+			}
+			size, err := strconv.ParseUint(fields[2], 10, 64)
+			if err != nil {
+				return fmt.Errorf("failed to parse size of Git blob %s from '%s': %w", hash, fields[2], err)
+			}
+
+			contents := make([]byte, size+1) // including the trailing newline
+			if _, err := io.ReadFull(output, contents); err != nil {
+				return fmt.Errorf("failed to read Git blob %s: %w", hash, err)
+			}
+			if err := fn(hash, contents[:size]); err != nil {
+				return err
+			}
+		}
+		return nil
+	}, "git", "cat-file", "--batch")
+	if err != nil {
+		return utils.WrapExitError(err)
+	}
+	return nil
+}
+
+// format of the commits output by `git log` in ListCommits: a NUL byte, then the commit's hash, parents, author,
+// signature status and message separated by unit separators, terminated by a record separator, followed by the output of --numstat.
+const commitLogFormat = "--format=%x00%H%x1f%P%x1f%an%x1f%G?%x1f%B%x1e"
+
+func (ExecBackend) ListCommits(ctx context.Context, dir string, opts LogOptions) ([]Commit, error) {
+	args := []string{"log", "--numstat", "--no-renames", commitLogFormat}
+	if opts.Limit > 0 {
+		args = append(args, "-n", strconv.Itoa(opts.Limit))
+	}
+	if opts.FirstParent {
+		args = append(args, "--first-parent")
+	}
+	if opts.Ref != "" {
+		args = append(args, opts.Ref, "--")
+	}
+
+	output, err := exec.CommandOutput(ctx, dir, "git", args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read Git history: %w", utils.WrapExitError(err))
+	}
+
+	commits := []Commit{}
+	for _, record := range strings.Split(string(output), "\x00")[1:] {
+		parts := strings.SplitN(record, "\x1e", 2)
+		fields := strings.SplitN(parts[0], "\x1f", 5)
+		if len(parts) < 2 || len(fields) < 5 {
+			return nil, fmt.Errorf("unexpected output from git log: '%s'", record)
+		}
+
+		files, err := parseNumstat(parts[1])
+		if err != nil {
+			return nil, err
+		}
+
+		commits = append(commits, Commit{
+			Hash:    fields[0],
+			Parents: len(strings.Fields(fields[1])),
+			Author:  fields[2],
+			// %G? is N when the commit is not signed, all other statuses indicate a signature that may or may not be valid.
+			Signed:  fields[3] != "N",
+			Message: strings.TrimSpace(fields[4]),
+			Files:   files,
+		})
+	}
+	return commits, nil
+}
+
+// parses the output of `git log --numstat` for a single commit, e.g. '12\t3\tpath/to/file' or '-\t-\tpath/to/binary'
+func parseNumstat(output string) ([]CommitFile, error) {
+	files := []CommitFile{}
+	for _, line := range strings.Split(strings.TrimSpace(output), "\n") {
+		fields := strings.SplitN(line, "\t", 3)
+		if len(fields) < 3 {
+			continue
+		}
+
+		if fields[0] == "-" && fields[1] == "-" {
+			files = append(files, CommitFile{Path: fields[2], Binary: true})
+			continue
+		}
+
+		additions, err := strconv.Atoi(fields[0])
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse number of additions from '%s': %w", line, err)
+		}
+		deletions, err := strconv.Atoi(fields[1])
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse number of deletions from '%s': %w", line, err)
+		}
+		files = append(files, CommitFile{Path: fields[2], Additions: additions, Deletions: deletions})
+	}
+
+	sort.Slice(files, func(i, j int) bool {
+		return files[i].Path < files[j].Path
+	})
+	return files, nil
+}
+
+func (ExecBackend) GetDefaultBranch(ctx context.Context, dir string) string {
+	if output, err := exec.CommandOutput(ctx, dir, "git", "symbolic-ref", "--short", "refs/remotes/origin/HEAD"); err == nil {
+		return strings.TrimPrefix(strings.TrimSpace(string(output)), "origin/")
+	}
+
+	for _, branch := range []string{"main", "master"} {
+		if _, err := exec.CommandOutput(ctx, dir, "git", "rev-parse", "--verify", "--quiet", "refs/heads/"+branch); err == nil {
+			return branch
+		}
+	}
+	return ""
+}
+
+func (ExecBackend) ListChangedFiles(ctx context.Context, dir string, ref string) ([]string, error) {
+	changed, err := exec.CommandOutput(ctx, dir, "git", "diff", "--name-only", "-z", "--no-renames", "--diff-filter=d", ref, "--")
+	if err != nil {
+		return nil, fmt.Errorf("failed to list files changed since '%s': %w", ref, utils.WrapExitError(err))
+	}
+	untracked, err := exec.CommandOutput(ctx, dir, "git", "ls-files", "-z", "--others", "--exclude-standard", "--full-name", "--", ":/")
+	if err != nil {
+		return nil, fmt.Errorf("failed to list untracked files: %w", utils.WrapExitError(err))
+	}
+	return splitNulSeparated(string(changed) + string(untracked)), nil
+}
+
+func (ExecBackend) ListStagedFiles(ctx context.Context, dir string) ([]string, error) {
+	output, err := exec.CommandOutput(ctx, dir, "git", "diff", "--cached", "--name-only", "-z", "--no-renames", "--diff-filter=d")
+	if err != nil {
+		return nil, fmt.Errorf("failed to list staged files: %w", utils.WrapExitError(err))
+	}
+	return splitNulSeparated(string(output)), nil
+}
+
+// splits NUL-separated output of Git into a sorted list of unique, non-empty entries.
+func splitNulSeparated(output string) []string {
+	seen := map[string]bool{}
+	entries := []string{}
+	for _, entry := range strings.Split(output, "\x00") {
+		if entry != "" && !seen[entry] {
+			seen[entry] = true
+			entries = append(entries, entry)
+		}
+	}
+	sort.Strings(entries)
+	return entries
+}
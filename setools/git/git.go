@@ -1,21 +1,15 @@
 package git
 
 import (
-	"fmt"
-	"path"
+	"context"
 	"sort"
-	"strconv"
-	"strings"
 
 	"github.com/bvobart/mllint/api"
-	"github.com/bvobart/mllint/utils"
-	"github.com/bvobart/mllint/utils/exec"
 )
 
 // Detect detects whether this directory is inside a Git repository
 func Detect(dir string) bool {
-	_, err := exec.CommandOutput(dir, "git", "rev-parse", "--git-dir")
-	return err == nil
+	return AutoBackend{}.Detect(context.Background(), dir)
 }
 
 // If the given directory is in a Git repository,
@@ -23,57 +17,58 @@ func Detect(dir string) bool {
 // then returns the absolute path to the root folder of that Git repository,
 // or the dir given as argument when it is not a Git repo.
 func GetGitRoot(dir string) string {
-	gitDir, err := exec.CommandOutput(dir, "git", "rev-parse", "--path-format=absolute", "--git-dir")
-	if err != nil {
-		return dir
-	}
-
-	rootDir := path.Dir(string(gitDir))
-	return rootDir
+	return AutoBackend{}.GetGitRoot(context.Background(), dir)
 }
 
-// MakeGitInfo creates a description of the Git repository in the given directory.
+// MakeGitInfo creates a description of the Git repository in the given directory, using the given Backend.
 // Returns an empty api.GitInfo{} when the dir is not a Git repo.
-func MakeGitInfo(dir string) api.GitInfo {
-	if !Detect(dir) {
+func MakeGitInfo(ctx context.Context, backend Backend, dir string) api.GitInfo {
+	if !backend.Detect(ctx, dir) {
 		return api.GitInfo{}
 	}
 
-	remote, _ := GetRemoteURL(dir)
-	commit, _ := GetCurrentCommit(dir)
-	branch, _ := GetCurrentBranch(dir)
-	dirty := IsDirty(dir)
+	remote, _ := backend.GetRemoteURL(ctx, dir)
+	commit, _ := backend.GetCurrentCommit(ctx, dir)
+	branch, _ := backend.GetCurrentBranch(ctx, dir)
+	dirty := backend.IsDirty(ctx, dir)
 	return api.GitInfo{RemoteURL: remote, Commit: commit, Branch: branch, Dirty: dirty}
 }
 
 // GetRemoteURL returns the URL of the `origin` Git remote.
-func GetRemoteURL(dir string) (string, error) {
-	output, err := exec.CommandOutput(dir, "git", "remote", "get-url", "origin")
-	return strings.TrimSpace(string(output)), err
+func GetRemoteURL(ctx context.Context, dir string) (string, error) {
+	return AutoBackend{}.GetRemoteURL(ctx, dir)
 }
 
-func GetCurrentCommit(dir string) (string, error) {
-	output, err := exec.CommandOutput(dir, "git", "rev-parse", "HEAD")
-	return strings.TrimSpace(string(output)), err
+func GetCurrentCommit(ctx context.Context, dir string) (string, error) {
+	return AutoBackend{}.GetCurrentCommit(ctx, dir)
 }
 
-func GetCurrentBranch(dir string) (string, error) {
-	output, err := exec.CommandOutput(dir, "git", "branch", "--show-current")
-	return strings.TrimSpace(string(output)), err
+func GetCurrentBranch(ctx context.Context, dir string) (string, error) {
+	return AutoBackend{}.GetCurrentBranch(ctx, dir)
 }
 
 // IsDirty returns true when there are changed files in the repository.
-func IsDirty(dir string) bool {
-	_, err := exec.CommandOutput(dir, "git", "diff", "--no-ext-diff", "--quiet")
-	return err != nil
+func IsDirty(ctx context.Context, dir string) bool {
+	return AutoBackend{}.IsDirty(ctx, dir)
 }
 
 // IsTracking checks whether the Git repository in the given folder is tracking the files specified
 // by the given pattern. This can be a literal folder or file name, but can also be a pattern
 // containing wildcards, e.g. 'foo.*'
-func IsTracking(dir string, pattern string) bool {
-	_, err := exec.CommandOutput(dir, "git", "ls-files", "--error-unmatch", pattern)
-	return err == nil
+func IsTracking(ctx context.Context, dir string, pattern string) bool {
+	return AutoBackend{}.IsTracking(ctx, dir, pattern)
+}
+
+// ListTrackedFiles lists all files in the given directory (and its subdirectories) that are being tracked by Git.
+// The paths are relative to the root of the Git repository.
+func ListTrackedFiles(ctx context.Context, dir string) ([]string, error) {
+	return AutoBackend{}.ListTrackedFiles(ctx, dir)
+}
+
+// FilterIgnored returns the given files, except for those that are ignored by Git, e.g. because of a `.gitignore` file.
+// The files should be absolute paths or relative to the given directory. Returns all of the files if dir is not a Git repository.
+func FilterIgnored(ctx context.Context, dir string, files []string) ([]string, error) {
+	return AutoBackend{}.FilterIgnored(ctx, dir, files)
 }
 
 // FileSize is the return type for FindLargeFiles. Contains the path to the file and its filesize,
@@ -86,82 +81,68 @@ type FileSize struct {
 
 // FindLargeFiles looks for any files being tracked in the current Git repository that have a
 // filesize larger than the given threshold, measured in bytes.
-func FindLargeFiles(dir string, threshold uint64) ([]FileSize, error) {
-	output, err := exec.CommandOutput(dir, "git", "ls-tree", "-r", "-t", "-l", "--full-name", "HEAD")
-	if err != nil {
-		return nil, fmt.Errorf("failed to read Git files: %w", utils.WrapExitError(err))
-	}
-
-	files := []FileSize{}
-	if string(output) == "" {
-		return files, nil
-	}
-
-	for _, line := range strings.Split(strings.TrimSpace(string(output)), "\n") {
-		fields := strings.Fields(line)
-		if len(fields) < 5 {
-			return nil, fmt.Errorf("unexpected output from git ls-tree: %s", string(output))
-		}
-
-		sizeStr := fields[3]
-		if sizeStr == "-" {
-			continue
-		}
-
-		size, err := strconv.ParseUint(sizeStr, 10, 64)
-		if err != nil {
-			return nil, fmt.Errorf("failed to parse filesize from '%s': %w", sizeStr, err)
-		}
-
-		if size > threshold {
-			files = append(files, FileSize{Path: fields[4], Size: size})
-		}
-	}
+func FindLargeFiles(ctx context.Context, dir string, threshold uint64) ([]FileSize, error) {
+	return AutoBackend{}.FindLargeFiles(ctx, dir, threshold)
+}
 
-	// sort files by filesize in descending order
-	sort.Slice(files, func(i, j int) bool {
-		return files[i].Size > files[j].Size
-	})
+// Blob is a single version of a file that is stored in a Git repository's history.
+type Blob struct {
+	// Hash of the blob object
+	Hash string
+	// Path of the file, relative to the root of the Git repository
+	Path string
+	Size uint64
+}
 
-	return files, nil
+// ListBlobsInHistory lists all blobs in the Git history of the repository in the given directory,
+// i.e. every version of every file that has ever been committed on any branch.
+func ListBlobsInHistory(ctx context.Context, dir string) ([]Blob, error) {
+	return AutoBackend{}.ListBlobsInHistory(ctx, dir)
 }
 
-func FindLargeFilesInHistory(dir string, threshold uint64) ([]FileSize, error) {
-	output, err := exec.PipelineOutput(dir, [][]string{
-		{"git", "rev-list", "--objects", "--all"},
-		{"git", "cat-file", "--batch-check=%(objecttype) %(objectname) %(objectsize) %(rest)"},
-	}...)
+// FindLargeFilesInHistory looks for any files in the Git history of the repository in the given directory
+// that have a filesize larger than the given threshold, measured in bytes, using the given Backend.
+func FindLargeFilesInHistory(ctx context.Context, backend Backend, dir string, threshold uint64) ([]FileSize, error) {
+	blobs, err := backend.ListBlobsInHistory(ctx, dir)
 	if err != nil {
-		return nil, fmt.Errorf("failed to read Git files: %w", utils.WrapExitError(err))
+		return nil, err
 	}
 
 	files := []FileSize{}
-	for _, line := range strings.Split(string(output), "\n") {
-		if !strings.HasPrefix(line, "blob") {
-			continue
+	for _, blob := range blobs {
+		if blob.Size > threshold {
+			files = append(files, FileSize{Path: blob.Path, CommitHash: blob.Hash, Size: blob.Size})
 		}
+	}
 
-		fields := strings.Fields(line)
-		if len(fields) < 4 {
-			return nil, fmt.Errorf("expecting 4 fields in this message but it has %d: '%s'", len(fields), line)
-		}
+	sortBySize(files)
+	return files, nil
+}
 
-		sizeStr := fields[2]
-		size, err := strconv.ParseUint(sizeStr, 10, 64)
-		if err != nil {
-			return nil, fmt.Errorf("failed to parse filesize from '%s': %w", sizeStr, err)
+// sorts files by filesize in descending order, then by path.
+func sortBySize(files []FileSize) {
+	sort.Slice(files, func(i, j int) bool {
+		if files[i].Size != files[j].Size {
+			return files[i].Size > files[j].Size
 		}
+		return files[i].Path < files[j].Path
+	})
+}
 
-		if size > threshold {
-			file := FileSize{Path: fields[3], CommitHash: fields[1], Size: size}
-			files = append(files, file)
-		}
-	}
+// FindBlobCommits returns a map from the hash of each blob in the Git history of the repository in the given directory,
+// to the hash of the (oldest) commit that introduced that blob.
+func FindBlobCommits(ctx context.Context, dir string) (map[string]string, error) {
+	return AutoBackend{}.FindBlobCommits(ctx, dir)
+}
 
-	// sort files by filesize in descending order
-	sort.Slice(files, func(i, j int) bool {
-		return files[i].Size > files[j].Size
-	})
+// ReadBlob returns the contents of the blob with the given hash in the Git repository in the given directory.
+func ReadBlob(ctx context.Context, dir string, hash string) ([]byte, error) {
+	return AutoBackend{}.ReadBlob(ctx, dir, hash)
+}
 
-	return files, err
+// ReadBlobs reads the blobs with the given hashes from the Git repository in the given directory all at once,
+// calling fn with the contents of each blob, in the order of the given hashes. Stops at the first error returned by fn.
+// This is much faster than calling ReadBlob for each of the blobs.
+func ReadBlobs(ctx context.Context, dir string, hashes []string, fn func(hash string, contents []byte) error) error {
+	return AutoBackend{}.ReadBlobs(ctx, dir, hashes, fn)
 }
@@ -1,9 +1,13 @@
 package git_test
 
 import (
+	"context"
+	"errors"
+	"fmt"
 	"io/ioutil"
 	"math"
 	"os"
+	"path"
 	"strings"
 	"testing"
 
@@ -28,12 +32,12 @@ func TestDetect(t *testing.T) {
 
 func TestMakeGitInfo(t *testing.T) {
 	dir := "."
-	info := git.MakeGitInfo(dir)
+	info := git.MakeGitInfo(context.Background(), git.AutoBackend{}, dir)
 	require.Contains(t, info.RemoteURL, "github.com")
 	require.Contains(t, info.RemoteURL, "bvobart/mllint")
 
 	dir = os.TempDir()
-	require.Equal(t, api.GitInfo{}, git.MakeGitInfo(dir))
+	require.Equal(t, api.GitInfo{}, git.MakeGitInfo(context.Background(), git.AutoBackend{}, dir))
 }
 
 func TestGetGitRoot(t *testing.T) {
@@ -48,7 +52,7 @@ func TestGetGitRoot(t *testing.T) {
 	// create Git repo in temp dir, expect that temp dir
 	dir, err := ioutil.TempDir(os.TempDir(), "mllint-tests-git-root")
 	require.NoError(t, err)
-	_, err = exec.CommandOutput(dir, "git", "init")
+	_, err = exec.CommandOutput(context.Background(), dir, "git", "init")
 	require.NoError(t, err)
 
 	rootDir = git.GetGitRoot(dir)
@@ -57,24 +61,81 @@ func TestGetGitRoot(t *testing.T) {
 
 func TestIsTracking(t *testing.T) {
 	dir := "."
-	require.True(t, git.IsTracking(dir, "git_test.go"))
-	require.True(t, git.IsTracking(dir, "git*.go"))
-	require.False(t, git.IsTracking(dir, "non-existant-file"))
+	require.True(t, git.IsTracking(context.Background(), dir, "git_test.go"))
+	require.True(t, git.IsTracking(context.Background(), dir, "git*.go"))
+	require.False(t, git.IsTracking(context.Background(), dir, "non-existant-file"))
 
 	file, err := ioutil.TempFile(dir, "git.is-tracking.test-resource.*.txt")
 	require.NoError(t, err)
-	require.False(t, git.IsTracking(dir, file.Name()))
+	require.False(t, git.IsTracking(context.Background(), dir, file.Name()))
 
 	require.NoError(t, os.Remove(file.Name())) // cleanup
 }
 
+func TestFilterIgnored(t *testing.T) {
+	dir, err := ioutil.TempDir(os.TempDir(), "mllint-tests-git-ignored")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	// more files than fit in the arguments of a single command.
+	many := []string{}
+	for i := 0; i < 40000; i++ {
+		many = append(many, path.Join(dir, "some", "deeply", "nested", "folder", fmt.Sprintf("with-a-long-filename-%d.txt", i)))
+	}
+
+	files := []string{".env", "config.yml", "secrets/key.json"}
+	for name, backend := range backends {
+		t.Run(name, func(t *testing.T) {
+			notIgnored, err := backend.FilterIgnored(context.Background(), dir, files)
+			require.NoError(t, err)
+			require.Equal(t, files, notIgnored) // not a Git repo
+		})
+	}
+
+	_, err = exec.CommandOutput(context.Background(), dir, "git", "init")
+	require.NoError(t, err)
+	require.NoError(t, ioutil.WriteFile(path.Join(dir, ".gitignore"), []byte(".env\nsecrets/\n*.txt\n"), 0644))
+
+	for name, backend := range backends {
+		t.Run(name, func(t *testing.T) {
+			notIgnored, err := backend.FilterIgnored(context.Background(), dir, files)
+			require.NoError(t, err)
+			require.Equal(t, []string{"config.yml"}, notIgnored)
+
+			notIgnored, err = backend.FilterIgnored(context.Background(), dir, []string{path.Join(dir, ".env"), path.Join(dir, "config.yml")})
+			require.NoError(t, err)
+			require.Equal(t, []string{path.Join(dir, "config.yml")}, notIgnored)
+
+			notIgnored, err = backend.FilterIgnored(context.Background(), dir, []string{})
+			require.NoError(t, err)
+			require.Equal(t, []string{}, notIgnored)
+
+			notIgnored, err = backend.FilterIgnored(context.Background(), dir, append(many, path.Join(dir, "config.yml")))
+			require.NoError(t, err)
+			require.Equal(t, []string{path.Join(dir, "config.yml")}, notIgnored)
+		})
+	}
+
+	require.NoError(t, ioutil.WriteFile(path.Join(dir, ".gitignore"), []byte(""), 0644))
+	notIgnored, err := git.FilterIgnored(context.Background(), dir, files)
+	require.NoError(t, err)
+	require.Equal(t, files, notIgnored) // nothing ignored
+}
+
 func TestFindLargeFiles(t *testing.T) {
 	dir := "."
 
 	threshold := uint64(1)
-	largeFiles, err := git.FindLargeFiles(dir, threshold)
+	largeFiles, err := git.FindLargeFiles(context.Background(), dir, threshold)
 	require.NoError(t, err)
-	require.Len(t, largeFiles, 2)
+	// only the files in this directory at HEAD
+	paths := []string{}
+	for _, file := range largeFiles {
+		paths = append(paths, file.Path)
+		require.True(t, strings.HasPrefix(file.Path, "setools/git/"), file.Path)
+	}
+	require.Contains(t, paths, "setools/git/git.go")
+	require.Contains(t, paths, "setools/git/git_test.go")
 
 	// test that largeFiles is sorted by filesize in descending order (i.e. largest files first)
 	prevSize := uint64(math.MaxUint64)
@@ -84,7 +145,7 @@ func TestFindLargeFiles(t *testing.T) {
 	}
 
 	threshold = uint64(1000000000)
-	largeFiles, err = git.FindLargeFiles(dir, threshold)
+	largeFiles, err = git.FindLargeFiles(context.Background(), dir, threshold)
 	require.NoError(t, err)
 	require.Len(t, largeFiles, 0)
 }
@@ -92,7 +153,8 @@ func TestFindLargeFiles(t *testing.T) {
 func TestFindLargeFilesInHistory(t *testing.T) {
 	dir := "."
 	threshold := uint64(4000)
-	exec.PipelineOutput = func(execdir string, commands ...[]string) ([]byte, error) {
+	defer func() { exec.PipelineOutput = exec.DefaultPipelineOutput }()
+	exec.PipelineOutput = func(_ context.Context, execdir string, commands ...[]string) ([]byte, error) {
 		require.Equal(t, dir, execdir)
 		require.Equal(t, []string{"git", "rev-list", "--objects", "--all"}, commands[0])
 		require.Equal(t, []string{"git", "cat-file", "--batch-check=%(objecttype) %(objectname) %(objectsize) %(rest)"}, commands[1])
@@ -105,7 +167,7 @@ func TestFindLargeFilesInHistory(t *testing.T) {
 		{Path: "projectlinters/dependencies.go", CommitHash: "070e0b0dc26f83093da32d58c77f49babee5fd39", Size: 8570},
 		{Path: "build/setup.py", CommitHash: "d7c8acfa5c917844da0d99eceb596c2c3cd9a94f", Size: 4454},
 	}
-	largeFiles, err := git.FindLargeFilesInHistory(dir, threshold)
+	largeFiles, err := git.FindLargeFilesInHistory(context.Background(), git.ExecBackend{}, dir, threshold)
 	require.NoError(t, err)
 	require.Equal(t, expected, largeFiles)
 	// test that largeFiles is sorted by filesize in descending order (i.e. largest files first)
@@ -116,6 +178,105 @@ func TestFindLargeFilesInHistory(t *testing.T) {
 	}
 }
 
+func TestHistoryBlobs(t *testing.T) {
+	ctx := context.Background()
+	dir, err := ioutil.TempDir(os.TempDir(), "mllint-tests-git-history")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	gitCommit := func(message string) string {
+		_, err := exec.CommandOutput(context.Background(), dir, "git", "add", "-A")
+		require.NoError(t, err)
+		_, err = exec.CommandOutput(context.Background(), dir, "git", "-c", "user.name=mllint", "-c", "user.email=mllint@example.com", "commit", "-m", message)
+		require.NoError(t, err)
+		commit, err := git.GetCurrentCommit(context.Background(), dir)
+		require.NoError(t, err)
+		return commit
+	}
+
+	_, err = exec.CommandOutput(context.Background(), dir, "git", "init")
+	require.NoError(t, err)
+	require.NoError(t, ioutil.WriteFile(path.Join(dir, "train.py"), []byte("print('training')\n"), 0644))
+	require.NoError(t, os.Mkdir(path.Join(dir, "my models"), 0755))
+	require.NoError(t, ioutil.WriteFile(path.Join(dir, "my models", "model.pkl"), []byte("pickled"), 0644))
+	first := gitCommit("first")
+
+	require.NoError(t, os.RemoveAll(path.Join(dir, "my models")))
+	require.NoError(t, ioutil.WriteFile(path.Join(dir, "train.py"), []byte("print('training better')\n"), 0644))
+	second := gitCommit("second")
+
+	for name, backend := range backends {
+		t.Run(name, func(t *testing.T) {
+			blobs, err := backend.ListBlobsInHistory(ctx, dir)
+			require.NoError(t, err)
+			require.Len(t, blobs, 3)
+
+			byPath := map[string][]git.Blob{}
+			for _, blob := range blobs {
+				byPath[blob.Path] = append(byPath[blob.Path], blob)
+			}
+			require.Len(t, byPath["train.py"], 2)
+			require.Len(t, byPath["my models/model.pkl"], 1)
+			model := byPath["my models/model.pkl"][0]
+			require.EqualValues(t, 7, model.Size)
+
+			contents, err := backend.ReadBlob(ctx, dir, model.Hash)
+			require.NoError(t, err)
+			require.Equal(t, "pickled", string(contents))
+			_, err = backend.ReadBlob(ctx, dir, "0000000000000000000000000000000000000000")
+			require.Error(t, err)
+
+			commits, err := backend.FindBlobCommits(ctx, dir)
+			require.NoError(t, err)
+			require.Len(t, commits, 3)
+			require.Equal(t, first, commits[model.Hash])
+			for _, blob := range byPath["train.py"] {
+				contents, err := backend.ReadBlob(ctx, dir, blob.Hash)
+				require.NoError(t, err)
+				if strings.Contains(string(contents), "better") {
+					require.Equal(t, second, commits[blob.Hash])
+				} else {
+					require.Equal(t, first, commits[blob.Hash])
+				}
+			}
+
+			hashes := []string{}
+			for _, blob := range blobs {
+				hashes = append(hashes, blob.Hash)
+			}
+			read := []string{}
+			err = backend.ReadBlobs(ctx, dir, hashes, func(hash string, contents []byte) error {
+				expected, err := backend.ReadBlob(ctx, dir, hash)
+				require.NoError(t, err)
+				require.Equal(t, expected, contents)
+				read = append(read, hash)
+				return nil
+			})
+			require.NoError(t, err)
+			require.Equal(t, hashes, read)
+
+			err = backend.ReadBlobs(ctx, dir, []string{model.Hash, "0000000000000000000000000000000000000000"}, func(hash string, contents []byte) error { return nil })
+			require.Error(t, err)
+			stopped := errors.New("stopped")
+			err = backend.ReadBlobs(ctx, dir, hashes, func(hash string, contents []byte) error { return stopped })
+			require.ErrorIs(t, err, stopped)
+
+			cancelled, cancel := context.WithCancel(ctx)
+			cancel()
+			_, err = backend.ListBlobsInHistory(cancelled, dir)
+			require.ErrorIs(t, err, context.Canceled)
+			_, err = backend.FindBlobCommits(cancelled, dir)
+			require.ErrorIs(t, err, context.Canceled)
+			_, err = backend.ListCommits(cancelled, dir, git.LogOptions{})
+			require.ErrorIs(t, err, context.Canceled)
+			err = backend.ReadBlobs(cancelled, dir, hashes, func(hash string, contents []byte) error { return nil })
+			require.ErrorIs(t, err, context.Canceled)
+		})
+	}
+}
+
+var backends = map[string]git.Backend{"Exec": git.ExecBackend{}, "Native": git.NativeBackend{}, "Auto": git.AutoBackend{}}
+
 const mockPipelineOutput = `blob 7aba7e93fc6f8da4e63a683f2fda4a657d7835b2 3484 .github/workflows/build-publish.yml
 tree 89417d9bb896948c6e612a9acd99f2f1161a8df0 460 
 tree 44401018fc8e5d6848e11002c2d58069f5f86d7d 148 build
@@ -0,0 +1,71 @@
+package git
+
+import (
+	"context"
+	"strings"
+)
+
+// Commit describes a commit in a Git repository's history.
+type Commit struct {
+	Hash   string
+	Author string
+	// The commit's full message, i.e. its subject and body.
+	Message string
+	// Number of parents of the commit. Merge commits have more than one parent, the root commit has none.
+	Parents int
+	// Whether the commit has a (GPG or SSH) signature. Note that the signature itself is not verified.
+	Signed bool
+	// The files changed by the commit compared to its first parent. Empty for merge commits.
+	Files []CommitFile
+}
+
+// CommitFile describes the changes that a commit made to a single file.
+type CommitFile struct {
+	// Path of the file, relative to the root of the Git repository
+	Path      string
+	Additions int
+	Deletions int
+	// Whether the file is a binary file, in which case no additions and deletions are counted.
+	Binary bool
+}
+
+// Subject returns the first line of the commit's message.
+func (c Commit) Subject() string {
+	return strings.TrimSpace(strings.SplitN(c.Message, "\n", 2)[0])
+}
+
+// IsMerge returns whether the commit is a merge commit, i.e. has more than one parent.
+func (c Commit) IsMerge() bool {
+	return c.Parents > 1
+}
+
+// LinesChanged returns the total number of lines added and deleted by the commit.
+func (c Commit) LinesChanged() int {
+	total := 0
+	for _, file := range c.Files {
+		total += file.Additions + file.Deletions
+	}
+	return total
+}
+
+// LogOptions specifies which commits ListCommits lists.
+type LogOptions struct {
+	// The revision (e.g. branch name or commit hash) to start listing commits from. Defaults to HEAD.
+	Ref string
+	// Maximum number of commits to list. Zero means no limit.
+	Limit int
+	// Only follow the first parent of merge commits, i.e. only list the commits made directly on the branch (like `git log --first-parent`).
+	FirstParent bool
+}
+
+// ListCommits lists the commits in the history of the repository in the given directory, newest commits first.
+func ListCommits(ctx context.Context, dir string, opts LogOptions) ([]Commit, error) {
+	return AutoBackend{}.ListCommits(ctx, dir, opts)
+}
+
+// GetDefaultBranch returns the name of the default branch of the repository in the given directory,
+// i.e. the branch that the `origin` remote's HEAD points to, or otherwise `main` or `master` if either of these exists locally.
+// Returns an empty string if the default branch cannot be determined.
+func GetDefaultBranch(ctx context.Context, dir string) string {
+	return AutoBackend{}.GetDefaultBranch(ctx, dir)
+}
@@ -0,0 +1,129 @@
+package git_test
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/bvobart/mllint/setools/git"
+	"github.com/bvobart/mllint/utils/exec"
+)
+
+// a syntactically valid signature that does not belong to the commit it is attached to.
+const fakeSignature = `-----BEGIN PGP SIGNATURE-----
+ 
+ iHUEABYIAB0WIQR2e2vNsI0TocFJF1mm/DPeoeHbXQUCatYDawAKCRCm/DPeoeHb
+ XfcXAP9yeGrlxF7kfrW/rW4lsQMSB8z4iJq3VoDd43KKZYznVAEA1c2wVcZSjh7M
+ /zLKo4LHIUU0RS3XhGT8N+WqBYsb2Ac=
+ =hC2a
+ -----END PGP SIGNATURE-----`
+
+func TestListCommits(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	runGit := func(args ...string) string {
+		output, err := exec.CommandOutput(context.Background(), dir, "git", append([]string{"-c", "user.name=mllint", "-c", "user.email=mllint@example.com", "-c", "commit.gpgsign=false"}, args...)...)
+		require.NoError(t, err, string(output))
+		return strings.TrimSpace(string(output))
+	}
+	// commits in this test need distinct timestamps for their order to be deterministic
+	timestamp := 1600000000
+	commit := func(args ...string) {
+		timestamp += 10
+		date := strconv.Itoa(timestamp) + " +0000"
+		require.NoError(t, os.Setenv("GIT_AUTHOR_DATE", date))
+		require.NoError(t, os.Setenv("GIT_COMMITTER_DATE", date))
+		defer os.Unsetenv("GIT_AUTHOR_DATE")
+		defer os.Unsetenv("GIT_COMMITTER_DATE")
+		runGit(args...)
+	}
+	writeFile := func(filename string, contents string) {
+		require.NoError(t, os.MkdirAll(path.Dir(path.Join(dir, filename)), 0755))
+		require.NoError(t, ioutil.WriteFile(path.Join(dir, filename), []byte(contents), 0644))
+	}
+
+	runGit("init")
+	runGit("symbolic-ref", "HEAD", "refs/heads/main")
+	writeFile("train.py", "import os\nprint('training')\n")
+	writeFile("data/model.bin", "\x00\x01\x02binary")
+	runGit("add", "-A")
+	commit("commit", "-m", "feat: initial commit\n\nWith a body.")
+
+	runGit("checkout", "-b", "feature")
+	writeFile("train.py", "import os\nprint('training!')\nprint('done')\n")
+	commit("commit", "-am", "fix training")
+	runGit("checkout", "main")
+	commit("merge", "--no-ff", "-m", "Merge branch 'feature'", "feature")
+
+	// create a commit with a signature that is present, but not valid.
+	tree := runGit("rev-parse", "HEAD^{tree}")
+	parent := runGit("rev-parse", "HEAD")
+	commitObject := "tree " + tree + "\nparent " + parent + "\nauthor mllint <mllint@example.com> 1600001000 +0000\ncommitter mllint <mllint@example.com> 1600001000 +0000\ngpgsig " + fakeSignature + "\n\nchore: signed commit\n"
+	require.NoError(t, ioutil.WriteFile(path.Join(dir, "commit-object"), []byte(commitObject), 0644))
+	signed := runGit("hash-object", "-t", "commit", "-w", "commit-object")
+	require.NoError(t, os.Remove(path.Join(dir, "commit-object")))
+	runGit("update-ref", "refs/heads/main", signed)
+
+	hashes := strings.Split(runGit("log", "--format=%H"), "\n")
+	require.Len(t, hashes, 4)
+
+	for name, backend := range backends {
+		t.Run(name, func(t *testing.T) {
+			commits, err := backend.ListCommits(ctx, dir, git.LogOptions{})
+			require.NoError(t, err)
+			require.Equal(t, []git.Commit{
+				{Hash: hashes[0], Author: "mllint", Message: "chore: signed commit", Parents: 1, Signed: true, Files: []git.CommitFile{}},
+				{Hash: hashes[1], Author: "mllint", Message: "Merge branch 'feature'", Parents: 2, Files: []git.CommitFile{}},
+				{Hash: hashes[2], Author: "mllint", Message: "fix training", Parents: 1, Files: []git.CommitFile{
+					{Path: "train.py", Additions: 2, Deletions: 1},
+				}},
+				{Hash: hashes[3], Author: "mllint", Message: "feat: initial commit\n\nWith a body.", Parents: 0, Files: []git.CommitFile{
+					{Path: "data/model.bin", Binary: true},
+					{Path: "train.py", Additions: 2},
+				}},
+			}, commits)
+			require.Equal(t, "feat: initial commit", commits[3].Subject())
+			require.True(t, commits[1].IsMerge())
+			require.Equal(t, 3, commits[2].LinesChanged())
+
+			commits, err = backend.ListCommits(ctx, dir, git.LogOptions{Limit: 2})
+			require.NoError(t, err)
+			require.Len(t, commits, 2)
+
+			commits, err = backend.ListCommits(ctx, dir, git.LogOptions{Ref: "main", FirstParent: true})
+			require.NoError(t, err)
+			require.Len(t, commits, 3)
+			require.Equal(t, []string{hashes[0], hashes[1], hashes[3]}, []string{commits[0].Hash, commits[1].Hash, commits[2].Hash})
+
+			commits, err = backend.ListCommits(ctx, dir, git.LogOptions{Ref: "feature", Limit: 1})
+			require.NoError(t, err)
+			require.Equal(t, hashes[2], commits[0].Hash)
+
+			_, err = backend.ListCommits(ctx, dir, git.LogOptions{Ref: "non-existent"})
+			require.Error(t, err)
+
+			require.Equal(t, "main", backend.GetDefaultBranch(ctx, dir))
+		})
+	}
+
+	runGit("remote", "add", "origin", "https://github.com/bvobart/mllint.git")
+	runGit("update-ref", "refs/remotes/origin/develop", hashes[0])
+	runGit("symbolic-ref", "refs/remotes/origin/HEAD", "refs/remotes/origin/develop")
+	for name, backend := range backends {
+		t.Run(name+"/OriginHEAD", func(t *testing.T) {
+			require.Equal(t, "develop", backend.GetDefaultBranch(ctx, dir))
+		})
+	}
+
+	for name, backend := range backends {
+		t.Run(name+"/NoDefaultBranch", func(t *testing.T) {
+			require.Equal(t, "", backend.GetDefaultBranch(ctx, t.TempDir()))
+		})
+	}
+}
@@ -0,0 +1,182 @@
+package git
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// MaxLFSPointerSize is the maximum size of a Git LFS pointer file, see https://github.com/git-lfs/git-lfs/blob/main/docs/spec.md
+const MaxLFSPointerSize = 1024
+
+const lfsPointerVersion = "version https://git-lfs.github.com/spec/v1"
+
+// LFSPatterns contains the patterns from a `.gitattributes` file that specify whether files should be stored using Git LFS.
+type LFSPatterns []lfsPattern
+
+type lfsPattern struct {
+	regex *regexp.Regexp
+	// whether the files matching this pattern are stored using LFS (i.e. `filter=lfs`) or not (e.g. `-filter`)
+	lfs bool
+}
+
+// ReadLFSPatterns reads the LFS patterns from the `.gitattributes` file in the given root directory of a Git repository, see Backend.GetGitRoot.
+// Returns empty LFSPatterns if there is no such file. Note that `.gitattributes` files in subdirectories are not considered.
+func ReadLFSPatterns(rootdir string) (LFSPatterns, error) {
+	file, err := os.Open(path.Join(rootdir, ".gitattributes"))
+	if os.IsNotExist(err) {
+		return LFSPatterns{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	return ParseLFSPatterns(file)
+}
+
+// ParseLFSPatterns parses the LFS patterns from the contents of a `.gitattributes` file,
+// i.e. the patterns of files that have the `filter=lfs` attribute set, or explicitly unset.
+func ParseLFSPatterns(reader io.Reader) (LFSPatterns, error) {
+	patterns := LFSPatterns{}
+	scanner := bufio.NewScanner(reader)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 2 || strings.HasPrefix(fields[0], "#") {
+			continue
+		}
+
+		for _, attr := range fields[1:] {
+			if !strings.HasPrefix(attr, "filter=") && attr != "-filter" && attr != "!filter" {
+				continue
+			}
+
+			regex, err := compileAttributesPattern(fields[0])
+			if err != nil {
+				return nil, fmt.Errorf("invalid pattern '%s' in .gitattributes: %w", fields[0], err)
+			}
+			patterns = append(patterns, lfsPattern{regex, attr == "filter=lfs"})
+		}
+	}
+	return patterns, scanner.Err()
+}
+
+// Matches returns whether the file at the given path, relative to the root of the Git repository, should be stored using Git LFS.
+func (p LFSPatterns) Matches(filename string) bool {
+	// as in .gitattributes, later patterns override earlier patterns.
+	for i := len(p) - 1; i >= 0; i-- {
+		if p[i].regex.MatchString(filename) {
+			return p[i].lfs
+		}
+	}
+	return false
+}
+
+// Any returns whether any files should be stored using Git LFS, i.e. whether there is any pattern with `filter=lfs`.
+func (p LFSPatterns) Any() bool {
+	for _, pattern := range p {
+		if pattern.lfs {
+			return true
+		}
+	}
+	return false
+}
+
+// converts a pattern in a `.gitattributes` file to a regex that matches file paths relative to the repository's root.
+// Patterns without a slash match files in any directory, other patterns are relative to the repository's root.
+func compileAttributesPattern(pattern string) (*regexp.Regexp, error) {
+	prefix := "^"
+	if !strings.Contains(pattern, "/") {
+		prefix = "^(?:.*/)?"
+	}
+	pattern = strings.TrimPrefix(pattern, "/")
+
+	regex := strings.Builder{}
+	for i := 0; i < len(pattern); i++ {
+		switch {
+		case strings.HasPrefix(pattern[i:], "**/"):
+			regex.WriteString("(?:.*/)?")
+			i += 2
+		case strings.HasPrefix(pattern[i:], "**"):
+			regex.WriteString(".*")
+			i++
+		case pattern[i] == '*':
+			regex.WriteString("[^/]*")
+		case pattern[i] == '?':
+			regex.WriteString("[^/]")
+		default:
+			regex.WriteString(regexp.QuoteMeta(pattern[i : i+1]))
+		}
+	}
+	return regexp.Compile(prefix + regex.String() + "$")
+}
+
+// LFSPointer is the contents of a Git LFS pointer file, which Git stores instead of the actual contents of a file stored using LFS.
+type LFSPointer struct {
+	// The object ID of the file's actual contents, e.g. `sha256:4d7a214614ab2935c943f9e0ff69d22eadbb8f32b1258daaa5e2ca24d17e2393`
+	OID string
+	// The size of the file's actual contents in bytes.
+	Size uint64
+}
+
+// ParseLFSPointer parses the given file contents as a Git LFS pointer file.
+// Returns false if the contents are not a valid LFS pointer.
+func ParseLFSPointer(contents []byte) (LFSPointer, bool) {
+	if len(contents) > MaxLFSPointerSize || !bytes.HasPrefix(contents, []byte(lfsPointerVersion+"\n")) {
+		return LFSPointer{}, false
+	}
+
+	pointer := LFSPointer{}
+	hasSize := false
+	for _, line := range strings.Split(string(contents), "\n") {
+		key, value := splitPointerLine(line)
+		switch key {
+		case "oid":
+			pointer.OID = value
+		case "size":
+			size, err := strconv.ParseUint(value, 10, 64)
+			if err != nil {
+				return LFSPointer{}, false
+			}
+			pointer.Size = size
+			hasSize = true
+		}
+	}
+
+	return pointer, pointer.OID != "" && hasSize
+}
+
+func splitPointerLine(line string) (string, string) {
+	parts := strings.SplitN(line, " ", 2)
+	if len(parts) < 2 {
+		return parts[0], ""
+	}
+	return parts[0], parts[1]
+}
+
+// FindLFSPointerBlobs returns the hashes of those of the given blobs in the Git repository in the given directory that are Git LFS pointers,
+// i.e. the blobs of files that were correctly stored using Git LFS in the commit that introduced them.
+func FindLFSPointerBlobs(ctx context.Context, backend Backend, dir string, blobs []Blob) (map[string]bool, error) {
+	hashes := []string{}
+	for _, blob := range blobs {
+		if blob.Size <= MaxLFSPointerSize {
+			hashes = append(hashes, blob.Hash)
+		}
+	}
+
+	pointers := map[string]bool{}
+	err := backend.ReadBlobs(ctx, dir, hashes, func(hash string, contents []byte) error {
+		if _, ok := ParseLFSPointer(contents); ok {
+			pointers[hash] = true
+		}
+		return nil
+	})
+	return pointers, err
+}
@@ -0,0 +1,69 @@
+package git_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/bvobart/mllint/setools/git"
+)
+
+const gitattributes = `# Git LFS
+*.pt filter=lfs diff=lfs merge=lfs -text
+/data/**/*.csv filter=lfs diff=lfs merge=lfs -text
+models/*.onnx filter=lfs diff=lfs merge=lfs -text
+data/small.csv -filter
+*.py text eol=lf
+`
+
+func TestParseLFSPatterns(t *testing.T) {
+	patterns, err := git.ParseLFSPatterns(strings.NewReader(gitattributes))
+	require.NoError(t, err)
+	require.Len(t, patterns, 4)
+
+	require.True(t, patterns.Matches("model.pt"))
+	require.True(t, patterns.Matches("nested/dir/model.pt"))
+	require.False(t, patterns.Matches("model.pth"))
+	require.True(t, patterns.Matches("data/train.csv"))
+	require.True(t, patterns.Matches("data/raw/2021/train.csv"))
+	require.False(t, patterns.Matches("other/data/train.csv"))
+	require.False(t, patterns.Matches("data/small.csv"))
+	require.True(t, patterns.Matches("models/model.onnx"))
+	require.False(t, patterns.Matches("models/v2/model.onnx"))
+	require.False(t, patterns.Matches("train.py"))
+	require.True(t, patterns.Any())
+
+	patterns, err = git.ParseLFSPatterns(strings.NewReader(""))
+	require.NoError(t, err)
+	require.False(t, patterns.Matches("model.pt"))
+	require.False(t, patterns.Any())
+
+	patterns, err = git.ParseLFSPatterns(strings.NewReader("*.csv -filter\n"))
+	require.NoError(t, err)
+	require.False(t, patterns.Any())
+}
+
+func TestReadLFSPatterns(t *testing.T) {
+	patterns, err := git.ReadLFSPatterns(t.TempDir())
+	require.NoError(t, err)
+	require.Len(t, patterns, 0)
+}
+
+func TestParseLFSPointer(t *testing.T) {
+	pointer, ok := git.ParseLFSPointer([]byte(`version https://git-lfs.github.com/spec/v1
+oid sha256:4d7a214614ab2935c943f9e0ff69d22eadbb8f32b1258daaa5e2ca24d17e2393
+size 12345
+`))
+	require.True(t, ok)
+	require.Equal(t, git.LFSPointer{OID: "sha256:4d7a214614ab2935c943f9e0ff69d22eadbb8f32b1258daaa5e2ca24d17e2393", Size: 12345}, pointer)
+
+	_, ok = git.ParseLFSPointer([]byte("version https://git-lfs.github.com/spec/v1\noid sha256:abc\nsize large\n"))
+	require.False(t, ok)
+	_, ok = git.ParseLFSPointer([]byte("version https://git-lfs.github.com/spec/v1\nsize 12345\n"))
+	require.False(t, ok)
+	_, ok = git.ParseLFSPointer([]byte("just some file\n"))
+	require.False(t, ok)
+	_, ok = git.ParseLFSPointer([]byte("version https://git-lfs.github.com/spec/v1\noid sha256:abc\nsize 1\n" + strings.Repeat("x", 1024)))
+	require.False(t, ok)
+}
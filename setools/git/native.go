@@ -0,0 +1,1000 @@
+package git
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/go-git/go-billy/v5/osfs"
+	gogit "github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/filemode"
+	"github.com/go-git/go-git/v5/plumbing/format/gitignore"
+	"github.com/go-git/go-git/v5/plumbing/format/index"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/go-git/go-git/v5/plumbing/storer"
+	"github.com/go-git/go-git/v5/utils/diff"
+	"github.com/sergi/go-diff/diffmatchpatch"
+
+	"github.com/bvobart/mllint/utils"
+)
+
+// NativeBackend is a Backend that reads Git repositories in-process using go-git, without requiring the `git` binary to be installed.
+// Object sizes are read directly from the repository's packfiles and loose objects, without decompressing the objects themselves.
+type NativeBackend struct{}
+
+// repository is a Git repository opened by go-git, which is shared by all operations on the same directory.
+// go-git's repositories are not safe for concurrent use, so operations must hold the lock while they use it.
+type repository struct {
+	sync.Mutex
+	*gogit.Repository
+}
+
+// the repositories opened by openRepository, keyed by the absolute path of the directory that they were opened from.
+var repositories = struct {
+	sync.Mutex
+	byDir map[string]*repository
+}{byDir: map[string]*repository{}}
+
+// opens the Git repository that the given directory is in, or returns the repository that was opened for it before,
+// locking it for the caller. Call the returned function once done with the repository to unlock it again.
+func openRepository(dir string) (*gogit.Repository, func(), error) {
+	if absdir, err := filepath.Abs(dir); err == nil {
+		dir = absdir
+	}
+
+	repositories.Lock()
+	repo, ok := repositories.byDir[dir]
+	if !ok {
+		opened, err := gogit.PlainOpenWithOptions(dir, &gogit.PlainOpenOptions{DetectDotGit: true, EnableDotGitCommonDir: true})
+		if err != nil {
+			repositories.Unlock()
+			return nil, nil, err
+		}
+		repo = &repository{Repository: opened}
+		repositories.byDir[dir] = repo
+	}
+	repositories.Unlock()
+
+	repo.Lock()
+	return repo.Repository, repo.Unlock, nil
+}
+
+// returns the absolute path to the root of the repository's worktree.
+func worktreeRoot(repo *gogit.Repository) (string, error) {
+	worktree, err := repo.Worktree()
+	if err != nil {
+		return "", err
+	}
+	return worktree.Filesystem.Root(), nil
+}
+
+// returns the path of the given directory relative to the root of the repository's worktree, using forward slashes,
+// or an empty string if the directory is the root itself.
+func relativeToRoot(root string, dir string) (string, error) {
+	abspath, err := filepath.Abs(dir)
+	if err != nil {
+		return "", err
+	}
+	if resolved, err := filepath.EvalSymlinks(abspath); err == nil {
+		abspath = resolved
+	}
+	if resolvedRoot, err := filepath.EvalSymlinks(root); err == nil {
+		root = resolvedRoot
+	}
+
+	relpath, err := filepath.Rel(root, abspath)
+	if err != nil {
+		return "", err
+	}
+	if relpath == ".." || strings.HasPrefix(relpath, "../") {
+		return "", fmt.Errorf("'%s' is outside of the Git repository at '%s'", dir, root)
+	}
+	if relpath == "." {
+		return "", nil
+	}
+	return filepath.ToSlash(relpath), nil
+}
+
+func (NativeBackend) Detect(ctx context.Context, dir string) bool {
+	_, unlock, err := openRepository(dir)
+	if err != nil {
+		return false
+	}
+	unlock()
+	return true
+}
+
+func (NativeBackend) GetGitRoot(ctx context.Context, dir string) string {
+	repo, unlock, err := openRepository(dir)
+	if err != nil {
+		return dir
+	}
+	defer unlock()
+
+	root, err := worktreeRoot(repo)
+	if err != nil {
+		return dir
+	}
+	return root
+}
+
+func (NativeBackend) GetRemoteURL(ctx context.Context, dir string) (string, error) {
+	repo, unlock, err := openRepository(dir)
+	if err != nil {
+		return "", err
+	}
+	defer unlock()
+
+	remote, err := repo.Remote("origin")
+	if err != nil {
+		return "", err
+	}
+	if len(remote.Config().URLs) == 0 {
+		return "", fmt.Errorf("remote 'origin' has no URL")
+	}
+	return remote.Config().URLs[0], nil
+}
+
+func (NativeBackend) GetCurrentCommit(ctx context.Context, dir string) (string, error) {
+	repo, unlock, err := openRepository(dir)
+	if err != nil {
+		return "", err
+	}
+	defer unlock()
+
+	head, err := repo.Head()
+	if err != nil {
+		return "", err
+	}
+	return head.Hash().String(), nil
+}
+
+func (NativeBackend) GetCurrentBranch(ctx context.Context, dir string) (string, error) {
+	repo, unlock, err := openRepository(dir)
+	if err != nil {
+		return "", err
+	}
+	defer unlock()
+
+	// HEAD is a symbolic reference to the current branch, even if that branch does not have any commits yet.
+	head, err := repo.Reference(plumbing.HEAD, false)
+	if err != nil {
+		return "", err
+	}
+	if head.Type() != plumbing.SymbolicReference || !head.Target().IsBranch() {
+		return "", nil // detached HEAD
+	}
+	return head.Target().Short(), nil
+}
+
+func (NativeBackend) IsDirty(ctx context.Context, dir string) bool {
+	repo, unlock, err := openRepository(dir)
+	if err != nil {
+		return true
+	}
+	defer unlock()
+	root, err := worktreeRoot(repo)
+	if err != nil {
+		return true
+	}
+	idx, err := repo.Storer.Index()
+	if err != nil {
+		return true
+	}
+
+	// like `git diff`, only compares the tracked files in the worktree with the index, so untracked files and staged changes do not count.
+	for _, entry := range idx.Entries {
+		if entry.Mode == filemode.Submodule || entry.SkipWorktree || entry.IntentToAdd {
+			continue
+		}
+		if isModified(root, entry) {
+			return true
+		}
+	}
+	return false
+}
+
+// returns whether the file in the worktree differs from its entry in the index.
+func isModified(root string, entry *index.Entry) bool {
+	filename := filepath.Join(root, filepath.FromSlash(entry.Name))
+	info, err := os.Lstat(filename)
+	if err != nil {
+		return true
+	}
+	if info.Mode()&os.ModeSymlink != 0 {
+		return entry.Mode != filemode.Symlink
+	}
+	if uint32(info.Size()) != entry.Size {
+		return true
+	}
+	if info.ModTime().Equal(entry.ModifiedAt) {
+		return false
+	}
+
+	// the file was touched, so compare its contents with the index.
+	contents, err := ioutil.ReadFile(filename)
+	if err != nil {
+		return true
+	}
+	return plumbing.ComputeHash(plumbing.BlobObject, contents) != entry.Hash
+}
+
+func (b NativeBackend) IsTracking(ctx context.Context, dir string, pattern string) bool {
+	repo, unlock, err := openRepository(dir)
+	if err != nil {
+		return false
+	}
+	defer unlock()
+	root, err := worktreeRoot(repo)
+	if err != nil {
+		return false
+	}
+	idx, err := repo.Storer.Index()
+	if err != nil {
+		return false
+	}
+
+	if !filepath.IsAbs(pattern) {
+		pattern = filepath.Join(dir, pattern)
+	}
+	relpattern, err := relativeToRoot(root, pattern)
+	if err != nil {
+		return false
+	}
+
+	// like Git pathspecs, the pattern matches a file, a directory containing tracked files, or a wildcard pattern.
+	glob := compilePathspec(relpattern)
+	for _, entry := range idx.Entries {
+		if relpattern == "" || entry.Name == relpattern || strings.HasPrefix(entry.Name, relpattern+"/") || glob.MatchString(entry.Name) {
+			return true
+		}
+	}
+	return false
+}
+
+// converts a Git pathspec to a regex. Unlike in .gitignore files, wildcards in pathspecs also match slashes.
+func compilePathspec(pathspec string) *regexp.Regexp {
+	regex := strings.Builder{}
+	for _, char := range pathspec {
+		switch char {
+		case '*':
+			regex.WriteString(".*")
+		case '?':
+			regex.WriteString(".")
+		default:
+			regex.WriteString(regexp.QuoteMeta(string(char)))
+		}
+	}
+	return regexp.MustCompile("^" + regex.String() + "$")
+}
+
+func (NativeBackend) ListTrackedFiles(ctx context.Context, dir string) ([]string, error) {
+	repo, unlock, err := openRepository(dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list Git files: %w", err)
+	}
+	defer unlock()
+	root, err := worktreeRoot(repo)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list Git files: %w", err)
+	}
+	prefix, err := relativeToRoot(root, dir)
+	if err != nil {
+		return nil, err
+	}
+	idx, err := repo.Storer.Index()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read Git index: %w", err)
+	}
+
+	files := []string{}
+	seen := map[string]bool{}
+	for _, entry := range idx.Entries {
+		if (prefix == "" || strings.HasPrefix(entry.Name, prefix+"/")) && !seen[entry.Name] {
+			seen[entry.Name] = true // files with merge conflicts have multiple entries
+			files = append(files, entry.Name)
+		}
+	}
+	return files, nil
+}
+
+func (NativeBackend) FilterIgnored(ctx context.Context, dir string, files []string) ([]string, error) {
+	if len(files) == 0 {
+		return files, nil
+	}
+
+	repo, unlock, err := openRepository(dir)
+	if errors.Is(err, gogit.ErrRepositoryNotExists) {
+		return files, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to check which files are ignored by Git: %w", err)
+	}
+	defer unlock()
+	root, err := worktreeRoot(repo)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check which files are ignored by Git: %w", err)
+	}
+
+	tracked := map[string]bool{}
+	if idx, err := repo.Storer.Index(); err == nil {
+		for _, entry := range idx.Entries {
+			tracked[entry.Name] = true
+		}
+	}
+
+	ignores := newIgnoreMatcher(root)
+	result := []string{}
+	for _, file := range files {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		filename := file
+		if !filepath.IsAbs(filename) {
+			filename = filepath.Join(dir, filename)
+		}
+
+		relpath, err := relativeToRoot(root, filename)
+		// like `git check-ignore`, tracked files are never ignored.
+		if err != nil || relpath == "" || tracked[relpath] || !ignores.isIgnored(relpath) {
+			result = append(result, file)
+		}
+	}
+	return result, nil
+}
+
+// ignoreMatcher determines whether files are ignored by Git, reading the `.gitignore` files in a directory only when needed.
+type ignoreMatcher struct {
+	root string
+	// the patterns from .git/info/exclude and the global core.excludesFile
+	base []gitignore.Pattern
+	// the patterns from the .gitignore file in each directory, relative to the root.
+	dirs map[string][]gitignore.Pattern
+}
+
+func newIgnoreMatcher(root string) *ignoreMatcher {
+	base, _ := gitignore.LoadGlobalPatterns(osfs.New("/"))
+	base = append(base, readIgnoreFile(filepath.Join(root, ".git", "info", "exclude"), nil)...)
+	return &ignoreMatcher{root: root, base: base, dirs: map[string][]gitignore.Pattern{}}
+}
+
+func (m *ignoreMatcher) patterns(dirParts []string) []gitignore.Pattern {
+	dir := path.Join(dirParts...)
+	if patterns, ok := m.dirs[dir]; ok {
+		return patterns
+	}
+
+	patterns := readIgnoreFile(filepath.Join(m.root, filepath.FromSlash(dir), ".gitignore"), dirParts)
+	m.dirs[dir] = patterns
+	return patterns
+}
+
+// returns whether the file at the given path relative to the root of the repository is ignored,
+// either directly, or because one of its parent directories is ignored.
+func (m *ignoreMatcher) isIgnored(relpath string) bool {
+	parts := strings.Split(relpath, "/")
+	patterns := append([]gitignore.Pattern{}, m.base...)
+	for i := range parts {
+		patterns = append(patterns, m.patterns(parts[:i])...)
+		isDir := i < len(parts)-1 || utils.FolderExists(filepath.Join(m.root, filepath.FromSlash(relpath)))
+		if gitignore.NewMatcher(patterns).Match(parts[:i+1], isDir) {
+			return true
+		}
+	}
+	return false
+}
+
+func readIgnoreFile(filename string, domain []string) []gitignore.Pattern {
+	file, err := os.Open(filename)
+	if err != nil {
+		return nil
+	}
+	defer file.Close()
+
+	patterns := []gitignore.Pattern{}
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if !strings.HasPrefix(line, "#") && strings.TrimSpace(line) != "" {
+			patterns = append(patterns, gitignore.ParsePattern(line, domain))
+		}
+	}
+	return patterns
+}
+
+func (b NativeBackend) FindLargeFiles(ctx context.Context, dir string, threshold uint64) ([]FileSize, error) {
+	repo, unlock, err := openRepository(dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read Git files: %w", err)
+	}
+	defer unlock()
+
+	head, err := repo.Head()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read Git files: %w", err)
+	}
+	commit, err := repo.CommitObject(head.Hash())
+	if err != nil {
+		return nil, fmt.Errorf("failed to read Git files: %w", err)
+	}
+
+	root, err := worktreeRoot(repo)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read Git files: %w", err)
+	}
+	prefix, err := relativeToRoot(root, dir)
+	if err != nil {
+		return nil, err
+	}
+
+	// like `git ls-tree` in a subdirectory, only the files in the given directory are considered.
+	treeHash := commit.TreeHash
+	if prefix != "" {
+		tree, err := commit.Tree()
+		if err != nil {
+			return nil, fmt.Errorf("failed to read Git files: %w", err)
+		}
+		subtree, err := tree.Tree(prefix)
+		if errors.Is(err, object.ErrDirectoryNotFound) {
+			return []FileSize{}, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read Git files: %w", err)
+		}
+		treeHash = subtree.Hash
+	}
+
+	files := []FileSize{}
+	err = walkTree(ctx, repo, treeHash, prefix, nil, func(blob Blob) {
+		if blob.Size > threshold {
+			files = append(files, FileSize{Path: blob.Path, Size: blob.Size})
+		}
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to read Git files: %w", err)
+	}
+
+	sortBySize(files)
+	return files, nil
+}
+
+func (NativeBackend) ListBlobsInHistory(ctx context.Context, dir string) ([]Blob, error) {
+	repo, unlock, err := openRepository(dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read Git files: %w", err)
+	}
+	defer unlock()
+
+	commits, err := allCommits(ctx, repo)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read Git history: %w", err)
+	}
+
+	// like `git rev-list --objects --all`, lists the blobs of the newest commits first, each blob only once.
+	blobs := []Blob{}
+	seen := map[plumbing.Hash]bool{}
+	for _, commit := range commits {
+		err := walkTree(ctx, repo, commit.TreeHash, "", seen, func(blob Blob) {
+			blobs = append(blobs, blob)
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to read Git files: %w", err)
+		}
+	}
+	return blobs, nil
+}
+
+func (NativeBackend) FindBlobCommits(ctx context.Context, dir string) (map[string]string, error) {
+	repo, unlock, err := openRepository(dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read Git history: %w", err)
+	}
+	defer unlock()
+
+	commits, err := allCommits(ctx, repo)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read Git history: %w", err)
+	}
+
+	// walking the commits from oldest to newest, each blob is first encountered in the commit that introduced it.
+	result := map[string]string{}
+	seen := map[plumbing.Hash]bool{}
+	for i := len(commits) - 1; i >= 0; i-- {
+		commit := commits[i]
+		err := walkTree(ctx, repo, commit.TreeHash, "", seen, func(blob Blob) {
+			result[blob.Hash] = commit.Hash.String()
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to read Git history: %w", err)
+		}
+	}
+	return result, nil
+}
+
+func (NativeBackend) ReadBlob(ctx context.Context, dir string, hash string) ([]byte, error) {
+	repo, unlock, err := openRepository(dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read Git blob %s: %w", hash, err)
+	}
+	defer unlock()
+	return readBlob(repo, hash)
+}
+
+func (b NativeBackend) ReadBlobs(ctx context.Context, dir string, hashes []string, fn func(hash string, contents []byte) error) error {
+	for _, hash := range hashes {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		// the repository is only opened once, but it is not kept locked while calling fn, which may use the repository as well.
+		contents, err := b.ReadBlob(ctx, dir, hash)
+		if err != nil {
+			return err
+		}
+		if err := fn(hash, contents); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func readBlob(repo *gogit.Repository, hash string) ([]byte, error) {
+	blob, err := repo.BlobObject(plumbing.NewHash(hash))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read Git blob %s: %w", hash, err)
+	}
+
+	reader, err := blob.Reader()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read Git blob %s: %w", hash, err)
+	}
+	defer reader.Close()
+	return ioutil.ReadAll(reader)
+}
+
+//---------------------------------------------------------------------------------------
+
+// returns all commits that are reachable from any reference in the repository (like `git rev-list --all`), newest commits first.
+// Stops with the context's error once the context is done.
+func allCommits(ctx context.Context, repo *gogit.Repository) ([]*object.Commit, error) {
+	refs, err := repo.References()
+	if err != nil {
+		return nil, err
+	}
+
+	tips := []plumbing.Hash{}
+	err = refs.ForEach(func(ref *plumbing.Reference) error {
+		if ref.Type() == plumbing.HashReference {
+			tips = append(tips, ref.Hash())
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	commits := []*object.Commit{}
+	seen := map[plumbing.Hash]bool{}
+	for len(tips) > 0 {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		hash := tips[len(tips)-1]
+		tips = tips[:len(tips)-1]
+		if seen[hash] {
+			continue
+		}
+		seen[hash] = true
+
+		commit, err := peelToCommit(repo, hash)
+		if errors.Is(err, errNotACommit) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		commits = append(commits, commit)
+		tips = append(tips, commit.ParentHashes...)
+	}
+
+	sort.SliceStable(commits, func(i, j int) bool {
+		return commits[i].Committer.When.After(commits[j].Committer.When)
+	})
+	return commits, nil
+}
+
+var errNotACommit = errors.New("not a commit")
+
+// returns the commit with the given hash, or the commit that the annotated tag with the given hash points to.
+func peelToCommit(repo *gogit.Repository, hash plumbing.Hash) (*object.Commit, error) {
+	obj, err := repo.Object(plumbing.AnyObject, hash)
+	if err != nil {
+		return nil, err
+	}
+
+	switch obj := obj.(type) {
+	case *object.Commit:
+		return obj, nil
+	case *object.Tag:
+		return peelToCommit(repo, obj.Target)
+	default:
+		return nil, errNotACommit
+	}
+}
+
+// objectSizer is implemented by storers that can determine the size of an object without reading it entirely,
+// such as go-git's filesystem storage, which reads the sizes from the headers of packed and loose objects.
+type objectSizer interface {
+	EncodedObjectSize(hash plumbing.Hash) (int64, error)
+}
+
+func objectSize(repo *gogit.Repository, hash plumbing.Hash) (uint64, error) {
+	if sizer, ok := repo.Storer.(objectSizer); ok {
+		size, err := sizer.EncodedObjectSize(hash)
+		return uint64(size), err
+	}
+
+	obj, err := repo.Storer.EncodedObject(plumbing.BlobObject, hash)
+	if err != nil {
+		return 0, err
+	}
+	return uint64(obj.Size()), nil
+}
+
+// calls fn for every blob in the tree with the given hash, including its subtrees, that is not yet in seen.
+// Trees and blobs that are in seen are skipped, while all trees and blobs that are walked are added to seen.
+// If seen is nil, fn is called for every file in the tree, including files with the same contents, like `git ls-tree -r`.
+// Stops with the context's error once the context is done.
+func walkTree(ctx context.Context, repo *gogit.Repository, hash plumbing.Hash, prefix string, seen map[plumbing.Hash]bool, fn func(blob Blob)) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	if seen[hash] {
+		return nil
+	}
+	if seen != nil {
+		seen[hash] = true
+	}
+
+	tree, err := repo.TreeObject(hash)
+	if err != nil {
+		return err
+	}
+
+	for _, entry := range tree.Entries {
+		filename := path.Join(prefix, entry.Name)
+		switch entry.Mode {
+		case filemode.Dir:
+			if err := walkTree(ctx, repo, entry.Hash, filename, seen, fn); err != nil {
+				return err
+			}
+		case filemode.Submodule:
+			continue
+		default:
+			if seen[entry.Hash] {
+				continue
+			}
+			if seen != nil {
+				seen[entry.Hash] = true
+			}
+
+			size, err := objectSize(repo, entry.Hash)
+			if err != nil {
+				return err
+			}
+			fn(Blob{Hash: entry.Hash.String(), Path: filename, Size: size})
+		}
+	}
+	return nil
+}
+
+//---------------------------------------------------------------------------------------
+
+func (NativeBackend) ListCommits(ctx context.Context, dir string, opts LogOptions) ([]Commit, error) {
+	repo, unlock, err := openRepository(dir)
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+
+	ref := opts.Ref
+	if ref == "" {
+		ref = "HEAD"
+	}
+	hash, err := repo.ResolveRevision(plumbing.Revision(ref))
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve '%s': %w", ref, err)
+	}
+
+	commits := []Commit{}
+	addCommit := func(c *object.Commit) error {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if opts.Limit > 0 && len(commits) >= opts.Limit {
+			return storer.ErrStop
+		}
+
+		commit, err := toCommit(c)
+		if err != nil {
+			return err
+		}
+		commits = append(commits, commit)
+		return nil
+	}
+
+	if opts.FirstParent {
+		c, err := repo.CommitObject(*hash)
+		for err == nil {
+			if err = addCommit(c); err != nil {
+				break
+			}
+			if c.NumParents() == 0 {
+				return commits, nil
+			}
+			c, err = c.Parent(0)
+		}
+		if err == storer.ErrStop {
+			return commits, nil
+		}
+		return nil, err
+	}
+
+	iter, err := repo.Log(&gogit.LogOptions{From: *hash, Order: gogit.LogOrderCommitterTime})
+	if err != nil {
+		return nil, err
+	}
+	if err := iter.ForEach(addCommit); err != nil {
+		return nil, err
+	}
+	return commits, nil
+}
+
+// converts a go-git commit to a Commit, computing the changes made to each file compared to its first parent, like `git log --numstat`.
+func toCommit(c *object.Commit) (Commit, error) {
+	commit := Commit{
+		Hash:    c.Hash.String(),
+		Author:  c.Author.Name,
+		Message: strings.TrimSpace(c.Message),
+		Parents: c.NumParents(),
+		Signed:  c.PGPSignature != "",
+		Files:   []CommitFile{},
+	}
+	if commit.IsMerge() {
+		return commit, nil
+	}
+
+	var parentTree *object.Tree
+	if c.NumParents() > 0 {
+		parent, err := c.Parent(0)
+		if err != nil {
+			return Commit{}, err
+		}
+		if parentTree, err = parent.Tree(); err != nil {
+			return Commit{}, err
+		}
+	}
+
+	tree, err := c.Tree()
+	if err != nil {
+		return Commit{}, err
+	}
+	changes, err := object.DiffTree(parentTree, tree)
+	if err != nil {
+		return Commit{}, err
+	}
+
+	for _, change := range changes {
+		file, err := toCommitFile(change)
+		if err != nil {
+			return Commit{}, err
+		}
+		commit.Files = append(commit.Files, file)
+	}
+
+	sort.Slice(commit.Files, func(i, j int) bool {
+		return commit.Files[i].Path < commit.Files[j].Path
+	})
+	return commit, nil
+}
+
+// computes the number of lines added to and deleted from the file in the given change, like `git diff --numstat`.
+// Rather than generating a full patch, binary files are not read beyond their first few kilobytes, added and deleted files
+// are counted as a whole and only the contents of modified text files are diffed line by line.
+func toCommitFile(change *object.Change) (CommitFile, error) {
+	file := CommitFile{Path: change.To.Name}
+	if file.Path == "" {
+		file.Path = change.From.Name
+	}
+	if change.From.TreeEntry.Hash == change.To.TreeEntry.Hash {
+		return file, nil
+	}
+
+	from, to, err := change.Files()
+	if err != nil {
+		return CommitFile{}, err
+	}
+
+	var fromContents, toContents string
+	for _, f := range []*object.File{from, to} {
+		if f == nil {
+			continue
+		}
+		if file.Binary, err = f.IsBinary(); err != nil || file.Binary {
+			return file, err
+		}
+	}
+	if from != nil {
+		if fromContents, err = from.Contents(); err != nil {
+			return CommitFile{}, err
+		}
+	}
+	if to != nil {
+		if toContents, err = to.Contents(); err != nil {
+			return CommitFile{}, err
+		}
+	}
+
+	if from == nil || to == nil {
+		file.Additions = countLines(toContents)
+		file.Deletions = countLines(fromContents)
+		return file, nil
+	}
+
+	for _, d := range diff.Do(fromContents, toContents) {
+		switch d.Type {
+		case diffmatchpatch.DiffInsert:
+			file.Additions += countLines(d.Text)
+		case diffmatchpatch.DiffDelete:
+			file.Deletions += countLines(d.Text)
+		}
+	}
+	return file, nil
+}
+
+func countLines(content string) int {
+	if content == "" {
+		return 0
+	}
+	lines := strings.Count(content, "\n")
+	if !strings.HasSuffix(content, "\n") {
+		lines++
+	}
+	return lines
+}
+
+func (NativeBackend) GetDefaultBranch(ctx context.Context, dir string) string {
+	repo, unlock, err := openRepository(dir)
+	if err != nil {
+		return ""
+	}
+	defer unlock()
+
+	if ref, err := repo.Reference(plumbing.NewRemoteHEADReferenceName("origin"), false); err == nil && ref.Type() == plumbing.SymbolicReference {
+		return strings.TrimPrefix(ref.Target().Short(), "origin/")
+	}
+
+	for _, branch := range []string{"main", "master"} {
+		if _, err := repo.Reference(plumbing.NewBranchReferenceName(branch), false); err == nil {
+			return branch
+		}
+	}
+	return ""
+}
+
+//---------------------------------------------------------------------------------------
+
+func (NativeBackend) ListChangedFiles(ctx context.Context, dir string, ref string) ([]string, error) {
+	repo, unlock, err := openRepository(dir)
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+	hash, err := repo.ResolveRevision(plumbing.Revision(ref))
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve '%s': %w", ref, err)
+	}
+	refTree, err := commitTree(repo, *hash)
+	if err != nil {
+		return nil, err
+	}
+	head, err := repo.Head()
+	if err != nil {
+		return nil, err
+	}
+	headTree, err := commitTree(repo, head.Hash())
+	if err != nil {
+		return nil, err
+	}
+
+	// files committed since the ref
+	changes, err := object.DiffTree(refTree, headTree)
+	if err != nil {
+		return nil, fmt.Errorf("failed to compare '%s' with HEAD: %w", ref, err)
+	}
+	changed := map[string]bool{}
+	for _, change := range changes {
+		if change.To.Name != "" {
+			changed[change.To.Name] = true
+		}
+	}
+
+	// files changed or added since HEAD, whether staged or not
+	status, err := worktreeStatus(repo)
+	if err != nil {
+		return nil, err
+	}
+	for filename, fileStatus := range status {
+		if fileStatus.Staging != gogit.Unmodified || fileStatus.Worktree != gogit.Unmodified {
+			changed[filename] = true
+		}
+	}
+
+	root, err := worktreeRoot(repo)
+	if err != nil {
+		return nil, err
+	}
+	files := []string{}
+	for filename := range changed {
+		if utils.FileExists(filepath.Join(root, filepath.FromSlash(filename))) {
+			files = append(files, filename)
+		}
+	}
+	sort.Strings(files)
+	return files, nil
+}
+
+func (NativeBackend) ListStagedFiles(ctx context.Context, dir string) ([]string, error) {
+	repo, unlock, err := openRepository(dir)
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+	status, err := worktreeStatus(repo)
+	if err != nil {
+		return nil, err
+	}
+
+	files := []string{}
+	for filename, fileStatus := range status {
+		switch fileStatus.Staging {
+		case gogit.Added, gogit.Modified, gogit.Renamed, gogit.Copied:
+			files = append(files, filename)
+		}
+	}
+	sort.Strings(files)
+	return files, nil
+}
+
+func commitTree(repo *gogit.Repository, hash plumbing.Hash) (*object.Tree, error) {
+	commit, err := repo.CommitObject(hash)
+	if err != nil {
+		return nil, err
+	}
+	return commit.Tree()
+}
+
+func worktreeStatus(repo *gogit.Repository) (gogit.Status, error) {
+	worktree, err := repo.Worktree()
+	if err != nil {
+		return nil, err
+	}
+	status, err := worktree.Status()
+	if err != nil {
+		return nil, fmt.Errorf("failed to determine the status of the Git worktree: %w", err)
+	}
+	return status, nil
+}
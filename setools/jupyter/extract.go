@@ -0,0 +1,65 @@
+package jupyter
+
+import (
+	"fmt"
+	"strings"
+)
+
+// CellLine refers to a line of a notebook cell. Both the cell and line numbers start counting at 1.
+type CellLine struct {
+	Cell int
+	Line int
+}
+
+func (cl CellLine) String() string {
+	return fmt.Sprintf("cell %d, line %d", cl.Cell, cl.Line)
+}
+
+// ExtractedCode contains the code from all of a notebook's code cells, joined into a single Python script,
+// such that it can be analysed with tools that do not support notebooks, e.g. Pylint or Mypy.
+type ExtractedCode struct {
+	Code string
+	// maps each line of Code (at index line - 1) to the notebook cell that it was extracted from.
+	lines []CellLine
+}
+
+// Locate returns the notebook cell and line that the given line (starting at 1) of the extracted code comes from.
+// Returns false if the line does not exist, or is not from any cell, e.g. the comment that marks the start of a cell.
+func (e ExtractedCode) Locate(line int) (CellLine, bool) {
+	if line < 1 || line > len(e.lines) || e.lines[line-1].Cell == 0 {
+		return CellLine{}, false
+	}
+	return e.lines[line-1], true
+}
+
+// ExtractCode joins the sources of the notebook's code cells into a single Python script.
+// IPython magics and shell commands are commented out, such that the line numbers of the extracted code
+// can still be mapped back to the cells they came from, see ExtractedCode.Locate.
+func (n *Notebook) ExtractCode() ExtractedCode {
+	code := strings.Builder{}
+	lines := []CellLine{}
+
+	for i, cell := range n.Cells {
+		if cell.CellType != CellTypeCode {
+			continue
+		}
+
+		if len(lines) > 0 {
+			code.WriteString("\n")
+			lines = append(lines, CellLine{})
+		}
+		code.WriteString(fmt.Sprintf("# In[%d]:\n", i+1))
+		lines = append(lines, CellLine{})
+
+		isCellMagic := cell.isCellMagic()
+		for j, line := range cell.Source.Lines() {
+			if isCellMagic || isMagic(strings.TrimSpace(line)) {
+				line = "# " + line
+			}
+			code.WriteString(line + "\n")
+			lines = append(lines, CellLine{Cell: i + 1, Line: j + 1})
+		}
+	}
+
+	return ExtractedCode{Code: code.String(), lines: lines}
+}
@@ -0,0 +1,204 @@
+package jupyter
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"strings"
+)
+
+const (
+	CellTypeCode     = "code"
+	CellTypeMarkdown = "markdown"
+	CellTypeRaw      = "raw"
+)
+
+// Notebook represents the contents of a Jupyter Notebook (*.ipynb) file, as far as mllint is concerned.
+// See https://nbformat.readthedocs.io/en/latest/format_description.html
+type Notebook struct {
+	Cells    []Cell `json:"cells"`
+	NBFormat int    `json:"nbformat"`
+}
+
+// Cell is a single cell in a Jupyter Notebook.
+type Cell struct {
+	CellType string `json:"cell_type"`
+	Source   Source `json:"source"`
+	// Only set on code cells. Nil when the cell has not been executed.
+	ExecutionCount *int     `json:"execution_count"`
+	Outputs        []Output `json:"outputs"`
+	// Files embedded into markdown cells, e.g. images, mapping filename to a map of MIME type to data.
+	Attachments map[string]map[string]json.RawMessage `json:"attachments"`
+}
+
+// Output is a single output of a code cell, e.g. something that was printed, or a plot that was displayed.
+type Output struct {
+	OutputType string `json:"output_type"`
+	// Maps MIME type to the output's data. Only set on 'execute_result' and 'display_data' outputs.
+	Data map[string]json.RawMessage `json:"data"`
+	// Text that was printed to stdout or stderr. Only set on 'stream' outputs.
+	Text Source `json:"text"`
+}
+
+// PlainText returns the textual contents of this output, i.e. the text of a 'stream' output, or the 'text/plain' data of other outputs.
+func (o Output) PlainText() Source {
+	if o.Text != "" {
+		return o.Text
+	}
+
+	var text Source
+	if data, ok := o.Data["text/plain"]; ok {
+		_ = json.Unmarshal(data, &text)
+	}
+	return text
+}
+
+// Source is the source text of a notebook cell. The nbformat stores this either as a single string,
+// or as a list of strings that each contain one line (including the newline character).
+type Source string
+
+func (s *Source) UnmarshalJSON(data []byte) error {
+	var text string
+	if err := json.Unmarshal(data, &text); err == nil {
+		*s = Source(text)
+		return nil
+	}
+
+	var lines []string
+	if err := json.Unmarshal(data, &lines); err != nil {
+		return fmt.Errorf("cell source must be a string or a list of strings: %w", err)
+	}
+	*s = Source(strings.Join(lines, ""))
+	return nil
+}
+
+// Lines returns the lines of this source, without trailing newline characters.
+func (s Source) Lines() []string {
+	if s == "" {
+		return []string{}
+	}
+	return strings.Split(strings.TrimSuffix(string(s), "\n"), "\n")
+}
+
+//---------------------------------------------------------------------------------------
+
+// ParseFile parses the Jupyter Notebook at the given location.
+func ParseFile(filename string) (*Notebook, error) {
+	file, err := os.Open(filename)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	notebook, err := Parse(file)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse notebook '%s': %w", filename, err)
+	}
+	return notebook, nil
+}
+
+// Parse parses a Jupyter Notebook from the given reader (tip: *os.File implements io.Reader)
+func Parse(reader io.Reader) (*Notebook, error) {
+	contents, err := ioutil.ReadAll(reader)
+	if err != nil {
+		return nil, err
+	}
+
+	notebook := Notebook{}
+	if err := json.Unmarshal(contents, &notebook); err != nil {
+		return nil, err
+	}
+	return &notebook, nil
+}
+
+//---------------------------------------------------------------------------------------
+
+// CountOutputs returns the total number of outputs stored in the notebook's code cells.
+func (n *Notebook) CountOutputs() int {
+	total := 0
+	for _, cell := range n.Cells {
+		total += len(cell.Outputs)
+	}
+	return total
+}
+
+// CountImages returns the number of images embedded into the notebook,
+// either as output of a code cell or as an attachment of a markdown cell.
+func (n *Notebook) CountImages() int {
+	total := 0
+	for _, cell := range n.Cells {
+		for _, output := range cell.Outputs {
+			if hasImage(output.Data) {
+				total++
+			}
+		}
+		for _, attachment := range cell.Attachments {
+			if hasImage(attachment) {
+				total++
+			}
+		}
+	}
+	return total
+}
+
+func hasImage(mimeData map[string]json.RawMessage) bool {
+	for mimeType := range mimeData {
+		if strings.HasPrefix(mimeType, "image/") {
+			return true
+		}
+	}
+	return false
+}
+
+// ExecutionCounts returns the execution counts of all the code cells in the notebook that have been executed, in order of appearance.
+func (n *Notebook) ExecutionCounts() []int {
+	counts := []int{}
+	for _, cell := range n.Cells {
+		if cell.CellType == CellTypeCode && cell.ExecutionCount != nil {
+			counts = append(counts, *cell.ExecutionCount)
+		}
+	}
+	return counts
+}
+
+// IsExecutedInOrder returns true if the execution counts of the notebook's code cells are strictly increasing,
+// i.e. the notebook's cells were last executed in order from top to bottom.
+func (n *Notebook) IsExecutedInOrder() bool {
+	counts := n.ExecutionCounts()
+	for i := 1; i < len(counts); i++ {
+		if counts[i] <= counts[i-1] {
+			return false
+		}
+	}
+	return true
+}
+
+// CountLoC returns the number of lines of code in the notebook's code cells, not counting empty lines, comments and IPython magics.
+func (n *Notebook) CountLoC() int {
+	total := 0
+	for _, cell := range n.Cells {
+		if cell.CellType != CellTypeCode || cell.isCellMagic() {
+			continue
+		}
+
+		for _, line := range cell.Source.Lines() {
+			trimmed := strings.TrimSpace(line)
+			if trimmed != "" && !strings.HasPrefix(trimmed, "#") && !isMagic(trimmed) {
+				total++
+			}
+		}
+	}
+	return total
+}
+
+// returns true if the cell starts with a cell magic, e.g. `%%bash`, meaning that the cell's contents are not Python code.
+func (c Cell) isCellMagic() bool {
+	return strings.HasPrefix(strings.TrimSpace(string(c.Source)), "%%")
+}
+
+// returns true if the (trimmed) line is an IPython magic or shell command, e.g. `%matplotlib inline` or `!pip install numpy`
+func isMagic(line string) bool {
+	return strings.HasPrefix(line, "%") || strings.HasPrefix(line, "!") || strings.HasSuffix(line, "?")
+}
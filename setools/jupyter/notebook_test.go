@@ -0,0 +1,91 @@
+package jupyter_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/bvobart/mllint/setools/jupyter"
+)
+
+func TestParseFile(t *testing.T) {
+	notebook, err := jupyter.ParseFile("test-resources/dirty.ipynb")
+	require.NoError(t, err)
+	require.Equal(t, 4, notebook.NBFormat)
+	require.Len(t, notebook.Cells, 4)
+	require.Equal(t, jupyter.CellTypeMarkdown, notebook.Cells[0].CellType)
+	require.Equal(t, jupyter.Source("df.plot()\nprint('hello')"), notebook.Cells[2].Source)
+	require.Nil(t, notebook.Cells[3].ExecutionCount)
+	require.Equal(t, jupyter.Source("<Figure size 432x288 with 1 Axes>"), notebook.Cells[2].Outputs[0].PlainText())
+	require.Equal(t, jupyter.Source("hello\n"), notebook.Cells[2].Outputs[1].PlainText())
+
+	notebook, err = jupyter.ParseFile("test-resources/clean.ipynb")
+	require.NoError(t, err)
+	require.Equal(t, []string{"import numpy as np", "x = np.arange(10)"}, notebook.Cells[0].Source.Lines())
+
+	_, err = jupyter.ParseFile("test-resources/invalid.ipynb")
+	require.Error(t, err)
+
+	_, err = jupyter.ParseFile("test-resources/non-existent.ipynb")
+	require.Error(t, err)
+}
+
+func TestNotebookStats(t *testing.T) {
+	dirty, err := jupyter.ParseFile("test-resources/dirty.ipynb")
+	require.NoError(t, err)
+	require.Equal(t, 2, dirty.CountOutputs())
+	require.Equal(t, 2, dirty.CountImages())
+	require.Equal(t, []int{3, 1}, dirty.ExecutionCounts())
+	require.False(t, dirty.IsExecutedInOrder())
+	require.Equal(t, 4, dirty.CountLoC())
+
+	clean, err := jupyter.ParseFile("test-resources/clean.ipynb")
+	require.NoError(t, err)
+	require.Equal(t, 0, clean.CountOutputs())
+	require.Equal(t, 0, clean.CountImages())
+	require.Equal(t, []int{}, clean.ExecutionCounts())
+	require.True(t, clean.IsExecutedInOrder())
+	require.Equal(t, 3, clean.CountLoC())
+}
+
+func TestExtractCode(t *testing.T) {
+	notebook, err := jupyter.ParseFile("test-resources/dirty.ipynb")
+	require.NoError(t, err)
+
+	extracted := notebook.ExtractCode()
+	expected := []string{
+		"# In[2]:",
+		"# %matplotlib inline",
+		"import pandas as pd",
+		"",
+		"# load the data",
+		"df = pd.read_csv('/home/alice/data/train.csv')",
+		"",
+		"# In[3]:",
+		"df.plot()",
+		"print('hello')",
+		"",
+		"# In[4]:",
+		"# %%bash",
+		"# ls -la",
+		"",
+	}
+	require.Equal(t, strings.Join(expected, "\n"), extracted.Code)
+
+	_, ok := extracted.Locate(1)
+	require.False(t, ok)
+	_, ok = extracted.Locate(7)
+	require.False(t, ok)
+	_, ok = extracted.Locate(100)
+	require.False(t, ok)
+
+	loc, ok := extracted.Locate(6)
+	require.True(t, ok)
+	require.Equal(t, jupyter.CellLine{Cell: 2, Line: 5}, loc)
+	require.Equal(t, "cell 2, line 5", loc.String())
+
+	loc, ok = extracted.Locate(10)
+	require.True(t, ok)
+	require.Equal(t, jupyter.CellLine{Cell: 3, Line: 2}, loc)
+}
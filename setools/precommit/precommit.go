@@ -0,0 +1,145 @@
+package precommit
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path"
+	"regexp"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/bvobart/mllint/utils"
+)
+
+// Filenames of pre-commit's configuration file, in order of preference.
+var ConfigFiles = []string{".pre-commit-config.yaml", ".pre-commit-config.yml"}
+
+// ErrNotFound is returned by ReadConfig when the project does not have a pre-commit configuration file.
+var ErrNotFound = errors.New("pre-commit configuration not found")
+
+// Special values of a repo's `repo` key, for hooks that are defined in the project itself or by pre-commit itself.
+const (
+	RepoLocal = "local"
+	RepoMeta  = "meta"
+)
+
+var (
+	// Matches full or abbreviated commit hashes, e.g. `6e2418c5521b7d606e72914dced3253f9ace1205`
+	commitHashPattern = regexp.MustCompile(`^[0-9a-f]{7,40}$`)
+	// Matches version tags, e.g. `v4.4.0`, `23.1.0` or `v1.0.0-alpha.1`
+	versionTagPattern = regexp.MustCompile(`^v?\d+(\.\d+)+([-+.]?[0-9A-Za-z.]+)?$`)
+)
+
+// Config describes the structure of a `.pre-commit-config.yaml` file.
+// See https://pre-commit.com/#pre-commit-configyaml---top-level
+type Config struct {
+	// Filename of the configuration file, relative to the project's root.
+	Filename string `yaml:"-"`
+	Repos    []Repo `yaml:"repos"`
+}
+
+// Repo is a repository of hooks in a pre-commit configuration.
+type Repo struct {
+	// URL of the repository to clone the hooks from, or `local` or `meta`
+	URL string `yaml:"repo"`
+	// Revision (tag or commit hash) of the repository to clone.
+	Rev   string `yaml:"rev"`
+	Hooks []Hook `yaml:"hooks"`
+}
+
+// Hook is a hook from a repo in a pre-commit configuration.
+type Hook struct {
+	ID string `yaml:"id"`
+	// The command that a local hook runs, e.g. `poetry run pylint`
+	Entry string `yaml:"entry"`
+}
+
+// Detect returns whether the project in the given directory has a pre-commit configuration file.
+func Detect(dir string) bool {
+	return findConfigFile(dir) != ""
+}
+
+// ReadConfig reads and parses the pre-commit configuration file of the project in the given directory.
+// Returns ErrNotFound if the project does not have one.
+func ReadConfig(dir string) (*Config, error) {
+	filename := findConfigFile(dir)
+	if filename == "" {
+		return nil, ErrNotFound
+	}
+
+	contents, err := os.ReadFile(path.Join(dir, filename))
+	if err != nil {
+		return nil, err
+	}
+
+	config := Config{Filename: filename}
+	if err := yaml.Unmarshal(contents, &config); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", filename, err)
+	}
+	return &config, nil
+}
+
+func findConfigFile(dir string) string {
+	for _, filename := range ConfigFiles {
+		if utils.FileExists(path.Join(dir, filename)) {
+			return filename
+		}
+	}
+	return ""
+}
+
+// HasHook returns whether any of the repos in the configuration has a hook that runs the tool with the given name,
+// e.g. `black` matches hooks with ID `black` or `black-jupyter`, or a local hook with entry `poetry run black`.
+func (c Config) HasHook(tool string) bool {
+	_, found := c.FindHook(tool)
+	return found
+}
+
+// FindHook returns the repo with the hook that runs the tool with the given name, see HasHook.
+func (c Config) FindHook(tool string) (Repo, bool) {
+	for _, repo := range c.Repos {
+		for _, hook := range repo.Hooks {
+			if hook.Runs(tool) {
+				return repo, true
+			}
+		}
+	}
+	return Repo{}, false
+}
+
+// UnpinnedRepos returns the remote repos whose revision is not pinned to a version tag or commit hash.
+func (c Config) UnpinnedRepos() []Repo {
+	unpinned := []Repo{}
+	for _, repo := range c.Repos {
+		if !repo.IsLocal() && !repo.IsPinned() {
+			unpinned = append(unpinned, repo)
+		}
+	}
+	return unpinned
+}
+
+// IsLocal returns whether the repo's hooks are defined in the project itself or by pre-commit itself, rather than in a remote repository.
+func (r Repo) IsLocal() bool {
+	return r.URL == RepoLocal || r.URL == RepoMeta
+}
+
+// IsPinned returns whether the repo's revision is a version tag or commit hash, rather than e.g. a branch name like `master` or `stable`
+func (r Repo) IsPinned() bool {
+	return commitHashPattern.MatchString(r.Rev) || versionTagPattern.MatchString(r.Rev)
+}
+
+// Runs returns whether the hook runs the tool with the given name.
+func (h Hook) Runs(tool string) bool {
+	if h.ID == tool || strings.HasPrefix(h.ID, tool+"-") {
+		return true
+	}
+
+	for _, word := range strings.Fields(h.Entry) {
+		if path.Base(word) == tool {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,86 @@
+package precommit_test
+
+import (
+	"os"
+	"path"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/bvobart/mllint/setools/precommit"
+)
+
+const preCommitConfig = `repos:
+  - repo: https://github.com/pre-commit/pre-commit-hooks
+    rev: v4.4.0
+    hooks:
+      - id: trailing-whitespace
+  - repo: https://github.com/psf/black
+    rev: stable
+    hooks:
+      - id: black-jupyter
+  - repo: https://github.com/PyCQA/isort
+    rev: 6e2418c5521b7d606e72914dced3253f9ace1205
+    hooks:
+      - id: isort
+  - repo: local
+    hooks:
+      - id: lint
+        name: lint
+        entry: .venv/bin/pylint --rcfile=.pylintrc
+        language: system
+  - repo: meta
+    hooks:
+      - id: check-hooks-apply
+`
+
+func TestReadConfig(t *testing.T) {
+	dir := t.TempDir()
+	require.False(t, precommit.Detect(dir))
+	_, err := precommit.ReadConfig(dir)
+	require.Equal(t, precommit.ErrNotFound, err)
+
+	require.NoError(t, os.WriteFile(path.Join(dir, ".pre-commit-config.yml"), []byte(preCommitConfig), 0644))
+	require.True(t, precommit.Detect(dir))
+
+	conf, err := precommit.ReadConfig(dir)
+	require.NoError(t, err)
+	require.Equal(t, ".pre-commit-config.yml", conf.Filename)
+	require.Len(t, conf.Repos, 5)
+	require.Equal(t, "https://github.com/psf/black", conf.Repos[1].URL)
+	require.Equal(t, "stable", conf.Repos[1].Rev)
+	require.Equal(t, []precommit.Hook{{ID: "lint", Entry: ".venv/bin/pylint --rcfile=.pylintrc"}}, conf.Repos[3].Hooks)
+
+	require.True(t, conf.HasHook("black"))
+	require.True(t, conf.HasHook("isort"))
+	require.True(t, conf.HasHook("pylint"))
+	require.False(t, conf.HasHook("mypy"))
+	require.False(t, conf.HasHook("flake8"))
+
+	repo, found := conf.FindHook("pylint")
+	require.True(t, found)
+	require.True(t, repo.IsLocal())
+
+	require.Equal(t, []precommit.Repo{conf.Repos[1]}, conf.UnpinnedRepos())
+}
+
+func TestReadConfigInvalid(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(path.Join(dir, ".pre-commit-config.yaml"), []byte("repos: {{"), 0644))
+
+	_, err := precommit.ReadConfig(dir)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "failed to parse .pre-commit-config.yaml")
+}
+
+func TestRepoIsPinned(t *testing.T) {
+	pinned := []string{"v4.4.0", "23.1.0", "v1.0.0-alpha.1", "1.5", "6e2418c", "6e2418c5521b7d606e72914dced3253f9ace1205"}
+	for _, rev := range pinned {
+		require.True(t, precommit.Repo{Rev: rev}.IsPinned(), rev)
+	}
+
+	unpinned := []string{"", "master", "main", "stable", "HEAD", "latest", "v1"}
+	for _, rev := range unpinned {
+		require.False(t, precommit.Repo{Rev: rev}.IsPinned(), rev)
+	}
+}
@@ -0,0 +1,247 @@
+package cache
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/gob"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+)
+
+// ErrMiss is returned by Cache.Get when there is no result stored under the requested key.
+var ErrMiss = errors.New("not found in cache")
+
+// Default is the cache that mllint's linters use to store the results of running external tools, such as Pylint or custom rules.
+// It is nil when caching is disabled, e.g. with the `--no-cache` flag.
+var Default *Cache
+
+// DefaultMaxSize is the default maximum total size of the entries in a Cache, namely 100 MB.
+const DefaultMaxSize = 100 * 1000 * 1000
+
+// Cache is an on-disk store of the results of running external tools on a project, keyed by a hash of everything those results depend on.
+// Values are stored with encoding/gob, so any interface values inside them must have their concrete types registered with gob.Register.
+// A nil *Cache is valid and simply never stores anything.
+type Cache struct {
+	Dir string
+
+	// Maximum total size of the entries in this cache in bytes. Whenever storing an entry makes the cache exceed this size,
+	// the least recently used entries are evicted. Zero means no limit.
+	MaxSize int64
+}
+
+// New creates a Cache that stores its entries in the given directory, with a maximum total size of DefaultMaxSize.
+func New(dir string) *Cache {
+	return &Cache{Dir: dir, MaxSize: DefaultMaxSize}
+}
+
+// UserDir returns the directory in which mllint stores its cache by default, i.e. `mllint` in the user's cache directory,
+// such as `$XDG_CACHE_HOME/mllint` or `~/.cache/mllint` on Linux.
+func UserDir() (string, error) {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "mllint"), nil
+}
+
+// Get retrieves the value stored under the given key and decodes it into value, which must be a pointer.
+// Returns ErrMiss if there is no (valid) value stored under this key.
+func (c *Cache) Get(key *Key, value interface{}) error {
+	if c == nil {
+		return ErrMiss
+	}
+
+	contents, err := os.ReadFile(c.path(key))
+	if errors.Is(err, os.ErrNotExist) {
+		return ErrMiss
+	}
+	if err != nil {
+		return err
+	}
+
+	if err := gob.NewDecoder(bytes.NewReader(contents)).Decode(value); err != nil {
+		return fmt.Errorf("%w: invalid cache entry: %s", ErrMiss, err)
+	}
+
+	// the modification time of an entry is its last use, such that evict can remove the least recently used entries first.
+	now := time.Now()
+	_ = os.Chtimes(c.path(key), now, now)
+	return nil
+}
+
+// Put stores the given value under the given key, replacing any value already stored under it.
+func (c *Cache) Put(key *Key, value interface{}) error {
+	if c == nil {
+		return nil
+	}
+
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(value); err != nil {
+		return fmt.Errorf("failed to encode cache entry: %w", err)
+	}
+
+	if err := os.MkdirAll(c.Dir, 0755); err != nil {
+		return err
+	}
+
+	// write to a temporary file first, so that concurrent readers never see a partially written entry.
+	tmp, err := os.CreateTemp(c.Dir, ".tmp-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(buf.Bytes()); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	if err := os.Rename(tmp.Name(), c.path(key)); err != nil {
+		return err
+	}
+	return c.evict()
+}
+
+// evict removes the least recently used entries from the cache until their total size no longer exceeds the cache's maximum size.
+func (c *Cache) evict() error {
+	if c.MaxSize <= 0 {
+		return nil
+	}
+
+	entries, err := ioutil.ReadDir(c.Dir)
+	if err != nil {
+		return err
+	}
+
+	total := int64(0)
+	files := []os.FileInfo{}
+	for _, entry := range entries {
+		if entry.IsDir() || filepath.Ext(entry.Name()) != ".gob" {
+			continue
+		}
+		total += entry.Size()
+		files = append(files, entry)
+	}
+
+	sort.Slice(files, func(i, j int) bool {
+		return files[i].ModTime().Before(files[j].ModTime())
+	})
+	for _, file := range files {
+		if total <= c.MaxSize {
+			break
+		}
+		// entries may concurrently be evicted by another mllint process, which is fine.
+		if err := os.Remove(filepath.Join(c.Dir, file.Name())); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return err
+		}
+		total -= file.Size()
+	}
+	return nil
+}
+
+// Clear removes all entries from the cache.
+func (c *Cache) Clear() error {
+	if c == nil {
+		return nil
+	}
+	return os.RemoveAll(c.Dir)
+}
+
+func (c *Cache) path(key *Key) string {
+	return filepath.Join(c.Dir, key.String()+".gob")
+}
+
+//---------------------------------------------------------------------------------------
+
+// Version is the version of mllint, which is included in every Key, such that upgrading mllint never returns results
+// that were cached by a version that e.g. ran or parsed the output of an external tool differently.
+var Version = "dev-snapshot"
+
+// Key identifies a cache entry by hashing everything that the cached value depends on.
+type Key struct {
+	h hash.Hash
+}
+
+// NewKey creates a key from the given strings, e.g. the name of the tool and the project directory.
+// Every key also includes the Version of mllint.
+func NewKey(parts ...string) *Key {
+	key := &Key{h: sha256.New()}
+	return key.Add(Version).Add(parts...)
+}
+
+// Add adds the given strings to the key.
+func (k *Key) Add(parts ...string) *Key {
+	for _, part := range parts {
+		// length-prefix each part, such that e.g. ("ab", "c") and ("a", "bc") result in different keys.
+		fmt.Fprintf(k.h, "%d:%s;", len(part), part)
+	}
+	return k
+}
+
+// AddFiles adds the names and contents of the given files to the key, in sorted order.
+// Files that do not exist are added by name only, such that creating them also changes the key.
+func (k *Key) AddFiles(filenames ...string) error {
+	sorted := append([]string{}, filenames...)
+	sort.Strings(sorted)
+
+	for _, filename := range sorted {
+		hash, err := fileHash(filename)
+		if errors.Is(err, os.ErrNotExist) {
+			k.Add(filename, "<missing>")
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		k.Add(filename, hash)
+	}
+	return nil
+}
+
+// String returns the hexadecimal representation of the key's hash.
+func (k *Key) String() string {
+	return hex.EncodeToString(k.h.Sum(nil))
+}
+
+// hashes memoizes the hashes of the contents of files by their name, size and modification time, such that the keys of
+// the different linters that analyse the same files, e.g. all of a project's Python files, only need to read each file once.
+var hashes sync.Map
+
+// fileHash returns the hexadecimal SHA-256 hash of the contents of the given file.
+func fileHash(filename string) (string, error) {
+	info, err := os.Stat(filename)
+	if err != nil {
+		return "", err
+	}
+
+	memo := fmt.Sprintf("%s;%d;%d", filename, info.Size(), info.ModTime().UnixNano())
+	if hash, ok := hashes.Load(memo); ok {
+		return hash.(string), nil
+	}
+
+	file, err := os.Open(filename)
+	if err != nil {
+		return "", err
+	}
+	defer file.Close()
+
+	h := sha256.New()
+	if _, err := io.Copy(h, file); err != nil {
+		return "", err
+	}
+
+	hash := hex.EncodeToString(h.Sum(nil))
+	hashes.Store(memo, hash)
+	return hash, nil
+}
@@ -0,0 +1,111 @@
+package cache_test
+
+import (
+	"os"
+	"path"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/bvobart/mllint/utils/cache"
+)
+
+func TestCache(t *testing.T) {
+	c := cache.New(path.Join(t.TempDir(), "cache"))
+	key := cache.NewKey("tool", "v1.0")
+
+	var value []string
+	require.ErrorIs(t, c.Get(key, &value), cache.ErrMiss)
+
+	require.NoError(t, c.Put(key, []string{"a", "b"}))
+	require.NoError(t, c.Get(cache.NewKey("tool", "v1.0"), &value))
+	require.Equal(t, []string{"a", "b"}, value)
+
+	require.ErrorIs(t, c.Get(cache.NewKey("tool", "v1.1"), &value), cache.ErrMiss)
+
+	require.NoError(t, c.Clear())
+	require.ErrorIs(t, c.Get(key, &value), cache.ErrMiss)
+	require.NoDirExists(t, c.Dir)
+}
+
+func TestCacheInvalidEntry(t *testing.T) {
+	c := cache.New(t.TempDir())
+	key := cache.NewKey("tool")
+	require.NoError(t, c.Put(key, "a string"))
+
+	var value int
+	require.ErrorIs(t, c.Get(key, &value), cache.ErrMiss)
+}
+
+func TestCacheEviction(t *testing.T) {
+	c := &cache.Cache{Dir: t.TempDir()}
+	keyA, keyB, keyC := cache.NewKey("a"), cache.NewKey("b"), cache.NewKey("c")
+	entry := func(key *cache.Key) string { return path.Join(c.Dir, key.String()+".gob") }
+	value := strings.Repeat("x", 100)
+
+	require.NoError(t, c.Put(keyA, value))
+	require.NoError(t, c.Put(keyB, value))
+	require.NoError(t, os.Chtimes(entry(keyA), time.Now().Add(-2*time.Hour), time.Now().Add(-2*time.Hour)))
+	require.NoError(t, os.Chtimes(entry(keyB), time.Now().Add(-1*time.Hour), time.Now().Add(-1*time.Hour)))
+
+	// reading A makes it the most recently used entry, so B is evicted when C no longer fits.
+	info, err := os.Stat(entry(keyA))
+	require.NoError(t, err)
+	c.MaxSize = 2 * info.Size()
+
+	var result string
+	require.NoError(t, c.Get(keyA, &result))
+	require.NoError(t, c.Put(keyC, value))
+
+	require.NoError(t, c.Get(keyA, &result))
+	require.ErrorIs(t, c.Get(keyB, &result), cache.ErrMiss)
+	require.NoError(t, c.Get(keyC, &result))
+	require.Equal(t, value, result)
+}
+
+func TestNilCache(t *testing.T) {
+	var c *cache.Cache
+	key := cache.NewKey("tool")
+
+	var value string
+	require.NoError(t, c.Put(key, "a string"))
+	require.ErrorIs(t, c.Get(key, &value), cache.ErrMiss)
+	require.NoError(t, c.Clear())
+}
+
+func TestKey(t *testing.T) {
+	require.Equal(t, cache.NewKey("a", "b").String(), cache.NewKey("a").Add("b").String())
+	require.NotEqual(t, cache.NewKey("ab", "c").String(), cache.NewKey("a", "bc").String())
+
+	dir := t.TempDir()
+	file := path.Join(dir, "file.py")
+	missing := path.Join(dir, "missing.py")
+	require.NoError(t, os.WriteFile(file, []byte("print('hello')"), 0644))
+
+	keyOf := func(filenames ...string) string {
+		key := cache.NewKey("tool")
+		require.NoError(t, key.AddFiles(filenames...))
+		return key.String()
+	}
+
+	before := keyOf(file, missing)
+	require.Equal(t, before, keyOf(missing, file))
+	require.NotEqual(t, before, keyOf(file))
+
+	require.NoError(t, os.WriteFile(file, []byte("print('world')"), 0644))
+	require.NotEqual(t, before, keyOf(file, missing))
+
+	// touching a file without modifying its contents does not change the key.
+	after := keyOf(file, missing)
+	require.NoError(t, os.Chtimes(file, time.Now().Add(time.Hour), time.Now().Add(time.Hour)))
+	require.Equal(t, after, keyOf(file, missing))
+
+	// upgrading mllint changes every key.
+	version := cache.Version
+	defer func() { cache.Version = version }()
+	before = cache.NewKey("tool").String()
+	cache.Version = "v0.0.1"
+	require.NotEqual(t, before, cache.NewKey("tool").String())
+}
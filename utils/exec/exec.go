@@ -2,8 +2,13 @@ package exec
 
 import (
 	"bytes"
+	"context"
 	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
 	"os/exec"
+	"time"
 )
 
 var (
@@ -17,39 +22,80 @@ var (
 	LookPath = DefaultLookPath
 
 	// CommandOutput is a function that performs `exec.Command` in a certain dir, returning the command's Output().
+	// The command is killed when the context is cancelled or its deadline expires.
 	// The sole purpose of this variable is to be able to mock calls to the exec module during tests.
 	CommandOutput = DefaultCommandOutput
 
 	// CommandCombinedOutput is a function that performs `exec.Command` in a certain dir, returning the command's CombinedOutput().
+	// The command is killed when the context is cancelled or its deadline expires.
 	// The sole purpose of this variable is to be able to mock calls to the exec module during tests.
 	CommandCombinedOutput = DefaultCommandCombinedOutput
 
+	// CommandCombinedOutputEnv is a function that performs `exec.Command` in a certain dir with additional environment variables,
+	// returning the command's CombinedOutput(). The command is killed when the context is cancelled or its deadline expires.
+	// The sole purpose of this variable is to be able to mock calls to the exec module during tests.
+	CommandCombinedOutputEnv = DefaultCommandCombinedOutputEnv
+
 	// PipelineOutput is a function that allows executing a pipeline of commands,
-	// i.e. commands like `ls -l | grep exec | wc -l`
+	// i.e. commands like `ls -l | grep exec | wc -l`. The commands are killed when the context is cancelled or its deadline expires.
 	PipelineOutput = DefaultPipelineOutput
+
+	// CommandStream is a function that performs `exec.Command` in a certain dir, reading its input from the given stdin,
+	// while the given function reads the command's output as it is written, e.g. for commands such as `git cat-file --batch`
+	// whose output is too large to keep in memory. The command is killed when the context is cancelled or its deadline expires.
+	// The sole purpose of this variable is to be able to mock calls to the exec module during tests.
+	CommandStream = DefaultCommandStream
 )
 
 // DefaultLookPath simply calls os/exec.LookPath
 func DefaultLookPath(file string) (string, error) { return exec.LookPath(file) }
 
-// DefaultCommandOutput simply calls os/exec.Command, sets the directory and returns the command's Output().
-func DefaultCommandOutput(dir string, name string, args ...string) ([]byte, error) {
+// DefaultCommandOutput calls os/exec.Command, sets the directory and returns the command's Output().
+// The command and any processes it started are killed when the context is cancelled or its deadline expires.
+func DefaultCommandOutput(ctx context.Context, dir string, name string, args ...string) ([]byte, error) {
 	cmd := exec.Command(name, args...)
 	cmd.Dir = dir
-	return cmd.Output()
+
+	stdout, stderr := bytes.Buffer{}, bytes.Buffer{}
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	err := run(ctx, cmd)
+	if exitErr, ok := err.(*exec.ExitError); ok {
+		exitErr.Stderr = stderr.Bytes()
+	}
+	return stdout.Bytes(), err
 }
 
-// DefaultCommandOutput simply calls os/exec.Command, sets the directory and returns the command's Output().
-func DefaultCommandCombinedOutput(dir string, name string, args ...string) ([]byte, error) {
+// DefaultCommandCombinedOutput calls os/exec.Command, sets the directory and returns the command's CombinedOutput().
+// The command and any processes it started are killed when the context is cancelled or its deadline expires.
+func DefaultCommandCombinedOutput(ctx context.Context, dir string, name string, args ...string) ([]byte, error) {
+	return DefaultCommandCombinedOutputEnv(ctx, dir, nil, name, args...)
+}
+
+// DefaultCommandCombinedOutputEnv calls os/exec.Command, sets the directory, appends the given environment variables
+// (formatted as `KEY=value`) to the current process' environment and returns the command's CombinedOutput().
+// The command and any processes it started are killed when the context is cancelled or its deadline expires.
+func DefaultCommandCombinedOutputEnv(ctx context.Context, dir string, env []string, name string, args ...string) ([]byte, error) {
 	cmd := exec.Command(name, args...)
 	cmd.Dir = dir
-	return cmd.CombinedOutput()
+	if len(env) > 0 {
+		cmd.Env = append(os.Environ(), env...)
+	}
+
+	output := bytes.Buffer{}
+	cmd.Stdout = &output
+	cmd.Stderr = &output
+	err := run(ctx, cmd)
+	return output.Bytes(), err
 }
 
-func DefaultPipelineOutput(dir string, commands ...[]string) ([]byte, error) {
+func DefaultPipelineOutput(ctx context.Context, dir string, commands ...[]string) ([]byte, error) {
 	if len(commands) == 0 {
 		return []byte{}, nil
 	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
 
 	// create all command objects
 	output := bytes.Buffer{}
@@ -73,17 +119,124 @@ func DefaultPipelineOutput(dir string, commands ...[]string) ([]byte, error) {
 		}
 	}
 
-	// start all commands
+	// start all commands, each in their own process group
+	start := time.Now()
 	for i, cmd := range cmds {
+		setProcessGroup(cmd)
 		if err := cmd.Start(); err != nil {
+			killProcessGroups(cmds[:i])
 			return nil, fmt.Errorf("failed to start command '%s': %w", commands[i], err)
 		}
 	}
+
+	stop := killOnDone(ctx, cmds...)
+	defer stop()
+	defer record(ctx, start, cmds...)
+
 	// then wait for each command to exit
 	for i, cmd := range cmds {
 		if err := cmd.Wait(); err != nil {
+			if ctx.Err() != nil {
+				return nil, ctx.Err()
+			}
 			return nil, fmt.Errorf("command failed: '%s': %w", commands[i], err)
 		}
 	}
 	return output.Bytes(), nil
 }
+
+// DefaultCommandStream calls os/exec.Command, sets the directory and stdin, then starts the command and calls fn with the command's stdout.
+// Any output that fn does not read is discarded. If fn returns an error, the command is killed and that error is returned.
+// The command and any processes it started are killed when the context is cancelled or its deadline expires.
+func DefaultCommandStream(ctx context.Context, dir string, stdin io.Reader, fn func(stdout io.Reader) error, name string, args ...string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	cmd := exec.Command(name, args...)
+	cmd.Dir = dir
+	cmd.Stdin = stdin
+	stderr := bytes.Buffer{}
+	cmd.Stderr = &stderr
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return fmt.Errorf("failed to create output pipe: %w", err)
+	}
+
+	setProcessGroup(cmd)
+	start := time.Now()
+	if err := cmd.Start(); err != nil {
+		return err
+	}
+
+	stop := killOnDone(ctx, cmd)
+	fnErr := fn(stdout)
+	if fnErr != nil {
+		killProcessGroup(cmd.Process)
+	}
+	// the command cannot exit while it is blocked on writing output that is not read.
+	_, _ = io.Copy(ioutil.Discard, stdout)
+	err = cmd.Wait()
+	stop()
+	record(ctx, start, cmd)
+
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+	if fnErr != nil {
+		return fnErr
+	}
+	if exitErr, ok := err.(*exec.ExitError); ok {
+		exitErr.Stderr = stderr.Bytes()
+	}
+	return err
+}
+
+//---------------------------------------------------------------------------------------
+
+// run starts the command in a new process group and waits for it to exit. When the context is cancelled or its deadline expires,
+// the whole process group is killed, i.e. including any child processes that the command started, such as the actual linter
+// started by `poetry run pylint`. In that case, the context's error is returned.
+func run(ctx context.Context, cmd *exec.Cmd) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	setProcessGroup(cmd)
+	start := time.Now()
+	if err := cmd.Start(); err != nil {
+		return err
+	}
+
+	stop := killOnDone(ctx, cmd)
+	err := cmd.Wait()
+	stop()
+	record(ctx, start, cmd)
+
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+	return err
+}
+
+// killOnDone kills the process groups of the given (started) commands once the context is done.
+// Call the returned function once the commands have exited, to stop watching the context.
+func killOnDone(ctx context.Context, cmds ...*exec.Cmd) (stop func()) {
+	exited := make(chan struct{})
+	go func() {
+		select {
+		case <-ctx.Done():
+			killProcessGroups(cmds)
+		case <-exited:
+		}
+	}()
+	return func() { close(exited) }
+}
+
+func killProcessGroups(cmds []*exec.Cmd) {
+	for _, cmd := range cmds {
+		if cmd.Process != nil {
+			killProcessGroup(cmd.Process)
+		}
+	}
+}
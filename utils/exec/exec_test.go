@@ -1,7 +1,13 @@
 package exec_test
 
 import (
+	"context"
+	"errors"
+	"io"
+	"io/ioutil"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/bvobart/mllint/utils/exec"
 	"github.com/stretchr/testify/require"
@@ -25,23 +31,104 @@ func TestDefaultLookPath(t *testing.T) {
 }
 
 func TestDefaultCommandOutput(t *testing.T) {
-	output, err := exec.CommandOutput(".", "ls", "-a")
+	output, err := exec.CommandOutput(context.Background(), ".", "ls", "-a")
 	require.NoError(t, err)
-	require.Equal(t, []byte(".\n..\nexec.go\nexec_test.go\nmockexec\n"), output)
+	require.Equal(t, []byte(".\n..\nexec.go\nexec_test.go\nmockexec\nprocess_unix.go\nprocess_windows.go\nrecorder.go\n"), output)
 }
 
 func TestDefaultCommandCombinedOutput(t *testing.T) {
-	output, err := exec.CommandCombinedOutput(".", "ls", "-a")
+	output, err := exec.CommandCombinedOutput(context.Background(), ".", "ls", "-a")
 	require.NoError(t, err)
-	require.Equal(t, []byte(".\n..\nexec.go\nexec_test.go\nmockexec\n"), output)
+	require.Equal(t, []byte(".\n..\nexec.go\nexec_test.go\nmockexec\nprocess_unix.go\nprocess_windows.go\nrecorder.go\n"), output)
+}
+
+func TestDefaultCommandCombinedOutputEnv(t *testing.T) {
+	output, err := exec.CommandCombinedOutputEnv(context.Background(), ".", []string{"MLLINT_TEST_VAR=hello"}, "sh", "-c", "echo $MLLINT_TEST_VAR")
+	require.NoError(t, err)
+	require.Equal(t, []byte("hello\n"), output)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	_, err = exec.CommandCombinedOutputEnv(ctx, ".", nil, "sleep", "5")
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+	require.ErrorIs(t, ctx.Err(), context.DeadlineExceeded)
 }
 
 func TestDefaultPipelineOutput(t *testing.T) {
-	output, err := exec.DefaultPipelineOutput(".", [][]string{
+	output, err := exec.DefaultPipelineOutput(context.Background(), ".", [][]string{
 		{"ls", "-al"},
 		{"grep", "exec"},
 		{"wc", "-l"},
 	}...)
 	require.NoError(t, err)
 	require.Equal(t, []byte("3\n"), output)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	_, err = exec.DefaultPipelineOutput(ctx, ".", [][]string{{"sleep", "5"}, {"wc", "-l"}}...)
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
+func TestDefaultCommandStream(t *testing.T) {
+	var output []byte
+	err := exec.DefaultCommandStream(context.Background(), ".", strings.NewReader("hello\nworld\n"), func(stdout io.Reader) (err error) {
+		output, err = ioutil.ReadAll(stdout)
+		return err
+	}, "tr", "a-z", "A-Z")
+	require.NoError(t, err)
+	require.Equal(t, []byte("HELLO\nWORLD\n"), output)
+
+	// output that is not read is discarded
+	err = exec.DefaultCommandStream(context.Background(), ".", nil, func(stdout io.Reader) error { return nil }, "seq", "100000")
+	require.NoError(t, err)
+
+	stopped := errors.New("stopped reading")
+	err = exec.DefaultCommandStream(context.Background(), ".", nil, func(stdout io.Reader) error { return stopped }, "yes")
+	require.ErrorIs(t, err, stopped)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	err = exec.DefaultCommandStream(ctx, ".", nil, func(stdout io.Reader) error {
+		_, err := ioutil.ReadAll(stdout)
+		return err
+	}, "sleep", "5")
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
+func TestCommandKilledWithChildren(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	// the shell starts `sleep` as a child process, which keeps the output pipe open unless it is killed as well.
+	start := time.Now()
+	_, err := exec.CommandOutput(ctx, ".", "sh", "-c", "sleep 5; echo done")
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+	require.Less(t, time.Since(start).Seconds(), 2.0)
+
+	start = time.Now()
+	_, err = exec.CommandCombinedOutput(ctx, ".", "sh", "-c", "sleep 5; echo done")
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+	require.Less(t, time.Since(start).Seconds(), 2.0)
+}
+
+type testRecorder struct {
+	commands []string
+}
+
+func (r *testRecorder) RecordProcess(command string, start time.Time, duration time.Duration) {
+	r.commands = append(r.commands, command)
+}
+
+func TestWithRecorder(t *testing.T) {
+	recorder := &testRecorder{}
+	ctx := exec.WithRecorder(context.Background(), recorder)
+
+	_, err := exec.DefaultCommandOutput(ctx, ".", "echo", "hello")
+	require.NoError(t, err)
+	_, err = exec.DefaultPipelineOutput(ctx, ".", []string{"echo", "hello"}, []string{"wc", "-l"})
+	require.NoError(t, err)
+	_, err = exec.DefaultCommandOutput(context.Background(), ".", "echo", "unrecorded")
+	require.NoError(t, err)
+
+	require.Equal(t, []string{"echo hello", "echo hello | wc -l"}, recorder.commands)
 }
@@ -1,6 +1,7 @@
 package mockexec
 
 import (
+	"context"
 	"errors"
 	"testing"
 
@@ -56,8 +57,8 @@ func (mc *mockCommand) CommandArgs(args ...string) *mockCommand {
 	return mc
 }
 
-func (mc mockCommand) ToOutput(output []byte, err error) func(string, string, ...string) ([]byte, error) {
-	return func(dir, name string, args ...string) ([]byte, error) {
+func (mc mockCommand) ToOutput(output []byte, err error) func(context.Context, string, string, ...string) ([]byte, error) {
+	return func(ctx context.Context, dir, name string, args ...string) ([]byte, error) {
 		if mc.dir != nil {
 			require.Equal(mc.t, *mc.dir, dir)
 		}
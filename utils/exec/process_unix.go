@@ -0,0 +1,23 @@
+//go:build !windows
+// +build !windows
+
+package exec
+
+import (
+	"os"
+	"os/exec"
+	"syscall"
+)
+
+// setProcessGroup makes the command start in a new process group, such that killProcessGroup also kills its child processes.
+func setProcessGroup(cmd *exec.Cmd) {
+	cmd.SysProcAttr = &syscall.SysProcAttr{Setpgid: true}
+}
+
+// killProcessGroup kills the process and all other processes in its process group.
+func killProcessGroup(process *os.Process) {
+	// the process group ID equals the ID of the process that started it, see setProcessGroup.
+	if err := syscall.Kill(-process.Pid, syscall.SIGKILL); err != nil {
+		_ = process.Kill()
+	}
+}
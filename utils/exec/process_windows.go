@@ -0,0 +1,21 @@
+package exec
+
+import (
+	"os"
+	"os/exec"
+	"strconv"
+	"syscall"
+)
+
+// setProcessGroup makes the command start in a new process group, such that it does not receive the Ctrl-C sent to mllint,
+// since mllint kills it explicitly.
+func setProcessGroup(cmd *exec.Cmd) {
+	cmd.SysProcAttr = &syscall.SysProcAttr{CreationFlags: syscall.CREATE_NEW_PROCESS_GROUP}
+}
+
+// killProcessGroup kills the process and all of its child processes.
+func killProcessGroup(process *os.Process) {
+	if err := exec.Command("taskkill", "/T", "/F", "/PID", strconv.Itoa(process.Pid)).Run(); err != nil {
+		_ = process.Kill()
+	}
+}
@@ -0,0 +1,46 @@
+package exec
+
+import (
+	"context"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// ProcessRecorder is notified of every external process that is executed with a context that carries it, see WithRecorder.
+type ProcessRecorder interface {
+	// RecordProcess is called once the process has exited, with a description of the command that was executed,
+	// the moment at which it was started and how long it ran for.
+	RecordProcess(command string, start time.Time, duration time.Duration)
+}
+
+type recorderKey struct{}
+
+// WithRecorder returns a context that carries the given recorder, such that running commands with that context records them,
+// e.g. to measure how much time a linter spends waiting for external processes.
+func WithRecorder(ctx context.Context, recorder ProcessRecorder) context.Context {
+	return context.WithValue(ctx, recorderKey{}, recorder)
+}
+
+// maximum length of the description of a command passed to a ProcessRecorder.
+const maxCommandLength = 120
+
+// record notifies the context's ProcessRecorder, if any, of the given commands that were started at the given time and have just exited.
+func record(ctx context.Context, start time.Time, cmds ...*exec.Cmd) {
+	recorder, ok := ctx.Value(recorderKey{}).(ProcessRecorder)
+	if !ok {
+		return
+	}
+
+	descriptions := make([]string, len(cmds))
+	for i, cmd := range cmds {
+		descriptions[i] = strings.Join(append([]string{filepath.Base(cmd.Path)}, cmd.Args[1:]...), " ")
+	}
+
+	command := strings.Join(descriptions, " | ")
+	if len(command) > maxCommandLength {
+		command = command[:maxCommandLength-3] + "..."
+	}
+	recorder.RecordProcess(command, start, time.Since(start))
+}
@@ -21,7 +21,7 @@ func FileExists(filename string) bool {
 // FolderExists checks if a folder exists
 func FolderExists(filename string) bool {
 	info, err := os.Stat(filename)
-	return !os.IsNotExist(err) && info.IsDir()
+	return !os.IsNotExist(err) && info != nil && info.IsDir()
 }
 
 // FolderIsEmpty checks if a folder is empty
@@ -71,16 +71,26 @@ func AbsolutePath(filename string) string {
 	return path.Join(cwd, filename)
 }
 
+// RelativePath returns the filename relative to the given directory,
+// or the filename itself if it is not inside that directory.
+func RelativePath(dir string, filename string) string {
+	relpath, err := filepath.Rel(dir, filename)
+	if err != nil || relpath == ".." || strings.HasPrefix(relpath, ".."+string(filepath.Separator)) {
+		return filename
+	}
+	return relpath
+}
+
 // FindPythonFilesIn finds all Python (*.py) files in the given directory and subdirectories
 // Returns their filepaths, relative to the given directory
-// Ignores hidden folders (folders whose names start with a '.'), but not hidden files.
+// Ignores hidden folders (folders whose names start with a '.'), but not hidden files, as well as anything ignored by FindFilesInDir.
 func FindPythonFilesIn(dir string) (Filenames, error) {
 	return FindFilesByExtInDir(dir, ".py")
 }
 
 // FindIPynbFilesIn finds all Jupyter Notebook (*.ipynb) files in the given directory and
 // subdirectories. Returns their filepaths, relative to the given directory.
-// Ignores hidden folders (folders whose names start with a '.'), but not hidden files.
+// Ignores hidden folders (folders whose names start with a '.'), but not hidden files, as well as anything ignored by FindFilesInDir.
 func FindIPynbFilesIn(dir string) (Filenames, error) {
 	return FindFilesByExtInDir(dir, ".ipynb")
 }
@@ -88,15 +98,43 @@ func FindIPynbFilesIn(dir string) (Filenames, error) {
 // FindFilesByExtInDir finds all files in the given directory and subdirectories that have
 // a certain file extension. File extension must start with a '.', e.g. ".py" or ".ipynb"
 // Returns filepaths relative to the given directory.
-// Ignores hidden folders (folders whose names start with a '.'), but not hidden files.
-// Also explicitly ignores `venv`, `env`. `venv.bak` and `env.bak` folders
+// Ignores hidden folders (folders whose names start with a '.'), but not hidden files, as well as anything ignored by FindFilesInDir.
 func FindFilesByExtInDir(dir string, extension string) (Filenames, error) {
+	return FileFilter{}.FindFilesByExt(dir, extension)
+}
+
+// FindFilesInDir finds all files in the given directory and subdirectories for which shouldInclude returns true.
+// shouldInclude receives the file's path relative to the given directory. Returns filepaths relative to the given directory.
+// Ignores hidden folders (folders whose names start with a '.'), but not hidden files.
+// Also explicitly ignores `venv`, `env`. `venv.bak` and `env.bak` folders,
+// as well as any files and folders ignored by the `.gitignore` files of the Git repository that the directory is in.
+func FindFilesInDir(dir string, shouldInclude func(filename string) bool) (Filenames, error) {
+	return FileFilter{}.FindFiles(dir, shouldInclude)
+}
+
+// FindFilesByExt finds all files in the given directory and subdirectories that have a certain file extension, like FindFilesByExtInDir,
+// but also ignores any files and folders that the filter does not include.
+func (f FileFilter) FindFilesByExt(dir string, extension string) (Filenames, error) {
+	return f.FindFiles(dir, func(filename string) bool {
+		return filepath.Ext(filename) == extension
+	})
+}
+
+// FindFiles finds all files in the given directory and subdirectories for which shouldInclude returns true, like FindFilesInDir,
+// but also ignores any files and folders that the filter does not include.
+func (f FileFilter) FindFiles(dir string, shouldInclude func(filename string) bool) (Filenames, error) {
 	files := Filenames{}
+	ignorer := newIgnorer(dir, f)
 	err := filepath.Walk(dir, func(path string, file os.FileInfo, err error) error {
 		if err != nil {
 			return err
 		}
 
+		relpath, _ := filepath.Rel(dir, path)
+		if relpath == "." {
+			return nil
+		}
+
 		if file.IsDir() && strings.HasPrefix(file.Name(), ".") {
 			return filepath.SkipDir
 		}
@@ -106,8 +144,19 @@ func FindFilesByExtInDir(dir string, extension string) (Filenames, error) {
 			return filepath.SkipDir
 		}
 
-		if !file.IsDir() && filepath.Ext(path) == extension {
-			relpath, _ := filepath.Rel(dir, path)
+		if ignorer.ignores(relpath, file.IsDir()) {
+			if file.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+
+		if file.IsDir() {
+			ignorer.enterDir(dir, relpath)
+			return nil
+		}
+
+		if shouldInclude(relpath) {
 			files = append(files, relpath)
 		}
 
@@ -143,6 +192,16 @@ func (names Filenames) Prefix(dir string) Filenames {
 	return names
 }
 
+// RelativeTo returns a copy of the filenames with each made relative to the given directory, see RelativePath.
+// i.e. Filenames{"something/name.py"}.RelativeTo("something") becomes Filenames{"name.py"}
+func (names Filenames) RelativeTo(dir string) Filenames {
+	res := make(Filenames, len(names))
+	for i, name := range names {
+		res[i] = RelativePath(dir, name)
+	}
+	return res
+}
+
 var langPython = gocloc.NewLanguage("Python", []string{"#"}, [][]string{{"\"\"\"", "\"\"\""}})
 
 func (names Filenames) CountLoC() int32 {
@@ -73,6 +73,15 @@ func TestFindPythonFiles(t *testing.T) {
 	require.Equal(t, utils.Filenames{"some_other_script.py", "some_script.py", "subfolder/yet_another_script.py"}, files)
 }
 
+func TestFindFilesInDir(t *testing.T) {
+	dir := "test-resources/python-files"
+	files, err := utils.FindFilesInDir(dir, func(filename string) bool {
+		return strings.HasPrefix(filename, "subfolder/") || strings.HasSuffix(filename, "ignored.py")
+	})
+	require.NoError(t, err)
+	require.Equal(t, utils.Filenames{"subfolder/yet_another_script.py"}, files)
+}
+
 func TestFilenamesFilter(t *testing.T) {
 	files := utils.Filenames{"some_script.py", "some_other_script.py", "subfolder/yet_another_script.py"}
 	files = files.Filter(func(filename string) bool {
@@ -104,3 +113,95 @@ func TestAbsolutePath(t *testing.T) {
 	require.NoError(t, err)
 	require.Equal(t, path.Join(cwd, "test-resources"), utils.AbsolutePath("test-resources"))
 }
+
+func TestRelativePath(t *testing.T) {
+	require.Equal(t, "file.py", utils.RelativePath("/project", "/project/file.py"))
+	require.Equal(t, "src/file.py", utils.RelativePath("/project", "/project/src/file.py"))
+	require.Equal(t, "..file.py", utils.RelativePath("/project", "/project/..file.py"))
+	require.Equal(t, "/other/file.py", utils.RelativePath("/project", "/other/file.py"))
+	require.Equal(t, "file.py", utils.RelativePath("/project", "file.py"))
+}
+
+func TestRelativeTo(t *testing.T) {
+	filenames := utils.Filenames{"/project/file1.py", "/project/folder/file2.py", "/other/file3.py"}
+	relative := filenames.RelativeTo("/project")
+
+	require.Equal(t, utils.Filenames{"file1.py", "folder/file2.py", "/other/file3.py"}, relative)
+	require.Equal(t, "/project/file1.py", filenames[0])
+}
+
+func createFiles(t *testing.T, dir string, files map[string]string) {
+	for filename, contents := range files {
+		filename = path.Join(dir, filename)
+		require.NoError(t, os.MkdirAll(path.Dir(filename), 0755))
+		require.NoError(t, os.WriteFile(filename, []byte(contents), 0644))
+	}
+}
+
+func TestFindFilesInDirGitignore(t *testing.T) {
+	repo := t.TempDir()
+	createFiles(t, repo, map[string]string{
+		".git/info/exclude":               "local_*.py\n",
+		".gitignore":                      "# build outputs\nbuild/\n*_pb2.py\n",
+		"project/.gitignore":              "/generated\n!keep_pb2.py\n",
+		"project/main.py":                 "",
+		"project/local_test.py":           "",
+		"project/build/lib.py":            "",
+		"project/generated/code.py":       "",
+		"project/src/generated/code.py":   "",
+		"project/src/model_pb2.py":        "",
+		"project/src/keep_pb2.py":         "",
+		"project/node_modules/.gitkeep":   "",
+		"project/node_modules/.gitignore": "*\n",
+		"project/node_modules/lib/x.py":   "",
+	})
+
+	files, err := utils.FindPythonFilesIn(path.Join(repo, "project"))
+	require.NoError(t, err)
+	require.Equal(t, utils.Filenames{"main.py", "src/generated/code.py", "src/keep_pb2.py"}, files)
+}
+
+func TestFindFilesInDirGitignoreSiblings(t *testing.T) {
+	dir := t.TempDir()
+	createFiles(t, dir, map[string]string{
+		"a/.gitignore":        "*.py\n",
+		"a/main.py":           "",
+		"a/sub/.gitignore":    "!keep.py\n",
+		"a/sub/keep.py":       "",
+		"a/sub/other.py":      "",
+		"b/main.py":           "",
+		"b/keep.py":           "",
+		"b/sub/.gitignore":    "/generated.py\n",
+		"b/sub/generated.py":  "",
+		"b/sub/model.py":      "",
+		"b/sub2/generated.py": "",
+		"main.py":             "",
+	})
+
+	// the patterns in a folder's .gitignore only apply to the files inside that folder, not to the files in its sibling folders.
+	files, err := utils.FindPythonFilesIn(dir)
+	require.NoError(t, err)
+	require.Equal(t, utils.Filenames{"a/sub/keep.py", "b/keep.py", "b/main.py", "b/sub/model.py", "b/sub2/generated.py", "main.py"}, files)
+}
+
+func TestFindFilesInDirFileFilter(t *testing.T) {
+	dir := t.TempDir()
+	createFiles(t, dir, map[string]string{
+		"main.py":                  "",
+		"src/model.py":             "",
+		"src/vendored/lib.py":      "",
+		"tests/test_model.py":      "",
+		"notebooks/analysis.py":    "",
+		"notebooks/analysis.ipynb": "",
+	})
+
+	filter := utils.FileFilter{Include: []string{"src/", "tests/**/*.py", "notebooks"}, Exclude: []string{"vendored/", "notebooks/*.py"}}
+
+	files, err := filter.FindFilesByExt(dir, ".py")
+	require.NoError(t, err)
+	require.Equal(t, utils.Filenames{"src/model.py", "tests/test_model.py"}, files)
+
+	notebooks, err := filter.FindFilesByExt(dir, ".ipynb")
+	require.NoError(t, err)
+	require.Equal(t, utils.Filenames{"notebooks/analysis.ipynb"}, notebooks)
+}
@@ -0,0 +1,144 @@
+package utils
+
+import (
+	"bufio"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/go-git/go-git/v5/plumbing/format/gitignore"
+)
+
+// FileFilter contains patterns that determine which of a project's files mllint analyses, on top of the project's `.gitignore` files.
+// Patterns use the `.gitignore` syntax, e.g. `build/`, `*_pb2.py` or `src/**/*.py`, and are matched against paths relative to the project's directory.
+type FileFilter struct {
+	// When not empty, only files matching at least one of these patterns are included.
+	Include []string
+	// Files and folders matching any of these patterns are excluded, even if they match an include pattern.
+	Exclude []string
+}
+
+// ignorer determines which files and folders should be ignored when searching a directory for files,
+// based on the `.gitignore` files of the Git repository that it is in (if any) and a FileFilter.
+// It expects the directory to be searched depth-first, such as by filepath.Walk, entering each folder before the files inside it.
+type ignorer struct {
+	// path components from the root of the Git repository to the directory being searched, empty if it is not in a repository.
+	prefix []string
+	// the folders that are currently being searched, from the directory being searched to the current folder.
+	levels  []ignoreLevel
+	include gitignore.Matcher
+	exclude gitignore.Matcher
+	hasIncl bool
+}
+
+// ignoreLevel contains the `.gitignore` patterns that apply to the files in a folder, i.e. those of the folder itself and all of its parents.
+type ignoreLevel struct {
+	// path of the folder relative to the directory being searched, "." for the directory itself.
+	relpath  string
+	patterns []gitignore.Pattern
+	matcher  gitignore.Matcher
+}
+
+func newIgnorer(dir string, filter FileFilter) *ignorer {
+	ig := &ignorer{}
+	patterns := []gitignore.Pattern{}
+
+	// match paths relative to the repository's root, such that .gitignore files in parent folders of the directory also apply.
+	if abs, err := filepath.Abs(dir); err == nil {
+		if root := findRepositoryRoot(abs); root != "" {
+			if relpath, err := filepath.Rel(root, abs); err == nil && relpath != "." {
+				ig.prefix = splitPath(relpath)
+			}
+			patterns = readIgnoreFile(filepath.Join(root, ".git", "info", "exclude"), nil)
+			for i := 0; i < len(ig.prefix); i++ {
+				patterns = append(patterns, readIgnoreFile(filepath.Join(root, filepath.Join(ig.prefix[:i]...), ".gitignore"), ig.prefix[:i])...)
+			}
+		}
+	}
+	patterns = append(patterns, readIgnoreFile(filepath.Join(dir, ".gitignore"), ig.prefix)...)
+	ig.levels = []ignoreLevel{{relpath: ".", patterns: patterns, matcher: gitignore.NewMatcher(patterns)}}
+
+	ig.include = gitignore.NewMatcher(parsePatterns(filter.Include, ig.prefix))
+	ig.exclude = gitignore.NewMatcher(parsePatterns(filter.Exclude, ig.prefix))
+	ig.hasIncl = len(filter.Include) > 0
+	return ig
+}
+
+// enterDir reads the `.gitignore` file in the given folder, relative to the directory being searched, if it has one.
+// Its patterns only apply to the files inside the folder, so they are forgotten again once the search leaves the folder.
+func (ig *ignorer) enterDir(dir string, relpath string) {
+	parent := ig.leaveDirs(filepath.Dir(relpath))
+
+	domain := append(append([]string{}, ig.prefix...), splitPath(relpath)...)
+	patterns := readIgnoreFile(filepath.Join(dir, relpath, ".gitignore"), domain)
+	if len(patterns) == 0 {
+		ig.levels = append(ig.levels, ignoreLevel{relpath: relpath, patterns: parent.patterns, matcher: parent.matcher})
+		return
+	}
+
+	patterns = append(append([]gitignore.Pattern{}, parent.patterns...), patterns...)
+	ig.levels = append(ig.levels, ignoreLevel{relpath: relpath, patterns: patterns, matcher: gitignore.NewMatcher(patterns)})
+}
+
+// leaveDirs removes the levels of the folders that the search has left, i.e. until the level of the given folder is on top, and returns that level.
+func (ig *ignorer) leaveDirs(relpath string) ignoreLevel {
+	for len(ig.levels) > 1 && ig.levels[len(ig.levels)-1].relpath != relpath {
+		ig.levels = ig.levels[:len(ig.levels)-1]
+	}
+	return ig.levels[len(ig.levels)-1]
+}
+
+// ignores returns whether the file or folder at the given path, relative to the directory being searched, should be ignored.
+func (ig *ignorer) ignores(relpath string, isDir bool) bool {
+	path := append(append([]string{}, ig.prefix...), splitPath(relpath)...)
+	if ig.leaveDirs(filepath.Dir(relpath)).matcher.Match(path, isDir) || ig.exclude.Match(path, isDir) {
+		return true
+	}
+	return !isDir && ig.hasIncl && !ig.include.Match(path, isDir)
+}
+
+// findRepositoryRoot returns the closest parent folder of the given absolute path that contains a `.git` folder (or file, for worktrees),
+// or an empty string if there is none.
+func findRepositoryRoot(dir string) string {
+	for {
+		if _, err := os.Stat(filepath.Join(dir, ".git")); err == nil {
+			return dir
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return ""
+		}
+		dir = parent
+	}
+}
+
+// readIgnoreFile parses the patterns in a `.gitignore` file, which apply to the given domain. A file that cannot be read has no patterns.
+func readIgnoreFile(filename string, domain []string) []gitignore.Pattern {
+	file, err := os.Open(filename)
+	if err != nil {
+		return nil
+	}
+	defer file.Close()
+
+	lines := []string{}
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		lines = append(lines, scanner.Text())
+	}
+	return parsePatterns(lines, domain)
+}
+
+func parsePatterns(lines []string, domain []string) []gitignore.Pattern {
+	patterns := []gitignore.Pattern{}
+	for _, line := range lines {
+		if strings.HasPrefix(line, "#") || strings.TrimSpace(line) == "" {
+			continue
+		}
+		patterns = append(patterns, gitignore.ParsePattern(line, append([]string{}, domain...)))
+	}
+	return patterns
+}
+
+func splitPath(relpath string) []string {
+	return strings.Split(filepath.ToSlash(relpath), "/")
+}
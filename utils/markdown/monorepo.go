@@ -0,0 +1,120 @@
+package markdown
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/bvobart/mllint/api"
+	"github.com/bvobart/mllint/categories"
+	"github.com/bvobart/mllint/config"
+)
+
+// FromMonorepo creates a report of a monorepo formatted as a Markdown string, starting with a summary table of the scores
+// of each of the monorepo's sub-projects per category, followed by the reports of each of the sub-projects.
+func FromMonorepo(root api.ProjectReport, projects []api.ProjectReport) string {
+	output := strings.Builder{}
+	writeMonorepoHeader(&output, root, projects)
+	writeMonorepoSummary(&output, root, projects)
+	for _, project := range projects {
+		writeSubProject(&output, subProjectName(root, project), project)
+	}
+	return output.String()
+}
+
+func subProjectName(root api.ProjectReport, project api.ProjectReport) string {
+	name, err := filepath.Rel(root.Dir, project.Dir)
+	if err != nil {
+		return project.Dir
+	}
+	return name
+}
+
+// subProjectConfigType returns the type of configuration file of the sub-project, or that it inherits the root's configuration if it has none.
+func subProjectConfigType(project api.ProjectReport) string {
+	if project.ConfigType == "" || project.ConfigType == config.TypeDefault {
+		return "_inherited_"
+	}
+	return "`" + project.ConfigType.String() + "`"
+}
+
+func writeMonorepoHeader(output *strings.Builder, root api.ProjectReport, projects []api.ProjectReport) {
+	output.WriteString("# ML Monorepo Report\n")
+	output.WriteString("**Monorepo** | **Details**\n")
+	output.WriteString("--------|--------\n")
+	output.WriteString(fmt.Sprintf("Date    | %s \n", time.Now().Format(time.RFC1123Z)))
+	output.WriteString("Path    | `" + root.Dir + "`\n")
+	output.WriteString("Config  | `" + root.ConfigType.String() + "`\n")
+	writeGitDetails(output, root.Git)
+	output.WriteString(fmt.Sprintf("Number of sub-projects | %d\n", len(projects)))
+	output.WriteString("\n---\n\n")
+}
+
+func writeMonorepoSummary(output *strings.Builder, root api.ProjectReport, projects []api.ProjectReport) {
+	// only include the categories for which any of the sub-projects has a report.
+	cats := []api.Category{}
+	for _, category := range categories.All {
+		for _, project := range projects {
+			if _, ok := project.Reports[category]; ok {
+				cats = append(cats, category)
+				break
+			}
+		}
+	}
+
+	output.WriteString("## Summary\n\n")
+	output.WriteString("Sub-projects inherit `mllint`'s configuration in the root of the monorepo, which their own configuration files may override.\n\n")
+
+	output.WriteString("Project | Config | Python files | Overall")
+	for _, category := range cats {
+		output.WriteString(" | " + category.Name)
+	}
+	output.WriteString("\n--------|--------|-------------:|:-----:")
+	output.WriteString(strings.Repeat("|:-----:", len(cats)))
+	output.WriteString("\n")
+
+	for _, project := range projects {
+		overallScore := project.OverallScore()
+		output.WriteString(fmt.Sprintf("`%s` | %s | %d | %s %.1f%%", subProjectName(root, project), subProjectConfigType(project), len(project.AllPythonFiles()), getPassedEmoji(overallScore), overallScore))
+		for _, category := range cats {
+			report, ok := project.Reports[category]
+			if !ok {
+				output.WriteString(" | —")
+				continue
+			}
+			score := report.OverallScore()
+			output.WriteString(fmt.Sprintf(" | %s %.1f%%", getPassedEmoji(score), score))
+		}
+		output.WriteString("\n")
+	}
+	output.WriteString("\n---\n\n")
+}
+
+func writeSubProject(output *strings.Builder, name string, project api.ProjectReport) {
+	output.WriteString(fmt.Sprintf("## Project `%s`\n\n", name))
+	output.WriteString("**Project** | **Details**\n")
+	output.WriteString("--------|--------\n")
+	output.WriteString("Path    | `" + project.Dir + "`\n")
+	output.WriteString("Config  | " + subProjectConfigType(project) + "\n")
+	output.WriteString(fmt.Sprintf("Number of Python files | %d\n", len(project.AllPythonFiles())))
+	output.WriteString(fmt.Sprintf("Lines of Python code   | %d\n", project.AllPythonFiles().CountLoC()))
+	if project.Partial != nil {
+		output.WriteString(fmt.Sprintf("Linted Python files    | %d (%s)\n", len(project.PythonFiles), project.Partial.Description))
+	}
+	output.WriteString("\n")
+
+	if project.Partial != nil {
+		writePartialNote(output, project.Project)
+	}
+	if len(project.Config.Rules.Disabled) > 0 {
+		writeDisabledRulesNote(output, project.Config)
+	}
+	if len(project.Config.Rules.Overrides) > 0 {
+		writeOverridesNote(output, project.Config)
+	}
+
+	writeCategoryReports(output, project)
+	writeErrors(output, "### Errors", project.Errors)
+	output.WriteString("\n---\n\n")
+}
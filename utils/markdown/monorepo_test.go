@@ -0,0 +1,62 @@
+package markdown_test
+
+import (
+	"strings"
+	stdtesting "testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/bvobart/mllint/api"
+	"github.com/bvobart/mllint/categories"
+	"github.com/bvobart/mllint/config"
+	"github.com/bvobart/mllint/linters/testing"
+	"github.com/bvobart/mllint/linters/versioncontrol"
+	"github.com/bvobart/mllint/utils/markdown"
+)
+
+func TestFromMonorepo(t *stdtesting.T) {
+	root := api.ProjectReport{Project: api.Project{Dir: "/monorepo", ConfigType: config.TypeYAML}}
+
+	training := api.ProjectReport{
+		Project: api.Project{Dir: "/monorepo/training", ConfigType: config.TypeYAML},
+		Reports: map[api.Category]api.Report{
+			categories.VersionControl: {
+				Scores:  map[api.Rule]float64{versioncontrol.RuleGit: 100, versioncontrol.RuleGitNoBigFiles: 0},
+				Details: map[api.Rule]string{versioncontrol.RuleGitNoBigFiles: "Your project contains a very large file"},
+			},
+		},
+	}
+	training.Config.Rules.Disabled = []string{"testing"}
+
+	serving := api.ProjectReport{
+		Project: api.Project{Dir: "/monorepo/serving"},
+		Reports: map[api.Category]api.Report{
+			categories.VersionControl: {Scores: map[api.Rule]float64{versioncontrol.RuleGit: 100}, Details: map[api.Rule]string{}},
+			categories.Testing:        {Scores: map[api.Rule]float64{testing.RuleHasTests: 100}, Details: map[api.Rule]string{}},
+		},
+	}
+
+	output := markdown.FromMonorepo(root, []api.ProjectReport{training, serving})
+	require.Contains(t, output, "# ML Monorepo Report\n")
+	require.Contains(t, output, "Path    | `/monorepo`\n")
+	require.Contains(t, output, "Number of sub-projects | 2\n")
+
+	// the summary has a column for each category that any of the sub-projects has a report for.
+	require.Contains(t, output, "Project | Config | Python files | Overall | Version Control | Testing\n")
+	require.Contains(t, output, "`training` | `.mllint.yml` | 0 | ❌ 50.0% | ❌ 50.0% | —\n")
+	require.Contains(t, output, "`serving` | _inherited_ | 0 | ✅ 100.0% | ✅ 100.0% | ✅ 100.0%\n")
+
+	// followed by the report of each sub-project, in the given order.
+	trainingIndex := strings.Index(output, "## Project `training`\n")
+	servingIndex := strings.Index(output, "## Project `serving`\n")
+	require.Greater(t, trainingIndex, strings.Index(output, "## Summary"))
+	require.Greater(t, servingIndex, trainingIndex)
+
+	trainingOutput, servingOutput := output[trainingIndex:servingIndex], output[servingIndex:]
+	require.Contains(t, trainingOutput, "Path    | `/monorepo/training`\n")
+	require.Contains(t, trainingOutput, "Your project contains a very large file")
+	require.Contains(t, trainingOutput, "`testing`")
+	require.NotContains(t, trainingOutput, testing.RuleHasTests.Name)
+	require.Contains(t, servingOutput, "Config  | _inherited_\n")
+	require.Contains(t, servingOutput, testing.RuleHasTests.Name)
+}
@@ -0,0 +1,40 @@
+package markdown
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/bvobart/mllint/commands/mllint"
+)
+
+// FromProfile creates a Markdown table of the given task timings, as recorded by the runner that ran mllint's linters,
+// listing the tasks that took longest first.
+func FromProfile(timings []mllint.TaskTiming) string {
+	sorted := append([]mllint.TaskTiming{}, timings...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].WallTime() > sorted[j].WallTime()
+	})
+
+	output := strings.Builder{}
+	output.WriteString("## Profile\n\n")
+	output.WriteString("Time that each of mllint's linters spent waiting for a free slot (_Queued_), from starting until completing (_Wall time_), ")
+	output.WriteString("waiting for the tasks it scheduled to complete (_Awaiting_) and running external processes such as Pylint or Mypy (_Processes_).\n\n")
+	output.WriteString("Task | Queued | Wall time | Awaiting | Processes | Process time\n")
+	output.WriteString("-----|-------:|----------:|---------:|----------:|------------:\n")
+	for _, timing := range sorted {
+		output.WriteString(fmt.Sprintf("%s | %s | %s | %s | %d | %s\n", timing.Name,
+			formatDuration(timing.QueuedTime()), formatDuration(timing.WallTime()), formatDuration(timing.AwaitingTime()),
+			len(timing.Processes), formatDuration(timing.ProcessTime())))
+	}
+	output.WriteString("\n")
+	return output.String()
+}
+
+func formatDuration(duration time.Duration) string {
+	if duration.Milliseconds() > 1000 {
+		return fmt.Sprintf("%.2f s", duration.Seconds())
+	}
+	return fmt.Sprint(duration.Milliseconds(), " ms")
+}
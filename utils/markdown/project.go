@@ -2,6 +2,7 @@ package markdown
 
 import (
 	"fmt"
+	"sort"
 	"strings"
 	"time"
 
@@ -18,8 +19,9 @@ import (
 func FromProject(project api.ProjectReport) string {
 	output := strings.Builder{}
 	writeProjectHeader(&output, project)
+	writePartialDetails(&output, project.Project)
 	writeConfigDetails(&output, project.Config)
-	writeProjectReports(&output, project.Reports)
+	writeProjectReports(&output, project)
 	writeProjectErrors(&output, project.Errors)
 	return output.String()
 }
@@ -41,50 +43,117 @@ func writeProjectHeader(output *strings.Builder, project api.ProjectReport) {
 	configIsDefault := cmp.Equal(project.Config, *config.Default())
 	output.WriteString("Default | " + humanizeBool(configIsDefault) + "\n")
 
-	if project.Git.RemoteURL != "" {
-		output.WriteString("Git: Remote URL | `" + project.Git.RemoteURL + "`\n")
-		output.WriteString("Git: Commit     | `" + project.Git.Commit + "`\n")
-		output.WriteString("Git: Branch     | `" + project.Git.Branch + "`\n")
-		output.WriteString("Git: Dirty Workspace?  | " + humanizeBool(project.Git.Dirty) + "\n")
-	}
+	writeGitDetails(output, project.Git)
 
-	output.WriteString(fmt.Sprintf("Number of Python files | %d\n", len(project.PythonFiles)))
-	output.WriteString(fmt.Sprintf("Lines of Python code   | %d\n", project.PythonFiles.CountLoC()))
+	output.WriteString(fmt.Sprintf("Number of Python files | %d\n", len(project.AllPythonFiles())))
+	output.WriteString(fmt.Sprintf("Lines of Python code   | %d\n", project.AllPythonFiles().CountLoC()))
+	if project.Partial != nil {
+		output.WriteString(fmt.Sprintf("Linted Python files    | %d (%s)\n", len(project.PythonFiles), project.Partial.Description))
+	}
 	output.WriteString("\n---\n\n")
 }
 
+func writeGitDetails(output *strings.Builder, git api.GitInfo) {
+	if git.RemoteURL != "" {
+		output.WriteString("Git: Remote URL | `" + git.RemoteURL + "`\n")
+		output.WriteString("Git: Commit     | `" + git.Commit + "`\n")
+		output.WriteString("Git: Branch     | `" + git.Branch + "`\n")
+		output.WriteString("Git: Dirty Workspace?  | " + humanizeBool(git.Dirty) + "\n")
+	}
+}
+
+func writePartialDetails(output *strings.Builder, project api.Project) {
+	if project.Partial == nil {
+		return
+	}
+
+	output.WriteString("## Partial Report\n\n")
+	writePartialNote(output, project)
+}
+
+func writePartialNote(output *strings.Builder, project api.Project) {
+	output.WriteString(fmt.Sprintf("**Note** — Only the %s were linted, i.e. %d out of %d Python files. ", project.Partial.Description, len(project.PythonFiles), len(project.AllPythonFiles())))
+	output.WriteString("Rules concerning the project as a whole were checked as usual, but the scores of rules marked _(partial)_ only reflect the linted files, so they may differ from those of a full run of `mllint`.\n\n")
+}
+
 func writeConfigDetails(output *strings.Builder, config config.Config) {
-	if len(config.Rules.Disabled) > 0 {
+	if len(config.Rules.Disabled) > 0 || len(config.Rules.Overrides) > 0 {
 		output.WriteString("## Config\n\n")
-		output.WriteString("**Note** — The following rules were disabled in `mllint`'s configuration:\n")
-		for _, slug := range config.Rules.Disabled {
-			output.WriteString(fmt.Sprintf("- `%s`\n", slug))
+	}
+	if len(config.Rules.Disabled) > 0 {
+		writeDisabledRulesNote(output, config)
+	}
+	if len(config.Rules.Overrides) > 0 {
+		writeOverridesNote(output, config)
+	}
+}
+
+func writeDisabledRulesNote(output *strings.Builder, config config.Config) {
+	output.WriteString("**Note** — The following rules were disabled in `mllint`'s configuration:\n")
+	for _, slug := range config.Rules.Disabled {
+		output.WriteString(fmt.Sprintf("- `%s`\n", slug))
+	}
+	output.WriteString("\n")
+}
+
+func writeOverridesNote(output *strings.Builder, config config.Config) {
+	slugs := make([]string, 0, len(config.Rules.Overrides))
+	for slug := range config.Rules.Overrides {
+		slugs = append(slugs, slug)
+	}
+	sort.Strings(slugs)
+
+	output.WriteString("**Note** — The weights and / or parameters of the following rules were overridden in `mllint`'s configuration:\n")
+	for _, slug := range slugs {
+		override := config.Rules.Overrides[slug]
+		settings := []string{}
+		if override.Weight != nil {
+			settings = append(settings, fmt.Sprintf("weight: %g", *override.Weight))
+		}
+		names := make([]string, 0, len(override.Params))
+		for name := range override.Params {
+			names = append(names, name)
 		}
-		output.WriteString("\n")
+		sort.Strings(names)
+		for _, name := range names {
+			settings = append(settings, fmt.Sprintf("%s: %g", name, override.Params[name]))
+		}
+		output.WriteString(fmt.Sprintf("- `%s` — %s\n", slug, strings.Join(settings, ", ")))
 	}
+	output.WriteString("\n")
 }
 
-func writeProjectReports(output *strings.Builder, reports map[api.Category]api.Report) {
+func writeProjectReports(output *strings.Builder, project api.ProjectReport) {
 	output.WriteString("## Reports\n\n")
+	output.WriteString(fmt.Sprintf("**Overall score** — %s **%.1f**%%, the weighted average of the scores of all rules.\n\n", getPassedEmoji(project.OverallScore()), project.OverallScore()))
+	writeCategoryReports(output, project)
+}
+
+func writeCategoryReports(output *strings.Builder, project api.ProjectReport) {
+	projectLinters := project.Linters
+	if projectLinters == nil {
+		projectLinters = linters.ByCategory
+	}
+
 	for _, category := range categories.All {
 		// check that a linter is implemented for this category
-		linter, ok := linters.ByCategory[category]
+		linter, ok := projectLinters[category]
 		if !ok {
 			continue
 		}
 
 		// check that a report was produced for this category
-		report, ok := reports[category]
+		report, ok := project.Reports[category]
 		if !ok {
 			continue
 		}
 
 		// if so, write the category's report to the output
-		writeCategoryReport(output, category, linter, report)
+		writeCategoryReport(output, category, linter, report, project.Partial != nil)
 	}
 }
 
-func writeCategoryReport(output *strings.Builder, category api.Category, linter api.Linter, report api.Report) {
+func writeCategoryReport(output *strings.Builder, category api.Category, linter api.Linter, report api.Report, partial bool) {
 	overallScore := report.OverallScore()
 
 	output.WriteString(fmt.Sprintf("### %s (`%s`) — **%.1f**%%\n", category.Name, category.Slug, overallScore))
@@ -92,18 +161,25 @@ func writeCategoryReport(output *strings.Builder, category api.Category, linter
 	output.WriteString("Passed | Score | Weight | Rule | Slug\n")
 	output.WriteString(":-----:|------:|-------:|------|-----\n")
 
+	// the weights of the rules in the report may have been overridden in the project's configuration, so find them by their slugs.
+	scoredRules := map[string]api.Rule{}
+	for rule := range report.Scores {
+		scoredRules[rule.Slug] = rule
+	}
+
 	details := strings.Builder{}
 	for _, rule := range linter.Rules() {
 		// check if the rule was scored and wasn't disabled
-		score, ok := report.Scores[*rule]
+		scoredRule, ok := scoredRules[rule.Slug]
 		if !ok || rule.Disabled {
 			continue
 		}
-		writeRuleScore(output, *rule, score)
+		score := report.Scores[scoredRule]
+		writeRuleScore(output, scoredRule, score, partial && rule.PerFile)
 
 		// include any details for the rule if the linter decided to report any.
-		if linterDetails, ok := report.Details[*rule]; ok {
-			writeRuleDetails(&details, *rule, score, linterDetails)
+		if linterDetails, ok := report.Details[scoredRule]; ok {
+			writeRuleDetails(&details, scoredRule, score, linterDetails)
 		}
 	}
 
@@ -114,9 +190,13 @@ func writeCategoryReport(output *strings.Builder, category api.Category, linter
 	output.WriteString(details.String())
 }
 
-func writeRuleScore(output *strings.Builder, rule api.Rule, score float64) {
+func writeRuleScore(output *strings.Builder, rule api.Rule, score float64, partial bool) {
 	passed := getPassedEmoji(score)
-	line := fmt.Sprintf("%s | %.1f%% | %.0f | %s | `%s`\n", passed, score, rule.Weight, rule.Name, rule.Slug)
+	name := rule.Name
+	if partial {
+		name += " _(partial)_"
+	}
+	line := fmt.Sprintf("%s | %.1f%% | %g | %s | `%s`\n", passed, score, rule.Weight, name, rule.Slug)
 	output.WriteString(line)
 }
 
@@ -128,11 +208,15 @@ func writeRuleDetails(output *strings.Builder, rule api.Rule, score float64, det
 }
 
 func writeProjectErrors(output *strings.Builder, multiErr *multierror.Error) {
+	writeErrors(output, "## Errors", multiErr)
+}
+
+func writeErrors(output *strings.Builder, heading string, multiErr *multierror.Error) {
 	if multiErr == nil {
 		return
 	}
 
-	output.WriteString("## Errors\n\n")
+	output.WriteString(heading + "\n\n")
 
 	multiErr.ErrorFormat = func(errors []error) string {
 		b := strings.Builder{}
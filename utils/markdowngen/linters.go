@@ -43,3 +43,20 @@ func RuleDetails(rule api.Rule) string {
 	builder.WriteString("\n")
 	return builder.String()
 }
+
+// RuleParams lists the given parameters of a rule, which can be overridden with `rules.overrides` in mllint's configuration.
+func RuleParams(params []api.RuleParam) string {
+	if len(params) == 0 {
+		return ""
+	}
+
+	builder := strings.Builder{}
+	builder.WriteString("### Parameters\n\n")
+	builder.WriteString("These can be overridden with `rules.overrides` in `mllint`'s configuration.\n\n")
+	builder.WriteString("Name | Default | Range | Description\n")
+	builder.WriteString("-----|--------:|-------|------------\n")
+	for _, param := range params {
+		builder.WriteString(fmt.Sprintf("`%s` | %g | %g – %g | %s\n", param.Name, param.Default, param.Min, param.Max, param.Description))
+	}
+	return builder.String()
+}
@@ -0,0 +1,159 @@
+package secrets
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"strings"
+)
+
+// Pattern is a known format of a secret or credential, e.g. an AWS access key ID.
+type Pattern struct {
+	Kind  string
+	Regex *regexp.Regexp
+	// index of the submatch in Regex that contains the secret itself, or 0 if the entire match is the secret.
+	Group int
+}
+
+// Patterns are the known credential formats that the Scanner looks for.
+var Patterns = []Pattern{
+	{Kind: "AWS Access Key ID", Regex: regexp.MustCompile(`\b((?:AKIA|ASIA)[0-9A-Z]{16})\b`), Group: 1},
+	{Kind: "AWS Secret Access Key", Regex: regexp.MustCompile(`(?i)aws_?secret_?(?:access_?)?key["']?\s*[:=]\s*["']?([A-Za-z0-9/+=]{40})\b`), Group: 1},
+	{Kind: "Private Key", Regex: regexp.MustCompile(`-----BEGIN (?:RSA |EC |DSA |OPENSSH |PGP |ENCRYPTED )?PRIVATE KEY(?: BLOCK)?-----`)},
+	{Kind: "Hugging Face Token", Regex: regexp.MustCompile(`\b(hf_[A-Za-z0-9]{30,})\b`), Group: 1},
+	{Kind: "Weights & Biases API Key", Regex: regexp.MustCompile(`(?i)wandb[\w.]*[^\n]{0,40}?\b([0-9a-f]{40})\b`), Group: 1},
+	{Kind: "GitHub Token", Regex: regexp.MustCompile(`\b(gh[pousr]_[A-Za-z0-9]{36,})\b`), Group: 1},
+	{Kind: "Google API Key", Regex: regexp.MustCompile(`\b(AIza[0-9A-Za-z_-]{35})\b`), Group: 1},
+	{Kind: "Slack Token", Regex: regexp.MustCompile(`\b(xox[abposr]-[0-9A-Za-z-]{10,})\b`), Group: 1},
+	{Kind: "OpenAI API Key", Regex: regexp.MustCompile(`\b(sk-[A-Za-z0-9_-]{32,})\b`), Group: 1},
+}
+
+// matches values assigned to variables or keys whose names suggest that they hold a secret, e.g. `api_key = "..."`.
+var (
+	secretName        = `[\w.-]*(?:api[_-]?key|access[_-]?key|secret|token|passw(?:or)?d|pwd|credentials?)[\w.-]*`
+	regexQuotedSecret = regexp.MustCompile(`(?i)` + secretName + `["']?\s*[:=]\s*[rbf]?["']([^"'\s]{8,})["']`)
+	// also matches unquoted values, e.g. `API_TOKEN=abc123` in a .env file. Only used for configuration files.
+	regexUnquotedSecret = regexp.MustCompile(`(?i)^\s*(?:export\s+)?` + secretName + `["']?\s*[:=]\s*["']?([^"'\s#]{8,})["']?\s*(?:#.*)?$`)
+)
+
+// values that are obviously placeholders rather than actual secrets.
+var regexPlaceholder = regexp.MustCompile(`(?i)[<>{}$%]|your|example|changeme|placeholder|dummy|redacted|xxxx|\*\*\*\*`)
+
+func isPlaceholder(value string) bool {
+	return regexPlaceholder.MatchString(value) || strings.Trim(value, value[:1]) == ""
+}
+
+// AllowlistComment can be added to a line to tell the Scanner that this line does not contain a secret.
+const AllowlistComment = "pragma: allowlist secret"
+
+// Match is a (possible) secret found by the Scanner.
+type Match struct {
+	// Line number, starting at 1.
+	Line int
+	// Kind of secret, e.g. 'AWS Access Key ID', or 'High-entropy string'
+	Kind string
+	// The secret itself.
+	Secret string
+}
+
+// Redacted returns the secret with all but its first four characters replaced, such that it can be displayed in a report.
+func (m Match) Redacted() string {
+	if len(m.Secret) <= 4 {
+		return strings.Repeat("*", len(m.Secret))
+	}
+	return m.Secret[:4] + strings.Repeat("*", int(math.Min(float64(len(m.Secret)-4), 16)))
+}
+
+// Scanner scans text for known credential patterns and high-entropy strings assigned to secret-sounding names.
+type Scanner struct {
+	allowlist  []*regexp.Regexp
+	minEntropy float64
+}
+
+// NewScanner creates a Scanner. Strings matching any of the regexes in the allowlist are never reported as secrets.
+// Values assigned to secret-sounding names are only reported if their Shannon entropy is at least minEntropy bits per character.
+func NewScanner(allowlist []string, minEntropy float64) (*Scanner, error) {
+	scanner := &Scanner{allowlist: make([]*regexp.Regexp, len(allowlist)), minEntropy: minEntropy}
+	for i, pattern := range allowlist {
+		regex, err := regexp.Compile(pattern)
+		if err != nil {
+			return nil, fmt.Errorf("invalid allowlist pattern `%s`: %w", pattern, err)
+		}
+		scanner.allowlist[i] = regex
+	}
+	return scanner, nil
+}
+
+// ScanText scans each line of the given text for secrets. When unquoted is true, values that are assigned
+// to secret-sounding names without quotes are also considered, which is useful for configuration files such as `.env` files.
+func (s *Scanner) ScanText(text string, unquoted bool) []Match {
+	matches := []Match{}
+	for i, line := range strings.Split(text, "\n") {
+		matches = append(matches, s.ScanLine(line, i+1, unquoted)...)
+	}
+	return matches
+}
+
+// ScanLine scans a single line for secrets. See ScanText.
+func (s *Scanner) ScanLine(line string, lineNumber int, unquoted bool) []Match {
+	if strings.Contains(line, AllowlistComment) {
+		return nil
+	}
+
+	matches := []Match{}
+	found := map[string]bool{}
+	for _, pattern := range Patterns {
+		for _, submatch := range pattern.Regex.FindAllStringSubmatch(line, -1) {
+			secret := submatch[pattern.Group]
+			if !found[secret] && !s.isAllowed(secret) {
+				found[secret] = true
+				matches = append(matches, Match{lineNumber, pattern.Kind, secret})
+			}
+		}
+	}
+
+	candidates := regexQuotedSecret.FindAllStringSubmatch(line, -1)
+	if unquoted {
+		candidates = append(candidates, regexUnquotedSecret.FindAllStringSubmatch(line, -1)...)
+	}
+	for _, submatch := range candidates {
+		secret := submatch[1]
+		if found[secret] || s.isAllowed(secret) || isPlaceholder(secret) || Entropy(secret) < s.minEntropy {
+			continue
+		}
+		found[secret] = true
+		matches = append(matches, Match{lineNumber, "High-entropy string", secret})
+	}
+
+	return matches
+}
+
+func (s *Scanner) isAllowed(secret string) bool {
+	for _, regex := range s.allowlist {
+		if regex.MatchString(secret) {
+			return true
+		}
+	}
+	return false
+}
+
+// Entropy calculates the Shannon entropy of the given string in bits per character.
+func Entropy(str string) float64 {
+	if str == "" {
+		return 0
+	}
+
+	counts := map[rune]int{}
+	total := 0
+	for _, char := range str {
+		counts[char]++
+		total++
+	}
+
+	entropy := 0.0
+	for _, count := range counts {
+		p := float64(count) / float64(total)
+		entropy -= p * math.Log2(p)
+	}
+	return entropy
+}